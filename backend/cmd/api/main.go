@@ -39,6 +39,10 @@ func main() {
 	if err != nil {
 		panic(fmt.Sprintf("Failed to initialize services: %v", err))
 	}
+
+	// Deliver queued customer notifications in the background
+	go service.NotificationsService.Run(ctx, services.NotificationDispatchInterval())
+
 	handler := handlers.NewHandlerInitializeParams(service)
 	router := chi.NewRouter()
 	routes := server.NewRoutes(*handler)
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+	config "github.com/kokweikhong/profilm_ewarranty/backend/configs"
+	database "github.com/kokweikhong/profilm_ewarranty/backend/internal/db"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "Write the normalized car makes and models (default is a dry run)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found or error loading .env file")
+	}
+
+	ctx := context.Background()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.NewPostgresPool(ctx, database.Config(cfg.Database))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	carsService := services.NewCarsService(db)
+
+	results, err := carsService.NormalizeWarrantyCarMakesAndModels(ctx, !*apply)
+	if err != nil {
+		log.Fatalf("Failed to normalize car makes and models: %v", err)
+	}
+
+	var normalized, unmatched int64
+	for _, r := range results {
+		switch r.Status {
+		case models.CarNormalizationStatusUnchanged:
+			continue
+		case models.CarNormalizationStatusNormalized:
+			normalized += r.WarrantyCount
+		default:
+			unmatched += r.WarrantyCount
+		}
+		fmt.Printf("%-16s %q %q -> %q %q (%d warranties)\n",
+			r.Status, r.CarBrand, r.CarModel, r.NewCarBrand, r.NewCarModel, r.WarrantyCount)
+	}
+
+	fmt.Println()
+	fmt.Printf("Normalized: %d warranties\n", normalized)
+	fmt.Printf("Unmatched:  %d warranties\n", unmatched)
+	if !*apply {
+		fmt.Println("Dry run only, re-run with -apply to save the changes.")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+	config "github.com/kokweikhong/profilm_ewarranty/backend/configs"
+	database "github.com/kokweikhong/profilm_ewarranty/backend/internal/db"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "Write the normalized plate and chassis numbers (default is a dry run)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found or error loading .env file")
+	}
+
+	ctx := context.Background()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.NewPostgresPool(ctx, database.Config(cfg.Database))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	warrantiesService := services.NewWarrantiesService(db)
+
+	results, err := warrantiesService.NormalizeWarrantyVehicles(ctx, !*apply)
+	if err != nil {
+		log.Fatalf("Failed to normalize plate and chassis numbers: %v", err)
+	}
+
+	var normalized, invalid int
+	for _, r := range results {
+		if r.Status == models.VehicleNormalizationStatusInvalid {
+			invalid++
+			fmt.Printf("%-10s %s: %s\n", r.Status, r.WarrantyNo, r.Error)
+			continue
+		}
+		normalized++
+		fmt.Printf("%-10s %s: %q %q -> %q %q\n",
+			r.Status, r.WarrantyNo, r.CarPlateNo, r.CarChassisNo, r.NewCarPlateNo, r.NewCarChassisNo)
+	}
+
+	fmt.Println()
+	fmt.Printf("Normalized: %d warranties\n", normalized)
+	fmt.Printf("Invalid:    %d warranties\n", invalid)
+	if !*apply {
+		fmt.Println("Dry run only, re-run with -apply to save the changes.")
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/joho/godotenv"
+	config "github.com/kokweikhong/profilm_ewarranty/backend/configs"
+	database "github.com/kokweikhong/profilm_ewarranty/backend/internal/db"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+)
+
+func main() {
+	apply := flag.Bool("apply", false, "Rewrite the inconsistent product hierarchies (default is a dry run)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found or error loading .env file")
+	}
+
+	ctx := context.Background()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	db, err := database.NewPostgresPool(ctx, database.Config(cfg.Database))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	productsService := services.NewProductsService(db)
+
+	rows, err := productsService.RepairProductHierarchies(ctx, !*apply)
+	if err != nil {
+		log.Fatalf("Failed to repair product hierarchies: %v", err)
+	}
+
+	for _, r := range rows {
+		fmt.Printf("product %d %q name %d: brand %d -> %d, type %d -> %d, series %d -> %d\n",
+			r.ID, r.FilmSerialNumber, r.NameID,
+			r.BrandID, r.ExpectedBrandID,
+			r.TypeID, r.ExpectedTypeID,
+			r.SeriesID, r.ExpectedSeriesID)
+	}
+
+	fmt.Println()
+	fmt.Printf("Inconsistent: %d products\n", len(rows))
+	if !*apply && len(rows) > 0 {
+		fmt.Println("Dry run only, re-run with -apply to save the changes.")
+	}
+}
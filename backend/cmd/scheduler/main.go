@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	config "github.com/kokweikhong/profilm_ewarranty/backend/configs"
+	database "github.com/kokweikhong/profilm_ewarranty/backend/internal/db"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+)
+
+// job is a scheduled task run on every tick of the scheduler.
+type job struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+func main() {
+	once := flag.Bool("once", false, "Run all jobs once and exit")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found or error loading .env file")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	db, err := database.NewPostgresPool(ctx, database.Config(cfg.Database))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	remindersService := services.NewRemindersService(db)
+	publicLookupsService := services.NewPublicLookupsService(db)
+	claimSLAService := services.NewClaimSLAService(db)
+	reordersService := services.NewReordersService(db)
+
+	jobs := []job{
+		{
+			name: "reminders",
+			run: func(ctx context.Context) error {
+				results, err := remindersService.RunReminderRules(ctx)
+				if err != nil {
+					return err
+				}
+				for _, r := range results {
+					if r.Error != "" {
+						log.Printf("reminder rule %d (%s): %s", r.RuleID, r.RuleName, r.Error)
+						continue
+					}
+					log.Printf("reminder rule %d (%s): queued %d reminder(s)", r.RuleID, r.RuleName, r.Queued)
+				}
+				return nil
+			},
+		},
+		{
+			name: "purge-public-lookup-logs",
+			run: func(ctx context.Context) error {
+				deleted, err := publicLookupsService.PurgePublicLookupLogs(ctx)
+				if err != nil {
+					return err
+				}
+				log.Printf("deleted %d expired public lookup log(s)", deleted)
+				return nil
+			},
+		},
+		{
+			name: "escalate-claims",
+			run: func(ctx context.Context) error {
+				result, err := claimSLAService.EscalateOverdueClaims(ctx)
+				if result != nil && result.Started > 0 {
+					log.Printf("started the SLA clock of %d claim(s)", result.Started)
+				}
+				if err != nil {
+					return err
+				}
+				for _, e := range result.Errors {
+					log.Printf("claim escalation: %s", e)
+				}
+				log.Printf("escalated %d overdue claim(s)", result.Escalated)
+				return nil
+			},
+		},
+		{
+			name: "low-stock",
+			run: func(ctx context.Context) error {
+				result, err := reordersService.CheckLowStock(ctx)
+				if err != nil {
+					return err
+				}
+				for _, e := range result.Errors {
+					log.Printf("low-stock check: %s", e)
+				}
+				log.Printf("opened %d low-stock alert(s), resolved %d", result.Alerted, result.Resolved)
+				return nil
+			},
+		},
+	}
+
+	runJobs(ctx, jobs)
+	if *once {
+		return
+	}
+
+	interval := schedulerInterval()
+	log.Printf("Scheduler running every %s", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Scheduler stopped")
+			return
+		case <-ticker.C:
+			runJobs(ctx, jobs)
+		}
+	}
+}
+
+// runJobs runs every job in order, logging failures without stopping the remaining jobs.
+func runJobs(ctx context.Context, jobs []job) {
+	for _, j := range jobs {
+		log.Printf("Running job %s", j.name)
+		if err := j.run(ctx); err != nil {
+			log.Printf("Job %s failed: %v", j.name, err)
+		}
+	}
+}
+
+// schedulerInterval reads SCHEDULER_INTERVAL_HOURS, defaulting to 24 hours.
+func schedulerInterval() time.Duration {
+	if v := os.Getenv("SCHEDULER_INTERVAL_HOURS"); v != "" {
+		if hours, err := strconv.Atoi(v); err == nil && hours > 0 {
+			return time.Duration(hours) * time.Hour
+		}
+	}
+	return 24 * time.Hour
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/products"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/shops"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
 )
 
@@ -458,6 +459,10 @@ func main() {
 	} else {
 		log.Println("Admin user already exists")
 	}
+	adminUser, err := usersService.GetUserByUsername(ctx, "admin")
+	if err != nil {
+		log.Fatalf("Failed to get admin user: %v", err)
+	}
 
 	// 2. Seed Products (20 products)
 	log.Println("Seeding products...")
@@ -494,7 +499,7 @@ func main() {
 
 	// 6. Seed Claims (30% of warranties)
 	log.Println("Seeding claims...")
-	claimsList, err := seedClaims(ctx, claimsService, warrantiesService, warrantiesList, len(warrantiesList)*30/100)
+	claimsList, err := seedClaims(ctx, claimsService, warrantiesService, adminUser.ID, warrantiesList, len(warrantiesList)*30/100)
 	if err != nil {
 		log.Fatalf("Failed to seed claims: %v", err)
 	}
@@ -517,12 +522,12 @@ func main() {
 func seedProducts(ctx context.Context, svc services.ProductsService, count int) ([]*products.Product, error) {
 	var productsList []*products.Product
 	for i := 0; i < count; i++ {
-		brands, err := svc.ListProductBrands(ctx)
+		brands, err := svc.ListProductBrands(ctx, false)
 		if err != nil || len(brands) == 0 {
 			log.Fatalf("Failed to list product brands: %v", err)
 		}
 		brand := brands[rand.Intn(len(brands))]
-		types, err := svc.ListProductTypes(ctx)
+		types, err := svc.ListProductTypes(ctx, false)
 		var filteredTypes []*products.ProductType
 		for _, t := range types {
 			if t.BrandID == brand.ID {
@@ -533,7 +538,7 @@ func seedProducts(ctx context.Context, svc services.ProductsService, count int)
 			log.Fatalf("Failed to list product types for brand ID %d: %v", brand.ID, err)
 		}
 		productTypeObj := filteredTypes[rand.Intn(len(filteredTypes))]
-		series, err := svc.ListProductSeries(ctx)
+		series, err := svc.ListProductSeries(ctx, false)
 		var filteredSeries []*products.ProductSeries
 		for _, s := range series {
 			if s.TypeID == productTypeObj.ID {
@@ -544,7 +549,7 @@ func seedProducts(ctx context.Context, svc services.ProductsService, count int)
 			log.Fatalf("Failed to list product series for type ID %d: %v", productTypeObj.ID, err)
 		}
 		productSeriesObj := filteredSeries[rand.Intn(len(filteredSeries))]
-		names, err := svc.ListProductNames(ctx)
+		names, err := svc.ListProductNames(ctx, false)
 		var filteredNames []*products.ProductName
 		for _, n := range names {
 			if n.SeriesID == productSeriesObj.ID {
@@ -562,7 +567,7 @@ func seedProducts(ctx context.Context, svc services.ProductsService, count int)
 			NameID:           productNameObj.ID,
 			WarrantyInMonths: warrantyMonths[rand.Intn(len(warrantyMonths))],
 			FilmSerialNumber: fmt.Sprintf("FSN-%d-%05d", time.Now().Year(), rand.Intn(99999)),
-			FilmQuantity:     int32(rand.Intn(1000) + 100),
+			FilmQuantity:     float64(rand.Intn(1000) + 100),
 			ShipmentNumber:   fmt.Sprintf("SHIP-%d-%04d", time.Now().Year(), rand.Intn(9999)),
 			Description:      fmt.Sprintf("Product batch %d", i+1),
 		})
@@ -644,7 +649,7 @@ func seedProductAllocations(ctx context.Context, svc services.ProductAllocations
 				ProductID: product.ID,
 				// ShopID:         get from outer range shopList
 				ShopID:         shopsList[i].ID,
-				FilmQuantity:   int32(rand.Intn(100) + 10),
+				UnitQuantity:   float64(rand.Intn(100) + 10),
 				AllocationDate: allocationDate,
 			})
 			if err != nil {
@@ -695,13 +700,16 @@ func seedWarranties(ctx context.Context, warrantiesService services.WarrantiesSe
 			clientFirstName := firstNames[rand.Intn(len(firstNames))]
 			clientLastName := lastNames[rand.Intn(len(lastNames))]
 
+			// carBrands and carModels are aligned so that the model belongs to the brand
+			carIndex := rand.Intn(len(carBrands))
+
 			createWarrantyParams := &warranties.CreateWarrantyParams{
 				ShopID:               shopsList[i].ID,
 				ClientName:           fmt.Sprintf("%s %s", clientFirstName, clientLastName),
 				ClientContact:        generatePhoneNumber(),
 				ClientEmail:          fmt.Sprintf("%s.%s@example.com", clientFirstName, clientLastName),
-				CarBrand:             carBrands[rand.Intn(len(carBrands))],
-				CarModel:             carModels[rand.Intn(len(carModels))],
+				CarBrand:             carBrands[carIndex],
+				CarModel:             carModels[carIndex],
 				CarColour:            carColors[rand.Intn(len(carColors))],
 				CarPlateNo:           generateCarPlateNumber(),
 				CarChassisNo:         fmt.Sprintf("CHASSIS-%s-%05d", generateRandomString(5), rand.Intn(99999)),
@@ -741,6 +749,8 @@ func seedWarranties(ctx context.Context, warrantiesService services.WarrantiesSe
 					ProductAllocationID:  productAllocationsFilter[rand.Intn(len(productAllocationsFilter))].ProductAllocationID,
 					CarPartID:            carPart.ID,
 					InstallationImageUrl: generateInstallationImageUrl(carPart.Code),
+					UnitOfMeasure:        models.UnitOfMeasureRoll,
+					UnitQuantity:         0.05,
 				}
 				parts = append(parts, part)
 			}
@@ -757,12 +767,21 @@ func seedWarranties(ctx context.Context, warrantiesService services.WarrantiesSe
 	return warrantiesList, nil
 }
 
-func seedClaims(ctx context.Context, svc services.ClaimsService, warrantySvc services.WarrantiesService, warrantiesList []*warranties.Warranty, count int) ([]*claims.Claim, error) {
+func seedClaims(ctx context.Context, svc services.ClaimsService, warrantySvc services.WarrantiesService, userID int32, warrantiesList []*warranties.Warranty, count int) ([]*claims.Claim, error) {
 	var claimsList []*claims.Claim
 
-	for i := 0; i < count; i++ {
-		// Select a random warranty
-		warranty := warrantiesList[rand.Intn(len(warrantiesList))]
+	// Each warranty is claimed at most once so that no part has two open claims
+	order := rand.Perm(len(warrantiesList))
+	for i := 0; i < min(count, len(order)); i++ {
+		warranty := warrantiesList[order[i]]
+
+		// Only approved warranties can be claimed
+		if _, err := warrantySvc.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
+			ID:             warranty.ID,
+			ApprovalStatus: models.ApprovalStatusApproved,
+		}); err != nil {
+			return nil, err
+		}
 
 		// Claim date should be after installation date
 		claimDate := warranty.InstallationDate.AddDate(0, 0, rand.Intn(60)+7) // 7-67 days after installation
@@ -790,8 +809,9 @@ func seedClaims(ctx context.Context, svc services.ClaimsService, warrantySvc ser
 		// Each claim affects 1-3 parts
 		numParts := min(rand.Intn(3)+1, len(warrantyParts))
 
+		partOrder := rand.Perm(len(warrantyParts))
 		for j := 0; j < numParts; j++ {
-			part := warrantyParts[rand.Intn(len(warrantyParts))]
+			part := warrantyParts[partOrder[j]]
 			remarks := fmt.Sprintf("Damage description for claim %d part %d", i+1, j+1)
 			claimWarrantyPartParam := &claims.CreateClaimWarrantyPartParams{
 				WarrantyPartID:  part.ID,
@@ -800,7 +820,7 @@ func seedClaims(ctx context.Context, svc services.ClaimsService, warrantySvc ser
 			}
 			claimWarrantyPartsParms = append(claimWarrantyPartsParms, claimWarrantyPartParam)
 		}
-		claim, err := svc.CreateClaimWithParts(ctx, claimParams, claimWarrantyPartsParms)
+		claim, err := svc.CreateClaimWithParts(ctx, userID, claimParams, claimWarrantyPartsParms)
 		if err != nil {
 			return nil, err
 		}
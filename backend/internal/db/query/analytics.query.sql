@@ -0,0 +1,108 @@
+-- name: ListClaimRatesByDimension :many
+-- Claims per 100 installations, grouped by product_name, series, shipment, car_part or shop,
+-- or 'all' for a single overall group.
+-- An installation is an approved warranty part installed within the installation range; it
+-- counts as claimed when any of its claims falls within the claim range. Empty range bounds
+-- are open.
+WITH installs AS (
+    SELECT
+        wp.id AS warranty_part_id,
+        (CASE sqlc.arg(dimension)::text
+            WHEN 'product_name' THEN pn.name
+            WHEN 'series' THEN ps.name
+            WHEN 'shipment' THEN p.shipment_number
+            WHEN 'car_part' THEN cp.name
+            WHEN 'shop' THEN s.shop_name || ' (' || s.branch_code || ')'
+            ELSE 'All'
+        END)::text AS group_key
+    FROM warranty_parts wp
+    JOIN warranties w ON wp.warranty_id = w.id
+    JOIN shops s ON w.shop_id = s.id
+    JOIN car_parts cp ON wp.car_part_id = cp.id
+    JOIN product_allocations pa ON wp.product_allocation_id = pa.id
+    JOIN products p ON pa.product_id = p.id
+    JOIN product_series ps ON p.series_id = ps.id
+    JOIN product_names pn ON p.name_id = pn.id
+    WHERE wp.approval_status = 'APPROVED'
+        AND w.is_active = TRUE
+        AND (sqlc.narg(installed_from)::date IS NULL OR w.installation_date >= sqlc.narg(installed_from)::date)
+        AND (sqlc.narg(installed_to)::date IS NULL OR w.installation_date <= sqlc.narg(installed_to)::date)
+),
+claimed AS (
+    SELECT
+        cwp.warranty_part_id,
+        BOOL_OR(cwp.approval_status = 'APPROVED') AS approved
+    FROM claim_warranty_parts cwp
+    JOIN claims c ON cwp.claim_id = c.id
+    WHERE (sqlc.narg(claimed_from)::date IS NULL OR c.claim_date >= sqlc.narg(claimed_from)::date)
+        AND (sqlc.narg(claimed_to)::date IS NULL OR c.claim_date <= sqlc.narg(claimed_to)::date)
+    GROUP BY cwp.warranty_part_id
+)
+SELECT
+    i.group_key,
+    COUNT(*)::int AS installations,
+    COUNT(cl.warranty_part_id)::int AS claimed_parts,
+    COUNT(*) FILTER (WHERE cl.approved)::int AS approved_claimed_parts,
+    ROUND(100.0 * COUNT(cl.warranty_part_id) / COUNT(*), 2)::float8 AS claims_per_100,
+    ROUND(100.0 * COUNT(*) FILTER (WHERE cl.approved) / COUNT(*), 2)::float8 AS approved_claims_per_100
+FROM installs i
+LEFT JOIN claimed cl ON cl.warranty_part_id = i.warranty_part_id
+GROUP BY i.group_key
+ORDER BY claims_per_100 DESC, installations DESC, i.group_key ASC;
+
+-- name: ListTimeToFailureByDimension :many
+-- Days from installation to the first claim of each claimed installation, summarised per group
+-- with the same dimensions and ranges as ListClaimRatesByDimension. Buckets are in months
+-- since installation.
+WITH failures AS (
+    SELECT
+        (CASE sqlc.arg(dimension)::text
+            WHEN 'product_name' THEN pn.name
+            WHEN 'series' THEN ps.name
+            WHEN 'shipment' THEN p.shipment_number
+            WHEN 'car_part' THEN cp.name
+            WHEN 'shop' THEN s.shop_name || ' (' || s.branch_code || ')'
+            ELSE 'All'
+        END)::text AS group_key,
+        w.installation_date,
+        MIN(c.claim_date) AS first_claim_date
+    FROM claim_warranty_parts cwp
+    JOIN claims c ON cwp.claim_id = c.id
+    JOIN warranty_parts wp ON cwp.warranty_part_id = wp.id
+    JOIN warranties w ON wp.warranty_id = w.id
+    JOIN shops s ON w.shop_id = s.id
+    JOIN car_parts cp ON wp.car_part_id = cp.id
+    JOIN product_allocations pa ON wp.product_allocation_id = pa.id
+    JOIN products p ON pa.product_id = p.id
+    JOIN product_series ps ON p.series_id = ps.id
+    JOIN product_names pn ON p.name_id = pn.id
+    WHERE wp.approval_status = 'APPROVED'
+        AND w.is_active = TRUE
+        AND (sqlc.narg(installed_from)::date IS NULL OR w.installation_date >= sqlc.narg(installed_from)::date)
+        AND (sqlc.narg(installed_to)::date IS NULL OR w.installation_date <= sqlc.narg(installed_to)::date)
+        AND (sqlc.narg(claimed_from)::date IS NULL OR c.claim_date >= sqlc.narg(claimed_from)::date)
+        AND (sqlc.narg(claimed_to)::date IS NULL OR c.claim_date <= sqlc.narg(claimed_to)::date)
+    GROUP BY wp.id, 1, w.installation_date
+),
+durations AS (
+    SELECT
+        group_key,
+        GREATEST(first_claim_date - installation_date, 0) AS days
+    FROM failures
+)
+SELECT
+    group_key,
+    COUNT(*)::int AS failures,
+    MIN(days)::int AS min_days,
+    MAX(days)::int AS max_days,
+    ROUND(AVG(days), 1)::float8 AS avg_days,
+    PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY days)::float8 AS median_days,
+    PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY days)::float8 AS p90_days,
+    COUNT(*) FILTER (WHERE days < 90)::int AS within_3_months,
+    COUNT(*) FILTER (WHERE days >= 90 AND days < 180)::int AS within_3_to_6_months,
+    COUNT(*) FILTER (WHERE days >= 180 AND days < 365)::int AS within_6_to_12_months,
+    COUNT(*) FILTER (WHERE days >= 365 AND days < 730)::int AS within_1_to_2_years,
+    COUNT(*) FILTER (WHERE days >= 730)::int AS after_2_years
+FROM durations
+GROUP BY group_key
+ORDER BY failures DESC, group_key ASC;
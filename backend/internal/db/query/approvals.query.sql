@@ -0,0 +1,20 @@
+-- name: ListApprovalRules :many
+SELECT
+    *
+FROM approval_rules
+ORDER BY entity_type ASC;
+
+-- name: GetApprovalRuleByEntityType :one
+SELECT
+    *
+FROM approval_rules
+WHERE entity_type = $1;
+
+-- name: UpdateApprovalRule :one
+UPDATE approval_rules
+SET
+    mixed_status = $2,
+    partial_while_pending = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE entity_type = $1
+RETURNING *;
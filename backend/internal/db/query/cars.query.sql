@@ -0,0 +1,146 @@
+-- name: ListCarMakes :many
+SELECT
+    *
+FROM car_makes
+ORDER BY name ASC;
+
+-- name: GetCarMakeByID :one
+SELECT
+    *
+FROM car_makes
+WHERE id = $1;
+
+-- name: CreateCarMake :one
+INSERT INTO car_makes (
+    name,
+    is_active
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: UpdateCarMake :one
+UPDATE car_makes
+SET
+    name = $2,
+    is_active = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCarMake :exec
+DELETE FROM car_makes
+WHERE id = $1;
+
+-- name: ListCarModels :many
+SELECT
+    *
+FROM car_models
+ORDER BY car_make_id ASC, name ASC;
+
+-- name: ListCarModelsByMakeID :many
+SELECT
+    *
+FROM car_models
+WHERE car_make_id = $1
+ORDER BY name ASC;
+
+-- name: GetCarModelByID :one
+SELECT
+    *
+FROM car_models
+WHERE id = $1;
+
+-- name: CreateCarModel :one
+INSERT INTO car_models (
+    car_make_id,
+    name,
+    is_active
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateCarModel :one
+UPDATE car_models
+SET
+    car_make_id = $2,
+    name = $3,
+    is_active = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteCarModel :exec
+DELETE FROM car_models
+WHERE id = $1;
+
+-- name: ListCarMakeAliases :many
+SELECT
+    *
+FROM car_make_aliases
+ORDER BY alias ASC;
+
+-- name: ListCarMakeAliasesByMakeID :many
+SELECT
+    *
+FROM car_make_aliases
+WHERE car_make_id = $1
+ORDER BY alias ASC;
+
+-- name: CreateCarMakeAlias :one
+INSERT INTO car_make_aliases (
+    car_make_id,
+    alias
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: DeleteCarMakeAlias :exec
+DELETE FROM car_make_aliases
+WHERE id = $1;
+
+-- name: ListCarModelAliases :many
+SELECT
+    *
+FROM car_model_aliases
+ORDER BY alias ASC;
+
+-- name: ListCarModelAliasesByModelID :many
+SELECT
+    *
+FROM car_model_aliases
+WHERE car_model_id = $1
+ORDER BY alias ASC;
+
+-- name: CreateCarModelAlias :one
+INSERT INTO car_model_aliases (
+    car_model_id,
+    alias
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: DeleteCarModelAlias :exec
+DELETE FROM car_model_aliases
+WHERE id = $1;
+
+-- name: ListWarrantyCarMakesAndModels :many
+SELECT
+    car_brand,
+    car_model,
+    COUNT(*) AS warranty_count
+FROM warranties
+GROUP BY car_brand, car_model
+ORDER BY car_brand ASC, car_model ASC;
+
+-- name: UpdateWarrantiesCarMakeAndModel :execrows
+UPDATE warranties
+SET
+    car_brand = sqlc.arg(new_car_brand),
+    car_model = sqlc.arg(new_car_model),
+    updated_at = CURRENT_TIMESTAMP
+WHERE car_brand = sqlc.arg(car_brand)
+    AND car_model = sqlc.arg(car_model);
@@ -1,15 +1,30 @@
 -- name: GetClaims :many
 -- claim_view
+-- Filters are skipped when empty. sla_state is one of breached, due_today or on_track.
 SELECT
     *
 FROM claim_view
+WHERE (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text)
+    AND (
+        sqlc.arg(sla_state)::text = ''
+        OR (sqlc.arg(sla_state)::text = 'breached' AND sla_breached)
+        OR (sqlc.arg(sla_state)::text = 'due_today' AND sla_due_date = (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Kuala_Lumpur')::date)
+        OR (sqlc.arg(sla_state)::text = 'on_track' AND sla_due_date IS NOT NULL AND NOT sla_breached)
+    )
 ORDER BY created_at DESC;
 
 -- name: GetClaimsByShopID :many
 SELECT
     *
 FROM claim_view
-WHERE shop_id = $1
+WHERE shop_id = sqlc.arg(shop_id)
+    AND (sqlc.arg(status)::text = '' OR status = sqlc.arg(status)::text)
+    AND (
+        sqlc.arg(sla_state)::text = ''
+        OR (sqlc.arg(sla_state)::text = 'breached' AND sla_breached)
+        OR (sqlc.arg(sla_state)::text = 'due_today' AND sla_due_date = (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Kuala_Lumpur')::date)
+        OR (sqlc.arg(sla_state)::text = 'on_track' AND sla_due_date IS NOT NULL AND NOT sla_breached)
+    )
 ORDER BY created_at DESC;
 
 -- name: GetClaimByID :one
@@ -45,6 +60,7 @@ INSERT INTO claims (
 RETURNING *;
 
 -- name: UpdateClaim :one
+-- Only a claim that is submitted or reopened can be edited.
 UPDATE claims
 SET
     warranty_id = $2,
@@ -52,6 +68,7 @@ SET
     claim_date = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
+    AND status IN ('SUBMITTED', 'REOPENED')
 RETURNING *;
 
 -- name: UpdateClaimApproval :one
@@ -77,14 +94,12 @@ INSERT INTO claim_warranty_parts (
 RETURNING *;
 
 -- name: UpdateClaimWarrantyPart :one
+-- The resolution of a part is recorded with UpsertClaimResolution and the claim lifecycle, not here.
 UPDATE claim_warranty_parts
 SET
     warranty_part_id = $2,
     damaged_image_url = $3,
-    status = $4,
-    remarks = $5,
-    resolution_date = $6,
-    resolution_image_url = $7,
+    remarks = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
@@ -106,10 +121,294 @@ SET
 WHERE id = $1
 RETURNING *;
 
--- name: UpdateClaimWarrantyPartStatus :one
+-- name: UpdateClaimWarrantyPartsStatusByClaimID :exec
+-- Parts follow the status of their claim; rejected parts are closed.
 UPDATE claim_warranty_parts
 SET
-    status = $2,
+    status = CASE WHEN approval_status = 'REJECTED' THEN 'CLOSED' ELSE sqlc.arg(status)::text END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE claim_id = sqlc.arg(claim_id);
+
+-- name: GetClaimActorByUserID :one
+SELECT
+    id,
+    shop_id,
+    role
+FROM users
+WHERE id = $1;
+
+-- name: CreateClaimStatusTransition :one
+INSERT INTO claim_status_transitions (
+    claim_id,
+    from_status,
+    to_status,
+    changed_by_user_id,
+    changed_by_customer_id,
+    actor_role,
+    remarks
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: ListClaimStatusTransitionsByClaimID :many
+SELECT
+    t.*,
+    u.username AS changed_by_username,
+    cu.name AS changed_by_customer_name
+FROM claim_status_transitions t
+LEFT JOIN users u ON t.changed_by_user_id = u.id
+LEFT JOIN customers cu ON t.changed_by_customer_id = cu.id
+WHERE t.claim_id = $1
+ORDER BY t.created_at ASC, t.id ASC;
+
+-- name: ListClaimEligibilityParts :many
+-- Everything the claim eligibility rules need to know about the requested warranty parts,
+-- including the latest claim on the part that is still open and not rejected, other than the
+-- claim being edited, if any.
+SELECT
+    wp.id,
+    wp.warranty_id,
+    wp.car_part_id,
+    wp.approval_status,
+    cp.name AS car_part_name,
+    w.warranty_no,
+    w.approval_status AS warranty_approval_status,
+    w.is_active AS warranty_is_active,
+    w.installation_date,
+    p.type_id AS product_type_id,
+    p.series_id AS product_series_id,
+    p.name_id AS product_name_id,
+    p.warranty_in_months,
+    (w.installation_date + make_interval(months => p.warranty_in_months))::date AS coverage_expiry_date,
+    (
+        SELECT c.claim_no
+        FROM claim_warranty_parts cwp
+        JOIN claims c ON cwp.claim_id = c.id
+        WHERE cwp.warranty_part_id = wp.id
+            AND c.status <> 'CLOSED'
+            AND cwp.status <> 'CLOSED'
+            AND cwp.approval_status <> 'REJECTED'
+            AND c.id IS DISTINCT FROM sqlc.narg(exclude_claim_id)::int
+        ORDER BY c.id DESC
+        LIMIT 1
+    ) AS open_claim_no
+FROM warranty_parts wp
+JOIN warranties w ON wp.warranty_id = w.id
+JOIN car_parts cp ON wp.car_part_id = cp.id
+JOIN product_allocations pa ON wp.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+WHERE wp.id = ANY(sqlc.arg(warranty_part_ids)::int[]);
+
+-- name: ListClaimExclusions :many
+SELECT
+    *
+FROM claim_exclusions
+ORDER BY id ASC;
+
+-- name: ListActiveClaimExclusions :many
+SELECT
+    *
+FROM claim_exclusions
+WHERE is_active = TRUE
+ORDER BY id ASC;
+
+-- name: GetClaimExclusionByID :one
+SELECT
+    *
+FROM claim_exclusions
+WHERE id = $1;
+
+-- name: CreateClaimExclusion :one
+INSERT INTO claim_exclusions (
+    name,
+    reason,
+    product_type_id,
+    product_series_id,
+    product_name_id,
+    car_part_id,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: UpdateClaimExclusion :one
+UPDATE claim_exclusions
+SET
+    name = $2,
+    reason = $3,
+    product_type_id = $4,
+    product_series_id = $5,
+    product_name_id = $6,
+    car_part_id = $7,
+    is_active = $8,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: UpdateClaimSLA :one
+-- Restarts the SLA clock for the claim's current status and clears any earlier escalation.
+UPDATE claims
+SET
+    sla_start_date = $2,
+    sla_due_date = $3,
+    sla_escalated_at = NULL
+WHERE id = $1
+RETURNING *;
+
+-- name: ListClaimsWithoutSLA :many
+-- Claims created before SLA tracking; the clock starts when the claim entered its status.
+SELECT
+    c.id,
+    c.status,
+    COALESCE(
+        (SELECT MAX(t.created_at) FROM claim_status_transitions t WHERE t.claim_id = c.id AND t.to_status = c.status),
+        c.created_at
+    )::date AS status_since
+FROM claims c
+WHERE c.sla_start_date IS NULL
+ORDER BY c.id;
+
+-- name: ListClaimsDueForEscalation :many
+SELECT
+    cv.id,
+    cv.claim_no,
+    cv.status,
+    cv.warranty_id,
+    cv.warranty_no,
+    cv.car_plate_no,
+    cv.sla_due_date,
+    s.shop_name
+FROM claim_view cv
+JOIN shops s ON cv.shop_id = s.id
+WHERE cv.sla_breached
+    AND cv.sla_escalated_at IS NULL
+ORDER BY cv.sla_due_date, cv.id;
+
+-- name: MarkClaimSLAEscalated :one
+UPDATE claims
+SET
+    sla_escalated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND sla_escalated_at IS NULL
+RETURNING claim_no;
+
+-- name: GetClaimWarrantyPartByID :one
+SELECT
+    *
+FROM claim_warranty_parts
+WHERE id = $1;
+
+-- name: UpdateClaimWarrantyPartResolution :one
+UPDATE claim_warranty_parts
+SET
+    resolution_date = $2,
+    resolution_image_url = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: GetClaimResolutionByClaimWarrantyPartID :one
+SELECT
+    *
+FROM claim_resolutions
+WHERE claim_warranty_part_id = $1;
+
+-- name: ListClaimResolutionsByClaimID :many
+SELECT
+    cr.*,
+    cwp.claim_id,
+    cwp.resolution_date,
+    cp.name AS car_part_name,
+    p.film_serial_number,
+    pn.name AS product_name
+FROM claim_resolutions cr
+JOIN claim_warranty_parts cwp ON cr.claim_warranty_part_id = cwp.id
+JOIN warranty_parts wp ON cwp.warranty_part_id = wp.id
+JOIN car_parts cp ON wp.car_part_id = cp.id
+JOIN product_allocations pa ON cr.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE cwp.claim_id = $1
+ORDER BY cr.id ASC;
+
+-- name: GetProductAllocationForUpdate :one
+-- Locks the allocation so that concurrent resolutions cannot overdraw it. The film left must be
+-- read with GetProductAllocationRemainingQuantity once the lock is held, so that it sees the
+-- resolutions that committed while waiting for it.
+SELECT
+    pa.id,
+    pa.product_id,
+    pa.shop_id,
+    pa.film_quantity,
+    p.roll_length_metres,
+    p.sheets_per_roll
+FROM product_allocations pa
+JOIN products p ON pa.product_id = p.id
+WHERE pa.id = $1
+FOR UPDATE OF pa;
+
+-- name: GetProductAllocationRemainingQuantity :one
+-- The film left on an allocation as defined by product_allocation_balances_view, with the
+-- quantity already recorded for the claim part added back so that its resolution can be
+-- corrected.
+SELECT
+    (bal.remaining_quantity + COALESCE((
+        SELECT SUM(cr.quantity_used)
+        FROM claim_resolutions cr
+        WHERE cr.product_allocation_id = bal.product_allocation_id
+            AND cr.claim_warranty_part_id = sqlc.arg(claim_warranty_part_id)
+    ), 0))::numeric AS remaining_quantity
+FROM product_allocation_balances_view bal
+WHERE bal.product_allocation_id = sqlc.arg(product_allocation_id);
+
+-- name: GetInstallFreezeRecallNo :one
+-- The open recall, if any, that freezes installs from the film of an allocation.
+SELECT
+    r.recall_no
+FROM recalls r
+JOIN recall_products rp ON rp.recall_id = r.id
+JOIN product_allocations pa ON pa.product_id = rp.product_id
+WHERE pa.id = sqlc.arg(product_allocation_id)
+    AND r.status = 'OPEN'
+    AND r.freeze_installs
+ORDER BY r.id
+LIMIT 1;
+
+-- name: UpsertClaimResolution :one
+INSERT INTO claim_resolutions (
+    claim_warranty_part_id,
+    product_allocation_id,
+    quantity_used,
+    unit_of_measure,
+    unit_quantity,
+    labour_cost,
+    material_cost,
+    remarks,
+    recorded_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (claim_warranty_part_id) DO UPDATE
+SET
+    product_allocation_id = EXCLUDED.product_allocation_id,
+    quantity_used = EXCLUDED.quantity_used,
+    unit_of_measure = EXCLUDED.unit_of_measure,
+    unit_quantity = EXCLUDED.unit_quantity,
+    labour_cost = EXCLUDED.labour_cost,
+    material_cost = EXCLUDED.material_cost,
+    remarks = EXCLUDED.remarks,
+    recorded_by_user_id = EXCLUDED.recorded_by_user_id,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetInProgressStockTakeNoByAllocationID :one
+-- The stock take, open or awaiting review, that is counting the allocation.
+SELECT
+    st.stock_take_no
+FROM stock_take_lines stl
+JOIN stock_takes st ON st.id = stl.stock_take_id
+WHERE stl.product_allocation_id = $1
+    AND st.status IN ('OPEN', 'SUBMITTED')
+LIMIT 1;
@@ -0,0 +1,120 @@
+-- name: GetCommentAuthorByUserID :one
+SELECT
+    id,
+    shop_id,
+    role,
+    username
+FROM users
+WHERE id = $1;
+
+-- name: GetWarrantyCommentSubject :one
+-- The warranty a thread is about, with the shop contact that is notified of HQ replies.
+SELECT
+    w.id AS warranty_id,
+    w.shop_id,
+    w.warranty_no,
+    w.car_plate_no,
+    s.shop_name,
+    s.pic_email
+FROM warranties w
+JOIN shops s ON w.shop_id = s.id
+WHERE w.id = $1;
+
+-- name: GetClaimCommentSubject :one
+SELECT
+    c.id AS claim_id,
+    c.claim_no,
+    w.id AS warranty_id,
+    w.shop_id,
+    w.warranty_no,
+    w.car_plate_no,
+    s.shop_name,
+    s.pic_email
+FROM claims c
+JOIN warranties w ON c.warranty_id = w.id
+JOIN shops s ON w.shop_id = s.id
+WHERE c.id = $1;
+
+-- name: GetCommentByID :one
+SELECT
+    *
+FROM comments
+WHERE id = $1;
+
+-- name: ListCommentsByThread :many
+-- A thread is either a warranty's or a claim's comments; the other ID is NULL. Internal
+-- comments are only returned when include_internal is set.
+SELECT
+    c.*,
+    u.username AS author_username,
+    u.shop_id AS author_shop_id,
+    (c.author_user_id = sqlc.arg(user_id) OR EXISTS (
+        SELECT 1 FROM comment_reads r WHERE r.comment_id = c.id AND r.user_id = sqlc.arg(user_id)
+    ))::bool AS is_read
+FROM comments c
+JOIN users u ON c.author_user_id = u.id
+WHERE c.warranty_id IS NOT DISTINCT FROM sqlc.narg(warranty_id)
+    AND c.claim_id IS NOT DISTINCT FROM sqlc.narg(claim_id)
+    AND (NOT c.is_internal OR sqlc.arg(include_internal)::bool)
+ORDER BY c.created_at ASC, c.id ASC;
+
+-- name: ListCommentAttachmentsByCommentIDs :many
+SELECT
+    *
+FROM comment_attachments
+WHERE comment_id = ANY(sqlc.arg(comment_ids)::int[])
+ORDER BY id ASC;
+
+-- name: CreateComment :one
+INSERT INTO comments (
+    warranty_id,
+    claim_id,
+    parent_comment_id,
+    author_user_id,
+    body,
+    is_internal
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: CreateCommentAttachment :one
+INSERT INTO comment_attachments (
+    comment_id,
+    file_url,
+    file_name
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: MarkCommentsRead :exec
+INSERT INTO comment_reads (comment_id, user_id)
+SELECT c.id, sqlc.arg(user_id)
+FROM comments c
+WHERE c.warranty_id IS NOT DISTINCT FROM sqlc.narg(warranty_id)
+    AND c.claim_id IS NOT DISTINCT FROM sqlc.narg(claim_id)
+    AND c.author_user_id <> sqlc.arg(user_id)
+    AND (NOT c.is_internal OR sqlc.arg(include_internal)::bool)
+ON CONFLICT DO NOTHING;
+
+-- name: ListUnreadCommentCounts :many
+-- Unread comments per thread the user can see: other users' comments without a read row,
+-- limited to the user's shop when shop_id is set.
+SELECT
+    c.warranty_id,
+    c.claim_id,
+    w.warranty_no,
+    cl.claim_no,
+    COUNT(*)::int AS unread_count
+FROM comments c
+LEFT JOIN claims cl ON c.claim_id = cl.id
+JOIN warranties w ON w.id = COALESCE(c.warranty_id, cl.warranty_id)
+WHERE c.author_user_id <> sqlc.arg(user_id)
+    AND (NOT c.is_internal OR sqlc.arg(include_internal)::bool)
+    AND (sqlc.narg(shop_id)::int IS NULL OR w.shop_id = sqlc.narg(shop_id))
+    AND NOT EXISTS (
+        SELECT 1 FROM comment_reads r WHERE r.comment_id = c.id AND r.user_id = sqlc.arg(user_id)
+    )
+GROUP BY c.warranty_id, c.claim_id, w.warranty_no, cl.claim_no
+ORDER BY MAX(c.created_at) DESC;
@@ -0,0 +1,151 @@
+-- name: ListCustomers :many
+SELECT
+    *
+FROM customers
+WHERE merged_into_id IS NULL
+ORDER BY name ASC;
+
+-- name: ListCustomersByShopID :many
+-- Customers, not merged, with at least one warranty installed by the shop.
+SELECT
+    *
+FROM customers c
+WHERE c.merged_into_id IS NULL
+    AND EXISTS (SELECT 1 FROM warranties w WHERE w.customer_id = c.id AND w.shop_id = $1)
+ORDER BY c.name ASC;
+
+-- name: GetCustomerByID :one
+SELECT
+    *
+FROM customers
+WHERE id = $1;
+
+-- name: GetCustomerByContact :one
+SELECT
+    *
+FROM customers
+WHERE phone_normalized = $1
+    AND email_normalized = $2
+ORDER BY merged_into_id NULLS FIRST, id ASC
+LIMIT 1;
+
+-- name: ListCustomersByIDs :many
+SELECT
+    *
+FROM customers
+WHERE id = ANY(sqlc.arg(ids)::int[])
+ORDER BY id ASC;
+
+-- name: CreateCustomer :one
+INSERT INTO customers (
+    name,
+    phone,
+    phone_normalized,
+    email
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: UpdateCustomerDetails :one
+UPDATE customers
+SET
+    name = $2,
+    phone = $3,
+    email = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ListCustomerDuplicatePairs :many
+SELECT
+    a.id AS customer_id,
+    b.id AS candidate_id,
+    (a.phone_normalized = b.phone_normalized) AS phone_match,
+    (a.email_normalized <> '' AND a.email_normalized = b.email_normalized) AS email_match
+FROM customers a
+JOIN customers b ON a.id < b.id
+    AND (
+        a.phone_normalized = b.phone_normalized
+        OR (a.email_normalized <> '' AND a.email_normalized = b.email_normalized)
+    )
+WHERE a.merged_into_id IS NULL
+    AND b.merged_into_id IS NULL
+ORDER BY a.id ASC, b.id ASC;
+
+-- name: ListCustomerCandidatesByCustomerID :many
+SELECT
+    b.*
+FROM customers a
+JOIN customers b ON b.id <> a.id
+    AND (
+        a.phone_normalized = b.phone_normalized
+        OR (a.email_normalized <> '' AND a.email_normalized = b.email_normalized)
+    )
+WHERE a.id = $1
+    AND b.merged_into_id IS NULL
+ORDER BY b.id ASC;
+
+-- name: MoveWarrantiesToCustomer :execrows
+UPDATE warranties
+SET
+    customer_id = sqlc.arg(target_customer_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE customer_id = ANY(sqlc.arg(source_customer_ids)::int[]);
+
+-- name: MarkCustomersMerged :exec
+UPDATE customers
+SET
+    merged_into_id = sqlc.arg(target_customer_id),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = ANY(sqlc.arg(source_customer_ids)::int[])
+    OR merged_into_id = ANY(sqlc.arg(source_customer_ids)::int[]);
+
+-- name: CreateCustomerMerge :one
+INSERT INTO customer_merges (
+    target_customer_id,
+    source_customer_id,
+    merged_by
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: ListCustomerMergesByTargetID :many
+SELECT
+    *
+FROM customer_merges
+WHERE target_customer_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateWarrantyCustomerID :exec
+UPDATE warranties
+SET
+    customer_id = $2
+WHERE id = $1;
+
+-- name: ListVehiclesByCustomerID :many
+SELECT DISTINCT
+    v.*
+FROM vehicles v
+JOIN warranties w ON w.vehicle_id = v.id
+WHERE w.customer_id = $1
+ORDER BY v.id ASC;
+
+-- name: ListWarrantiesByCustomerID :many
+SELECT
+    w.*,
+    s.shop_name,
+    s.branch_code
+FROM warranties w
+JOIN shops s ON w.shop_id = s.id
+WHERE w.customer_id = $1
+ORDER BY w.installation_date DESC;
+
+-- name: ListClaimsByCustomerID :many
+SELECT
+    cv.*
+FROM claim_view cv
+JOIN warranties w ON cv.warranty_id = w.id
+WHERE w.customer_id = $1
+ORDER BY cv.claim_date DESC;
@@ -0,0 +1,124 @@
+-- name: LockProductForInventory :one
+-- Serializes stock movements of a product so that concurrent postings cannot overdraw it.
+SELECT id
+FROM products
+WHERE id = sqlc.arg(product_id)
+FOR UPDATE;
+
+-- name: CreateInventoryMovement :one
+INSERT INTO inventory_movements (
+    product_id,
+    shop_id,
+    movement_type,
+    quantity,
+    product_allocation_id,
+    claim_resolution_id,
+    warranty_part_id,
+    remarks,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: GetInventoryOnHand :one
+-- On-hand quantity of a product at a shop, or at HQ when shop_id is NULL.
+SELECT COALESCE(SUM(quantity), 0)::numeric AS on_hand
+FROM inventory_movements
+WHERE product_id = sqlc.arg(product_id)
+    AND shop_id IS NOT DISTINCT FROM sqlc.narg(shop_id);
+
+-- name: ListInventoryBalances :many
+SELECT
+    im.product_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    im.shop_id,
+    s.shop_name,
+    s.branch_code,
+    SUM(im.quantity)::numeric AS on_hand
+FROM inventory_movements im
+JOIN products p ON p.id = im.product_id
+JOIN product_names pn ON pn.id = p.name_id
+LEFT JOIN shops s ON s.id = im.shop_id
+GROUP BY im.product_id, p.film_serial_number, pn.name, im.shop_id, s.shop_name, s.branch_code
+HAVING SUM(im.quantity) <> 0
+ORDER BY s.shop_name NULLS FIRST, pn.name, p.film_serial_number;
+
+-- name: ListHQInventoryBalances :many
+SELECT
+    im.product_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    SUM(im.quantity)::numeric AS on_hand
+FROM inventory_movements im
+JOIN products p ON p.id = im.product_id
+JOIN product_names pn ON pn.id = p.name_id
+WHERE im.shop_id IS NULL
+GROUP BY im.product_id, p.film_serial_number, pn.name
+HAVING SUM(im.quantity) <> 0
+ORDER BY pn.name, p.film_serial_number;
+
+-- name: ListShopInventoryBalances :many
+SELECT
+    im.product_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    SUM(im.quantity)::numeric AS on_hand
+FROM inventory_movements im
+JOIN products p ON p.id = im.product_id
+JOIN product_names pn ON pn.id = p.name_id
+WHERE im.shop_id = $1
+GROUP BY im.product_id, p.film_serial_number, pn.name
+HAVING SUM(im.quantity) <> 0
+ORDER BY pn.name, p.film_serial_number;
+
+-- name: ListInventoryMovements :many
+-- Movements newest first, optionally narrowed to a product and to a shop or HQ.
+SELECT
+    im.id,
+    im.product_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    im.shop_id,
+    s.shop_name,
+    im.movement_type,
+    im.quantity,
+    im.product_allocation_id,
+    im.claim_resolution_id,
+    im.warranty_part_id,
+    im.remarks,
+    im.created_by_user_id,
+    im.created_at
+FROM inventory_movements im
+JOIN products p ON p.id = im.product_id
+JOIN product_names pn ON pn.id = p.name_id
+LEFT JOIN shops s ON s.id = im.shop_id
+WHERE (sqlc.narg(product_id)::int IS NULL OR im.product_id = sqlc.narg(product_id))
+    AND (sqlc.narg(shop_id)::int IS NULL OR im.shop_id = sqlc.narg(shop_id))
+    AND (NOT sqlc.arg(hq_only)::boolean OR im.shop_id IS NULL)
+ORDER BY im.created_at DESC, im.id DESC;
+
+-- name: ListInventoryNetByClaimResolutionID :many
+-- What the ledger currently holds for a claim resolution, so that a correction can reverse it.
+SELECT
+    product_id,
+    shop_id,
+    product_allocation_id,
+    SUM(quantity)::numeric AS quantity
+FROM inventory_movements
+WHERE claim_resolution_id = $1
+GROUP BY product_id, shop_id, product_allocation_id
+HAVING SUM(quantity) <> 0;
+
+-- name: ListInventoryNetByWarrantyPartID :many
+-- What the ledger currently holds for a warranty part's install, so that a correction can reverse it.
+SELECT
+    product_id,
+    shop_id,
+    product_allocation_id,
+    SUM(quantity)::numeric AS quantity
+FROM inventory_movements
+WHERE warranty_part_id = $1
+GROUP BY product_id, shop_id, product_allocation_id
+HAVING SUM(quantity) <> 0;
@@ -0,0 +1,143 @@
+-- name: CreateNotification :one
+INSERT INTO notifications (
+    event_type,
+    channel,
+    recipient,
+    warranty_id,
+    claim_id,
+    payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetNotificationByID :one
+SELECT
+    *
+FROM notifications
+WHERE id = $1;
+
+-- name: ListNotifications :many
+SELECT
+    *
+FROM notifications
+ORDER BY created_at DESC;
+
+-- name: ListNotificationsByStatus :many
+SELECT
+    *
+FROM notifications
+WHERE status = $1
+ORDER BY created_at DESC;
+
+-- name: GetNotificationsByWarrantyID :many
+SELECT
+    *
+FROM notifications
+WHERE warranty_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetNotificationsByClaimID :many
+SELECT
+    *
+FROM notifications
+WHERE claim_id = $1
+ORDER BY created_at DESC;
+
+-- name: ClaimDueNotification :one
+-- Leases the next due notification to a dispatcher by pushing its next attempt past the lease,
+-- so that concurrent dispatchers skip it while it is being sent. A dispatcher that stops
+-- mid-send leaves the notification to be picked up again once the lease runs out.
+UPDATE notifications
+SET
+    next_attempt_at = CURRENT_TIMESTAMP + (sqlc.arg(lease_seconds)::int * INTERVAL '1 second'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = (
+    SELECT n.id
+    FROM notifications n
+    WHERE n.status = 'PENDING'
+      AND n.next_attempt_at <= CURRENT_TIMESTAMP
+    ORDER BY n.next_attempt_at ASC, n.id ASC
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkNotificationSent :one
+UPDATE notifications
+SET
+    status = 'SENT',
+    provider = $2,
+    locale = $3,
+    subject = $4,
+    body = $5,
+    attempts = attempts + 1,
+    last_error = NULL,
+    sent_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkNotificationRetry :one
+UPDATE notifications
+SET
+    provider = $2,
+    attempts = attempts + 1,
+    last_error = $3,
+    next_attempt_at = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkNotificationFailed :one
+UPDATE notifications
+SET
+    status = 'FAILED',
+    provider = $2,
+    attempts = attempts + 1,
+    last_error = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkNotificationSkipped :one
+UPDATE notifications
+SET
+    status = 'SKIPPED',
+    last_error = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: RequeueNotification :one
+-- Only a failed or skipped notification is requeued, so that one a dispatcher is still sending
+-- cannot be picked up again. The attempts keep counting; the notification gets retry_attempts
+-- more of them.
+UPDATE notifications
+SET
+    status = 'PENDING',
+    max_attempts = attempts + sqlc.arg(retry_attempts)::int,
+    next_attempt_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)
+    AND status IN ('FAILED', 'SKIPPED')
+RETURNING *;
+
+-- name: CreateNotificationAttempt :one
+INSERT INTO notification_attempts (
+    notification_id,
+    attempt_no,
+    provider,
+    is_success,
+    error_message
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetNotificationAttemptsByNotificationID :many
+SELECT
+    *
+FROM notification_attempts
+WHERE notification_id = $1
+ORDER BY attempt_no ASC;
@@ -0,0 +1,112 @@
+-- Customer portal queries. Portal sessions are scoped to the phone number or e-mail
+-- address verified with a one-time code: a customer sees the warranties of every
+-- active (not merged) customer record sharing that contact.
+
+-- name: ListPortalCustomersByContact :many
+SELECT
+    *
+FROM customers c
+WHERE c.merged_into_id IS NULL
+    AND (
+        (sqlc.arg(channel)::text = 'sms' AND c.phone_normalized = sqlc.arg(contact)::text)
+        OR (sqlc.arg(channel)::text = 'email' AND c.email_normalized = sqlc.arg(contact)::text)
+    )
+ORDER BY c.id ASC;
+
+-- name: CreateCustomerOtp :one
+INSERT INTO customer_otps (
+    channel,
+    recipient,
+    code_hash,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetLatestCustomerOtp :one
+SELECT
+    *
+FROM customer_otps
+WHERE channel = $1
+    AND recipient = $2
+    AND consumed_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CountCustomerOtpsSince :one
+SELECT
+    COUNT(*)
+FROM customer_otps
+WHERE channel = sqlc.arg(channel)
+    AND recipient = sqlc.arg(recipient)
+    AND created_at >= sqlc.arg(since);
+
+-- name: IncrementCustomerOtpAttempts :one
+UPDATE customer_otps
+SET
+    attempts = attempts + 1
+WHERE id = $1
+RETURNING *;
+
+-- name: ConsumeCustomerOtps :exec
+UPDATE customer_otps
+SET
+    consumed_at = CURRENT_TIMESTAMP
+WHERE channel = $1
+    AND recipient = $2
+    AND consumed_at IS NULL;
+
+-- name: ListPortalWarranties :many
+SELECT
+    w.*,
+    s.shop_name,
+    s.branch_code
+FROM warranties w
+JOIN shops s ON w.shop_id = s.id
+JOIN customers c ON w.customer_id = c.id
+WHERE c.merged_into_id IS NULL
+    AND (
+        (sqlc.arg(channel)::text = 'sms' AND c.phone_normalized = sqlc.arg(contact)::text)
+        OR (sqlc.arg(channel)::text = 'email' AND c.email_normalized = sqlc.arg(contact)::text)
+    )
+ORDER BY w.installation_date DESC;
+
+-- name: GetPortalWarrantyByID :one
+SELECT
+    w.*,
+    s.shop_name,
+    s.branch_code
+FROM warranties w
+JOIN shops s ON w.shop_id = s.id
+JOIN customers c ON w.customer_id = c.id
+WHERE w.id = sqlc.arg(id)
+    AND c.merged_into_id IS NULL
+    AND (
+        (sqlc.arg(channel)::text = 'sms' AND c.phone_normalized = sqlc.arg(contact)::text)
+        OR (sqlc.arg(channel)::text = 'email' AND c.email_normalized = sqlc.arg(contact)::text)
+    );
+
+-- name: ListPortalClaims :many
+SELECT
+    cv.*
+FROM claim_view cv
+JOIN warranties w ON cv.warranty_id = w.id
+JOIN customers c ON w.customer_id = c.id
+WHERE c.merged_into_id IS NULL
+    AND (
+        (sqlc.arg(channel)::text = 'sms' AND c.phone_normalized = sqlc.arg(contact)::text)
+        OR (sqlc.arg(channel)::text = 'email' AND c.email_normalized = sqlc.arg(contact)::text)
+    )
+ORDER BY cv.claim_date DESC;
+
+-- name: CreatePortalClaim :one
+INSERT INTO claims (
+    warranty_id,
+    claim_no,
+    claim_date,
+    submitted_by_customer_id
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
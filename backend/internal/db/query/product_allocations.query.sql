@@ -6,6 +6,8 @@ SELECT
     shop_name,
     branch_code,
     film_quantity,
+    unit_of_measure,
+    unit_quantity,
     allocation_date,
     created_at,
     updated_at
@@ -17,11 +19,13 @@ INSERT INTO product_allocations (
     product_id,
     shop_id,
     film_quantity,
+    unit_of_measure,
+    unit_quantity,
     allocation_date,
     created_at,
     updated_at
 ) VALUES (
-    $1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+    $1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
 )
 RETURNING *;
 
@@ -33,7 +37,9 @@ SELECT
     film_quantity,
     allocation_date,
     created_at,
-    updated_at
+    updated_at,
+    unit_of_measure,
+    unit_quantity
 FROM product_allocations
 WHERE id = $1;
 
@@ -43,7 +49,9 @@ SET
     product_id = $2,
     shop_id = $3,
     film_quantity = $4,
-    allocation_date = $5,
+    unit_of_measure = $5,
+    unit_quantity = $6,
+    allocation_date = $7,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
@@ -59,9 +67,14 @@ SELECT
     p.warranty_in_months,
     p.film_serial_number,
     p.film_quantity,
+    p.unit_of_measure,
+    p.roll_length_metres,
+    p.sheets_per_roll,
     p.shipment_number,
-    p.description
+    p.description,
+    bal.remaining_quantity
 FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
 JOIN products p ON pa.product_id = p.id
 JOIN product_names pn ON p.name_id = pn.id
 JOIN product_series ps ON pn.series_id = ps.id
@@ -69,4 +82,174 @@ JOIN product_types pt ON ps.type_id = pt.id
 JOIN product_brands pb ON pt.brand_id = pb.id
 AND p.is_active = TRUE
 WHERE pa.shop_id = $1
-ORDER BY brand_name ASC;
\ No newline at end of file
+ORDER BY brand_name ASC;
+
+-- name: ListProductAllocationBalancesByShopID :many
+-- Remaining film per allocation, as defined by product_allocation_balances_view.
+SELECT
+    pa.id AS product_allocation_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    pa.allocation_date,
+    bal.allocated_quantity,
+    bal.consumed_quantity,
+    bal.installed_quantity,
+    bal.transferred_quantity,
+    bal.adjusted_quantity,
+    bal.remaining_quantity
+FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE pa.shop_id = $1
+ORDER BY pa.allocation_date DESC, pa.id DESC;
+
+-- name: GetProductAllocationByIDForUpdate :one
+SELECT
+    id,
+    product_id,
+    shop_id,
+    film_quantity,
+    allocation_date,
+    created_at,
+    updated_at,
+    unit_of_measure,
+    unit_quantity
+FROM product_allocations
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetProductAllocationUsage :one
+-- What already draws on an allocation: film used by claim resolutions and by warranty installs,
+-- film returned or transferred out, stock-take adjustments posted against it, the film left as
+-- defined by product_allocation_balances_view, warranty parts installed from it, and whether it
+-- was opened by a transfer.
+SELECT
+    bal.consumed_quantity,
+    bal.installed_quantity,
+    bal.transferred_quantity,
+    bal.adjusted_quantity,
+    bal.remaining_quantity,
+    (SELECT COUNT(*) FROM warranty_parts wp WHERE wp.product_allocation_id = bal.product_allocation_id)::int AS warranty_part_count,
+    EXISTS (SELECT 1 FROM product_allocation_transfers pat WHERE pat.to_product_allocation_id = bal.product_allocation_id) AS is_transfer_in
+FROM product_allocation_balances_view bal
+WHERE bal.product_allocation_id = sqlc.arg(product_allocation_id);
+
+-- name: CreateProductAllocationTransfer :one
+INSERT INTO product_allocation_transfers (
+    product_allocation_id,
+    transfer_type,
+    film_quantity,
+    to_shop_id,
+    to_product_allocation_id,
+    transfer_date,
+    remarks,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListProductAllocationTransfers :many
+-- Returns and transfers newest first, optionally limited to those leaving or reaching a shop.
+SELECT
+    pat.id,
+    pat.product_allocation_id,
+    pat.transfer_type,
+    pat.film_quantity,
+    pa.shop_id AS from_shop_id,
+    fs.shop_name AS from_shop_name,
+    pat.to_shop_id,
+    ts.shop_name AS to_shop_name,
+    pat.to_product_allocation_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    pat.transfer_date,
+    pat.remarks,
+    pat.created_by_user_id,
+    pat.created_at
+FROM product_allocation_transfers pat
+JOIN product_allocations pa ON pat.product_allocation_id = pa.id
+JOIN shops fs ON pa.shop_id = fs.id
+LEFT JOIN shops ts ON pat.to_shop_id = ts.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE (sqlc.narg(shop_id)::int IS NULL OR pa.shop_id = sqlc.narg(shop_id) OR pat.to_shop_id = sqlc.narg(shop_id))
+    AND (sqlc.narg(product_allocation_id)::int IS NULL
+        OR pat.product_allocation_id = sqlc.narg(product_allocation_id)
+        OR pat.to_product_allocation_id = sqlc.narg(product_allocation_id))
+ORDER BY pat.transfer_date DESC, pat.id DESC;
+
+-- name: GetProductByFilmSerialNumber :one
+-- Matches a scanned serial regardless of case, preferring an exact match.
+SELECT
+    p.id,
+    p.film_serial_number,
+    p.film_quantity,
+    p.unit_of_measure,
+    p.roll_length_metres,
+    p.sheets_per_roll,
+    p.warranty_in_months,
+    p.shipment_number,
+    p.is_active,
+    pb.name AS brand_name,
+    pt.name AS type_name,
+    ps.name AS series_name,
+    pn.name AS product_name
+FROM products p
+JOIN product_names pn ON p.name_id = pn.id
+JOIN product_series ps ON pn.series_id = ps.id
+JOIN product_types pt ON ps.type_id = pt.id
+JOIN product_brands pb ON pt.brand_id = pb.id
+WHERE UPPER(p.film_serial_number) = UPPER(sqlc.arg(film_serial_number))
+ORDER BY (p.film_serial_number = sqlc.arg(film_serial_number)) DESC, p.id
+LIMIT 1;
+
+-- name: ListProductAllocationBalancesByProductAndShop :many
+-- Remaining film of each allocation of a product at a shop, newest first.
+SELECT
+    pa.id AS product_allocation_id,
+    pa.allocation_date,
+    bal.allocated_quantity,
+    bal.consumed_quantity,
+    bal.installed_quantity,
+    bal.transferred_quantity,
+    bal.adjusted_quantity,
+    bal.remaining_quantity
+FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
+WHERE pa.product_id = sqlc.arg(product_id)
+    AND pa.shop_id = sqlc.arg(shop_id)
+ORDER BY pa.allocation_date DESC, pa.id DESC;
+
+-- name: GetProductUnitsByID :one
+SELECT
+    id,
+    unit_of_measure,
+    roll_length_metres,
+    sheets_per_roll
+FROM products
+WHERE id = $1;
+
+-- name: GetInProgressStockTakeNoByAllocationID :one
+-- The stock take, open or awaiting review, that is counting the allocation.
+SELECT
+    st.stock_take_no
+FROM stock_take_lines stl
+JOIN stock_takes st ON st.id = stl.stock_take_id
+WHERE stl.product_allocation_id = $1
+    AND st.status IN ('OPEN', 'SUBMITTED')
+LIMIT 1;
+
+-- name: GetInstallFreezeRecallNoByProductID :one
+-- The open recall, if any, that freezes installs from the film of a product, so that no more of
+-- it is allocated to shops.
+SELECT
+    r.recall_no
+FROM recalls r
+JOIN recall_products rp ON rp.recall_id = r.id
+WHERE rp.product_id = sqlc.arg(product_id)
+    AND r.status = 'OPEN'
+    AND r.freeze_installs
+ORDER BY r.id
+LIMIT 1;
@@ -24,9 +24,12 @@ INSERT INTO products (
     film_serial_number,
     film_quantity,
     shipment_number,
-    description
+    description,
+    unit_of_measure,
+    roll_length_metres,
+    sheets_per_roll
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
 RETURNING *;
 
@@ -43,6 +46,9 @@ SET
     shipment_number = $9,
     description = $10,
     is_active = $11,
+    unit_of_measure = $12,
+    roll_length_metres = $13,
+    sheets_per_roll = $14,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
@@ -53,8 +59,10 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_brands
+WHERE is_active OR sqlc.arg(include_inactive)::boolean
 ORDER BY name;
 
 -- name: ListProductTypes :many
@@ -64,8 +72,10 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_types
+WHERE is_active OR sqlc.arg(include_inactive)::boolean
 ORDER BY name;
 
 -- name: ListProductSeries :many
@@ -75,8 +85,10 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_series
+WHERE is_active OR sqlc.arg(include_inactive)::boolean
 ORDER BY name;
 
 -- name: ListProductNames :many
@@ -86,6 +98,281 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_names
-ORDER BY name;
\ No newline at end of file
+WHERE is_active OR sqlc.arg(include_inactive)::boolean
+ORDER BY name;
+
+-- name: GetProductBrandByID :one
+SELECT
+    id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_brands
+WHERE id = $1;
+
+-- name: CountProductBrandsByName :one
+SELECT COUNT(*)::int AS total
+FROM product_brands
+WHERE LOWER(name) = LOWER(sqlc.arg(name))
+    AND id <> sqlc.arg(exclude_id);
+
+-- name: CreateProductBrand :one
+INSERT INTO product_brands (
+    name,
+    description
+) VALUES (
+    $1, $2
+)
+RETURNING *;
+
+-- name: UpdateProductBrand :one
+UPDATE product_brands
+SET
+    name = $2,
+    description = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProductBrandActive :one
+UPDATE product_brands
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CountProductsByBrandID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE brand_id = $1;
+
+-- name: CountActiveProductTypesByBrandID :one
+SELECT COUNT(*)::int AS total
+FROM product_types
+WHERE brand_id = $1
+    AND is_active;
+
+-- name: GetProductTypeByID :one
+SELECT
+    id,
+    brand_id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_types
+WHERE id = $1;
+
+-- name: CountProductTypesByName :one
+SELECT COUNT(*)::int AS total
+FROM product_types
+WHERE brand_id = sqlc.arg(brand_id)
+    AND LOWER(name) = LOWER(sqlc.arg(name))
+    AND id <> sqlc.arg(exclude_id);
+
+-- name: CreateProductType :one
+INSERT INTO product_types (
+    brand_id,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateProductType :one
+UPDATE product_types
+SET
+    brand_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProductTypeActive :one
+UPDATE product_types
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CountProductsByTypeID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE type_id = $1;
+
+-- name: CountActiveProductSeriesByTypeID :one
+SELECT COUNT(*)::int AS total
+FROM product_series
+WHERE type_id = $1
+    AND is_active;
+
+-- name: GetProductSeriesByID :one
+SELECT
+    id,
+    type_id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_series
+WHERE id = $1;
+
+-- name: CountProductSeriesByName :one
+SELECT COUNT(*)::int AS total
+FROM product_series
+WHERE type_id = sqlc.arg(type_id)
+    AND LOWER(name) = LOWER(sqlc.arg(name))
+    AND id <> sqlc.arg(exclude_id);
+
+-- name: CreateProductSeries :one
+INSERT INTO product_series (
+    type_id,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateProductSeries :one
+UPDATE product_series
+SET
+    type_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProductSeriesActive :one
+UPDATE product_series
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CountProductsBySeriesID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE series_id = $1;
+
+-- name: CountActiveProductNamesBySeriesID :one
+SELECT COUNT(*)::int AS total
+FROM product_names
+WHERE series_id = $1
+    AND is_active;
+
+-- name: GetProductNameByID :one
+SELECT
+    id,
+    series_id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_names
+WHERE id = $1;
+
+-- name: CountProductNamesByName :one
+SELECT COUNT(*)::int AS total
+FROM product_names
+WHERE series_id = sqlc.arg(series_id)
+    AND LOWER(name) = LOWER(sqlc.arg(name))
+    AND id <> sqlc.arg(exclude_id);
+
+-- name: CreateProductName :one
+INSERT INTO product_names (
+    series_id,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateProductName :one
+UPDATE product_names
+SET
+    series_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetProductNameActive :one
+UPDATE product_names
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CountProductsByNameID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE name_id = $1;
+
+-- name: GetProductHierarchyByNameID :one
+SELECT
+    pn.id AS name_id,
+    ps.id AS series_id,
+    pt.id AS type_id,
+    pb.id AS brand_id,
+    pn.is_active AS name_is_active
+FROM product_names pn
+JOIN product_series ps ON ps.id = pn.series_id
+JOIN product_types pt ON pt.id = ps.type_id
+JOIN product_brands pb ON pb.id = pt.brand_id
+WHERE pn.id = sqlc.arg(name_id);
+
+-- name: ListInconsistentProductHierarchies :many
+SELECT
+    p.id,
+    p.film_serial_number,
+    p.name_id,
+    p.brand_id,
+    p.type_id,
+    p.series_id,
+    pb.id AS expected_brand_id,
+    pt.id AS expected_type_id,
+    ps.id AS expected_series_id
+FROM products p
+JOIN product_names pn ON pn.id = p.name_id
+JOIN product_series ps ON ps.id = pn.series_id
+JOIN product_types pt ON pt.id = ps.type_id
+JOIN product_brands pb ON pb.id = pt.brand_id
+WHERE p.brand_id <> pb.id
+    OR p.type_id <> pt.id
+    OR p.series_id <> ps.id
+ORDER BY p.id;
+
+-- name: UpdateProductHierarchy :exec
+UPDATE products
+SET
+    brand_id = $2,
+    type_id = $3,
+    series_id = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
@@ -0,0 +1,41 @@
+-- name: CreatePublicLookupLog :one
+INSERT INTO public_lookup_logs (
+    ip_address,
+    user_agent,
+    search_term,
+    result_count,
+    outcome
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ListPublicLookupLogs :many
+SELECT
+    *
+FROM public_lookup_logs
+WHERE created_at >= sqlc.arg(since)
+    AND (sqlc.arg(ip_address)::text = '' OR ip_address = sqlc.arg(ip_address)::text)
+ORDER BY created_at DESC
+LIMIT 1000;
+
+-- name: ListPublicLookupSummaries :many
+-- Lookups per client, busiest first. Many distinct search terms from one address
+-- usually means someone is enumerating plate numbers.
+SELECT
+    ip_address,
+    COUNT(*) AS lookup_count,
+    COUNT(DISTINCT search_term) AS distinct_search_terms,
+    COUNT(*) FILTER (WHERE outcome = 'OK' AND result_count > 0) AS found_count,
+    COUNT(*) FILTER (WHERE outcome <> 'OK') AS blocked_count,
+    MIN(created_at)::timestamptz AS first_seen_at,
+    MAX(created_at)::timestamptz AS last_seen_at
+FROM public_lookup_logs
+WHERE created_at >= sqlc.arg(since)
+GROUP BY ip_address
+ORDER BY lookup_count DESC, ip_address ASC
+LIMIT 100;
+
+-- name: DeletePublicLookupLogsBefore :execrows
+DELETE FROM public_lookup_logs
+WHERE created_at < sqlc.arg(before);
@@ -0,0 +1,173 @@
+-- name: ListRecalls :many
+SELECT
+    r.id,
+    r.recall_no,
+    r.scope,
+    r.product_id,
+    p.film_serial_number,
+    r.shipment_id,
+    s.shipment_number,
+    r.reason,
+    r.status,
+    r.freeze_installs,
+    r.customers_notified_at,
+    r.created_at,
+    r.closed_at,
+    (SELECT COUNT(*) FROM recall_products rp WHERE rp.recall_id = r.id)::int AS product_count,
+    (SELECT COUNT(DISTINCT wp.warranty_id)
+        FROM recall_products rp
+        JOIN product_allocations pa ON pa.product_id = rp.product_id
+        JOIN warranty_parts wp ON wp.product_allocation_id = pa.id
+        WHERE rp.recall_id = r.id)::int AS warranty_count
+FROM recalls r
+LEFT JOIN products p ON p.id = r.product_id
+LEFT JOIN shipments s ON s.id = r.shipment_id
+ORDER BY r.created_at DESC, r.id DESC;
+
+-- name: GetRecallByID :one
+SELECT *
+FROM recalls
+WHERE id = $1;
+
+-- name: GetRecallByIDForUpdate :one
+SELECT *
+FROM recalls
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetLatestRecallNoByPrefix :one
+SELECT
+    recall_no
+FROM recalls
+WHERE recall_no LIKE $1
+ORDER BY recall_no DESC
+LIMIT 1;
+
+-- name: GetProductIDByFilmSerialNumber :one
+-- Matches a serial regardless of case, preferring an exact match.
+SELECT
+    id
+FROM products
+WHERE UPPER(film_serial_number) = UPPER(sqlc.arg(film_serial_number))
+ORDER BY (film_serial_number = sqlc.arg(film_serial_number)) DESC, id
+LIMIT 1;
+
+-- name: GetShipmentNumberByID :one
+SELECT
+    shipment_number
+FROM shipments
+WHERE id = $1;
+
+-- name: ListProductIDsByShipmentID :many
+SELECT
+    id
+FROM products
+WHERE shipment_id = $1
+ORDER BY id;
+
+-- name: CreateRecall :one
+INSERT INTO recalls (
+    recall_no,
+    scope,
+    product_id,
+    shipment_id,
+    reason,
+    freeze_installs,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: AddRecallProduct :exec
+INSERT INTO recall_products (
+    recall_id,
+    product_id
+) VALUES (
+    $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: CloseRecall :one
+UPDATE recalls
+SET
+    status = 'CLOSED',
+    closed_by_user_id = $2,
+    closed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetRecallCustomersNotified :one
+UPDATE recalls
+SET
+    customers_notified_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ListRecallProducts :many
+SELECT
+    p.id,
+    p.film_serial_number,
+    p.film_quantity,
+    p.shipment_number,
+    pn.name AS product_name
+FROM recall_products rp
+JOIN products p ON p.id = rp.product_id
+JOIN product_names pn ON pn.id = p.name_id
+WHERE rp.recall_id = $1
+ORDER BY p.film_serial_number;
+
+-- name: ListRecallAllocations :many
+-- Every allocation of the recalled film, including allocations opened by transfers between
+-- shops, with the film still unused at the shop.
+SELECT
+    pa.id,
+    pa.product_id,
+    p.film_serial_number,
+    pa.shop_id,
+    sh.shop_name,
+    sh.branch_code,
+    pa.film_quantity,
+    pa.allocation_date,
+    bal.remaining_quantity,
+    (SELECT COUNT(*) FROM warranty_parts wp WHERE wp.product_allocation_id = pa.id)::int AS warranty_part_count
+FROM recall_products rp
+JOIN product_allocations pa ON pa.product_id = rp.product_id
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
+JOIN products p ON p.id = pa.product_id
+JOIN shops sh ON sh.id = pa.shop_id
+WHERE rp.recall_id = $1
+ORDER BY sh.shop_name, pa.allocation_date, pa.id;
+
+-- name: ListRecallWarrantyParts :many
+-- Warranty parts installed from the recalled film, with the warranty, shop and customer.
+SELECT
+    wp.id,
+    wp.warranty_id,
+    w.warranty_no,
+    w.installation_date,
+    w.approval_status,
+    w.is_active,
+    w.customer_id,
+    w.client_name,
+    w.client_contact,
+    w.client_email,
+    w.car_brand,
+    w.car_model,
+    w.car_plate_no,
+    w.shop_id,
+    sh.shop_name,
+    wp.product_allocation_id,
+    p.film_serial_number,
+    cp.name AS car_part_name
+FROM recall_products rp
+JOIN product_allocations pa ON pa.product_id = rp.product_id
+JOIN warranty_parts wp ON wp.product_allocation_id = pa.id
+JOIN warranties w ON w.id = wp.warranty_id
+JOIN shops sh ON sh.id = w.shop_id
+JOIN products p ON p.id = pa.product_id
+JOIN car_parts cp ON cp.id = wp.car_part_id
+WHERE rp.recall_id = $1
+ORDER BY sh.shop_name, w.warranty_no, wp.id;
@@ -0,0 +1,115 @@
+-- name: ListReimbursementStatements :many
+SELECT
+    rs.*,
+    s.shop_name,
+    s.branch_code
+FROM shop_reimbursement_statements rs
+JOIN shops s ON rs.shop_id = s.id
+ORDER BY rs.created_at DESC;
+
+-- name: ListReimbursementStatementsByShopID :many
+SELECT
+    rs.*,
+    s.shop_name,
+    s.branch_code
+FROM shop_reimbursement_statements rs
+JOIN shops s ON rs.shop_id = s.id
+WHERE rs.shop_id = $1
+ORDER BY rs.created_at DESC;
+
+-- name: GetReimbursementStatementByID :one
+SELECT
+    rs.*,
+    s.shop_name,
+    s.branch_code
+FROM shop_reimbursement_statements rs
+JOIN shops s ON rs.shop_id = s.id
+WHERE rs.id = $1;
+
+-- name: CreateReimbursementStatement :one
+INSERT INTO shop_reimbursement_statements (
+    shop_id,
+    period_start,
+    period_end,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: AssignClaimResolutionsToStatement :exec
+-- Adds the shop's unbilled resolutions of claims resolved within the period to the statement.
+UPDATE claim_resolutions cr
+SET
+    reimbursement_statement_id = sqlc.arg(statement_id),
+    updated_at = CURRENT_TIMESTAMP
+FROM claim_warranty_parts cwp, claims c, warranties w
+WHERE cr.claim_warranty_part_id = cwp.id
+    AND cwp.claim_id = c.id
+    AND c.warranty_id = w.id
+    AND w.shop_id = sqlc.arg(shop_id)
+    AND cr.reimbursement_statement_id IS NULL
+    AND c.status IN ('RESOLVED', 'CLOSED')
+    AND cwp.resolution_date BETWEEN sqlc.arg(period_start) AND sqlc.arg(period_end);
+
+-- name: UpdateReimbursementStatementTotals :one
+UPDATE shop_reimbursement_statements rs
+SET
+    total_labour_cost = totals.labour_cost,
+    total_material_cost = totals.material_cost,
+    resolution_count = totals.resolution_count,
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT
+        COALESCE(SUM(labour_cost), 0) AS labour_cost,
+        COALESCE(SUM(material_cost), 0) AS material_cost,
+        COUNT(*)::int AS resolution_count
+    FROM claim_resolutions
+    WHERE reimbursement_statement_id = $1
+) totals
+WHERE rs.id = $1
+RETURNING rs.*;
+
+-- name: ReviewReimbursementStatement :one
+UPDATE shop_reimbursement_statements
+SET
+    status = $2,
+    remarks = $3,
+    reviewed_by_user_id = $4,
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND status = 'PENDING'
+RETURNING *;
+
+-- name: ReleaseClaimResolutionsFromStatement :exec
+UPDATE claim_resolutions
+SET
+    reimbursement_statement_id = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE reimbursement_statement_id = $1;
+
+-- name: ListReimbursementStatementLines :many
+SELECT
+    cr.id AS claim_resolution_id,
+    c.claim_no,
+    w.warranty_no,
+    w.car_plate_no,
+    cp.name AS car_part_name,
+    cwp.resolution_date,
+    p.film_serial_number,
+    pn.name AS product_name,
+    cr.quantity_used,
+    cr.labour_cost,
+    cr.material_cost
+FROM claim_resolutions cr
+JOIN claim_warranty_parts cwp ON cr.claim_warranty_part_id = cwp.id
+JOIN claims c ON cwp.claim_id = c.id
+JOIN warranties w ON c.warranty_id = w.id
+JOIN warranty_parts wp ON cwp.warranty_part_id = wp.id
+JOIN car_parts cp ON wp.car_part_id = cp.id
+JOIN product_allocations pa ON cr.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE cr.reimbursement_statement_id = $1
+ORDER BY cwp.resolution_date ASC, c.claim_no ASC;
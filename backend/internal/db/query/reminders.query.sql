@@ -0,0 +1,151 @@
+-- name: ListReminderRules :many
+SELECT
+    r.*,
+    pt.name AS product_type_name
+FROM reminder_rules r
+JOIN product_types pt ON r.product_type_id = pt.id
+ORDER BY r.id ASC;
+
+-- name: ListActiveReminderRules :many
+SELECT
+    *
+FROM reminder_rules
+WHERE is_active = TRUE
+ORDER BY id ASC;
+
+-- name: GetReminderRuleByID :one
+SELECT
+    *
+FROM reminder_rules
+WHERE id = $1;
+
+-- name: CreateReminderRule :one
+INSERT INTO reminder_rules (
+    name,
+    product_type_id,
+    reminder_type,
+    offset_days,
+    offset_months,
+    window_days,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: UpdateReminderRule :one
+UPDATE reminder_rules
+SET
+    name = $2,
+    product_type_id = $3,
+    reminder_type = $4,
+    offset_days = $5,
+    offset_months = $6,
+    window_days = $7,
+    is_active = $8,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ListReminderCandidatesByRuleID :many
+-- Approved warranties with a part of the rule's product type whose reminder is due
+-- (and at most window_days overdue) and has not been queued yet.
+SELECT
+    w.id,
+    w.shop_id,
+    w.client_name,
+    w.client_contact,
+    w.client_email,
+    w.car_brand,
+    w.car_model,
+    w.car_plate_no,
+    w.warranty_no,
+    MIN(w.installation_date + make_interval(months => p.warranty_in_months))::date AS expiry_date,
+    MIN(
+        CASE
+            WHEN r.reminder_type = 'WARRANTY_EXPIRY'
+                THEN w.installation_date + make_interval(months => p.warranty_in_months) - make_interval(days => r.offset_days)
+            ELSE w.installation_date + make_interval(months => r.offset_months, days => r.offset_days)
+        END
+    )::date AS due_date
+FROM reminder_rules r
+JOIN products p ON p.type_id = r.product_type_id
+JOIN product_allocations pa ON pa.product_id = p.id
+JOIN warranty_parts wp ON wp.product_allocation_id = pa.id
+JOIN warranties w ON wp.warranty_id = w.id
+WHERE r.id = $1
+  AND r.is_active = TRUE
+  AND w.is_active = TRUE
+  AND w.approval_status IN ('APPROVED', 'PARTIALLY_APPROVED')
+  AND wp.approval_status = 'APPROVED'
+  AND NOT EXISTS (
+      SELECT 1 FROM reminder_deliveries rd
+      WHERE rd.reminder_rule_id = r.id AND rd.warranty_id = w.id
+  )
+GROUP BY w.id, r.id
+HAVING MIN(
+        CASE
+            WHEN r.reminder_type = 'WARRANTY_EXPIRY'
+                THEN w.installation_date + make_interval(months => p.warranty_in_months) - make_interval(days => r.offset_days)
+            ELSE w.installation_date + make_interval(months => r.offset_months, days => r.offset_days)
+        END
+    )::date BETWEEN CURRENT_DATE - r.window_days AND CURRENT_DATE
+ORDER BY w.id ASC;
+
+-- name: CreateReminderDelivery :one
+-- Returns no rows when the reminder has already been queued for the warranty.
+INSERT INTO reminder_deliveries (
+    reminder_rule_id,
+    warranty_id,
+    due_date,
+    expiry_date
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (reminder_rule_id, warranty_id) DO NOTHING
+RETURNING *;
+
+-- name: ListReminderDeliveriesByRuleID :many
+SELECT
+    *
+FROM reminder_deliveries
+WHERE reminder_rule_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListRenewalLeads :many
+-- Warranties that received an expiry reminder, for shops to follow up on renewals.
+SELECT
+    rd.*,
+    r.name AS reminder_rule_name,
+    w.shop_id,
+    w.warranty_no,
+    w.client_name,
+    w.client_contact,
+    w.client_email,
+    w.car_brand,
+    w.car_model,
+    w.car_plate_no
+FROM reminder_deliveries rd
+JOIN reminder_rules r ON rd.reminder_rule_id = r.id
+JOIN warranties w ON rd.warranty_id = w.id
+WHERE r.reminder_type = 'WARRANTY_EXPIRY'
+ORDER BY rd.expiry_date ASC;
+
+-- name: ListRenewalLeadsByShopID :many
+SELECT
+    rd.*,
+    r.name AS reminder_rule_name,
+    w.shop_id,
+    w.warranty_no,
+    w.client_name,
+    w.client_contact,
+    w.client_email,
+    w.car_brand,
+    w.car_model,
+    w.car_plate_no
+FROM reminder_deliveries rd
+JOIN reminder_rules r ON rd.reminder_rule_id = r.id
+JOIN warranties w ON rd.warranty_id = w.id
+WHERE r.reminder_type = 'WARRANTY_EXPIRY'
+  AND w.shop_id = $1
+ORDER BY rd.expiry_date ASC;
@@ -0,0 +1,195 @@
+-- name: ListStockThresholds :many
+-- Thresholds with the shop's current balance of the product name: the remaining film of the
+-- shop's allocations of it, as defined by product_allocation_balances_view.
+SELECT
+    t.id,
+    t.shop_id,
+    s.shop_name,
+    s.branch_code,
+    t.name_id,
+    pn.name AS product_name,
+    t.min_quantity,
+    t.reorder_quantity,
+    t.is_active,
+    COALESCE((
+        SELECT SUM(bal.remaining_quantity)
+        FROM product_allocation_balances_view bal
+        JOIN products p ON p.id = bal.product_id
+        WHERE bal.shop_id = t.shop_id
+            AND p.name_id = t.name_id
+    ), 0)::numeric AS balance,
+    t.created_at,
+    t.updated_at
+FROM shop_stock_thresholds t
+JOIN shops s ON s.id = t.shop_id
+JOIN product_names pn ON pn.id = t.name_id
+WHERE (sqlc.narg(shop_id)::int IS NULL OR t.shop_id = sqlc.narg(shop_id))
+ORDER BY s.shop_name, pn.name;
+
+-- name: GetStockThresholdByID :one
+SELECT *
+FROM shop_stock_thresholds
+WHERE id = $1;
+
+-- name: UpsertStockThreshold :one
+INSERT INTO shop_stock_thresholds (
+    shop_id,
+    name_id,
+    min_quantity,
+    reorder_quantity,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (shop_id, name_id) DO UPDATE
+SET
+    min_quantity = EXCLUDED.min_quantity,
+    reorder_quantity = EXCLUDED.reorder_quantity,
+    is_active = EXCLUDED.is_active,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: DeleteStockThreshold :exec
+DELETE FROM shop_stock_thresholds
+WHERE id = $1;
+
+-- name: ListStockThresholdChecks :many
+-- Active thresholds, and inactive ones with an open alert, with the shop's current balance and
+-- the open alert if any. The balance is computed as in ListStockThresholds.
+SELECT
+    t.id,
+    t.shop_id,
+    s.shop_name,
+    s.company_email,
+    s.pic_email,
+    t.name_id,
+    pn.name AS product_name,
+    t.min_quantity,
+    t.is_active,
+    COALESCE((
+        SELECT SUM(bal.remaining_quantity)
+        FROM product_allocation_balances_view bal
+        JOIN products p ON p.id = bal.product_id
+        WHERE bal.shop_id = t.shop_id
+            AND p.name_id = t.name_id
+    ), 0)::numeric AS balance,
+    a.id AS open_alert_id
+FROM shop_stock_thresholds t
+JOIN shops s ON s.id = t.shop_id
+JOIN product_names pn ON pn.id = t.name_id
+LEFT JOIN low_stock_alerts a ON a.threshold_id = t.id AND a.resolved_at IS NULL
+WHERE t.is_active
+    OR a.id IS NOT NULL
+ORDER BY t.id;
+
+-- name: CreateLowStockAlert :one
+-- Opens an alert unless the threshold already has one open; no row is returned in that case.
+INSERT INTO low_stock_alerts (
+    threshold_id,
+    balance,
+    min_quantity
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (threshold_id) WHERE resolved_at IS NULL DO NOTHING
+RETURNING *;
+
+-- name: ResolveLowStockAlert :exec
+UPDATE low_stock_alerts
+SET resolved_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND resolved_at IS NULL;
+
+-- name: ListLowStockAlerts :many
+-- Alerts newest first, optionally narrowed to a shop and to alerts still open.
+SELECT
+    a.id,
+    a.threshold_id,
+    t.shop_id,
+    s.shop_name,
+    t.name_id,
+    pn.name AS product_name,
+    a.balance,
+    a.min_quantity,
+    a.alerted_at,
+    a.resolved_at
+FROM low_stock_alerts a
+JOIN shop_stock_thresholds t ON t.id = a.threshold_id
+JOIN shops s ON s.id = t.shop_id
+JOIN product_names pn ON pn.id = t.name_id
+WHERE (sqlc.narg(shop_id)::int IS NULL OR t.shop_id = sqlc.narg(shop_id))
+    AND (NOT sqlc.arg(open_only)::boolean OR a.resolved_at IS NULL)
+ORDER BY a.alerted_at DESC, a.id DESC;
+
+-- name: GetProductNameByID :one
+SELECT *
+FROM product_names
+WHERE id = $1;
+
+-- name: ListReorderRequests :many
+-- Requests newest first, optionally narrowed to a shop and a status.
+SELECT
+    rr.id,
+    rr.shop_id,
+    s.shop_name,
+    rr.name_id,
+    pn.name AS product_name,
+    rr.requested_quantity,
+    rr.remarks,
+    rr.status,
+    rr.product_allocation_id,
+    rr.requested_by_user_id,
+    rr.reviewed_by_user_id,
+    rr.reviewed_at,
+    rr.review_remarks,
+    rr.created_at,
+    rr.updated_at
+FROM reorder_requests rr
+JOIN shops s ON s.id = rr.shop_id
+JOIN product_names pn ON pn.id = rr.name_id
+WHERE (sqlc.narg(shop_id)::int IS NULL OR rr.shop_id = sqlc.narg(shop_id))
+    AND (sqlc.narg(status)::text IS NULL OR rr.status = sqlc.narg(status))
+ORDER BY rr.created_at DESC, rr.id DESC;
+
+-- name: GetReorderRequestByID :one
+SELECT *
+FROM reorder_requests
+WHERE id = $1;
+
+-- name: GetReorderRequestByIDForUpdate :one
+SELECT *
+FROM reorder_requests
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CreateReorderRequest :one
+INSERT INTO reorder_requests (
+    shop_id,
+    name_id,
+    requested_quantity,
+    remarks,
+    requested_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: ReviewReorderRequest :one
+UPDATE reorder_requests
+SET
+    status = $2,
+    product_allocation_id = $3,
+    reviewed_by_user_id = $4,
+    review_remarks = $5,
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: GetProductForReorder :one
+SELECT
+    id,
+    name_id,
+    is_active
+FROM products
+WHERE id = $1;
@@ -0,0 +1,147 @@
+-- name: ListShipments :many
+SELECT
+    s.id,
+    s.shipment_number,
+    s.supplier_name,
+    s.shipped_date,
+    s.received_date,
+    s.status,
+    s.created_at,
+    COUNT(sl.id)::int AS line_count,
+    COALESCE(SUM(sl.film_quantity), 0)::numeric AS total_quantity
+FROM shipments s
+LEFT JOIN shipment_lines sl ON sl.shipment_id = s.id
+GROUP BY s.id
+ORDER BY s.created_at DESC, s.id DESC;
+
+-- name: GetShipmentByID :one
+SELECT *
+FROM shipments
+WHERE id = $1;
+
+-- name: GetShipmentByIDForUpdate :one
+SELECT *
+FROM shipments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetShipmentByNumberForUpdate :one
+SELECT *
+FROM shipments
+WHERE shipment_number = $1
+FOR UPDATE;
+
+-- name: CreateShipment :one
+INSERT INTO shipments (
+    shipment_number,
+    supplier_name,
+    shipped_date,
+    remarks,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: UpdateShipmentHeader :one
+UPDATE shipments
+SET
+    supplier_name = $2,
+    shipped_date = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: MarkShipmentReceived :one
+UPDATE shipments
+SET
+    status = 'RECEIVED',
+    received_date = $2,
+    received_by_user_id = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ListShipmentLines :many
+SELECT
+    sl.id,
+    sl.shipment_id,
+    sl.name_id,
+    pn.name AS product_name,
+    ps.name AS series_name,
+    sl.film_serial_number,
+    sl.film_quantity,
+    sl.warranty_in_months,
+    sl.description,
+    sl.product_id,
+    sl.created_at,
+    sl.updated_at
+FROM shipment_lines sl
+JOIN product_names pn ON pn.id = sl.name_id
+JOIN product_series ps ON ps.id = pn.series_id
+WHERE sl.shipment_id = $1
+ORDER BY sl.id;
+
+-- name: ListUnreceivedShipmentLines :many
+SELECT *
+FROM shipment_lines
+WHERE shipment_id = $1
+    AND product_id IS NULL
+ORDER BY id;
+
+-- name: GetShipmentLineBySerial :one
+SELECT *
+FROM shipment_lines
+WHERE film_serial_number = $1;
+
+-- name: CreateShipmentLine :one
+INSERT INTO shipment_lines (
+    shipment_id,
+    name_id,
+    film_serial_number,
+    film_quantity,
+    warranty_in_months,
+    description
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: UpdateShipmentLine :one
+UPDATE shipment_lines
+SET
+    name_id = $2,
+    film_quantity = $3,
+    warranty_in_months = $4,
+    description = $5,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: SetShipmentLineProduct :exec
+UPDATE shipment_lines
+SET
+    product_id = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: SetProductShipment :exec
+UPDATE products
+SET shipment_id = $2
+WHERE id = $1;
+
+-- name: CountProductsBySerialNumber :one
+SELECT COUNT(*)::int AS total
+FROM products
+WHERE film_serial_number = $1;
+
+-- name: ListActiveProductNamesWithSeries :many
+-- Catalog used to resolve the product names on a supplier packing list.
+SELECT
+    pn.id,
+    pn.name,
+    ps.name AS series_name
+FROM product_names pn
+JOIN product_series ps ON ps.id = pn.series_id
+WHERE pn.is_active
+ORDER BY pn.id;
@@ -4,7 +4,8 @@ SELECT
     name,
     code,
     created_at,
-    updated_at
+    updated_at,
+    weekend_days
 FROM msia_states;
 
 -- name: GetMsiaStateByID :one
@@ -0,0 +1,86 @@
+-- name: ListClaimSlaPolicies :many
+SELECT
+    *
+FROM claim_sla_policies
+ORDER BY status ASC;
+
+-- name: GetClaimSlaPolicyByStatus :one
+SELECT
+    *
+FROM claim_sla_policies
+WHERE status = $1;
+
+-- name: UpsertClaimSlaPolicy :one
+INSERT INTO claim_sla_policies (
+    status,
+    working_days,
+    is_active
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (status) DO UPDATE
+SET
+    working_days = EXCLUDED.working_days,
+    is_active = EXCLUDED.is_active,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListPublicHolidays :many
+SELECT
+    ph.*,
+    ms.name AS msia_state_name
+FROM public_holidays ph
+LEFT JOIN msia_states ms ON ph.msia_state_id = ms.id
+WHERE EXTRACT(YEAR FROM ph.holiday_date) = sqlc.arg(year)::int
+ORDER BY ph.holiday_date ASC, ms.name ASC NULLS FIRST;
+
+-- name: GetPublicHolidayByID :one
+SELECT
+    *
+FROM public_holidays
+WHERE id = $1;
+
+-- name: CreatePublicHoliday :one
+INSERT INTO public_holidays (
+    holiday_date,
+    name,
+    msia_state_id
+) VALUES (
+    $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdatePublicHoliday :one
+UPDATE public_holidays
+SET
+    holiday_date = $2,
+    name = $3,
+    msia_state_id = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeletePublicHoliday :exec
+DELETE FROM public_holidays
+WHERE id = $1;
+
+-- name: GetClaimCalendar :one
+-- The working-day calendar of the shop that owns the claim. Shops without a state fall
+-- back to a Saturday and Sunday weekend and national holidays only.
+SELECT
+    s.msia_state_id,
+    COALESCE(ms.weekend_days, '{0,6}')::int[] AS weekend_days
+FROM claims c
+JOIN warranties w ON c.warranty_id = w.id
+JOIN shops s ON w.shop_id = s.id
+LEFT JOIN msia_states ms ON s.msia_state_id = ms.id
+WHERE c.id = $1;
+
+-- name: ListPublicHolidayDatesFrom :many
+-- National holidays plus the holidays of the given state from a date onwards.
+SELECT
+    holiday_date
+FROM public_holidays
+WHERE (msia_state_id IS NULL OR msia_state_id = $1)
+    AND holiday_date >= $2
+ORDER BY holiday_date ASC;
@@ -0,0 +1,262 @@
+-- name: ListStockTakes :many
+-- Stock takes newest period first, optionally narrowed to a shop and a status, with how far the
+-- count has got.
+SELECT
+    st.id,
+    st.stock_take_no,
+    st.shop_id,
+    s.shop_name,
+    st.period,
+    st.status,
+    st.tolerance_percent,
+    (SELECT COUNT(*) FROM stock_take_lines stl WHERE stl.stock_take_id = st.id)::int AS line_count,
+    (SELECT COUNT(*) FROM stock_take_lines stl WHERE stl.stock_take_id = st.id AND stl.counted_quantity IS NOT NULL)::int AS counted_count,
+    (SELECT COUNT(*) FROM stock_take_lines stl WHERE stl.stock_take_id = st.id AND stl.is_flagged)::int AS flagged_count,
+    st.opened_by_user_id,
+    st.submitted_at,
+    st.posted_at,
+    st.cancelled_at,
+    st.created_at
+FROM stock_takes st
+JOIN shops s ON s.id = st.shop_id
+WHERE (sqlc.narg(shop_id)::int IS NULL OR st.shop_id = sqlc.narg(shop_id))
+    AND (sqlc.narg(status)::text IS NULL OR st.status = sqlc.narg(status))
+ORDER BY st.period DESC, st.id DESC;
+
+-- name: GetStockTakeByID :one
+SELECT *
+FROM stock_takes
+WHERE id = $1;
+
+-- name: GetStockTakeByIDForUpdate :one
+SELECT *
+FROM stock_takes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetLatestStockTakeNoByPrefix :one
+SELECT
+    stock_take_no
+FROM stock_takes
+WHERE stock_take_no LIKE $1
+ORDER BY stock_take_no DESC
+LIMIT 1;
+
+-- name: GetConflictingStockTake :one
+-- A stock take of the shop that blocks opening another: one for the same period that was not
+-- cancelled, or one still in progress.
+SELECT
+    stock_take_no,
+    period,
+    status
+FROM stock_takes
+WHERE shop_id = sqlc.arg(shop_id)
+    AND status <> 'CANCELLED'
+    AND (period = sqlc.arg(period) OR status IN ('OPEN', 'SUBMITTED'))
+ORDER BY status IN ('OPEN', 'SUBMITTED') DESC, period DESC
+LIMIT 1;
+
+-- name: CreateStockTake :one
+INSERT INTO stock_takes (
+    stock_take_no,
+    shop_id,
+    period,
+    tolerance_percent,
+    remarks,
+    opened_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: ListShopAllocationBalancesForUpdate :many
+-- Remaining film of every allocation at a shop, as defined by product_allocation_balances_view.
+-- The allocations are locked so that nothing draws on them while the stock take snapshots them.
+SELECT
+    pa.id AS product_allocation_id,
+    bal.remaining_quantity
+FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
+WHERE pa.shop_id = $1
+ORDER BY pa.id
+FOR UPDATE OF pa;
+
+-- name: CreateStockTakeLine :exec
+INSERT INTO stock_take_lines (
+    stock_take_id,
+    product_allocation_id,
+    system_quantity
+) VALUES (
+    $1, $2, $3
+);
+
+-- name: ListStockTakeLines :many
+-- Lines in film serial order with the product they count.
+SELECT
+    stl.id,
+    stl.stock_take_id,
+    stl.product_allocation_id,
+    pa.product_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    pa.allocation_date,
+    stl.system_quantity,
+    stl.counted_quantity,
+    stl.unit_of_measure,
+    stl.unit_quantity,
+    stl.variance_quantity,
+    stl.is_flagged,
+    stl.review_status,
+    stl.adjusted_quantity,
+    stl.inventory_movement_id,
+    stl.remarks,
+    stl.counted_by_user_id,
+    stl.counted_at,
+    stl.reviewed_by_user_id,
+    stl.reviewed_at,
+    stl.review_remarks
+FROM stock_take_lines stl
+JOIN product_allocations pa ON pa.id = stl.product_allocation_id
+JOIN products p ON p.id = pa.product_id
+JOIN product_names pn ON pn.id = p.name_id
+WHERE stl.stock_take_id = $1
+ORDER BY p.film_serial_number, pa.allocation_date, stl.id;
+
+-- name: ListStockTakeLineIDsByFilmSerialNumber :many
+-- Lines counting a film serial, matched regardless of case.
+SELECT
+    stl.id
+FROM stock_take_lines stl
+JOIN product_allocations pa ON pa.id = stl.product_allocation_id
+JOIN products p ON p.id = pa.product_id
+WHERE stl.stock_take_id = sqlc.arg(stock_take_id)
+    AND UPPER(p.film_serial_number) = UPPER(sqlc.arg(film_serial_number))
+ORDER BY stl.id;
+
+-- name: GetStockTakeLineIDByAllocationID :one
+SELECT
+    id
+FROM stock_take_lines
+WHERE stock_take_id = sqlc.arg(stock_take_id)
+    AND product_allocation_id = sqlc.arg(product_allocation_id);
+
+-- name: GetStockTakeLineForCount :one
+-- A line with the conversion factors of its product, locked while the count is recorded.
+SELECT
+    stl.id,
+    stl.stock_take_id,
+    stl.product_allocation_id,
+    stl.system_quantity,
+    p.roll_length_metres,
+    p.sheets_per_roll
+FROM stock_take_lines stl
+JOIN product_allocations pa ON pa.id = stl.product_allocation_id
+JOIN products p ON p.id = pa.product_id
+WHERE stl.id = $1
+FOR UPDATE OF stl;
+
+-- name: RecordStockTakeCount :one
+UPDATE stock_take_lines
+SET
+    counted_quantity = $2,
+    unit_of_measure = $3,
+    unit_quantity = $4,
+    variance_quantity = $5,
+    is_flagged = $6,
+    remarks = $7,
+    counted_by_user_id = $8,
+    counted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: GetStockTakeLineCounts :one
+SELECT
+    COUNT(*)::int AS line_count,
+    COUNT(counted_quantity)::int AS counted_count,
+    COUNT(*) FILTER (WHERE is_flagged)::int AS flagged_count,
+    COUNT(*) FILTER (WHERE review_status = 'PENDING')::int AS pending_count
+FROM stock_take_lines
+WHERE stock_take_id = $1;
+
+-- name: SubmitStockTake :one
+UPDATE stock_takes
+SET
+    status = 'SUBMITTED',
+    submitted_by_user_id = $2,
+    submitted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ApproveUnflaggedStockTakeLines :exec
+-- Variances within the tolerance need no review.
+UPDATE stock_take_lines
+SET
+    review_status = 'APPROVED',
+    updated_at = CURRENT_TIMESTAMP
+WHERE stock_take_id = $1
+    AND NOT is_flagged;
+
+-- name: GetStockTakeLineByID :one
+SELECT *
+FROM stock_take_lines
+WHERE id = sqlc.arg(id)
+    AND stock_take_id = sqlc.arg(stock_take_id);
+
+-- name: ReviewStockTakeLine :one
+UPDATE stock_take_lines
+SET
+    review_status = $2,
+    reviewed_by_user_id = $3,
+    review_remarks = $4,
+    reviewed_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ListApprovedStockTakeVariances :many
+-- Approved lines whose count differs from the system quantity, with where the film is held.
+SELECT
+    stl.id,
+    stl.product_allocation_id,
+    pa.product_id,
+    pa.shop_id,
+    p.film_serial_number,
+    stl.variance_quantity
+FROM stock_take_lines stl
+JOIN product_allocations pa ON pa.id = stl.product_allocation_id
+JOIN products p ON p.id = pa.product_id
+WHERE stl.stock_take_id = $1
+    AND stl.review_status = 'APPROVED'
+    AND stl.variance_quantity <> 0
+ORDER BY stl.id
+FOR UPDATE OF stl;
+
+-- name: PostStockTakeLine :exec
+UPDATE stock_take_lines
+SET
+    adjusted_quantity = $2,
+    inventory_movement_id = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: PostStockTake :one
+UPDATE stock_takes
+SET
+    status = 'POSTED',
+    posted_by_user_id = $2,
+    posted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CancelStockTake :one
+UPDATE stock_takes
+SET
+    status = 'CANCELLED',
+    cancelled_by_user_id = $2,
+    cancelled_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
@@ -0,0 +1,86 @@
+-- name: GetVehicleByID :one
+SELECT
+    *
+FROM vehicles
+WHERE id = $1;
+
+-- name: UpsertVehicle :one
+INSERT INTO vehicles (
+    chassis_no,
+    plate_no,
+    car_brand,
+    car_model,
+    car_colour
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (chassis_no_normalized) DO UPDATE
+SET
+    chassis_no = EXCLUDED.chassis_no,
+    car_brand = EXCLUDED.car_brand,
+    car_model = EXCLUDED.car_model,
+    car_colour = EXCLUDED.car_colour,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: UpsertVehiclePlate :one
+INSERT INTO vehicle_plates (
+    vehicle_id,
+    plate_no,
+    first_seen_on,
+    last_seen_on
+) VALUES (
+    sqlc.arg(vehicle_id), sqlc.arg(plate_no), sqlc.arg(seen_on), sqlc.arg(seen_on)
+)
+ON CONFLICT (vehicle_id, plate_no_normalized) DO UPDATE
+SET
+    plate_no = EXCLUDED.plate_no,
+    first_seen_on = LEAST(vehicle_plates.first_seen_on, EXCLUDED.first_seen_on),
+    last_seen_on = GREATEST(vehicle_plates.last_seen_on, EXCLUDED.last_seen_on),
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: RefreshVehiclePlateNo :one
+UPDATE vehicles
+SET
+    plate_no = (
+        SELECT vp.plate_no
+        FROM vehicle_plates vp
+        WHERE vp.vehicle_id = vehicles.id
+        ORDER BY vp.last_seen_on DESC, vp.updated_at DESC
+        LIMIT 1
+    ),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: ListVehiclePlatesByVehicleID :many
+SELECT
+    *
+FROM vehicle_plates
+WHERE vehicle_id = $1
+ORDER BY last_seen_on DESC;
+
+-- name: UpdateWarrantyVehicleID :exec
+UPDATE warranties
+SET
+    vehicle_id = $2
+WHERE id = $1;
+
+-- name: ListWarrantiesByVehicleID :many
+SELECT
+    w.*,
+    s.shop_name,
+    s.branch_code
+FROM warranties w
+JOIN shops s ON w.shop_id = s.id
+WHERE w.vehicle_id = $1
+ORDER BY w.installation_date DESC;
+
+-- name: ListClaimsByVehicleID :many
+SELECT
+    cv.*
+FROM claim_view cv
+JOIN warranties w ON cv.warranty_id = w.id
+WHERE w.vehicle_id = $1
+ORDER BY cv.claim_date DESC;
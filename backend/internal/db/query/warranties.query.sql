@@ -84,9 +84,12 @@ INSERT INTO warranty_parts (
     car_part_id,
     product_allocation_id,
     installation_image_url,
-    remarks
+    remarks,
+    film_quantity,
+    unit_of_measure,
+    unit_quantity
 ) VALUES
-    ( $1, $2, $3, $4, $5 )
+    ( $1, $2, $3, $4, $5, $6, $7, $8 )
 RETURNING *;
 
 -- name: UpdateWarrantyPart :one
@@ -97,6 +100,9 @@ SET
     product_allocation_id = $4,
     installation_image_url = $5,
     remarks = $6,
+    film_quantity = $7,
+    unit_of_measure = $8,
+    unit_quantity = $9,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING *;
@@ -124,8 +130,13 @@ SELECT DISTINCT
 FROM warranties w
 JOIN shops s ON w.shop_id = s.id
 LEFT JOIN warranty_parts wp ON w.id = wp.warranty_id
-WHERE LOWER(w.warranty_no) = LOWER($1)
-   OR LOWER(w.car_plate_no) = LOWER($1)
+WHERE LOWER(w.warranty_no) = LOWER(sqlc.arg(search_term))
+   OR w.car_plate_no_normalized = sqlc.arg(search_key)
+   OR w.vehicle_id IN (
+       SELECT vp.vehicle_id
+       FROM vehicle_plates vp
+       WHERE vp.plate_no_normalized = sqlc.arg(search_key)
+   )
 ORDER BY w.created_at DESC;
 
 -- name: GetWarrantyPartsByWarrantyID :many
@@ -161,4 +172,67 @@ ORDER BY w.created_at DESC;
 
 -- name: DeleteWarrantyPart :exec
 DELETE FROM warranty_parts
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListWarrantyVehicles :many
+SELECT
+    id,
+    warranty_no,
+    car_plate_no,
+    car_chassis_no
+FROM warranties
+ORDER BY id ASC;
+
+-- name: UpdateWarrantyVehicle :exec
+UPDATE warranties
+SET
+    car_plate_no = $2,
+    car_chassis_no = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: GetProductAllocationForInstall :one
+-- An allocation a warranty part installs film from, locked so that concurrent installs cannot
+-- overdraw it, with the conversion factors of its product. The film left must be read with
+-- GetProductAllocationRemainingQuantity once the lock is held, so that it sees the installs that
+-- committed while waiting for it.
+SELECT
+    pa.id,
+    pa.product_id,
+    pa.shop_id,
+    p.roll_length_metres,
+    p.sheets_per_roll
+FROM product_allocations pa
+JOIN products p ON p.id = pa.product_id
+WHERE pa.id = $1
+FOR UPDATE OF pa;
+
+-- name: GetProductAllocationRemainingQuantity :one
+-- The film left on an allocation as defined by product_allocation_balances_view.
+SELECT
+    remaining_quantity
+FROM product_allocation_balances_view
+WHERE product_allocation_id = $1;
+
+-- name: GetInstallFreezeRecallNo :one
+-- The open recall, if any, that freezes installs from the film of an allocation.
+SELECT
+    r.recall_no
+FROM recalls r
+JOIN recall_products rp ON rp.recall_id = r.id
+JOIN product_allocations pa ON pa.product_id = rp.product_id
+WHERE pa.id = sqlc.arg(product_allocation_id)
+    AND r.status = 'OPEN'
+    AND r.freeze_installs
+ORDER BY r.id
+LIMIT 1;
+
+-- name: GetInProgressStockTakeNoByAllocationID :one
+-- The stock take, open or awaiting review, that is counting the allocation.
+SELECT
+    st.stock_take_no
+FROM stock_take_lines stl
+JOIN stock_takes st ON st.id = stl.stock_take_id
+WHERE stl.product_allocation_id = $1
+    AND st.status IN ('OPEN', 'SUBMITTED')
+LIMIT 1;
@@ -20,7 +20,7 @@ INSERT INTO claims (
 ) VALUES (
     $1, $2, $3
 )
-RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at
+RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at
 `
 
 type CreateClaimParams struct {
@@ -42,6 +42,111 @@ func (q *Queries) CreateClaim(ctx context.Context, arg *CreateClaimParams) (*Cla
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedByCustomerID,
+		&i.SlaStartDate,
+		&i.SlaDueDate,
+		&i.SlaEscalatedAt,
+	)
+	return &i, err
+}
+
+const createClaimExclusion = `-- name: CreateClaimExclusion :one
+INSERT INTO claim_exclusions (
+    name,
+    reason,
+    product_type_id,
+    product_series_id,
+    product_name_id,
+    car_part_id,
+    is_active
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, name, reason, product_type_id, product_series_id, product_name_id, car_part_id, is_active, created_at, updated_at
+`
+
+type CreateClaimExclusionParams struct {
+	Name            string `db:"name" json:"name"`
+	Reason          string `db:"reason" json:"reason"`
+	ProductTypeID   *int32 `db:"product_type_id" json:"productTypeId"`
+	ProductSeriesID *int32 `db:"product_series_id" json:"productSeriesId"`
+	ProductNameID   *int32 `db:"product_name_id" json:"productNameId"`
+	CarPartID       *int32 `db:"car_part_id" json:"carPartId"`
+	IsActive        bool   `db:"is_active" json:"isActive"`
+}
+
+func (q *Queries) CreateClaimExclusion(ctx context.Context, arg *CreateClaimExclusionParams) (*ClaimExclusion, error) {
+	row := q.db.QueryRow(ctx, createClaimExclusion,
+		arg.Name,
+		arg.Reason,
+		arg.ProductTypeID,
+		arg.ProductSeriesID,
+		arg.ProductNameID,
+		arg.CarPartID,
+		arg.IsActive,
+	)
+	var i ClaimExclusion
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Reason,
+		&i.ProductTypeID,
+		&i.ProductSeriesID,
+		&i.ProductNameID,
+		&i.CarPartID,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const createClaimStatusTransition = `-- name: CreateClaimStatusTransition :one
+INSERT INTO claim_status_transitions (
+    claim_id,
+    from_status,
+    to_status,
+    changed_by_user_id,
+    changed_by_customer_id,
+    actor_role,
+    remarks
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, claim_id, from_status, to_status, changed_by_user_id, changed_by_customer_id, actor_role, remarks, created_at
+`
+
+type CreateClaimStatusTransitionParams struct {
+	ClaimID             int32   `db:"claim_id" json:"claimId"`
+	FromStatus          *string `db:"from_status" json:"fromStatus"`
+	ToStatus            string  `db:"to_status" json:"toStatus"`
+	ChangedByUserID     *int32  `db:"changed_by_user_id" json:"changedByUserId"`
+	ChangedByCustomerID *int32  `db:"changed_by_customer_id" json:"changedByCustomerId"`
+	ActorRole           string  `db:"actor_role" json:"actorRole"`
+	Remarks             *string `db:"remarks" json:"remarks"`
+}
+
+func (q *Queries) CreateClaimStatusTransition(ctx context.Context, arg *CreateClaimStatusTransitionParams) (*ClaimStatusTransition, error) {
+	row := q.db.QueryRow(ctx, createClaimStatusTransition,
+		arg.ClaimID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.ChangedByUserID,
+		arg.ChangedByCustomerID,
+		arg.ActorRole,
+		arg.Remarks,
+	)
+	var i ClaimStatusTransition
+	err := row.Scan(
+		&i.ID,
+		&i.ClaimID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.ChangedByUserID,
+		&i.ChangedByCustomerID,
+		&i.ActorRole,
+		&i.Remarks,
+		&i.CreatedAt,
 	)
 	return &i, err
 }
@@ -95,9 +200,35 @@ func (q *Queries) CreateClaimWarrantyPart(ctx context.Context, arg *CreateClaimW
 	return &i, err
 }
 
+const getClaimActorByUserID = `-- name: GetClaimActorByUserID :one
+SELECT
+    id,
+    shop_id,
+    role
+FROM users
+WHERE id = $1
+`
+
+type GetClaimActorByUserIDRow struct {
+	ID     int32  `db:"id" json:"id"`
+	ShopID *int32 `db:"shop_id" json:"shopId"`
+	Role   string `db:"role" json:"role"`
+}
+
+func (q *Queries) GetClaimActorByUserID(ctx context.Context, id int32) (*GetClaimActorByUserIDRow, error) {
+	row := q.db.QueryRow(ctx, getClaimActorByUserID, id)
+	var i GetClaimActorByUserIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.ShopID,
+		&i.Role,
+	)
+	return &i, err
+}
+
 const getClaimByID = `-- name: GetClaimByID :one
 SELECT
-    id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url
+    id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, sla_breached
 FROM claim_view
 WHERE id = $1
 `
@@ -115,6 +246,10 @@ func (q *Queries) GetClaimByID(ctx context.Context, id int32) (*ClaimView, error
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedByCustomerID,
+		&i.SlaStartDate,
+		&i.SlaDueDate,
+		&i.SlaEscalatedAt,
 		&i.ShopID,
 		&i.ClientName,
 		&i.ClientContact,
@@ -128,6 +263,86 @@ func (q *Queries) GetClaimByID(ctx context.Context, id int32) (*ClaimView, error
 		&i.ReferenceNo,
 		&i.WarrantyNo,
 		&i.InvoiceAttachmentUrl,
+		&i.SlaBreached,
+	)
+	return &i, err
+}
+
+const getClaimExclusionByID = `-- name: GetClaimExclusionByID :one
+SELECT
+    id, name, reason, product_type_id, product_series_id, product_name_id, car_part_id, is_active, created_at, updated_at
+FROM claim_exclusions
+WHERE id = $1
+`
+
+func (q *Queries) GetClaimExclusionByID(ctx context.Context, id int32) (*ClaimExclusion, error) {
+	row := q.db.QueryRow(ctx, getClaimExclusionByID, id)
+	var i ClaimExclusion
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Reason,
+		&i.ProductTypeID,
+		&i.ProductSeriesID,
+		&i.ProductNameID,
+		&i.CarPartID,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const getClaimResolutionByClaimWarrantyPartID = `-- name: GetClaimResolutionByClaimWarrantyPartID :one
+SELECT
+    id, claim_warranty_part_id, product_allocation_id, quantity_used, labour_cost, material_cost, remarks, reimbursement_statement_id, recorded_by_user_id, created_at, updated_at, unit_of_measure, unit_quantity
+FROM claim_resolutions
+WHERE claim_warranty_part_id = $1
+`
+
+func (q *Queries) GetClaimResolutionByClaimWarrantyPartID(ctx context.Context, claimWarrantyPartID int32) (*ClaimResolution, error) {
+	row := q.db.QueryRow(ctx, getClaimResolutionByClaimWarrantyPartID, claimWarrantyPartID)
+	var i ClaimResolution
+	err := row.Scan(
+		&i.ID,
+		&i.ClaimWarrantyPartID,
+		&i.ProductAllocationID,
+		&i.QuantityUsed,
+		&i.LabourCost,
+		&i.MaterialCost,
+		&i.Remarks,
+		&i.ReimbursementStatementID,
+		&i.RecordedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
+	)
+	return &i, err
+}
+
+const getClaimWarrantyPartByID = `-- name: GetClaimWarrantyPartByID :one
+SELECT
+    id, claim_id, warranty_part_id, damaged_image_url, status, remarks, resolution_date, resolution_image_url, approval_status, created_at, updated_at
+FROM claim_warranty_parts
+WHERE id = $1
+`
+
+func (q *Queries) GetClaimWarrantyPartByID(ctx context.Context, id int32) (*ClaimWarrantyPart, error) {
+	row := q.db.QueryRow(ctx, getClaimWarrantyPartByID, id)
+	var i ClaimWarrantyPart
+	err := row.Scan(
+		&i.ID,
+		&i.ClaimID,
+		&i.WarrantyPartID,
+		&i.DamagedImageUrl,
+		&i.Status,
+		&i.Remarks,
+		&i.ResolutionDate,
+		&i.ResolutionImageUrl,
+		&i.ApprovalStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return &i, err
 }
@@ -184,14 +399,27 @@ func (q *Queries) GetClaimWarrantyPartsByClaimID(ctx context.Context, claimID in
 
 const getClaims = `-- name: GetClaims :many
 SELECT
-    id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url
+    id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, sla_breached
 FROM claim_view
+WHERE ($1::text = '' OR status = $1::text)
+    AND (
+        $2::text = ''
+        OR ($2::text = 'breached' AND sla_breached)
+        OR ($2::text = 'due_today' AND sla_due_date = (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Kuala_Lumpur')::date)
+        OR ($2::text = 'on_track' AND sla_due_date IS NOT NULL AND NOT sla_breached)
+    )
 ORDER BY created_at DESC
 `
 
+type GetClaimsParams struct {
+	Status   string `db:"status" json:"status"`
+	SlaState string `db:"sla_state" json:"slaState"`
+}
+
 // claim_view
-func (q *Queries) GetClaims(ctx context.Context) ([]*ClaimView, error) {
-	rows, err := q.db.Query(ctx, getClaims)
+// Filters are skipped when empty. sla_state is one of breached, due_today or on_track.
+func (q *Queries) GetClaims(ctx context.Context, arg *GetClaimsParams) ([]*ClaimView, error) {
+	rows, err := q.db.Query(ctx, getClaims, arg.Status, arg.SlaState)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +437,10 @@ func (q *Queries) GetClaims(ctx context.Context) ([]*ClaimView, error) {
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SubmittedByCustomerID,
+			&i.SlaStartDate,
+			&i.SlaDueDate,
+			&i.SlaEscalatedAt,
 			&i.ShopID,
 			&i.ClientName,
 			&i.ClientContact,
@@ -222,6 +454,7 @@ func (q *Queries) GetClaims(ctx context.Context) ([]*ClaimView, error) {
 			&i.ReferenceNo,
 			&i.WarrantyNo,
 			&i.InvoiceAttachmentUrl,
+			&i.SlaBreached,
 		); err != nil {
 			return nil, err
 		}
@@ -235,14 +468,27 @@ func (q *Queries) GetClaims(ctx context.Context) ([]*ClaimView, error) {
 
 const getClaimsByShopID = `-- name: GetClaimsByShopID :many
 SELECT
-    id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url
+    id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, sla_breached
 FROM claim_view
 WHERE shop_id = $1
+    AND ($2::text = '' OR status = $2::text)
+    AND (
+        $3::text = ''
+        OR ($3::text = 'breached' AND sla_breached)
+        OR ($3::text = 'due_today' AND sla_due_date = (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Kuala_Lumpur')::date)
+        OR ($3::text = 'on_track' AND sla_due_date IS NOT NULL AND NOT sla_breached)
+    )
 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetClaimsByShopID(ctx context.Context, shopID int32) ([]*ClaimView, error) {
-	rows, err := q.db.Query(ctx, getClaimsByShopID, shopID)
+type GetClaimsByShopIDParams struct {
+	ShopID   int32  `db:"shop_id" json:"shopId"`
+	Status   string `db:"status" json:"status"`
+	SlaState string `db:"sla_state" json:"slaState"`
+}
+
+func (q *Queries) GetClaimsByShopID(ctx context.Context, arg *GetClaimsByShopIDParams) ([]*ClaimView, error) {
+	rows, err := q.db.Query(ctx, getClaimsByShopID, arg.ShopID, arg.Status, arg.SlaState)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +506,10 @@ func (q *Queries) GetClaimsByShopID(ctx context.Context, shopID int32) ([]*Claim
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SubmittedByCustomerID,
+			&i.SlaStartDate,
+			&i.SlaDueDate,
+			&i.SlaEscalatedAt,
 			&i.ShopID,
 			&i.ClientName,
 			&i.ClientContact,
@@ -273,6 +523,7 @@ func (q *Queries) GetClaimsByShopID(ctx context.Context, shopID int32) ([]*Claim
 			&i.ReferenceNo,
 			&i.WarrantyNo,
 			&i.InvoiceAttachmentUrl,
+			&i.SlaBreached,
 		); err != nil {
 			return nil, err
 		}
@@ -284,6 +535,45 @@ func (q *Queries) GetClaimsByShopID(ctx context.Context, shopID int32) ([]*Claim
 	return items, nil
 }
 
+const getInProgressStockTakeNoByAllocationID = `-- name: GetInProgressStockTakeNoByAllocationID :one
+SELECT
+    st.stock_take_no
+FROM stock_take_lines stl
+JOIN stock_takes st ON st.id = stl.stock_take_id
+WHERE stl.product_allocation_id = $1
+    AND st.status IN ('OPEN', 'SUBMITTED')
+LIMIT 1
+`
+
+// The stock take, open or awaiting review, that is counting the allocation.
+func (q *Queries) GetInProgressStockTakeNoByAllocationID(ctx context.Context, productAllocationID int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInProgressStockTakeNoByAllocationID, productAllocationID)
+	var stock_take_no string
+	err := row.Scan(&stock_take_no)
+	return stock_take_no, err
+}
+
+const getInstallFreezeRecallNo = `-- name: GetInstallFreezeRecallNo :one
+SELECT
+    r.recall_no
+FROM recalls r
+JOIN recall_products rp ON rp.recall_id = r.id
+JOIN product_allocations pa ON pa.product_id = rp.product_id
+WHERE pa.id = $1
+    AND r.status = 'OPEN'
+    AND r.freeze_installs
+ORDER BY r.id
+LIMIT 1
+`
+
+// The open recall, if any, that freezes installs from the film of an allocation.
+func (q *Queries) GetInstallFreezeRecallNo(ctx context.Context, productAllocationID int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInstallFreezeRecallNo, productAllocationID)
+	var recall_no string
+	err := row.Scan(&recall_no)
+	return recall_no, err
+}
+
 const getLatestWarrantyNoByPrefix = `-- name: GetLatestWarrantyNoByPrefix :one
 SELECT
     claim_no
@@ -300,6 +590,502 @@ func (q *Queries) GetLatestWarrantyNoByPrefix(ctx context.Context, claimNo strin
 	return claim_no, err
 }
 
+const getProductAllocationForUpdate = `-- name: GetProductAllocationForUpdate :one
+SELECT
+    pa.id,
+    pa.product_id,
+    pa.shop_id,
+    pa.film_quantity,
+    p.roll_length_metres,
+    p.sheets_per_roll
+FROM product_allocations pa
+JOIN products p ON pa.product_id = p.id
+WHERE pa.id = $1
+FOR UPDATE OF pa
+`
+
+type GetProductAllocationForUpdateRow struct {
+	ID               int32    `db:"id" json:"id"`
+	ProductID        int32    `db:"product_id" json:"productId"`
+	ShopID           int32    `db:"shop_id" json:"shopId"`
+	FilmQuantity     float64  `db:"film_quantity" json:"filmQuantity"`
+	RollLengthMetres *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
+}
+
+// Locks the allocation so that concurrent resolutions cannot overdraw it. The film left must be
+// read with GetProductAllocationRemainingQuantity once the lock is held, so that it sees the
+// resolutions that committed while waiting for it.
+func (q *Queries) GetProductAllocationForUpdate(ctx context.Context, id int32) (*GetProductAllocationForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getProductAllocationForUpdate, id)
+	var i GetProductAllocationForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ShopID,
+		&i.FilmQuantity,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
+	)
+	return &i, err
+}
+
+const getProductAllocationRemainingQuantity = `-- name: GetProductAllocationRemainingQuantity :one
+SELECT
+    (bal.remaining_quantity + COALESCE((
+        SELECT SUM(cr.quantity_used)
+        FROM claim_resolutions cr
+        WHERE cr.product_allocation_id = bal.product_allocation_id
+            AND cr.claim_warranty_part_id = $1
+    ), 0))::numeric AS remaining_quantity
+FROM product_allocation_balances_view bal
+WHERE bal.product_allocation_id = $2
+`
+
+type GetProductAllocationRemainingQuantityParams struct {
+	ClaimWarrantyPartID int32 `db:"claim_warranty_part_id" json:"claimWarrantyPartId"`
+	ProductAllocationID int32 `db:"product_allocation_id" json:"productAllocationId"`
+}
+
+// The film left on an allocation as defined by product_allocation_balances_view, with the
+// quantity already recorded for the claim part added back so that its resolution can be
+// corrected.
+func (q *Queries) GetProductAllocationRemainingQuantity(ctx context.Context, arg *GetProductAllocationRemainingQuantityParams) (float64, error) {
+	row := q.db.QueryRow(ctx, getProductAllocationRemainingQuantity, arg.ClaimWarrantyPartID, arg.ProductAllocationID)
+	var remaining_quantity float64
+	err := row.Scan(&remaining_quantity)
+	return remaining_quantity, err
+}
+
+const listActiveClaimExclusions = `-- name: ListActiveClaimExclusions :many
+SELECT
+    id, name, reason, product_type_id, product_series_id, product_name_id, car_part_id, is_active, created_at, updated_at
+FROM claim_exclusions
+WHERE is_active = TRUE
+ORDER BY id ASC
+`
+
+func (q *Queries) ListActiveClaimExclusions(ctx context.Context) ([]*ClaimExclusion, error) {
+	rows, err := q.db.Query(ctx, listActiveClaimExclusions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ClaimExclusion{}
+	for rows.Next() {
+		var i ClaimExclusion
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Reason,
+			&i.ProductTypeID,
+			&i.ProductSeriesID,
+			&i.ProductNameID,
+			&i.CarPartID,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClaimEligibilityParts = `-- name: ListClaimEligibilityParts :many
+SELECT
+    wp.id,
+    wp.warranty_id,
+    wp.car_part_id,
+    wp.approval_status,
+    cp.name AS car_part_name,
+    w.warranty_no,
+    w.approval_status AS warranty_approval_status,
+    w.is_active AS warranty_is_active,
+    w.installation_date,
+    p.type_id AS product_type_id,
+    p.series_id AS product_series_id,
+    p.name_id AS product_name_id,
+    p.warranty_in_months,
+    (w.installation_date + make_interval(months => p.warranty_in_months))::date AS coverage_expiry_date,
+    (
+        SELECT c.claim_no
+        FROM claim_warranty_parts cwp
+        JOIN claims c ON cwp.claim_id = c.id
+        WHERE cwp.warranty_part_id = wp.id
+            AND c.status <> 'CLOSED'
+            AND cwp.status <> 'CLOSED'
+            AND cwp.approval_status <> 'REJECTED'
+            AND c.id IS DISTINCT FROM $1::int
+        ORDER BY c.id DESC
+        LIMIT 1
+    ) AS open_claim_no
+FROM warranty_parts wp
+JOIN warranties w ON wp.warranty_id = w.id
+JOIN car_parts cp ON wp.car_part_id = cp.id
+JOIN product_allocations pa ON wp.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+WHERE wp.id = ANY($2::int[])
+`
+
+type ListClaimEligibilityPartsParams struct {
+	ExcludeClaimID  *int32  `db:"exclude_claim_id" json:"excludeClaimId"`
+	WarrantyPartIds []int32 `db:"warranty_part_ids" json:"warrantyPartIds"`
+}
+
+type ListClaimEligibilityPartsRow struct {
+	ID                     int32                 `db:"id" json:"id"`
+	WarrantyID             int32                 `db:"warranty_id" json:"warrantyId"`
+	CarPartID              int32                 `db:"car_part_id" json:"carPartId"`
+	ApprovalStatus         models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	CarPartName            string                `db:"car_part_name" json:"carPartName"`
+	WarrantyNo             string                `db:"warranty_no" json:"warrantyNo"`
+	WarrantyApprovalStatus models.ApprovalStatus `db:"warranty_approval_status" json:"warrantyApprovalStatus"`
+	WarrantyIsActive       bool                  `db:"warranty_is_active" json:"warrantyIsActive"`
+	InstallationDate       time.Time             `db:"installation_date" json:"installationDate"`
+	ProductTypeID          int32                 `db:"product_type_id" json:"productTypeId"`
+	ProductSeriesID        int32                 `db:"product_series_id" json:"productSeriesId"`
+	ProductNameID          int32                 `db:"product_name_id" json:"productNameId"`
+	WarrantyInMonths       int32                 `db:"warranty_in_months" json:"warrantyInMonths"`
+	CoverageExpiryDate     time.Time             `db:"coverage_expiry_date" json:"coverageExpiryDate"`
+	OpenClaimNo            *string               `db:"open_claim_no" json:"openClaimNo"`
+}
+
+// Everything the claim eligibility rules need to know about the requested warranty parts,
+// including the latest claim on the part that is still open and not rejected, other than the
+// claim being edited, if any.
+func (q *Queries) ListClaimEligibilityParts(ctx context.Context, arg *ListClaimEligibilityPartsParams) ([]*ListClaimEligibilityPartsRow, error) {
+	rows, err := q.db.Query(ctx, listClaimEligibilityParts, arg.ExcludeClaimID, arg.WarrantyPartIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListClaimEligibilityPartsRow{}
+	for rows.Next() {
+		var i ListClaimEligibilityPartsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WarrantyID,
+			&i.CarPartID,
+			&i.ApprovalStatus,
+			&i.CarPartName,
+			&i.WarrantyNo,
+			&i.WarrantyApprovalStatus,
+			&i.WarrantyIsActive,
+			&i.InstallationDate,
+			&i.ProductTypeID,
+			&i.ProductSeriesID,
+			&i.ProductNameID,
+			&i.WarrantyInMonths,
+			&i.CoverageExpiryDate,
+			&i.OpenClaimNo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClaimExclusions = `-- name: ListClaimExclusions :many
+SELECT
+    id, name, reason, product_type_id, product_series_id, product_name_id, car_part_id, is_active, created_at, updated_at
+FROM claim_exclusions
+ORDER BY id ASC
+`
+
+func (q *Queries) ListClaimExclusions(ctx context.Context) ([]*ClaimExclusion, error) {
+	rows, err := q.db.Query(ctx, listClaimExclusions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ClaimExclusion{}
+	for rows.Next() {
+		var i ClaimExclusion
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Reason,
+			&i.ProductTypeID,
+			&i.ProductSeriesID,
+			&i.ProductNameID,
+			&i.CarPartID,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClaimResolutionsByClaimID = `-- name: ListClaimResolutionsByClaimID :many
+SELECT
+    cr.id, cr.claim_warranty_part_id, cr.product_allocation_id, cr.quantity_used, cr.labour_cost, cr.material_cost, cr.remarks, cr.reimbursement_statement_id, cr.recorded_by_user_id, cr.created_at, cr.updated_at, cr.unit_of_measure, cr.unit_quantity,
+    cwp.claim_id,
+    cwp.resolution_date,
+    cp.name AS car_part_name,
+    p.film_serial_number,
+    pn.name AS product_name
+FROM claim_resolutions cr
+JOIN claim_warranty_parts cwp ON cr.claim_warranty_part_id = cwp.id
+JOIN warranty_parts wp ON cwp.warranty_part_id = wp.id
+JOIN car_parts cp ON wp.car_part_id = cp.id
+JOIN product_allocations pa ON cr.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE cwp.claim_id = $1
+ORDER BY cr.id ASC
+`
+
+type ListClaimResolutionsByClaimIDRow struct {
+	ID                       int32      `db:"id" json:"id"`
+	ClaimWarrantyPartID      int32      `db:"claim_warranty_part_id" json:"claimWarrantyPartId"`
+	ProductAllocationID      int32      `db:"product_allocation_id" json:"productAllocationId"`
+	QuantityUsed             float64    `db:"quantity_used" json:"quantityUsed"`
+	LabourCost               float64    `db:"labour_cost" json:"labourCost"`
+	MaterialCost             float64    `db:"material_cost" json:"materialCost"`
+	Remarks                  *string    `db:"remarks" json:"remarks"`
+	ReimbursementStatementID *int32     `db:"reimbursement_statement_id" json:"reimbursementStatementId"`
+	RecordedByUserID         *int32     `db:"recorded_by_user_id" json:"recordedByUserId"`
+	CreatedAt                time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt                time.Time  `db:"updated_at" json:"updatedAt"`
+	UnitOfMeasure            string     `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity             float64    `db:"unit_quantity" json:"unitQuantity"`
+	ClaimID                  int32      `db:"claim_id" json:"claimId"`
+	ResolutionDate           *time.Time `db:"resolution_date" json:"resolutionDate"`
+	CarPartName              string     `db:"car_part_name" json:"carPartName"`
+	FilmSerialNumber         string     `db:"film_serial_number" json:"filmSerialNumber"`
+	ProductName              string     `db:"product_name" json:"productName"`
+}
+
+func (q *Queries) ListClaimResolutionsByClaimID(ctx context.Context, claimID int32) ([]*ListClaimResolutionsByClaimIDRow, error) {
+	rows, err := q.db.Query(ctx, listClaimResolutionsByClaimID, claimID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListClaimResolutionsByClaimIDRow{}
+	for rows.Next() {
+		var i ListClaimResolutionsByClaimIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ClaimWarrantyPartID,
+			&i.ProductAllocationID,
+			&i.QuantityUsed,
+			&i.LabourCost,
+			&i.MaterialCost,
+			&i.Remarks,
+			&i.ReimbursementStatementID,
+			&i.RecordedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.UnitOfMeasure,
+			&i.UnitQuantity,
+			&i.ClaimID,
+			&i.ResolutionDate,
+			&i.CarPartName,
+			&i.FilmSerialNumber,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClaimStatusTransitionsByClaimID = `-- name: ListClaimStatusTransitionsByClaimID :many
+SELECT
+    t.id, t.claim_id, t.from_status, t.to_status, t.changed_by_user_id, t.changed_by_customer_id, t.actor_role, t.remarks, t.created_at,
+    u.username AS changed_by_username,
+    cu.name AS changed_by_customer_name
+FROM claim_status_transitions t
+LEFT JOIN users u ON t.changed_by_user_id = u.id
+LEFT JOIN customers cu ON t.changed_by_customer_id = cu.id
+WHERE t.claim_id = $1
+ORDER BY t.created_at ASC, t.id ASC
+`
+
+type ListClaimStatusTransitionsByClaimIDRow struct {
+	ID                    int32     `db:"id" json:"id"`
+	ClaimID               int32     `db:"claim_id" json:"claimId"`
+	FromStatus            *string   `db:"from_status" json:"fromStatus"`
+	ToStatus              string    `db:"to_status" json:"toStatus"`
+	ChangedByUserID       *int32    `db:"changed_by_user_id" json:"changedByUserId"`
+	ChangedByCustomerID   *int32    `db:"changed_by_customer_id" json:"changedByCustomerId"`
+	ActorRole             string    `db:"actor_role" json:"actorRole"`
+	Remarks               *string   `db:"remarks" json:"remarks"`
+	CreatedAt             time.Time `db:"created_at" json:"createdAt"`
+	ChangedByUsername     *string   `db:"changed_by_username" json:"changedByUsername"`
+	ChangedByCustomerName *string   `db:"changed_by_customer_name" json:"changedByCustomerName"`
+}
+
+func (q *Queries) ListClaimStatusTransitionsByClaimID(ctx context.Context, claimID int32) ([]*ListClaimStatusTransitionsByClaimIDRow, error) {
+	rows, err := q.db.Query(ctx, listClaimStatusTransitionsByClaimID, claimID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListClaimStatusTransitionsByClaimIDRow{}
+	for rows.Next() {
+		var i ListClaimStatusTransitionsByClaimIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ClaimID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.ChangedByUserID,
+			&i.ChangedByCustomerID,
+			&i.ActorRole,
+			&i.Remarks,
+			&i.CreatedAt,
+			&i.ChangedByUsername,
+			&i.ChangedByCustomerName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClaimsDueForEscalation = `-- name: ListClaimsDueForEscalation :many
+SELECT
+    cv.id,
+    cv.claim_no,
+    cv.status,
+    cv.warranty_id,
+    cv.warranty_no,
+    cv.car_plate_no,
+    cv.sla_due_date,
+    s.shop_name
+FROM claim_view cv
+JOIN shops s ON cv.shop_id = s.id
+WHERE cv.sla_breached
+    AND cv.sla_escalated_at IS NULL
+ORDER BY cv.sla_due_date, cv.id
+`
+
+type ListClaimsDueForEscalationRow struct {
+	ID         int32      `db:"id" json:"id"`
+	ClaimNo    string     `db:"claim_no" json:"claimNo"`
+	Status     string     `db:"status" json:"status"`
+	WarrantyID int32      `db:"warranty_id" json:"warrantyId"`
+	WarrantyNo string     `db:"warranty_no" json:"warrantyNo"`
+	CarPlateNo string     `db:"car_plate_no" json:"carPlateNo"`
+	SlaDueDate *time.Time `db:"sla_due_date" json:"slaDueDate"`
+	ShopName   string     `db:"shop_name" json:"shopName"`
+}
+
+func (q *Queries) ListClaimsDueForEscalation(ctx context.Context) ([]*ListClaimsDueForEscalationRow, error) {
+	rows, err := q.db.Query(ctx, listClaimsDueForEscalation)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListClaimsDueForEscalationRow{}
+	for rows.Next() {
+		var i ListClaimsDueForEscalationRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ClaimNo,
+			&i.Status,
+			&i.WarrantyID,
+			&i.WarrantyNo,
+			&i.CarPlateNo,
+			&i.SlaDueDate,
+			&i.ShopName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClaimsWithoutSLA = `-- name: ListClaimsWithoutSLA :many
+SELECT
+    c.id,
+    c.status,
+    COALESCE(
+        (SELECT MAX(t.created_at) FROM claim_status_transitions t WHERE t.claim_id = c.id AND t.to_status = c.status),
+        c.created_at
+    )::date AS status_since
+FROM claims c
+WHERE c.sla_start_date IS NULL
+ORDER BY c.id
+`
+
+type ListClaimsWithoutSLARow struct {
+	ID          int32     `db:"id" json:"id"`
+	Status      string    `db:"status" json:"status"`
+	StatusSince time.Time `db:"status_since" json:"statusSince"`
+}
+
+// Claims created before SLA tracking; the clock starts when the claim entered its status.
+func (q *Queries) ListClaimsWithoutSLA(ctx context.Context) ([]*ListClaimsWithoutSLARow, error) {
+	rows, err := q.db.Query(ctx, listClaimsWithoutSLA)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListClaimsWithoutSLARow{}
+	for rows.Next() {
+		var i ListClaimsWithoutSLARow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.StatusSince,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markClaimSLAEscalated = `-- name: MarkClaimSLAEscalated :one
+UPDATE claims
+SET
+    sla_escalated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+    AND sla_escalated_at IS NULL
+RETURNING claim_no
+`
+
+func (q *Queries) MarkClaimSLAEscalated(ctx context.Context, id int32) (string, error) {
+	row := q.db.QueryRow(ctx, markClaimSLAEscalated, id)
+	var claim_no string
+	err := row.Scan(&claim_no)
+	return claim_no, err
+}
+
 const updateClaim = `-- name: UpdateClaim :one
 UPDATE claims
 SET
@@ -308,7 +1094,8 @@ SET
     claim_date = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at
+    AND status IN ('SUBMITTED', 'REOPENED')
+RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at
 `
 
 type UpdateClaimParams struct {
@@ -318,6 +1105,7 @@ type UpdateClaimParams struct {
 	ClaimDate  time.Time `db:"claim_date" json:"claimDate"`
 }
 
+// Only a claim that is submitted or reopened can be edited.
 func (q *Queries) UpdateClaim(ctx context.Context, arg *UpdateClaimParams) (*Claim, error) {
 	row := q.db.QueryRow(ctx, updateClaim,
 		arg.ID,
@@ -336,6 +1124,10 @@ func (q *Queries) UpdateClaim(ctx context.Context, arg *UpdateClaimParams) (*Cla
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedByCustomerID,
+		&i.SlaStartDate,
+		&i.SlaDueDate,
+		&i.SlaEscalatedAt,
 	)
 	return &i, err
 }
@@ -347,7 +1139,7 @@ SET
     remarks = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at
+RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at
 `
 
 type UpdateClaimApprovalParams struct {
@@ -369,6 +1161,101 @@ func (q *Queries) UpdateClaimApproval(ctx context.Context, arg *UpdateClaimAppro
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedByCustomerID,
+		&i.SlaStartDate,
+		&i.SlaDueDate,
+		&i.SlaEscalatedAt,
+	)
+	return &i, err
+}
+
+const updateClaimExclusion = `-- name: UpdateClaimExclusion :one
+UPDATE claim_exclusions
+SET
+    name = $2,
+    reason = $3,
+    product_type_id = $4,
+    product_series_id = $5,
+    product_name_id = $6,
+    car_part_id = $7,
+    is_active = $8,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, reason, product_type_id, product_series_id, product_name_id, car_part_id, is_active, created_at, updated_at
+`
+
+type UpdateClaimExclusionParams struct {
+	ID              int32  `db:"id" json:"id"`
+	Name            string `db:"name" json:"name"`
+	Reason          string `db:"reason" json:"reason"`
+	ProductTypeID   *int32 `db:"product_type_id" json:"productTypeId"`
+	ProductSeriesID *int32 `db:"product_series_id" json:"productSeriesId"`
+	ProductNameID   *int32 `db:"product_name_id" json:"productNameId"`
+	CarPartID       *int32 `db:"car_part_id" json:"carPartId"`
+	IsActive        bool   `db:"is_active" json:"isActive"`
+}
+
+func (q *Queries) UpdateClaimExclusion(ctx context.Context, arg *UpdateClaimExclusionParams) (*ClaimExclusion, error) {
+	row := q.db.QueryRow(ctx, updateClaimExclusion,
+		arg.ID,
+		arg.Name,
+		arg.Reason,
+		arg.ProductTypeID,
+		arg.ProductSeriesID,
+		arg.ProductNameID,
+		arg.CarPartID,
+		arg.IsActive,
+	)
+	var i ClaimExclusion
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Reason,
+		&i.ProductTypeID,
+		&i.ProductSeriesID,
+		&i.ProductNameID,
+		&i.CarPartID,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return &i, err
+}
+
+const updateClaimSLA = `-- name: UpdateClaimSLA :one
+UPDATE claims
+SET
+    sla_start_date = $2,
+    sla_due_date = $3,
+    sla_escalated_at = NULL
+WHERE id = $1
+RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at
+`
+
+type UpdateClaimSLAParams struct {
+	ID           int32      `db:"id" json:"id"`
+	SlaStartDate *time.Time `db:"sla_start_date" json:"slaStartDate"`
+	SlaDueDate   *time.Time `db:"sla_due_date" json:"slaDueDate"`
+}
+
+// Restarts the SLA clock for the claim's current status and clears any earlier escalation.
+func (q *Queries) UpdateClaimSLA(ctx context.Context, arg *UpdateClaimSLAParams) (*Claim, error) {
+	row := q.db.QueryRow(ctx, updateClaimSLA, arg.ID, arg.SlaStartDate, arg.SlaDueDate)
+	var i Claim
+	err := row.Scan(
+		&i.ID,
+		&i.WarrantyID,
+		&i.ClaimNo,
+		&i.ClaimDate,
+		&i.ApprovalStatus,
+		&i.Status,
+		&i.Remarks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SubmittedByCustomerID,
+		&i.SlaStartDate,
+		&i.SlaDueDate,
+		&i.SlaEscalatedAt,
 	)
 	return &i, err
 }
@@ -379,7 +1266,7 @@ SET
     status = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at
+RETURNING id, warranty_id, claim_no, claim_date, approval_status, status, remarks, created_at, updated_at, submitted_by_customer_id, sla_start_date, sla_due_date, sla_escalated_at
 `
 
 type UpdateClaimStatusParams struct {
@@ -400,6 +1287,10 @@ func (q *Queries) UpdateClaimStatus(ctx context.Context, arg *UpdateClaimStatusP
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SubmittedByCustomerID,
+		&i.SlaStartDate,
+		&i.SlaDueDate,
+		&i.SlaEscalatedAt,
 	)
 	return &i, err
 }
@@ -409,34 +1300,26 @@ UPDATE claim_warranty_parts
 SET
     warranty_part_id = $2,
     damaged_image_url = $3,
-    status = $4,
-    remarks = $5,
-    resolution_date = $6,
-    resolution_image_url = $7,
+    remarks = $4,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING id, claim_id, warranty_part_id, damaged_image_url, status, remarks, resolution_date, resolution_image_url, approval_status, created_at, updated_at
 `
 
 type UpdateClaimWarrantyPartParams struct {
-	ID                 int32      `db:"id" json:"id"`
-	WarrantyPartID     int32      `db:"warranty_part_id" json:"warrantyPartId"`
-	DamagedImageUrl    string     `db:"damaged_image_url" json:"damagedImageUrl"`
-	Status             string     `db:"status" json:"status"`
-	Remarks            *string    `db:"remarks" json:"remarks"`
-	ResolutionDate     *time.Time `db:"resolution_date" json:"resolutionDate"`
-	ResolutionImageUrl *string    `db:"resolution_image_url" json:"resolutionImageUrl"`
+	ID              int32   `db:"id" json:"id"`
+	WarrantyPartID  int32   `db:"warranty_part_id" json:"warrantyPartId"`
+	DamagedImageUrl string  `db:"damaged_image_url" json:"damagedImageUrl"`
+	Remarks         *string `db:"remarks" json:"remarks"`
 }
 
+// The resolution of a part is recorded with UpsertClaimResolution and the claim lifecycle, not here.
 func (q *Queries) UpdateClaimWarrantyPart(ctx context.Context, arg *UpdateClaimWarrantyPartParams) (*ClaimWarrantyPart, error) {
 	row := q.db.QueryRow(ctx, updateClaimWarrantyPart,
 		arg.ID,
 		arg.WarrantyPartID,
 		arg.DamagedImageUrl,
-		arg.Status,
 		arg.Remarks,
-		arg.ResolutionDate,
-		arg.ResolutionImageUrl,
 	)
 	var i ClaimWarrantyPart
 	err := row.Scan(
@@ -490,22 +1373,24 @@ func (q *Queries) UpdateClaimWarrantyPartApproval(ctx context.Context, arg *Upda
 	return &i, err
 }
 
-const updateClaimWarrantyPartStatus = `-- name: UpdateClaimWarrantyPartStatus :one
+const updateClaimWarrantyPartResolution = `-- name: UpdateClaimWarrantyPartResolution :one
 UPDATE claim_warranty_parts
 SET
-    status = $2,
+    resolution_date = $2,
+    resolution_image_url = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
 RETURNING id, claim_id, warranty_part_id, damaged_image_url, status, remarks, resolution_date, resolution_image_url, approval_status, created_at, updated_at
 `
 
-type UpdateClaimWarrantyPartStatusParams struct {
-	ID     int32  `db:"id" json:"id"`
-	Status string `db:"status" json:"status"`
+type UpdateClaimWarrantyPartResolutionParams struct {
+	ID                 int32      `db:"id" json:"id"`
+	ResolutionDate     *time.Time `db:"resolution_date" json:"resolutionDate"`
+	ResolutionImageUrl *string    `db:"resolution_image_url" json:"resolutionImageUrl"`
 }
 
-func (q *Queries) UpdateClaimWarrantyPartStatus(ctx context.Context, arg *UpdateClaimWarrantyPartStatusParams) (*ClaimWarrantyPart, error) {
-	row := q.db.QueryRow(ctx, updateClaimWarrantyPartStatus, arg.ID, arg.Status)
+func (q *Queries) UpdateClaimWarrantyPartResolution(ctx context.Context, arg *UpdateClaimWarrantyPartResolutionParams) (*ClaimWarrantyPart, error) {
+	row := q.db.QueryRow(ctx, updateClaimWarrantyPartResolution, arg.ID, arg.ResolutionDate, arg.ResolutionImageUrl)
 	var i ClaimWarrantyPart
 	err := row.Scan(
 		&i.ID,
@@ -522,3 +1407,93 @@ func (q *Queries) UpdateClaimWarrantyPartStatus(ctx context.Context, arg *Update
 	)
 	return &i, err
 }
+
+const updateClaimWarrantyPartsStatusByClaimID = `-- name: UpdateClaimWarrantyPartsStatusByClaimID :exec
+UPDATE claim_warranty_parts
+SET
+    status = CASE WHEN approval_status = 'REJECTED' THEN 'CLOSED' ELSE $1::text END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE claim_id = $2
+`
+
+type UpdateClaimWarrantyPartsStatusByClaimIDParams struct {
+	Status  string `db:"status" json:"status"`
+	ClaimID int32  `db:"claim_id" json:"claimId"`
+}
+
+// Parts follow the status of their claim; rejected parts are closed.
+func (q *Queries) UpdateClaimWarrantyPartsStatusByClaimID(ctx context.Context, arg *UpdateClaimWarrantyPartsStatusByClaimIDParams) error {
+	_, err := q.db.Exec(ctx, updateClaimWarrantyPartsStatusByClaimID, arg.Status, arg.ClaimID)
+	return err
+}
+
+const upsertClaimResolution = `-- name: UpsertClaimResolution :one
+INSERT INTO claim_resolutions (
+    claim_warranty_part_id,
+    product_allocation_id,
+    quantity_used,
+    unit_of_measure,
+    unit_quantity,
+    labour_cost,
+    material_cost,
+    remarks,
+    recorded_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (claim_warranty_part_id) DO UPDATE
+SET
+    product_allocation_id = EXCLUDED.product_allocation_id,
+    quantity_used = EXCLUDED.quantity_used,
+    unit_of_measure = EXCLUDED.unit_of_measure,
+    unit_quantity = EXCLUDED.unit_quantity,
+    labour_cost = EXCLUDED.labour_cost,
+    material_cost = EXCLUDED.material_cost,
+    remarks = EXCLUDED.remarks,
+    recorded_by_user_id = EXCLUDED.recorded_by_user_id,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id, claim_warranty_part_id, product_allocation_id, quantity_used, labour_cost, material_cost, remarks, reimbursement_statement_id, recorded_by_user_id, created_at, updated_at, unit_of_measure, unit_quantity
+`
+
+type UpsertClaimResolutionParams struct {
+	ClaimWarrantyPartID int32   `db:"claim_warranty_part_id" json:"claimWarrantyPartId"`
+	ProductAllocationID int32   `db:"product_allocation_id" json:"productAllocationId"`
+	QuantityUsed        float64 `db:"quantity_used" json:"quantityUsed"`
+	UnitOfMeasure       string  `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity        float64 `db:"unit_quantity" json:"unitQuantity"`
+	LabourCost          float64 `db:"labour_cost" json:"labourCost"`
+	MaterialCost        float64 `db:"material_cost" json:"materialCost"`
+	Remarks             *string `db:"remarks" json:"remarks"`
+	RecordedByUserID    *int32  `db:"recorded_by_user_id" json:"recordedByUserId"`
+}
+
+func (q *Queries) UpsertClaimResolution(ctx context.Context, arg *UpsertClaimResolutionParams) (*ClaimResolution, error) {
+	row := q.db.QueryRow(ctx, upsertClaimResolution,
+		arg.ClaimWarrantyPartID,
+		arg.ProductAllocationID,
+		arg.QuantityUsed,
+		arg.UnitOfMeasure,
+		arg.UnitQuantity,
+		arg.LabourCost,
+		arg.MaterialCost,
+		arg.Remarks,
+		arg.RecordedByUserID,
+	)
+	var i ClaimResolution
+	err := row.Scan(
+		&i.ID,
+		&i.ClaimWarrantyPartID,
+		&i.ProductAllocationID,
+		&i.QuantityUsed,
+		&i.LabourCost,
+		&i.MaterialCost,
+		&i.Remarks,
+		&i.ReimbursementStatementID,
+		&i.RecordedByUserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
+	)
+	return &i, err
+}
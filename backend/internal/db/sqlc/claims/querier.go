@@ -10,19 +10,58 @@ import (
 
 type Querier interface {
 	CreateClaim(ctx context.Context, arg *CreateClaimParams) (*Claim, error)
+	CreateClaimExclusion(ctx context.Context, arg *CreateClaimExclusionParams) (*ClaimExclusion, error)
+	CreateClaimStatusTransition(ctx context.Context, arg *CreateClaimStatusTransitionParams) (*ClaimStatusTransition, error)
 	CreateClaimWarrantyPart(ctx context.Context, arg *CreateClaimWarrantyPartParams) (*ClaimWarrantyPart, error)
+	GetClaimActorByUserID(ctx context.Context, id int32) (*GetClaimActorByUserIDRow, error)
 	GetClaimByID(ctx context.Context, id int32) (*ClaimView, error)
+	GetClaimExclusionByID(ctx context.Context, id int32) (*ClaimExclusion, error)
+	GetClaimResolutionByClaimWarrantyPartID(ctx context.Context, claimWarrantyPartID int32) (*ClaimResolution, error)
+	GetClaimWarrantyPartByID(ctx context.Context, id int32) (*ClaimWarrantyPart, error)
 	GetClaimWarrantyPartsByClaimID(ctx context.Context, claimID int32) ([]*ClaimWarrantyPartsView, error)
 	// claim_view
-	GetClaims(ctx context.Context) ([]*ClaimView, error)
-	GetClaimsByShopID(ctx context.Context, shopID int32) ([]*ClaimView, error)
+	// Filters are skipped when empty. sla_state is one of breached, due_today or on_track.
+	GetClaims(ctx context.Context, arg *GetClaimsParams) ([]*ClaimView, error)
+	GetClaimsByShopID(ctx context.Context, arg *GetClaimsByShopIDParams) ([]*ClaimView, error)
+	// The stock take, open or awaiting review, that is counting the allocation.
+	GetInProgressStockTakeNoByAllocationID(ctx context.Context, productAllocationID int32) (string, error)
+	// The open recall, if any, that freezes installs from the film of an allocation.
+	GetInstallFreezeRecallNo(ctx context.Context, productAllocationID int32) (string, error)
 	GetLatestWarrantyNoByPrefix(ctx context.Context, claimNo string) (string, error)
+	// Locks the allocation so that concurrent resolutions cannot overdraw it. The film left must be
+	// read with GetProductAllocationRemainingQuantity once the lock is held, so that it sees the
+	// resolutions that committed while waiting for it.
+	GetProductAllocationForUpdate(ctx context.Context, id int32) (*GetProductAllocationForUpdateRow, error)
+	// The film left on an allocation as defined by product_allocation_balances_view, with the
+	// quantity already recorded for the claim part added back so that its resolution can be
+	// corrected.
+	GetProductAllocationRemainingQuantity(ctx context.Context, arg *GetProductAllocationRemainingQuantityParams) (float64, error)
+	ListActiveClaimExclusions(ctx context.Context) ([]*ClaimExclusion, error)
+	// Everything the claim eligibility rules need to know about the requested warranty parts,
+	// including the latest claim on the part that is still open and not rejected, other than the
+	// claim being edited, if any.
+	ListClaimEligibilityParts(ctx context.Context, arg *ListClaimEligibilityPartsParams) ([]*ListClaimEligibilityPartsRow, error)
+	ListClaimExclusions(ctx context.Context) ([]*ClaimExclusion, error)
+	ListClaimResolutionsByClaimID(ctx context.Context, claimID int32) ([]*ListClaimResolutionsByClaimIDRow, error)
+	ListClaimStatusTransitionsByClaimID(ctx context.Context, claimID int32) ([]*ListClaimStatusTransitionsByClaimIDRow, error)
+	ListClaimsDueForEscalation(ctx context.Context) ([]*ListClaimsDueForEscalationRow, error)
+	// Claims created before SLA tracking; the clock starts when the claim entered its status.
+	ListClaimsWithoutSLA(ctx context.Context) ([]*ListClaimsWithoutSLARow, error)
+	MarkClaimSLAEscalated(ctx context.Context, id int32) (string, error)
+	// Only a claim that is submitted or reopened can be edited.
 	UpdateClaim(ctx context.Context, arg *UpdateClaimParams) (*Claim, error)
 	UpdateClaimApproval(ctx context.Context, arg *UpdateClaimApprovalParams) (*Claim, error)
+	UpdateClaimExclusion(ctx context.Context, arg *UpdateClaimExclusionParams) (*ClaimExclusion, error)
+	// Restarts the SLA clock for the claim's current status and clears any earlier escalation.
+	UpdateClaimSLA(ctx context.Context, arg *UpdateClaimSLAParams) (*Claim, error)
 	UpdateClaimStatus(ctx context.Context, arg *UpdateClaimStatusParams) (*Claim, error)
+	// The resolution of a part is recorded with UpsertClaimResolution and the claim lifecycle, not here.
 	UpdateClaimWarrantyPart(ctx context.Context, arg *UpdateClaimWarrantyPartParams) (*ClaimWarrantyPart, error)
 	UpdateClaimWarrantyPartApproval(ctx context.Context, arg *UpdateClaimWarrantyPartApprovalParams) (*ClaimWarrantyPart, error)
-	UpdateClaimWarrantyPartStatus(ctx context.Context, arg *UpdateClaimWarrantyPartStatusParams) (*ClaimWarrantyPart, error)
+	UpdateClaimWarrantyPartResolution(ctx context.Context, arg *UpdateClaimWarrantyPartResolutionParams) (*ClaimWarrantyPart, error)
+	// Parts follow the status of their claim; rejected parts are closed.
+	UpdateClaimWarrantyPartsStatusByClaimID(ctx context.Context, arg *UpdateClaimWarrantyPartsStatusByClaimIDParams) error
+	UpsertClaimResolution(ctx context.Context, arg *UpsertClaimResolutionParams) (*ClaimResolution, error)
 }
 
 var _ Querier = (*Queries)(nil)
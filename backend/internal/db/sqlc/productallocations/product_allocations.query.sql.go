@@ -15,19 +15,23 @@ INSERT INTO product_allocations (
     product_id,
     shop_id,
     film_quantity,
+    unit_of_measure,
+    unit_quantity,
     allocation_date,
     created_at,
     updated_at
 ) VALUES (
-    $1, $2, $3, $4, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+    $1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
 )
-RETURNING id, product_id, shop_id, film_quantity, allocation_date, created_at, updated_at
+RETURNING id, product_id, shop_id, film_quantity, allocation_date, created_at, updated_at, unit_of_measure, unit_quantity
 `
 
 type CreateProductAllocationParams struct {
 	ProductID      int32     `db:"product_id" json:"productId"`
 	ShopID         int32     `db:"shop_id" json:"shopId"`
-	FilmQuantity   int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity   float64   `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure  string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity   float64   `db:"unit_quantity" json:"unitQuantity"`
 	AllocationDate time.Time `db:"allocation_date" json:"allocationDate"`
 }
 
@@ -36,6 +40,8 @@ func (q *Queries) CreateProductAllocation(ctx context.Context, arg *CreateProduc
 		arg.ProductID,
 		arg.ShopID,
 		arg.FilmQuantity,
+		arg.UnitOfMeasure,
+		arg.UnitQuantity,
 		arg.AllocationDate,
 	)
 	var i ProductAllocation
@@ -47,10 +53,105 @@ func (q *Queries) CreateProductAllocation(ctx context.Context, arg *CreateProduc
 		&i.AllocationDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
 	)
 	return &i, err
 }
 
+const createProductAllocationTransfer = `-- name: CreateProductAllocationTransfer :one
+INSERT INTO product_allocation_transfers (
+    product_allocation_id,
+    transfer_type,
+    film_quantity,
+    to_shop_id,
+    to_product_allocation_id,
+    transfer_date,
+    remarks,
+    created_by_user_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id, product_allocation_id, transfer_type, film_quantity, to_shop_id, to_product_allocation_id, transfer_date, remarks, created_by_user_id, created_at
+`
+
+type CreateProductAllocationTransferParams struct {
+	ProductAllocationID   int32     `db:"product_allocation_id" json:"productAllocationId"`
+	TransferType          string    `db:"transfer_type" json:"transferType"`
+	FilmQuantity          float64   `db:"film_quantity" json:"filmQuantity"`
+	ToShopID              *int32    `db:"to_shop_id" json:"toShopId"`
+	ToProductAllocationID *int32    `db:"to_product_allocation_id" json:"toProductAllocationId"`
+	TransferDate          time.Time `db:"transfer_date" json:"transferDate"`
+	Remarks               *string   `db:"remarks" json:"remarks"`
+	CreatedByUserID       *int32    `db:"created_by_user_id" json:"createdByUserId"`
+}
+
+func (q *Queries) CreateProductAllocationTransfer(ctx context.Context, arg *CreateProductAllocationTransferParams) (*ProductAllocationTransfer, error) {
+	row := q.db.QueryRow(ctx, createProductAllocationTransfer,
+		arg.ProductAllocationID,
+		arg.TransferType,
+		arg.FilmQuantity,
+		arg.ToShopID,
+		arg.ToProductAllocationID,
+		arg.TransferDate,
+		arg.Remarks,
+		arg.CreatedByUserID,
+	)
+	var i ProductAllocationTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.ProductAllocationID,
+		&i.TransferType,
+		&i.FilmQuantity,
+		&i.ToShopID,
+		&i.ToProductAllocationID,
+		&i.TransferDate,
+		&i.Remarks,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
+const getInProgressStockTakeNoByAllocationID = `-- name: GetInProgressStockTakeNoByAllocationID :one
+SELECT
+    st.stock_take_no
+FROM stock_take_lines stl
+JOIN stock_takes st ON st.id = stl.stock_take_id
+WHERE stl.product_allocation_id = $1
+    AND st.status IN ('OPEN', 'SUBMITTED')
+LIMIT 1
+`
+
+// The stock take, open or awaiting review, that is counting the allocation.
+func (q *Queries) GetInProgressStockTakeNoByAllocationID(ctx context.Context, productAllocationID int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInProgressStockTakeNoByAllocationID, productAllocationID)
+	var stock_take_no string
+	err := row.Scan(&stock_take_no)
+	return stock_take_no, err
+}
+
+const getInstallFreezeRecallNoByProductID = `-- name: GetInstallFreezeRecallNoByProductID :one
+SELECT
+    r.recall_no
+FROM recalls r
+JOIN recall_products rp ON rp.recall_id = r.id
+WHERE rp.product_id = $1
+    AND r.status = 'OPEN'
+    AND r.freeze_installs
+ORDER BY r.id
+LIMIT 1
+`
+
+// The open recall, if any, that freezes installs from the film of a product, so that no more of
+// it is allocated to shops.
+func (q *Queries) GetInstallFreezeRecallNoByProductID(ctx context.Context, productID int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInstallFreezeRecallNoByProductID, productID)
+	var recall_no string
+	err := row.Scan(&recall_no)
+	return recall_no, err
+}
+
 const getProductAllocationByID = `-- name: GetProductAllocationByID :one
 SELECT
     id,
@@ -59,7 +160,9 @@ SELECT
     film_quantity,
     allocation_date,
     created_at,
-    updated_at
+    updated_at,
+    unit_of_measure,
+    unit_quantity
 FROM product_allocations
 WHERE id = $1
 `
@@ -75,6 +178,175 @@ func (q *Queries) GetProductAllocationByID(ctx context.Context, id int32) (*Prod
 		&i.AllocationDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
+	)
+	return &i, err
+}
+
+const getProductAllocationByIDForUpdate = `-- name: GetProductAllocationByIDForUpdate :one
+SELECT
+    id,
+    product_id,
+    shop_id,
+    film_quantity,
+    allocation_date,
+    created_at,
+    updated_at,
+    unit_of_measure,
+    unit_quantity
+FROM product_allocations
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductAllocationByIDForUpdate(ctx context.Context, id int32) (*ProductAllocation, error) {
+	row := q.db.QueryRow(ctx, getProductAllocationByIDForUpdate, id)
+	var i ProductAllocation
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ShopID,
+		&i.FilmQuantity,
+		&i.AllocationDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
+	)
+	return &i, err
+}
+
+const getProductAllocationUsage = `-- name: GetProductAllocationUsage :one
+SELECT
+    bal.consumed_quantity,
+    bal.installed_quantity,
+    bal.transferred_quantity,
+    bal.adjusted_quantity,
+    bal.remaining_quantity,
+    (SELECT COUNT(*) FROM warranty_parts wp WHERE wp.product_allocation_id = bal.product_allocation_id)::int AS warranty_part_count,
+    EXISTS (SELECT 1 FROM product_allocation_transfers pat WHERE pat.to_product_allocation_id = bal.product_allocation_id) AS is_transfer_in
+FROM product_allocation_balances_view bal
+WHERE bal.product_allocation_id = $1
+`
+
+type GetProductAllocationUsageRow struct {
+	ConsumedQuantity    float64 `db:"consumed_quantity" json:"consumedQuantity"`
+	InstalledQuantity   float64 `db:"installed_quantity" json:"installedQuantity"`
+	TransferredQuantity float64 `db:"transferred_quantity" json:"transferredQuantity"`
+	AdjustedQuantity    float64 `db:"adjusted_quantity" json:"adjustedQuantity"`
+	RemainingQuantity   float64 `db:"remaining_quantity" json:"remainingQuantity"`
+	WarrantyPartCount   int32   `db:"warranty_part_count" json:"warrantyPartCount"`
+	IsTransferIn        bool    `db:"is_transfer_in" json:"isTransferIn"`
+}
+
+// What already draws on an allocation: film used by claim resolutions and by warranty installs,
+// film returned or transferred out, stock-take adjustments posted against it, the film left as
+// defined by product_allocation_balances_view, warranty parts installed from it, and whether it
+// was opened by a transfer.
+func (q *Queries) GetProductAllocationUsage(ctx context.Context, productAllocationID int32) (*GetProductAllocationUsageRow, error) {
+	row := q.db.QueryRow(ctx, getProductAllocationUsage, productAllocationID)
+	var i GetProductAllocationUsageRow
+	err := row.Scan(
+		&i.ConsumedQuantity,
+		&i.InstalledQuantity,
+		&i.TransferredQuantity,
+		&i.AdjustedQuantity,
+		&i.RemainingQuantity,
+		&i.WarrantyPartCount,
+		&i.IsTransferIn,
+	)
+	return &i, err
+}
+
+const getProductByFilmSerialNumber = `-- name: GetProductByFilmSerialNumber :one
+SELECT
+    p.id,
+    p.film_serial_number,
+    p.film_quantity,
+    p.unit_of_measure,
+    p.roll_length_metres,
+    p.sheets_per_roll,
+    p.warranty_in_months,
+    p.shipment_number,
+    p.is_active,
+    pb.name AS brand_name,
+    pt.name AS type_name,
+    ps.name AS series_name,
+    pn.name AS product_name
+FROM products p
+JOIN product_names pn ON p.name_id = pn.id
+JOIN product_series ps ON pn.series_id = ps.id
+JOIN product_types pt ON ps.type_id = pt.id
+JOIN product_brands pb ON pt.brand_id = pb.id
+WHERE UPPER(p.film_serial_number) = UPPER($1)
+ORDER BY (p.film_serial_number = $1) DESC, p.id
+LIMIT 1
+`
+
+type GetProductByFilmSerialNumberRow struct {
+	ID               int32    `db:"id" json:"id"`
+	FilmSerialNumber string   `db:"film_serial_number" json:"filmSerialNumber"`
+	FilmQuantity     float64  `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure    string   `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
+	WarrantyInMonths int32    `db:"warranty_in_months" json:"warrantyInMonths"`
+	ShipmentNumber   string   `db:"shipment_number" json:"shipmentNumber"`
+	IsActive         bool     `db:"is_active" json:"isActive"`
+	BrandName        string   `db:"brand_name" json:"brandName"`
+	TypeName         string   `db:"type_name" json:"typeName"`
+	SeriesName       string   `db:"series_name" json:"seriesName"`
+	ProductName      string   `db:"product_name" json:"productName"`
+}
+
+// Matches a scanned serial regardless of case, preferring an exact match.
+func (q *Queries) GetProductByFilmSerialNumber(ctx context.Context, filmSerialNumber string) (*GetProductByFilmSerialNumberRow, error) {
+	row := q.db.QueryRow(ctx, getProductByFilmSerialNumber, filmSerialNumber)
+	var i GetProductByFilmSerialNumberRow
+	err := row.Scan(
+		&i.ID,
+		&i.FilmSerialNumber,
+		&i.FilmQuantity,
+		&i.UnitOfMeasure,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
+		&i.WarrantyInMonths,
+		&i.ShipmentNumber,
+		&i.IsActive,
+		&i.BrandName,
+		&i.TypeName,
+		&i.SeriesName,
+		&i.ProductName,
+	)
+	return &i, err
+}
+
+const getProductUnitsByID = `-- name: GetProductUnitsByID :one
+SELECT
+    id,
+    unit_of_measure,
+    roll_length_metres,
+    sheets_per_roll
+FROM products
+WHERE id = $1
+`
+
+type GetProductUnitsByIDRow struct {
+	ID               int32    `db:"id" json:"id"`
+	UnitOfMeasure    string   `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
+}
+
+func (q *Queries) GetProductUnitsByID(ctx context.Context, id int32) (*GetProductUnitsByIDRow, error) {
+	row := q.db.QueryRow(ctx, getProductUnitsByID, id)
+	var i GetProductUnitsByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UnitOfMeasure,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
 	)
 	return &i, err
 }
@@ -90,9 +362,14 @@ SELECT
     p.warranty_in_months,
     p.film_serial_number,
     p.film_quantity,
+    p.unit_of_measure,
+    p.roll_length_metres,
+    p.sheets_per_roll,
     p.shipment_number,
-    p.description
+    p.description,
+    bal.remaining_quantity
 FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
 JOIN products p ON pa.product_id = p.id
 JOIN product_names pn ON p.name_id = pn.id
 JOIN product_series ps ON pn.series_id = ps.id
@@ -104,17 +381,21 @@ ORDER BY brand_name ASC
 `
 
 type GetProductsFromProductAllocationsByShopIDRow struct {
-	ProductAllocationID int32  `db:"product_allocation_id" json:"productAllocationId"`
-	ProductID           int32  `db:"product_id" json:"productId"`
-	BrandName           string `db:"brand_name" json:"brandName"`
-	TypeName            string `db:"type_name" json:"typeName"`
-	SeriesName          string `db:"series_name" json:"seriesName"`
-	ProductName         string `db:"product_name" json:"productName"`
-	WarrantyInMonths    int32  `db:"warranty_in_months" json:"warrantyInMonths"`
-	FilmSerialNumber    string `db:"film_serial_number" json:"filmSerialNumber"`
-	FilmQuantity        int32  `db:"film_quantity" json:"filmQuantity"`
-	ShipmentNumber      string `db:"shipment_number" json:"shipmentNumber"`
-	Description         string `db:"description" json:"description"`
+	ProductAllocationID int32    `db:"product_allocation_id" json:"productAllocationId"`
+	ProductID           int32    `db:"product_id" json:"productId"`
+	BrandName           string   `db:"brand_name" json:"brandName"`
+	TypeName            string   `db:"type_name" json:"typeName"`
+	SeriesName          string   `db:"series_name" json:"seriesName"`
+	ProductName         string   `db:"product_name" json:"productName"`
+	WarrantyInMonths    int32    `db:"warranty_in_months" json:"warrantyInMonths"`
+	FilmSerialNumber    string   `db:"film_serial_number" json:"filmSerialNumber"`
+	FilmQuantity        float64  `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure       string   `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres    *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll       *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
+	ShipmentNumber      string   `db:"shipment_number" json:"shipmentNumber"`
+	Description         string   `db:"description" json:"description"`
+	RemainingQuantity   float64  `db:"remaining_quantity" json:"remainingQuantity"`
 }
 
 func (q *Queries) GetProductsFromProductAllocationsByShopID(ctx context.Context, shopID int32) ([]*GetProductsFromProductAllocationsByShopIDRow, error) {
@@ -136,8 +417,230 @@ func (q *Queries) GetProductsFromProductAllocationsByShopID(ctx context.Context,
 			&i.WarrantyInMonths,
 			&i.FilmSerialNumber,
 			&i.FilmQuantity,
+			&i.UnitOfMeasure,
+			&i.RollLengthMetres,
+			&i.SheetsPerRoll,
 			&i.ShipmentNumber,
 			&i.Description,
+			&i.RemainingQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductAllocationBalancesByProductAndShop = `-- name: ListProductAllocationBalancesByProductAndShop :many
+SELECT
+    pa.id AS product_allocation_id,
+    pa.allocation_date,
+    bal.allocated_quantity,
+    bal.consumed_quantity,
+    bal.installed_quantity,
+    bal.transferred_quantity,
+    bal.adjusted_quantity,
+    bal.remaining_quantity
+FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
+WHERE pa.product_id = $1
+    AND pa.shop_id = $2
+ORDER BY pa.allocation_date DESC, pa.id DESC
+`
+
+type ListProductAllocationBalancesByProductAndShopParams struct {
+	ProductID int32 `db:"product_id" json:"productId"`
+	ShopID    int32 `db:"shop_id" json:"shopId"`
+}
+
+type ListProductAllocationBalancesByProductAndShopRow struct {
+	ProductAllocationID int32     `db:"product_allocation_id" json:"productAllocationId"`
+	AllocationDate      time.Time `db:"allocation_date" json:"allocationDate"`
+	AllocatedQuantity   float64   `db:"allocated_quantity" json:"allocatedQuantity"`
+	ConsumedQuantity    float64   `db:"consumed_quantity" json:"consumedQuantity"`
+	InstalledQuantity   float64   `db:"installed_quantity" json:"installedQuantity"`
+	TransferredQuantity float64   `db:"transferred_quantity" json:"transferredQuantity"`
+	AdjustedQuantity    float64   `db:"adjusted_quantity" json:"adjustedQuantity"`
+	RemainingQuantity   float64   `db:"remaining_quantity" json:"remainingQuantity"`
+}
+
+// Remaining film of each allocation of a product at a shop, newest first.
+func (q *Queries) ListProductAllocationBalancesByProductAndShop(ctx context.Context, arg *ListProductAllocationBalancesByProductAndShopParams) ([]*ListProductAllocationBalancesByProductAndShopRow, error) {
+	rows, err := q.db.Query(ctx, listProductAllocationBalancesByProductAndShop, arg.ProductID, arg.ShopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListProductAllocationBalancesByProductAndShopRow{}
+	for rows.Next() {
+		var i ListProductAllocationBalancesByProductAndShopRow
+		if err := rows.Scan(
+			&i.ProductAllocationID,
+			&i.AllocationDate,
+			&i.AllocatedQuantity,
+			&i.ConsumedQuantity,
+			&i.InstalledQuantity,
+			&i.TransferredQuantity,
+			&i.AdjustedQuantity,
+			&i.RemainingQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductAllocationBalancesByShopID = `-- name: ListProductAllocationBalancesByShopID :many
+SELECT
+    pa.id AS product_allocation_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    pa.allocation_date,
+    bal.allocated_quantity,
+    bal.consumed_quantity,
+    bal.installed_quantity,
+    bal.transferred_quantity,
+    bal.adjusted_quantity,
+    bal.remaining_quantity
+FROM product_allocations pa
+JOIN product_allocation_balances_view bal ON bal.product_allocation_id = pa.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE pa.shop_id = $1
+ORDER BY pa.allocation_date DESC, pa.id DESC
+`
+
+type ListProductAllocationBalancesByShopIDRow struct {
+	ProductAllocationID int32     `db:"product_allocation_id" json:"productAllocationId"`
+	FilmSerialNumber    string    `db:"film_serial_number" json:"filmSerialNumber"`
+	ProductName         string    `db:"product_name" json:"productName"`
+	AllocationDate      time.Time `db:"allocation_date" json:"allocationDate"`
+	AllocatedQuantity   float64   `db:"allocated_quantity" json:"allocatedQuantity"`
+	ConsumedQuantity    float64   `db:"consumed_quantity" json:"consumedQuantity"`
+	InstalledQuantity   float64   `db:"installed_quantity" json:"installedQuantity"`
+	TransferredQuantity float64   `db:"transferred_quantity" json:"transferredQuantity"`
+	AdjustedQuantity    float64   `db:"adjusted_quantity" json:"adjustedQuantity"`
+	RemainingQuantity   float64   `db:"remaining_quantity" json:"remainingQuantity"`
+}
+
+// Remaining film per allocation, as defined by product_allocation_balances_view.
+func (q *Queries) ListProductAllocationBalancesByShopID(ctx context.Context, shopID int32) ([]*ListProductAllocationBalancesByShopIDRow, error) {
+	rows, err := q.db.Query(ctx, listProductAllocationBalancesByShopID, shopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListProductAllocationBalancesByShopIDRow{}
+	for rows.Next() {
+		var i ListProductAllocationBalancesByShopIDRow
+		if err := rows.Scan(
+			&i.ProductAllocationID,
+			&i.FilmSerialNumber,
+			&i.ProductName,
+			&i.AllocationDate,
+			&i.AllocatedQuantity,
+			&i.ConsumedQuantity,
+			&i.InstalledQuantity,
+			&i.TransferredQuantity,
+			&i.AdjustedQuantity,
+			&i.RemainingQuantity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductAllocationTransfers = `-- name: ListProductAllocationTransfers :many
+SELECT
+    pat.id,
+    pat.product_allocation_id,
+    pat.transfer_type,
+    pat.film_quantity,
+    pa.shop_id AS from_shop_id,
+    fs.shop_name AS from_shop_name,
+    pat.to_shop_id,
+    ts.shop_name AS to_shop_name,
+    pat.to_product_allocation_id,
+    p.film_serial_number,
+    pn.name AS product_name,
+    pat.transfer_date,
+    pat.remarks,
+    pat.created_by_user_id,
+    pat.created_at
+FROM product_allocation_transfers pat
+JOIN product_allocations pa ON pat.product_allocation_id = pa.id
+JOIN shops fs ON pa.shop_id = fs.id
+LEFT JOIN shops ts ON pat.to_shop_id = ts.id
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+WHERE ($1::int IS NULL OR pa.shop_id = $1 OR pat.to_shop_id = $1)
+    AND ($2::int IS NULL
+        OR pat.product_allocation_id = $2
+        OR pat.to_product_allocation_id = $2)
+ORDER BY pat.transfer_date DESC, pat.id DESC
+`
+
+type ListProductAllocationTransfersParams struct {
+	ShopID              *int32 `db:"shop_id" json:"shopId"`
+	ProductAllocationID *int32 `db:"product_allocation_id" json:"productAllocationId"`
+}
+
+type ListProductAllocationTransfersRow struct {
+	ID                    int32     `db:"id" json:"id"`
+	ProductAllocationID   int32     `db:"product_allocation_id" json:"productAllocationId"`
+	TransferType          string    `db:"transfer_type" json:"transferType"`
+	FilmQuantity          float64   `db:"film_quantity" json:"filmQuantity"`
+	FromShopID            int32     `db:"from_shop_id" json:"fromShopId"`
+	FromShopName          string    `db:"from_shop_name" json:"fromShopName"`
+	ToShopID              *int32    `db:"to_shop_id" json:"toShopId"`
+	ToShopName            *string   `db:"to_shop_name" json:"toShopName"`
+	ToProductAllocationID *int32    `db:"to_product_allocation_id" json:"toProductAllocationId"`
+	FilmSerialNumber      string    `db:"film_serial_number" json:"filmSerialNumber"`
+	ProductName           string    `db:"product_name" json:"productName"`
+	TransferDate          time.Time `db:"transfer_date" json:"transferDate"`
+	Remarks               *string   `db:"remarks" json:"remarks"`
+	CreatedByUserID       *int32    `db:"created_by_user_id" json:"createdByUserId"`
+	CreatedAt             time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Returns and transfers newest first, optionally limited to those leaving or reaching a shop.
+func (q *Queries) ListProductAllocationTransfers(ctx context.Context, arg *ListProductAllocationTransfersParams) ([]*ListProductAllocationTransfersRow, error) {
+	rows, err := q.db.Query(ctx, listProductAllocationTransfers, arg.ShopID, arg.ProductAllocationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListProductAllocationTransfersRow{}
+	for rows.Next() {
+		var i ListProductAllocationTransfersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductAllocationID,
+			&i.TransferType,
+			&i.FilmQuantity,
+			&i.FromShopID,
+			&i.FromShopName,
+			&i.ToShopID,
+			&i.ToShopName,
+			&i.ToProductAllocationID,
+			&i.FilmSerialNumber,
+			&i.ProductName,
+			&i.TransferDate,
+			&i.Remarks,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -157,6 +660,8 @@ SELECT
     shop_name,
     branch_code,
     film_quantity,
+    unit_of_measure,
+    unit_quantity,
     allocation_date,
     created_at,
     updated_at
@@ -170,7 +675,9 @@ type ListProductAllocationsViewRow struct {
 	ProductName      string    `db:"product_name" json:"productName"`
 	ShopName         string    `db:"shop_name" json:"shopName"`
 	BranchCode       string    `db:"branch_code" json:"branchCode"`
-	FilmQuantity     int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity     float64   `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure    string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity     float64   `db:"unit_quantity" json:"unitQuantity"`
 	AllocationDate   time.Time `db:"allocation_date" json:"allocationDate"`
 	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updatedAt"`
@@ -192,6 +699,8 @@ func (q *Queries) ListProductAllocationsView(ctx context.Context) ([]*ListProduc
 			&i.ShopName,
 			&i.BranchCode,
 			&i.FilmQuantity,
+			&i.UnitOfMeasure,
+			&i.UnitQuantity,
 			&i.AllocationDate,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -212,17 +721,21 @@ SET
     product_id = $2,
     shop_id = $3,
     film_quantity = $4,
-    allocation_date = $5,
+    unit_of_measure = $5,
+    unit_quantity = $6,
+    allocation_date = $7,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, product_id, shop_id, film_quantity, allocation_date, created_at, updated_at
+RETURNING id, product_id, shop_id, film_quantity, allocation_date, created_at, updated_at, unit_of_measure, unit_quantity
 `
 
 type UpdateProductAllocationParams struct {
 	ID             int32     `db:"id" json:"id"`
 	ProductID      int32     `db:"product_id" json:"productId"`
 	ShopID         int32     `db:"shop_id" json:"shopId"`
-	FilmQuantity   int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity   float64   `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure  string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity   float64   `db:"unit_quantity" json:"unitQuantity"`
 	AllocationDate time.Time `db:"allocation_date" json:"allocationDate"`
 }
 
@@ -232,6 +745,8 @@ func (q *Queries) UpdateProductAllocation(ctx context.Context, arg *UpdateProduc
 		arg.ProductID,
 		arg.ShopID,
 		arg.FilmQuantity,
+		arg.UnitOfMeasure,
+		arg.UnitQuantity,
 		arg.AllocationDate,
 	)
 	var i ProductAllocation
@@ -243,6 +758,8 @@ func (q *Queries) UpdateProductAllocation(ctx context.Context, arg *UpdateProduc
 		&i.AllocationDate,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
 	)
 	return &i, err
 }
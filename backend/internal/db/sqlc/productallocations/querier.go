@@ -10,8 +10,29 @@ import (
 
 type Querier interface {
 	CreateProductAllocation(ctx context.Context, arg *CreateProductAllocationParams) (*ProductAllocation, error)
+	CreateProductAllocationTransfer(ctx context.Context, arg *CreateProductAllocationTransferParams) (*ProductAllocationTransfer, error)
+	// The stock take, open or awaiting review, that is counting the allocation.
+	GetInProgressStockTakeNoByAllocationID(ctx context.Context, productAllocationID int32) (string, error)
+	// The open recall, if any, that freezes installs from the film of a product, so that no more of
+	// it is allocated to shops.
+	GetInstallFreezeRecallNoByProductID(ctx context.Context, productID int32) (string, error)
 	GetProductAllocationByID(ctx context.Context, id int32) (*ProductAllocation, error)
+	GetProductAllocationByIDForUpdate(ctx context.Context, id int32) (*ProductAllocation, error)
+	// What already draws on an allocation: film used by claim resolutions and by warranty installs,
+	// film returned or transferred out, stock-take adjustments posted against it, the film left as
+	// defined by product_allocation_balances_view, warranty parts installed from it, and whether it
+	// was opened by a transfer.
+	GetProductAllocationUsage(ctx context.Context, productAllocationID int32) (*GetProductAllocationUsageRow, error)
+	// Matches a scanned serial regardless of case, preferring an exact match.
+	GetProductByFilmSerialNumber(ctx context.Context, filmSerialNumber string) (*GetProductByFilmSerialNumberRow, error)
+	GetProductUnitsByID(ctx context.Context, id int32) (*GetProductUnitsByIDRow, error)
 	GetProductsFromProductAllocationsByShopID(ctx context.Context, shopID int32) ([]*GetProductsFromProductAllocationsByShopIDRow, error)
+	// Remaining film of each allocation of a product at a shop, newest first.
+	ListProductAllocationBalancesByProductAndShop(ctx context.Context, arg *ListProductAllocationBalancesByProductAndShopParams) ([]*ListProductAllocationBalancesByProductAndShopRow, error)
+	// Remaining film per allocation, as defined by product_allocation_balances_view.
+	ListProductAllocationBalancesByShopID(ctx context.Context, shopID int32) ([]*ListProductAllocationBalancesByShopIDRow, error)
+	// Returns and transfers newest first, optionally limited to those leaving or reaching a shop.
+	ListProductAllocationTransfers(ctx context.Context, arg *ListProductAllocationTransfersParams) ([]*ListProductAllocationTransfersRow, error)
 	ListProductAllocationsView(ctx context.Context) ([]*ListProductAllocationsViewRow, error)
 	UpdateProductAllocation(ctx context.Context, arg *UpdateProductAllocationParams) (*ProductAllocation, error)
 }
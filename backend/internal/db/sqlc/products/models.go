@@ -5,11 +5,50 @@
 package products
 
 import (
+	"encoding/json"
 	"time"
 
 	models "github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
 )
 
+type ApprovalRule struct {
+	EntityType          string    `db:"entity_type" json:"entityType"`
+	MixedStatus         string    `db:"mixed_status" json:"mixedStatus"`
+	PartialWhilePending bool      `db:"partial_while_pending" json:"partialWhilePending"`
+	UpdatedAt           time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CarMake struct {
+	ID        int32     `db:"id" json:"id"`
+	Name      string    `db:"name" json:"name"`
+	IsActive  bool      `db:"is_active" json:"isActive"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CarMakeAlias struct {
+	ID        int32     `db:"id" json:"id"`
+	CarMakeID int32     `db:"car_make_id" json:"carMakeId"`
+	Alias     string    `db:"alias" json:"alias"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+type CarModel struct {
+	ID        int32     `db:"id" json:"id"`
+	CarMakeID int32     `db:"car_make_id" json:"carMakeId"`
+	Name      string    `db:"name" json:"name"`
+	IsActive  bool      `db:"is_active" json:"isActive"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CarModelAlias struct {
+	ID         int32     `db:"id" json:"id"`
+	CarModelID int32     `db:"car_model_id" json:"carModelId"`
+	Alias      string    `db:"alias" json:"alias"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+}
+
 type CarPart struct {
 	ID          int32     `db:"id" json:"id"`
 	Name        string    `db:"name" json:"name"`
@@ -20,40 +59,97 @@ type CarPart struct {
 }
 
 type Claim struct {
-	ID             int32                 `db:"id" json:"id"`
-	WarrantyID     int32                 `db:"warranty_id" json:"warrantyId"`
-	ClaimNo        string                `db:"claim_no" json:"claimNo"`
-	ClaimDate      time.Time             `db:"claim_date" json:"claimDate"`
-	ApprovalStatus models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
-	Status         string                `db:"status" json:"status"`
-	Remarks        *string               `db:"remarks" json:"remarks"`
-	CreatedAt      time.Time             `db:"created_at" json:"createdAt"`
-	UpdatedAt      time.Time             `db:"updated_at" json:"updatedAt"`
+	ID                    int32                 `db:"id" json:"id"`
+	WarrantyID            int32                 `db:"warranty_id" json:"warrantyId"`
+	ClaimNo               string                `db:"claim_no" json:"claimNo"`
+	ClaimDate             time.Time             `db:"claim_date" json:"claimDate"`
+	ApprovalStatus        models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	Status                string                `db:"status" json:"status"`
+	Remarks               *string               `db:"remarks" json:"remarks"`
+	CreatedAt             time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt             time.Time             `db:"updated_at" json:"updatedAt"`
+	SubmittedByCustomerID *int32                `db:"submitted_by_customer_id" json:"submittedByCustomerId"`
+	SlaStartDate          *time.Time            `db:"sla_start_date" json:"slaStartDate"`
+	SlaDueDate            *time.Time            `db:"sla_due_date" json:"slaDueDate"`
+	SlaEscalatedAt        *time.Time            `db:"sla_escalated_at" json:"slaEscalatedAt"`
+}
+
+type ClaimExclusion struct {
+	ID              int32     `db:"id" json:"id"`
+	Name            string    `db:"name" json:"name"`
+	Reason          string    `db:"reason" json:"reason"`
+	ProductTypeID   *int32    `db:"product_type_id" json:"productTypeId"`
+	ProductSeriesID *int32    `db:"product_series_id" json:"productSeriesId"`
+	ProductNameID   *int32    `db:"product_name_id" json:"productNameId"`
+	CarPartID       *int32    `db:"car_part_id" json:"carPartId"`
+	IsActive        bool      `db:"is_active" json:"isActive"`
+	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type ClaimResolution struct {
+	ID                       int32     `db:"id" json:"id"`
+	ClaimWarrantyPartID      int32     `db:"claim_warranty_part_id" json:"claimWarrantyPartId"`
+	ProductAllocationID      int32     `db:"product_allocation_id" json:"productAllocationId"`
+	QuantityUsed             float64   `db:"quantity_used" json:"quantityUsed"`
+	LabourCost               float64   `db:"labour_cost" json:"labourCost"`
+	MaterialCost             float64   `db:"material_cost" json:"materialCost"`
+	Remarks                  *string   `db:"remarks" json:"remarks"`
+	ReimbursementStatementID *int32    `db:"reimbursement_statement_id" json:"reimbursementStatementId"`
+	RecordedByUserID         *int32    `db:"recorded_by_user_id" json:"recordedByUserId"`
+	CreatedAt                time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt                time.Time `db:"updated_at" json:"updatedAt"`
+	UnitOfMeasure            string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity             float64   `db:"unit_quantity" json:"unitQuantity"`
+}
+
+type ClaimSlaPolicy struct {
+	Status      string    `db:"status" json:"status"`
+	WorkingDays int32     `db:"working_days" json:"workingDays"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type ClaimStatusTransition struct {
+	ID                  int32     `db:"id" json:"id"`
+	ClaimID             int32     `db:"claim_id" json:"claimId"`
+	FromStatus          *string   `db:"from_status" json:"fromStatus"`
+	ToStatus            string    `db:"to_status" json:"toStatus"`
+	ChangedByUserID     *int32    `db:"changed_by_user_id" json:"changedByUserId"`
+	ChangedByCustomerID *int32    `db:"changed_by_customer_id" json:"changedByCustomerId"`
+	ActorRole           string    `db:"actor_role" json:"actorRole"`
+	Remarks             *string   `db:"remarks" json:"remarks"`
+	CreatedAt           time.Time `db:"created_at" json:"createdAt"`
 }
 
 type ClaimView struct {
-	ID                   int32                 `db:"id" json:"id"`
-	WarrantyID           int32                 `db:"warranty_id" json:"warrantyId"`
-	ClaimNo              string                `db:"claim_no" json:"claimNo"`
-	ClaimDate            time.Time             `db:"claim_date" json:"claimDate"`
-	ApprovalStatus       models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
-	Status               string                `db:"status" json:"status"`
-	Remarks              *string               `db:"remarks" json:"remarks"`
-	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
-	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
-	ShopID               int32                 `db:"shop_id" json:"shopId"`
-	ClientName           string                `db:"client_name" json:"clientName"`
-	ClientContact        string                `db:"client_contact" json:"clientContact"`
-	ClientEmail          string                `db:"client_email" json:"clientEmail"`
-	CarBrand             string                `db:"car_brand" json:"carBrand"`
-	CarModel             string                `db:"car_model" json:"carModel"`
-	CarColour            string                `db:"car_colour" json:"carColour"`
-	CarPlateNo           string                `db:"car_plate_no" json:"carPlateNo"`
-	CarChassisNo         string                `db:"car_chassis_no" json:"carChassisNo"`
-	InstallationDate     time.Time             `db:"installation_date" json:"installationDate"`
-	ReferenceNo          *string               `db:"reference_no" json:"referenceNo"`
-	WarrantyNo           string                `db:"warranty_no" json:"warrantyNo"`
-	InvoiceAttachmentUrl string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
+	ID                    int32                 `db:"id" json:"id"`
+	WarrantyID            int32                 `db:"warranty_id" json:"warrantyId"`
+	ClaimNo               string                `db:"claim_no" json:"claimNo"`
+	ClaimDate             time.Time             `db:"claim_date" json:"claimDate"`
+	ApprovalStatus        models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	Status                string                `db:"status" json:"status"`
+	Remarks               *string               `db:"remarks" json:"remarks"`
+	CreatedAt             time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt             time.Time             `db:"updated_at" json:"updatedAt"`
+	SubmittedByCustomerID *int32                `db:"submitted_by_customer_id" json:"submittedByCustomerId"`
+	SlaStartDate          *time.Time            `db:"sla_start_date" json:"slaStartDate"`
+	SlaDueDate            *time.Time            `db:"sla_due_date" json:"slaDueDate"`
+	SlaEscalatedAt        *time.Time            `db:"sla_escalated_at" json:"slaEscalatedAt"`
+	ShopID                int32                 `db:"shop_id" json:"shopId"`
+	ClientName            string                `db:"client_name" json:"clientName"`
+	ClientContact         string                `db:"client_contact" json:"clientContact"`
+	ClientEmail           string                `db:"client_email" json:"clientEmail"`
+	CarBrand              string                `db:"car_brand" json:"carBrand"`
+	CarModel              string                `db:"car_model" json:"carModel"`
+	CarColour             string                `db:"car_colour" json:"carColour"`
+	CarPlateNo            string                `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo          string                `db:"car_chassis_no" json:"carChassisNo"`
+	InstallationDate      time.Time             `db:"installation_date" json:"installationDate"`
+	ReferenceNo           *string               `db:"reference_no" json:"referenceNo"`
+	WarrantyNo            string                `db:"warranty_no" json:"warrantyNo"`
+	InvoiceAttachmentUrl  string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
+	SlaBreached           *bool                 `db:"sla_breached" json:"slaBreached"`
 }
 
 type ClaimWarrantyPart struct {
@@ -94,12 +190,125 @@ type ClaimWarrantyPartsView struct {
 	WarrantyInMonths     int32                 `db:"warranty_in_months" json:"warrantyInMonths"`
 }
 
-type MsiaState struct {
+type Comment struct {
+	ID              int32     `db:"id" json:"id"`
+	WarrantyID      *int32    `db:"warranty_id" json:"warrantyId"`
+	ClaimID         *int32    `db:"claim_id" json:"claimId"`
+	ParentCommentID *int32    `db:"parent_comment_id" json:"parentCommentId"`
+	AuthorUserID    int32     `db:"author_user_id" json:"authorUserId"`
+	Body            string    `db:"body" json:"body"`
+	IsInternal      bool      `db:"is_internal" json:"isInternal"`
+	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CommentAttachment struct {
 	ID        int32     `db:"id" json:"id"`
-	Name      string    `db:"name" json:"name"`
-	Code      string    `db:"code" json:"code"`
+	CommentID int32     `db:"comment_id" json:"commentId"`
+	FileUrl   string    `db:"file_url" json:"fileUrl"`
+	FileName  string    `db:"file_name" json:"fileName"`
 	CreatedAt time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CommentRead struct {
+	CommentID int32     `db:"comment_id" json:"commentId"`
+	UserID    int32     `db:"user_id" json:"userId"`
+	ReadAt    time.Time `db:"read_at" json:"readAt"`
+}
+
+type Customer struct {
+	ID              int32     `db:"id" json:"id"`
+	Name            string    `db:"name" json:"name"`
+	Phone           string    `db:"phone" json:"phone"`
+	PhoneNormalized string    `db:"phone_normalized" json:"phoneNormalized"`
+	Email           string    `db:"email" json:"email"`
+	EmailNormalized string    `db:"email_normalized" json:"emailNormalized"`
+	MergedIntoID    *int32    `db:"merged_into_id" json:"mergedIntoId"`
+	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type CustomerMerge struct {
+	ID               int32     `db:"id" json:"id"`
+	TargetCustomerID int32     `db:"target_customer_id" json:"targetCustomerId"`
+	SourceCustomerID int32     `db:"source_customer_id" json:"sourceCustomerId"`
+	MergedBy         *int32    `db:"merged_by" json:"mergedBy"`
+	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
+}
+
+type CustomerOtp struct {
+	ID         int32      `db:"id" json:"id"`
+	Channel    string     `db:"channel" json:"channel"`
+	Recipient  string     `db:"recipient" json:"recipient"`
+	CodeHash   string     `db:"code_hash" json:"codeHash"`
+	ExpiresAt  time.Time  `db:"expires_at" json:"expiresAt"`
+	Attempts   int32      `db:"attempts" json:"attempts"`
+	ConsumedAt *time.Time `db:"consumed_at" json:"consumedAt"`
+	CreatedAt  time.Time  `db:"created_at" json:"createdAt"`
+}
+
+type InventoryMovement struct {
+	ID                  int32     `db:"id" json:"id"`
+	ProductID           int32     `db:"product_id" json:"productId"`
+	ShopID              *int32    `db:"shop_id" json:"shopId"`
+	MovementType        string    `db:"movement_type" json:"movementType"`
+	Quantity            float64   `db:"quantity" json:"quantity"`
+	ProductAllocationID *int32    `db:"product_allocation_id" json:"productAllocationId"`
+	ClaimResolutionID   *int32    `db:"claim_resolution_id" json:"claimResolutionId"`
+	Remarks             *string   `db:"remarks" json:"remarks"`
+	CreatedByUserID     *int32    `db:"created_by_user_id" json:"createdByUserId"`
+	CreatedAt           time.Time `db:"created_at" json:"createdAt"`
+	WarrantyPartID      *int32    `db:"warranty_part_id" json:"warrantyPartId"`
+}
+
+type LowStockAlert struct {
+	ID          int32      `db:"id" json:"id"`
+	ThresholdID int32      `db:"threshold_id" json:"thresholdId"`
+	Balance     float64    `db:"balance" json:"balance"`
+	MinQuantity float64    `db:"min_quantity" json:"minQuantity"`
+	AlertedAt   time.Time  `db:"alerted_at" json:"alertedAt"`
+	ResolvedAt  *time.Time `db:"resolved_at" json:"resolvedAt"`
+}
+
+type MsiaState struct {
+	ID          int32     `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Code        string    `db:"code" json:"code"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+	WeekendDays []int32   `db:"weekend_days" json:"weekendDays"`
+}
+
+type Notification struct {
+	ID            int32           `db:"id" json:"id"`
+	EventType     string          `db:"event_type" json:"eventType"`
+	Channel       string          `db:"channel" json:"channel"`
+	Recipient     string          `db:"recipient" json:"recipient"`
+	WarrantyID    *int32          `db:"warranty_id" json:"warrantyId"`
+	ClaimID       *int32          `db:"claim_id" json:"claimId"`
+	Payload       json.RawMessage `db:"payload" json:"payload"`
+	Locale        *string         `db:"locale" json:"locale"`
+	Subject       *string         `db:"subject" json:"subject"`
+	Body          *string         `db:"body" json:"body"`
+	Status        string          `db:"status" json:"status"`
+	Provider      *string         `db:"provider" json:"provider"`
+	Attempts      int32           `db:"attempts" json:"attempts"`
+	MaxAttempts   int32           `db:"max_attempts" json:"maxAttempts"`
+	LastError     *string         `db:"last_error" json:"lastError"`
+	NextAttemptAt time.Time       `db:"next_attempt_at" json:"nextAttemptAt"`
+	SentAt        *time.Time      `db:"sent_at" json:"sentAt"`
+	CreatedAt     time.Time       `db:"created_at" json:"createdAt"`
+	UpdatedAt     time.Time       `db:"updated_at" json:"updatedAt"`
+}
+
+type NotificationAttempt struct {
+	ID             int32     `db:"id" json:"id"`
+	NotificationID int32     `db:"notification_id" json:"notificationId"`
+	AttemptNo      int32     `db:"attempt_no" json:"attemptNo"`
+	Provider       string    `db:"provider" json:"provider"`
+	IsSuccess      bool      `db:"is_success" json:"isSuccess"`
+	ErrorMessage   *string   `db:"error_message" json:"errorMessage"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
 }
 
 type Product struct {
@@ -110,22 +319,53 @@ type Product struct {
 	NameID           int32     `db:"name_id" json:"nameId"`
 	WarrantyInMonths int32     `db:"warranty_in_months" json:"warrantyInMonths"`
 	FilmSerialNumber string    `db:"film_serial_number" json:"filmSerialNumber"`
-	FilmQuantity     int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity     float64   `db:"film_quantity" json:"filmQuantity"`
 	ShipmentNumber   string    `db:"shipment_number" json:"shipmentNumber"`
 	Description      string    `db:"description" json:"description"`
 	IsActive         bool      `db:"is_active" json:"isActive"`
 	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updatedAt"`
+	ShipmentID       *int32    `db:"shipment_id" json:"shipmentId"`
+	UnitOfMeasure    string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres *float64  `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32    `db:"sheets_per_roll" json:"sheetsPerRoll"`
 }
 
 type ProductAllocation struct {
 	ID             int32     `db:"id" json:"id"`
 	ProductID      int32     `db:"product_id" json:"productId"`
 	ShopID         int32     `db:"shop_id" json:"shopId"`
-	FilmQuantity   int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity   float64   `db:"film_quantity" json:"filmQuantity"`
 	AllocationDate time.Time `db:"allocation_date" json:"allocationDate"`
 	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt      time.Time `db:"updated_at" json:"updatedAt"`
+	UnitOfMeasure  string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity   float64   `db:"unit_quantity" json:"unitQuantity"`
+}
+
+type ProductAllocationBalancesView struct {
+	ProductAllocationID int32   `db:"product_allocation_id" json:"productAllocationId"`
+	ShopID              int32   `db:"shop_id" json:"shopId"`
+	ProductID           int32   `db:"product_id" json:"productId"`
+	AllocatedQuantity   float64 `db:"allocated_quantity" json:"allocatedQuantity"`
+	ConsumedQuantity    float64 `db:"consumed_quantity" json:"consumedQuantity"`
+	InstalledQuantity   float64 `db:"installed_quantity" json:"installedQuantity"`
+	TransferredQuantity float64 `db:"transferred_quantity" json:"transferredQuantity"`
+	AdjustedQuantity    float64 `db:"adjusted_quantity" json:"adjustedQuantity"`
+	RemainingQuantity   float64 `db:"remaining_quantity" json:"remainingQuantity"`
+}
+
+type ProductAllocationTransfer struct {
+	ID                    int32     `db:"id" json:"id"`
+	ProductAllocationID   int32     `db:"product_allocation_id" json:"productAllocationId"`
+	TransferType          string    `db:"transfer_type" json:"transferType"`
+	FilmQuantity          float64   `db:"film_quantity" json:"filmQuantity"`
+	ToShopID              *int32    `db:"to_shop_id" json:"toShopId"`
+	ToProductAllocationID *int32    `db:"to_product_allocation_id" json:"toProductAllocationId"`
+	TransferDate          time.Time `db:"transfer_date" json:"transferDate"`
+	Remarks               *string   `db:"remarks" json:"remarks"`
+	CreatedByUserID       *int32    `db:"created_by_user_id" json:"createdByUserId"`
+	CreatedAt             time.Time `db:"created_at" json:"createdAt"`
 }
 
 type ProductAllocationsView struct {
@@ -138,10 +378,12 @@ type ProductAllocationsView struct {
 	ProductName      string    `db:"product_name" json:"productName"`
 	ShopName         string    `db:"shop_name" json:"shopName"`
 	BranchCode       string    `db:"branch_code" json:"branchCode"`
-	FilmQuantity     int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity     float64   `db:"film_quantity" json:"filmQuantity"`
 	AllocationDate   time.Time `db:"allocation_date" json:"allocationDate"`
 	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updatedAt"`
+	UnitOfMeasure    string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity     float64   `db:"unit_quantity" json:"unitQuantity"`
 }
 
 type ProductBrand struct {
@@ -150,6 +392,7 @@ type ProductBrand struct {
 	Description string    `db:"description" json:"description"`
 	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
 }
 
 type ProductName struct {
@@ -159,6 +402,7 @@ type ProductName struct {
 	Description string    `db:"description" json:"description"`
 	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
 }
 
 type ProductSeries struct {
@@ -168,6 +412,7 @@ type ProductSeries struct {
 	Description string    `db:"description" json:"description"`
 	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
 }
 
 type ProductType struct {
@@ -177,6 +422,113 @@ type ProductType struct {
 	Description string    `db:"description" json:"description"`
 	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+	IsActive    bool      `db:"is_active" json:"isActive"`
+}
+
+type PublicHoliday struct {
+	ID          int32     `db:"id" json:"id"`
+	HolidayDate time.Time `db:"holiday_date" json:"holidayDate"`
+	Name        string    `db:"name" json:"name"`
+	MsiaStateID *int32    `db:"msia_state_id" json:"msiaStateId"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type PublicLookupLog struct {
+	ID          int32     `db:"id" json:"id"`
+	IpAddress   string    `db:"ip_address" json:"ipAddress"`
+	UserAgent   string    `db:"user_agent" json:"userAgent"`
+	SearchTerm  string    `db:"search_term" json:"searchTerm"`
+	ResultCount int32     `db:"result_count" json:"resultCount"`
+	Outcome     string    `db:"outcome" json:"outcome"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+type Recall struct {
+	ID                  int32      `db:"id" json:"id"`
+	RecallNo            string     `db:"recall_no" json:"recallNo"`
+	Scope               string     `db:"scope" json:"scope"`
+	ProductID           *int32     `db:"product_id" json:"productId"`
+	ShipmentID          *int32     `db:"shipment_id" json:"shipmentId"`
+	Reason              string     `db:"reason" json:"reason"`
+	Status              string     `db:"status" json:"status"`
+	FreezeInstalls      bool       `db:"freeze_installs" json:"freezeInstalls"`
+	CustomersNotifiedAt *time.Time `db:"customers_notified_at" json:"customersNotifiedAt"`
+	CreatedByUserID     *int32     `db:"created_by_user_id" json:"createdByUserId"`
+	ClosedByUserID      *int32     `db:"closed_by_user_id" json:"closedByUserId"`
+	ClosedAt            *time.Time `db:"closed_at" json:"closedAt"`
+	CreatedAt           time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt           time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+type RecallProduct struct {
+	RecallID  int32 `db:"recall_id" json:"recallId"`
+	ProductID int32 `db:"product_id" json:"productId"`
+}
+
+type ReminderDelivery struct {
+	ID             int32     `db:"id" json:"id"`
+	ReminderRuleID int32     `db:"reminder_rule_id" json:"reminderRuleId"`
+	WarrantyID     int32     `db:"warranty_id" json:"warrantyId"`
+	DueDate        time.Time `db:"due_date" json:"dueDate"`
+	ExpiryDate     time.Time `db:"expiry_date" json:"expiryDate"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+type ReminderRule struct {
+	ID            int32     `db:"id" json:"id"`
+	Name          string    `db:"name" json:"name"`
+	ProductTypeID int32     `db:"product_type_id" json:"productTypeId"`
+	ReminderType  string    `db:"reminder_type" json:"reminderType"`
+	OffsetDays    int32     `db:"offset_days" json:"offsetDays"`
+	OffsetMonths  int32     `db:"offset_months" json:"offsetMonths"`
+	WindowDays    int32     `db:"window_days" json:"windowDays"`
+	IsActive      bool      `db:"is_active" json:"isActive"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt     time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type ReorderRequest struct {
+	ID                  int32      `db:"id" json:"id"`
+	ShopID              int32      `db:"shop_id" json:"shopId"`
+	NameID              int32      `db:"name_id" json:"nameId"`
+	RequestedQuantity   float64    `db:"requested_quantity" json:"requestedQuantity"`
+	Remarks             *string    `db:"remarks" json:"remarks"`
+	Status              string     `db:"status" json:"status"`
+	ProductAllocationID *int32     `db:"product_allocation_id" json:"productAllocationId"`
+	RequestedByUserID   *int32     `db:"requested_by_user_id" json:"requestedByUserId"`
+	ReviewedByUserID    *int32     `db:"reviewed_by_user_id" json:"reviewedByUserId"`
+	ReviewedAt          *time.Time `db:"reviewed_at" json:"reviewedAt"`
+	ReviewRemarks       *string    `db:"review_remarks" json:"reviewRemarks"`
+	CreatedAt           time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt           time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+type Shipment struct {
+	ID               int32      `db:"id" json:"id"`
+	ShipmentNumber   string     `db:"shipment_number" json:"shipmentNumber"`
+	SupplierName     string     `db:"supplier_name" json:"supplierName"`
+	ShippedDate      *time.Time `db:"shipped_date" json:"shippedDate"`
+	ReceivedDate     *time.Time `db:"received_date" json:"receivedDate"`
+	Status           string     `db:"status" json:"status"`
+	Remarks          *string    `db:"remarks" json:"remarks"`
+	CreatedByUserID  *int32     `db:"created_by_user_id" json:"createdByUserId"`
+	ReceivedByUserID *int32     `db:"received_by_user_id" json:"receivedByUserId"`
+	CreatedAt        time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+type ShipmentLine struct {
+	ID               int32     `db:"id" json:"id"`
+	ShipmentID       int32     `db:"shipment_id" json:"shipmentId"`
+	NameID           int32     `db:"name_id" json:"nameId"`
+	FilmSerialNumber string    `db:"film_serial_number" json:"filmSerialNumber"`
+	FilmQuantity     float64   `db:"film_quantity" json:"filmQuantity"`
+	WarrantyInMonths int32     `db:"warranty_in_months" json:"warrantyInMonths"`
+	Description      string    `db:"description" json:"description"`
+	ProductID        *int32    `db:"product_id" json:"productId"`
+	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updatedAt"`
 }
 
 type Shop struct {
@@ -201,6 +553,76 @@ type Shop struct {
 	UpdatedAt                 time.Time `db:"updated_at" json:"updatedAt"`
 }
 
+type ShopReimbursementStatement struct {
+	ID                int32      `db:"id" json:"id"`
+	ShopID            int32      `db:"shop_id" json:"shopId"`
+	PeriodStart       time.Time  `db:"period_start" json:"periodStart"`
+	PeriodEnd         time.Time  `db:"period_end" json:"periodEnd"`
+	Status            string     `db:"status" json:"status"`
+	TotalLabourCost   float64    `db:"total_labour_cost" json:"totalLabourCost"`
+	TotalMaterialCost float64    `db:"total_material_cost" json:"totalMaterialCost"`
+	ResolutionCount   int32      `db:"resolution_count" json:"resolutionCount"`
+	Remarks           *string    `db:"remarks" json:"remarks"`
+	CreatedByUserID   *int32     `db:"created_by_user_id" json:"createdByUserId"`
+	ReviewedByUserID  *int32     `db:"reviewed_by_user_id" json:"reviewedByUserId"`
+	ReviewedAt        *time.Time `db:"reviewed_at" json:"reviewedAt"`
+	CreatedAt         time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+type ShopStockThreshold struct {
+	ID              int32     `db:"id" json:"id"`
+	ShopID          int32     `db:"shop_id" json:"shopId"`
+	NameID          int32     `db:"name_id" json:"nameId"`
+	MinQuantity     float64   `db:"min_quantity" json:"minQuantity"`
+	ReorderQuantity float64   `db:"reorder_quantity" json:"reorderQuantity"`
+	IsActive        bool      `db:"is_active" json:"isActive"`
+	CreatedAt       time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type StockTake struct {
+	ID                int32      `db:"id" json:"id"`
+	StockTakeNo       string     `db:"stock_take_no" json:"stockTakeNo"`
+	ShopID            int32      `db:"shop_id" json:"shopId"`
+	Period            time.Time  `db:"period" json:"period"`
+	Status            string     `db:"status" json:"status"`
+	TolerancePercent  float64    `db:"tolerance_percent" json:"tolerancePercent"`
+	Remarks           *string    `db:"remarks" json:"remarks"`
+	OpenedByUserID    *int32     `db:"opened_by_user_id" json:"openedByUserId"`
+	SubmittedByUserID *int32     `db:"submitted_by_user_id" json:"submittedByUserId"`
+	SubmittedAt       *time.Time `db:"submitted_at" json:"submittedAt"`
+	PostedByUserID    *int32     `db:"posted_by_user_id" json:"postedByUserId"`
+	PostedAt          *time.Time `db:"posted_at" json:"postedAt"`
+	CancelledByUserID *int32     `db:"cancelled_by_user_id" json:"cancelledByUserId"`
+	CancelledAt       *time.Time `db:"cancelled_at" json:"cancelledAt"`
+	CreatedAt         time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
+type StockTakeLine struct {
+	ID                  int32      `db:"id" json:"id"`
+	StockTakeID         int32      `db:"stock_take_id" json:"stockTakeId"`
+	ProductAllocationID int32      `db:"product_allocation_id" json:"productAllocationId"`
+	SystemQuantity      float64    `db:"system_quantity" json:"systemQuantity"`
+	CountedQuantity     *float64   `db:"counted_quantity" json:"countedQuantity"`
+	UnitOfMeasure       *string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity        *float64   `db:"unit_quantity" json:"unitQuantity"`
+	VarianceQuantity    *float64   `db:"variance_quantity" json:"varianceQuantity"`
+	IsFlagged           bool       `db:"is_flagged" json:"isFlagged"`
+	ReviewStatus        string     `db:"review_status" json:"reviewStatus"`
+	AdjustedQuantity    *float64   `db:"adjusted_quantity" json:"adjustedQuantity"`
+	InventoryMovementID *int32     `db:"inventory_movement_id" json:"inventoryMovementId"`
+	Remarks             *string    `db:"remarks" json:"remarks"`
+	CountedByUserID     *int32     `db:"counted_by_user_id" json:"countedByUserId"`
+	CountedAt           *time.Time `db:"counted_at" json:"countedAt"`
+	ReviewedByUserID    *int32     `db:"reviewed_by_user_id" json:"reviewedByUserId"`
+	ReviewedAt          *time.Time `db:"reviewed_at" json:"reviewedAt"`
+	ReviewRemarks       *string    `db:"review_remarks" json:"reviewRemarks"`
+	CreatedAt           time.Time  `db:"created_at" json:"createdAt"`
+	UpdatedAt           time.Time  `db:"updated_at" json:"updatedAt"`
+}
+
 type User struct {
 	ID           int32     `db:"id" json:"id"`
 	ShopID       *int32    `db:"shop_id" json:"shopId"`
@@ -211,26 +633,53 @@ type User struct {
 	UpdatedAt    time.Time `db:"updated_at" json:"updatedAt"`
 }
 
+type Vehicle struct {
+	ID                  int32     `db:"id" json:"id"`
+	ChassisNo           string    `db:"chassis_no" json:"chassisNo"`
+	ChassisNoNormalized string    `db:"chassis_no_normalized" json:"chassisNoNormalized"`
+	PlateNo             string    `db:"plate_no" json:"plateNo"`
+	CarBrand            string    `db:"car_brand" json:"carBrand"`
+	CarModel            string    `db:"car_model" json:"carModel"`
+	CarColour           string    `db:"car_colour" json:"carColour"`
+	CreatedAt           time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt           time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type VehiclePlate struct {
+	ID                int32     `db:"id" json:"id"`
+	VehicleID         int32     `db:"vehicle_id" json:"vehicleId"`
+	PlateNo           string    `db:"plate_no" json:"plateNo"`
+	PlateNoNormalized string    `db:"plate_no_normalized" json:"plateNoNormalized"`
+	FirstSeenOn       time.Time `db:"first_seen_on" json:"firstSeenOn"`
+	LastSeenOn        time.Time `db:"last_seen_on" json:"lastSeenOn"`
+	CreatedAt         time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updatedAt"`
+}
+
 type Warranty struct {
-	ID                   int32                 `db:"id" json:"id"`
-	ShopID               int32                 `db:"shop_id" json:"shopId"`
-	ClientName           string                `db:"client_name" json:"clientName"`
-	ClientContact        string                `db:"client_contact" json:"clientContact"`
-	ClientEmail          string                `db:"client_email" json:"clientEmail"`
-	CarBrand             string                `db:"car_brand" json:"carBrand"`
-	CarModel             string                `db:"car_model" json:"carModel"`
-	CarColour            string                `db:"car_colour" json:"carColour"`
-	CarPlateNo           string                `db:"car_plate_no" json:"carPlateNo"`
-	CarChassisNo         string                `db:"car_chassis_no" json:"carChassisNo"`
-	InstallationDate     time.Time             `db:"installation_date" json:"installationDate"`
-	ReferenceNo          *string               `db:"reference_no" json:"referenceNo"`
-	WarrantyNo           string                `db:"warranty_no" json:"warrantyNo"`
-	InvoiceAttachmentUrl string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
-	IsActive             bool                  `db:"is_active" json:"isActive"`
-	ApprovalStatus       models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
-	Remarks              *string               `db:"remarks" json:"remarks"`
-	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
-	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
+	ID                     int32                 `db:"id" json:"id"`
+	ShopID                 int32                 `db:"shop_id" json:"shopId"`
+	ClientName             string                `db:"client_name" json:"clientName"`
+	ClientContact          string                `db:"client_contact" json:"clientContact"`
+	ClientEmail            string                `db:"client_email" json:"clientEmail"`
+	CarBrand               string                `db:"car_brand" json:"carBrand"`
+	CarModel               string                `db:"car_model" json:"carModel"`
+	CarColour              string                `db:"car_colour" json:"carColour"`
+	CarPlateNo             string                `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo           string                `db:"car_chassis_no" json:"carChassisNo"`
+	InstallationDate       time.Time             `db:"installation_date" json:"installationDate"`
+	ReferenceNo            *string               `db:"reference_no" json:"referenceNo"`
+	WarrantyNo             string                `db:"warranty_no" json:"warrantyNo"`
+	InvoiceAttachmentUrl   string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
+	IsActive               bool                  `db:"is_active" json:"isActive"`
+	ApprovalStatus         models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	Remarks                *string               `db:"remarks" json:"remarks"`
+	CreatedAt              time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt              time.Time             `db:"updated_at" json:"updatedAt"`
+	CarPlateNoNormalized   string                `db:"car_plate_no_normalized" json:"carPlateNoNormalized"`
+	CarChassisNoNormalized string                `db:"car_chassis_no_normalized" json:"carChassisNoNormalized"`
+	VehicleID              *int32                `db:"vehicle_id" json:"vehicleId"`
+	CustomerID             *int32                `db:"customer_id" json:"customerId"`
 }
 
 type WarrantyPart struct {
@@ -243,4 +692,7 @@ type WarrantyPart struct {
 	Remarks              *string               `db:"remarks" json:"remarks"`
 	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
 	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
+	FilmQuantity         float64               `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure        string                `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity         float64               `db:"unit_quantity" json:"unitQuantity"`
 }
@@ -10,6 +10,222 @@ import (
 	"time"
 )
 
+const countActiveProductNamesBySeriesID = `-- name: CountActiveProductNamesBySeriesID :one
+SELECT COUNT(*)::int AS total
+FROM product_names
+WHERE series_id = $1
+    AND is_active
+`
+
+func (q *Queries) CountActiveProductNamesBySeriesID(ctx context.Context, seriesID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, countActiveProductNamesBySeriesID, seriesID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countActiveProductSeriesByTypeID = `-- name: CountActiveProductSeriesByTypeID :one
+SELECT COUNT(*)::int AS total
+FROM product_series
+WHERE type_id = $1
+    AND is_active
+`
+
+func (q *Queries) CountActiveProductSeriesByTypeID(ctx context.Context, typeID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, countActiveProductSeriesByTypeID, typeID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countActiveProductTypesByBrandID = `-- name: CountActiveProductTypesByBrandID :one
+SELECT COUNT(*)::int AS total
+FROM product_types
+WHERE brand_id = $1
+    AND is_active
+`
+
+func (q *Queries) CountActiveProductTypesByBrandID(ctx context.Context, brandID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, countActiveProductTypesByBrandID, brandID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countProductBrandsByName = `-- name: CountProductBrandsByName :one
+SELECT COUNT(*)::int AS total
+FROM product_brands
+WHERE LOWER(name) = LOWER($1)
+    AND id <> $2
+`
+
+type CountProductBrandsByNameParams struct {
+	Name      string `db:"name" json:"name"`
+	ExcludeID int32  `db:"exclude_id" json:"excludeId"`
+}
+
+func (q *Queries) CountProductBrandsByName(ctx context.Context, arg *CountProductBrandsByNameParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countProductBrandsByName, arg.Name, arg.ExcludeID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countProductNamesByName = `-- name: CountProductNamesByName :one
+SELECT COUNT(*)::int AS total
+FROM product_names
+WHERE series_id = $1
+    AND LOWER(name) = LOWER($2)
+    AND id <> $3
+`
+
+type CountProductNamesByNameParams struct {
+	SeriesID  int32  `db:"series_id" json:"seriesId"`
+	Name      string `db:"name" json:"name"`
+	ExcludeID int32  `db:"exclude_id" json:"excludeId"`
+}
+
+func (q *Queries) CountProductNamesByName(ctx context.Context, arg *CountProductNamesByNameParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countProductNamesByName, arg.SeriesID, arg.Name, arg.ExcludeID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countProductSeriesByName = `-- name: CountProductSeriesByName :one
+SELECT COUNT(*)::int AS total
+FROM product_series
+WHERE type_id = $1
+    AND LOWER(name) = LOWER($2)
+    AND id <> $3
+`
+
+type CountProductSeriesByNameParams struct {
+	TypeID    int32  `db:"type_id" json:"typeId"`
+	Name      string `db:"name" json:"name"`
+	ExcludeID int32  `db:"exclude_id" json:"excludeId"`
+}
+
+func (q *Queries) CountProductSeriesByName(ctx context.Context, arg *CountProductSeriesByNameParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countProductSeriesByName, arg.TypeID, arg.Name, arg.ExcludeID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countProductTypesByName = `-- name: CountProductTypesByName :one
+SELECT COUNT(*)::int AS total
+FROM product_types
+WHERE brand_id = $1
+    AND LOWER(name) = LOWER($2)
+    AND id <> $3
+`
+
+type CountProductTypesByNameParams struct {
+	BrandID   int32  `db:"brand_id" json:"brandId"`
+	Name      string `db:"name" json:"name"`
+	ExcludeID int32  `db:"exclude_id" json:"excludeId"`
+}
+
+func (q *Queries) CountProductTypesByName(ctx context.Context, arg *CountProductTypesByNameParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countProductTypesByName, arg.BrandID, arg.Name, arg.ExcludeID)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const countProductsByBrandID = `-- name: CountProductsByBrandID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE brand_id = $1
+`
+
+type CountProductsByBrandIDRow struct {
+	Total  int32 `db:"total" json:"total"`
+	Active int32 `db:"active" json:"active"`
+}
+
+func (q *Queries) CountProductsByBrandID(ctx context.Context, brandID int32) (*CountProductsByBrandIDRow, error) {
+	row := q.db.QueryRow(ctx, countProductsByBrandID, brandID)
+	var i CountProductsByBrandIDRow
+	err := row.Scan(
+		&i.Total,
+		&i.Active,
+	)
+	return &i, err
+}
+
+const countProductsByNameID = `-- name: CountProductsByNameID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE name_id = $1
+`
+
+type CountProductsByNameIDRow struct {
+	Total  int32 `db:"total" json:"total"`
+	Active int32 `db:"active" json:"active"`
+}
+
+func (q *Queries) CountProductsByNameID(ctx context.Context, nameID int32) (*CountProductsByNameIDRow, error) {
+	row := q.db.QueryRow(ctx, countProductsByNameID, nameID)
+	var i CountProductsByNameIDRow
+	err := row.Scan(
+		&i.Total,
+		&i.Active,
+	)
+	return &i, err
+}
+
+const countProductsBySeriesID = `-- name: CountProductsBySeriesID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE series_id = $1
+`
+
+type CountProductsBySeriesIDRow struct {
+	Total  int32 `db:"total" json:"total"`
+	Active int32 `db:"active" json:"active"`
+}
+
+func (q *Queries) CountProductsBySeriesID(ctx context.Context, seriesID int32) (*CountProductsBySeriesIDRow, error) {
+	row := q.db.QueryRow(ctx, countProductsBySeriesID, seriesID)
+	var i CountProductsBySeriesIDRow
+	err := row.Scan(
+		&i.Total,
+		&i.Active,
+	)
+	return &i, err
+}
+
+const countProductsByTypeID = `-- name: CountProductsByTypeID :one
+SELECT
+    COUNT(*)::int AS total,
+    (COUNT(*) FILTER (WHERE is_active))::int AS active
+FROM products
+WHERE type_id = $1
+`
+
+type CountProductsByTypeIDRow struct {
+	Total  int32 `db:"total" json:"total"`
+	Active int32 `db:"active" json:"active"`
+}
+
+func (q *Queries) CountProductsByTypeID(ctx context.Context, typeID int32) (*CountProductsByTypeIDRow, error) {
+	row := q.db.QueryRow(ctx, countProductsByTypeID, typeID)
+	var i CountProductsByTypeIDRow
+	err := row.Scan(
+		&i.Total,
+		&i.Active,
+	)
+	return &i, err
+}
+
 const createProduct = `-- name: CreateProduct :one
 INSERT INTO products (
     brand_id,
@@ -20,23 +236,29 @@ INSERT INTO products (
     film_serial_number,
     film_quantity,
     shipment_number,
-    description
+    description,
+    unit_of_measure,
+    roll_length_metres,
+    sheets_per_roll
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
 )
-RETURNING id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at
+RETURNING id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at, shipment_id, unit_of_measure, roll_length_metres, sheets_per_roll
 `
 
 type CreateProductParams struct {
-	BrandID          int32  `db:"brand_id" json:"brandId"`
-	TypeID           int32  `db:"type_id" json:"typeId"`
-	SeriesID         int32  `db:"series_id" json:"seriesId"`
-	NameID           int32  `db:"name_id" json:"nameId"`
-	WarrantyInMonths int32  `db:"warranty_in_months" json:"warrantyInMonths"`
-	FilmSerialNumber string `db:"film_serial_number" json:"filmSerialNumber"`
-	FilmQuantity     int32  `db:"film_quantity" json:"filmQuantity"`
-	ShipmentNumber   string `db:"shipment_number" json:"shipmentNumber"`
-	Description      string `db:"description" json:"description"`
+	BrandID          int32    `db:"brand_id" json:"brandId"`
+	TypeID           int32    `db:"type_id" json:"typeId"`
+	SeriesID         int32    `db:"series_id" json:"seriesId"`
+	NameID           int32    `db:"name_id" json:"nameId"`
+	WarrantyInMonths int32    `db:"warranty_in_months" json:"warrantyInMonths"`
+	FilmSerialNumber string   `db:"film_serial_number" json:"filmSerialNumber"`
+	FilmQuantity     float64  `db:"film_quantity" json:"filmQuantity"`
+	ShipmentNumber   string   `db:"shipment_number" json:"shipmentNumber"`
+	Description      string   `db:"description" json:"description"`
+	UnitOfMeasure    string   `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg *CreateProductParams) (*Product, error) {
@@ -50,6 +272,9 @@ func (q *Queries) CreateProduct(ctx context.Context, arg *CreateProductParams) (
 		arg.FilmQuantity,
 		arg.ShipmentNumber,
 		arg.Description,
+		arg.UnitOfMeasure,
+		arg.RollLengthMetres,
+		arg.SheetsPerRoll,
 	)
 	var i Product
 	err := row.Scan(
@@ -66,13 +291,168 @@ func (q *Queries) CreateProduct(ctx context.Context, arg *CreateProductParams) (
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShipmentID,
+		&i.UnitOfMeasure,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
+	)
+	return &i, err
+}
+
+const createProductBrand = `-- name: CreateProductBrand :one
+INSERT INTO product_brands (
+    name,
+    description
+) VALUES (
+    $1, $2
+)
+RETURNING id, name, description, created_at, updated_at, is_active
+`
+
+type CreateProductBrandParams struct {
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) CreateProductBrand(ctx context.Context, arg *CreateProductBrandParams) (*ProductBrand, error) {
+	row := q.db.QueryRow(ctx, createProductBrand, arg.Name, arg.Description)
+	var i ProductBrand
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const createProductName = `-- name: CreateProductName :one
+INSERT INTO product_names (
+    series_id,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, series_id, name, description, created_at, updated_at, is_active
+`
+
+type CreateProductNameParams struct {
+	SeriesID    int32  `db:"series_id" json:"seriesId"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) CreateProductName(ctx context.Context, arg *CreateProductNameParams) (*ProductName, error) {
+	row := q.db.QueryRow(ctx, createProductName, arg.SeriesID, arg.Name, arg.Description)
+	var i ProductName
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const createProductSeries = `-- name: CreateProductSeries :one
+INSERT INTO product_series (
+    type_id,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, type_id, name, description, created_at, updated_at, is_active
+`
+
+type CreateProductSeriesParams struct {
+	TypeID      int32  `db:"type_id" json:"typeId"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) CreateProductSeries(ctx context.Context, arg *CreateProductSeriesParams) (*ProductSeries, error) {
+	row := q.db.QueryRow(ctx, createProductSeries, arg.TypeID, arg.Name, arg.Description)
+	var i ProductSeries
+	err := row.Scan(
+		&i.ID,
+		&i.TypeID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const createProductType = `-- name: CreateProductType :one
+INSERT INTO product_types (
+    brand_id,
+    name,
+    description
+) VALUES (
+    $1, $2, $3
+)
+RETURNING id, brand_id, name, description, created_at, updated_at, is_active
+`
+
+type CreateProductTypeParams struct {
+	BrandID     int32  `db:"brand_id" json:"brandId"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) CreateProductType(ctx context.Context, arg *CreateProductTypeParams) (*ProductType, error) {
+	row := q.db.QueryRow(ctx, createProductType, arg.BrandID, arg.Name, arg.Description)
+	var i ProductType
+	err := row.Scan(
+		&i.ID,
+		&i.BrandID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const getProductBrandByID = `-- name: GetProductBrandByID :one
+SELECT
+    id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_brands
+WHERE id = $1
+`
+
+func (q *Queries) GetProductBrandByID(ctx context.Context, id int32) (*ProductBrand, error) {
+	row := q.db.QueryRow(ctx, getProductBrandByID, id)
+	var i ProductBrand
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
 	)
 	return &i, err
 }
 
 const getProductByID = `-- name: GetProductByID :one
 SELECT
-    id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at
+    id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at, shipment_id, unit_of_measure, roll_length_metres, sheets_per_roll
 FROM products
 WHERE id = $1
 `
@@ -94,13 +474,136 @@ func (q *Queries) GetProductByID(ctx context.Context, id int32) (*Product, error
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShipmentID,
+		&i.UnitOfMeasure,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
+	)
+	return &i, err
+}
+
+const getProductHierarchyByNameID = `-- name: GetProductHierarchyByNameID :one
+SELECT
+    pn.id AS name_id,
+    ps.id AS series_id,
+    pt.id AS type_id,
+    pb.id AS brand_id,
+    pn.is_active AS name_is_active
+FROM product_names pn
+JOIN product_series ps ON ps.id = pn.series_id
+JOIN product_types pt ON pt.id = ps.type_id
+JOIN product_brands pb ON pb.id = pt.brand_id
+WHERE pn.id = $1
+`
+
+type GetProductHierarchyByNameIDRow struct {
+	NameID       int32 `db:"name_id" json:"nameId"`
+	SeriesID     int32 `db:"series_id" json:"seriesId"`
+	TypeID       int32 `db:"type_id" json:"typeId"`
+	BrandID      int32 `db:"brand_id" json:"brandId"`
+	NameIsActive bool  `db:"name_is_active" json:"nameIsActive"`
+}
+
+func (q *Queries) GetProductHierarchyByNameID(ctx context.Context, nameID int32) (*GetProductHierarchyByNameIDRow, error) {
+	row := q.db.QueryRow(ctx, getProductHierarchyByNameID, nameID)
+	var i GetProductHierarchyByNameIDRow
+	err := row.Scan(
+		&i.NameID,
+		&i.SeriesID,
+		&i.TypeID,
+		&i.BrandID,
+		&i.NameIsActive,
+	)
+	return &i, err
+}
+
+const getProductNameByID = `-- name: GetProductNameByID :one
+SELECT
+    id,
+    series_id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_names
+WHERE id = $1
+`
+
+func (q *Queries) GetProductNameByID(ctx context.Context, id int32) (*ProductName, error) {
+	row := q.db.QueryRow(ctx, getProductNameByID, id)
+	var i ProductName
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const getProductSeriesByID = `-- name: GetProductSeriesByID :one
+SELECT
+    id,
+    type_id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_series
+WHERE id = $1
+`
+
+func (q *Queries) GetProductSeriesByID(ctx context.Context, id int32) (*ProductSeries, error) {
+	row := q.db.QueryRow(ctx, getProductSeriesByID, id)
+	var i ProductSeries
+	err := row.Scan(
+		&i.ID,
+		&i.TypeID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const getProductTypeByID = `-- name: GetProductTypeByID :one
+SELECT
+    id,
+    brand_id,
+    name,
+    description,
+    created_at,
+    updated_at,
+    is_active
+FROM product_types
+WHERE id = $1
+`
+
+func (q *Queries) GetProductTypeByID(ctx context.Context, id int32) (*ProductType, error) {
+	row := q.db.QueryRow(ctx, getProductTypeByID, id)
+	var i ProductType
+	err := row.Scan(
+		&i.ID,
+		&i.BrandID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
 	)
 	return &i, err
 }
 
 const getProducts = `-- name: GetProducts :many
 SELECT
-    id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at,
+    id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at, shipment_id, unit_of_measure, roll_length_metres, sheets_per_roll,
     (SELECT name FROM product_brands WHERE id = p.brand_id) AS brand_name,
     (SELECT name FROM product_types WHERE id = p.type_id) AS type_name,
     (SELECT name FROM product_series WHERE id = p.series_id) AS series_name,
@@ -117,12 +620,16 @@ type GetProductsRow struct {
 	NameID           int32     `db:"name_id" json:"nameId"`
 	WarrantyInMonths int32     `db:"warranty_in_months" json:"warrantyInMonths"`
 	FilmSerialNumber string    `db:"film_serial_number" json:"filmSerialNumber"`
-	FilmQuantity     int32     `db:"film_quantity" json:"filmQuantity"`
+	FilmQuantity     float64   `db:"film_quantity" json:"filmQuantity"`
 	ShipmentNumber   string    `db:"shipment_number" json:"shipmentNumber"`
 	Description      string    `db:"description" json:"description"`
 	IsActive         bool      `db:"is_active" json:"isActive"`
 	CreatedAt        time.Time `db:"created_at" json:"createdAt"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updatedAt"`
+	ShipmentID       *int32    `db:"shipment_id" json:"shipmentId"`
+	UnitOfMeasure    string    `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres *float64  `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32    `db:"sheets_per_roll" json:"sheetsPerRoll"`
 	BrandName        string    `db:"brand_name" json:"brandName"`
 	TypeName         string    `db:"type_name" json:"typeName"`
 	SeriesName       string    `db:"series_name" json:"seriesName"`
@@ -152,6 +659,10 @@ func (q *Queries) GetProducts(ctx context.Context) ([]*GetProductsRow, error) {
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ShipmentID,
+			&i.UnitOfMeasure,
+			&i.RollLengthMetres,
+			&i.SheetsPerRoll,
 			&i.BrandName,
 			&i.TypeName,
 			&i.SeriesName,
@@ -167,19 +678,85 @@ func (q *Queries) GetProducts(ctx context.Context) ([]*GetProductsRow, error) {
 	return items, nil
 }
 
+const listInconsistentProductHierarchies = `-- name: ListInconsistentProductHierarchies :many
+SELECT
+    p.id,
+    p.film_serial_number,
+    p.name_id,
+    p.brand_id,
+    p.type_id,
+    p.series_id,
+    pb.id AS expected_brand_id,
+    pt.id AS expected_type_id,
+    ps.id AS expected_series_id
+FROM products p
+JOIN product_names pn ON pn.id = p.name_id
+JOIN product_series ps ON ps.id = pn.series_id
+JOIN product_types pt ON pt.id = ps.type_id
+JOIN product_brands pb ON pb.id = pt.brand_id
+WHERE p.brand_id <> pb.id
+    OR p.type_id <> pt.id
+    OR p.series_id <> ps.id
+ORDER BY p.id
+`
+
+type ListInconsistentProductHierarchiesRow struct {
+	ID               int32  `db:"id" json:"id"`
+	FilmSerialNumber string `db:"film_serial_number" json:"filmSerialNumber"`
+	NameID           int32  `db:"name_id" json:"nameId"`
+	BrandID          int32  `db:"brand_id" json:"brandId"`
+	TypeID           int32  `db:"type_id" json:"typeId"`
+	SeriesID         int32  `db:"series_id" json:"seriesId"`
+	ExpectedBrandID  int32  `db:"expected_brand_id" json:"expectedBrandId"`
+	ExpectedTypeID   int32  `db:"expected_type_id" json:"expectedTypeId"`
+	ExpectedSeriesID int32  `db:"expected_series_id" json:"expectedSeriesId"`
+}
+
+func (q *Queries) ListInconsistentProductHierarchies(ctx context.Context) ([]*ListInconsistentProductHierarchiesRow, error) {
+	rows, err := q.db.Query(ctx, listInconsistentProductHierarchies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListInconsistentProductHierarchiesRow{}
+	for rows.Next() {
+		var i ListInconsistentProductHierarchiesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FilmSerialNumber,
+			&i.NameID,
+			&i.BrandID,
+			&i.TypeID,
+			&i.SeriesID,
+			&i.ExpectedBrandID,
+			&i.ExpectedTypeID,
+			&i.ExpectedSeriesID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProductBrands = `-- name: ListProductBrands :many
 SELECT
     id,
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_brands
+WHERE is_active OR $1::boolean
 ORDER BY name
 `
 
-func (q *Queries) ListProductBrands(ctx context.Context) ([]*ProductBrand, error) {
-	rows, err := q.db.Query(ctx, listProductBrands)
+func (q *Queries) ListProductBrands(ctx context.Context, includeInactive bool) ([]*ProductBrand, error) {
+	rows, err := q.db.Query(ctx, listProductBrands, includeInactive)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +770,7 @@ func (q *Queries) ListProductBrands(ctx context.Context) ([]*ProductBrand, error
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsActive,
 		); err != nil {
 			return nil, err
 		}
@@ -211,13 +789,15 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_names
+WHERE is_active OR $1::boolean
 ORDER BY name
 `
 
-func (q *Queries) ListProductNames(ctx context.Context) ([]*ProductName, error) {
-	rows, err := q.db.Query(ctx, listProductNames)
+func (q *Queries) ListProductNames(ctx context.Context, includeInactive bool) ([]*ProductName, error) {
+	rows, err := q.db.Query(ctx, listProductNames, includeInactive)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +812,7 @@ func (q *Queries) ListProductNames(ctx context.Context) ([]*ProductName, error)
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsActive,
 		); err != nil {
 			return nil, err
 		}
@@ -250,13 +831,15 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_series
+WHERE is_active OR $1::boolean
 ORDER BY name
 `
 
-func (q *Queries) ListProductSeries(ctx context.Context) ([]*ProductSeries, error) {
-	rows, err := q.db.Query(ctx, listProductSeries)
+func (q *Queries) ListProductSeries(ctx context.Context, includeInactive bool) ([]*ProductSeries, error) {
+	rows, err := q.db.Query(ctx, listProductSeries, includeInactive)
 	if err != nil {
 		return nil, err
 	}
@@ -271,6 +854,7 @@ func (q *Queries) ListProductSeries(ctx context.Context) ([]*ProductSeries, erro
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsActive,
 		); err != nil {
 			return nil, err
 		}
@@ -289,13 +873,15 @@ SELECT
     name,
     description,
     created_at,
-    updated_at
+    updated_at,
+    is_active
 FROM product_types
+WHERE is_active OR $1::boolean
 ORDER BY name
 `
 
-func (q *Queries) ListProductTypes(ctx context.Context) ([]*ProductType, error) {
-	rows, err := q.db.Query(ctx, listProductTypes)
+func (q *Queries) ListProductTypes(ctx context.Context, includeInactive bool) ([]*ProductType, error) {
+	rows, err := q.db.Query(ctx, listProductTypes, includeInactive)
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +896,7 @@ func (q *Queries) ListProductTypes(ctx context.Context) ([]*ProductType, error)
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsActive,
 		); err != nil {
 			return nil, err
 		}
@@ -321,6 +908,121 @@ func (q *Queries) ListProductTypes(ctx context.Context) ([]*ProductType, error)
 	return items, nil
 }
 
+const setProductBrandActive = `-- name: SetProductBrandActive :one
+UPDATE product_brands
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, description, created_at, updated_at, is_active
+`
+
+type SetProductBrandActiveParams struct {
+	ID       int32 `db:"id" json:"id"`
+	IsActive bool  `db:"is_active" json:"isActive"`
+}
+
+func (q *Queries) SetProductBrandActive(ctx context.Context, arg *SetProductBrandActiveParams) (*ProductBrand, error) {
+	row := q.db.QueryRow(ctx, setProductBrandActive, arg.ID, arg.IsActive)
+	var i ProductBrand
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const setProductNameActive = `-- name: SetProductNameActive :one
+UPDATE product_names
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, series_id, name, description, created_at, updated_at, is_active
+`
+
+type SetProductNameActiveParams struct {
+	ID       int32 `db:"id" json:"id"`
+	IsActive bool  `db:"is_active" json:"isActive"`
+}
+
+func (q *Queries) SetProductNameActive(ctx context.Context, arg *SetProductNameActiveParams) (*ProductName, error) {
+	row := q.db.QueryRow(ctx, setProductNameActive, arg.ID, arg.IsActive)
+	var i ProductName
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const setProductSeriesActive = `-- name: SetProductSeriesActive :one
+UPDATE product_series
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, type_id, name, description, created_at, updated_at, is_active
+`
+
+type SetProductSeriesActiveParams struct {
+	ID       int32 `db:"id" json:"id"`
+	IsActive bool  `db:"is_active" json:"isActive"`
+}
+
+func (q *Queries) SetProductSeriesActive(ctx context.Context, arg *SetProductSeriesActiveParams) (*ProductSeries, error) {
+	row := q.db.QueryRow(ctx, setProductSeriesActive, arg.ID, arg.IsActive)
+	var i ProductSeries
+	err := row.Scan(
+		&i.ID,
+		&i.TypeID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const setProductTypeActive = `-- name: SetProductTypeActive :one
+UPDATE product_types
+SET
+    is_active = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, brand_id, name, description, created_at, updated_at, is_active
+`
+
+type SetProductTypeActiveParams struct {
+	ID       int32 `db:"id" json:"id"`
+	IsActive bool  `db:"is_active" json:"isActive"`
+}
+
+func (q *Queries) SetProductTypeActive(ctx context.Context, arg *SetProductTypeActiveParams) (*ProductType, error) {
+	row := q.db.QueryRow(ctx, setProductTypeActive, arg.ID, arg.IsActive)
+	var i ProductType
+	err := row.Scan(
+		&i.ID,
+		&i.BrandID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE products
 SET
@@ -334,23 +1036,29 @@ SET
     shipment_number = $9,
     description = $10,
     is_active = $11,
+    unit_of_measure = $12,
+    roll_length_metres = $13,
+    sheets_per_roll = $14,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at
+RETURNING id, brand_id, type_id, series_id, name_id, warranty_in_months, film_serial_number, film_quantity, shipment_number, description, is_active, created_at, updated_at, shipment_id, unit_of_measure, roll_length_metres, sheets_per_roll
 `
 
 type UpdateProductParams struct {
-	ID               int32  `db:"id" json:"id"`
-	BrandID          int32  `db:"brand_id" json:"brandId"`
-	TypeID           int32  `db:"type_id" json:"typeId"`
-	SeriesID         int32  `db:"series_id" json:"seriesId"`
-	NameID           int32  `db:"name_id" json:"nameId"`
-	WarrantyInMonths int32  `db:"warranty_in_months" json:"warrantyInMonths"`
-	FilmSerialNumber string `db:"film_serial_number" json:"filmSerialNumber"`
-	FilmQuantity     int32  `db:"film_quantity" json:"filmQuantity"`
-	ShipmentNumber   string `db:"shipment_number" json:"shipmentNumber"`
-	Description      string `db:"description" json:"description"`
-	IsActive         bool   `db:"is_active" json:"isActive"`
+	ID               int32    `db:"id" json:"id"`
+	BrandID          int32    `db:"brand_id" json:"brandId"`
+	TypeID           int32    `db:"type_id" json:"typeId"`
+	SeriesID         int32    `db:"series_id" json:"seriesId"`
+	NameID           int32    `db:"name_id" json:"nameId"`
+	WarrantyInMonths int32    `db:"warranty_in_months" json:"warrantyInMonths"`
+	FilmSerialNumber string   `db:"film_serial_number" json:"filmSerialNumber"`
+	FilmQuantity     float64  `db:"film_quantity" json:"filmQuantity"`
+	ShipmentNumber   string   `db:"shipment_number" json:"shipmentNumber"`
+	Description      string   `db:"description" json:"description"`
+	IsActive         bool     `db:"is_active" json:"isActive"`
+	UnitOfMeasure    string   `db:"unit_of_measure" json:"unitOfMeasure"`
+	RollLengthMetres *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg *UpdateProductParams) (*Product, error) {
@@ -366,6 +1074,9 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg *UpdateProductParams) (
 		arg.ShipmentNumber,
 		arg.Description,
 		arg.IsActive,
+		arg.UnitOfMeasure,
+		arg.RollLengthMetres,
+		arg.SheetsPerRoll,
 	)
 	var i Product
 	err := row.Scan(
@@ -382,6 +1093,181 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg *UpdateProductParams) (
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ShipmentID,
+		&i.UnitOfMeasure,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
+	)
+	return &i, err
+}
+
+const updateProductBrand = `-- name: UpdateProductBrand :one
+UPDATE product_brands
+SET
+    name = $2,
+    description = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, description, created_at, updated_at, is_active
+`
+
+type UpdateProductBrandParams struct {
+	ID          int32  `db:"id" json:"id"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) UpdateProductBrand(ctx context.Context, arg *UpdateProductBrandParams) (*ProductBrand, error) {
+	row := q.db.QueryRow(ctx, updateProductBrand, arg.ID, arg.Name, arg.Description)
+	var i ProductBrand
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const updateProductHierarchy = `-- name: UpdateProductHierarchy :exec
+UPDATE products
+SET
+    brand_id = $2,
+    type_id = $3,
+    series_id = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+type UpdateProductHierarchyParams struct {
+	ID       int32 `db:"id" json:"id"`
+	BrandID  int32 `db:"brand_id" json:"brandId"`
+	TypeID   int32 `db:"type_id" json:"typeId"`
+	SeriesID int32 `db:"series_id" json:"seriesId"`
+}
+
+func (q *Queries) UpdateProductHierarchy(ctx context.Context, arg *UpdateProductHierarchyParams) error {
+	_, err := q.db.Exec(ctx, updateProductHierarchy,
+		arg.ID,
+		arg.BrandID,
+		arg.TypeID,
+		arg.SeriesID,
+	)
+	return err
+}
+
+const updateProductName = `-- name: UpdateProductName :one
+UPDATE product_names
+SET
+    series_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, series_id, name, description, created_at, updated_at, is_active
+`
+
+type UpdateProductNameParams struct {
+	ID          int32  `db:"id" json:"id"`
+	SeriesID    int32  `db:"series_id" json:"seriesId"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) UpdateProductName(ctx context.Context, arg *UpdateProductNameParams) (*ProductName, error) {
+	row := q.db.QueryRow(ctx, updateProductName,
+		arg.ID,
+		arg.SeriesID,
+		arg.Name,
+		arg.Description,
+	)
+	var i ProductName
+	err := row.Scan(
+		&i.ID,
+		&i.SeriesID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const updateProductSeries = `-- name: UpdateProductSeries :one
+UPDATE product_series
+SET
+    type_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, type_id, name, description, created_at, updated_at, is_active
+`
+
+type UpdateProductSeriesParams struct {
+	ID          int32  `db:"id" json:"id"`
+	TypeID      int32  `db:"type_id" json:"typeId"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) UpdateProductSeries(ctx context.Context, arg *UpdateProductSeriesParams) (*ProductSeries, error) {
+	row := q.db.QueryRow(ctx, updateProductSeries,
+		arg.ID,
+		arg.TypeID,
+		arg.Name,
+		arg.Description,
+	)
+	var i ProductSeries
+	err := row.Scan(
+		&i.ID,
+		&i.TypeID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return &i, err
+}
+
+const updateProductType = `-- name: UpdateProductType :one
+UPDATE product_types
+SET
+    brand_id = $2,
+    name = $3,
+    description = $4,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, brand_id, name, description, created_at, updated_at, is_active
+`
+
+type UpdateProductTypeParams struct {
+	ID          int32  `db:"id" json:"id"`
+	BrandID     int32  `db:"brand_id" json:"brandId"`
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (q *Queries) UpdateProductType(ctx context.Context, arg *UpdateProductTypeParams) (*ProductType, error) {
+	row := q.db.QueryRow(ctx, updateProductType,
+		arg.ID,
+		arg.BrandID,
+		arg.Name,
+		arg.Description,
+	)
+	var i ProductType
+	err := row.Scan(
+		&i.ID,
+		&i.BrandID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
 	)
 	return &i, err
 }
@@ -9,14 +9,44 @@ import (
 )
 
 type Querier interface {
+	CountActiveProductNamesBySeriesID(ctx context.Context, seriesID int32) (int32, error)
+	CountActiveProductSeriesByTypeID(ctx context.Context, typeID int32) (int32, error)
+	CountActiveProductTypesByBrandID(ctx context.Context, brandID int32) (int32, error)
+	CountProductBrandsByName(ctx context.Context, arg *CountProductBrandsByNameParams) (int32, error)
+	CountProductNamesByName(ctx context.Context, arg *CountProductNamesByNameParams) (int32, error)
+	CountProductSeriesByName(ctx context.Context, arg *CountProductSeriesByNameParams) (int32, error)
+	CountProductTypesByName(ctx context.Context, arg *CountProductTypesByNameParams) (int32, error)
+	CountProductsByBrandID(ctx context.Context, brandID int32) (*CountProductsByBrandIDRow, error)
+	CountProductsByNameID(ctx context.Context, nameID int32) (*CountProductsByNameIDRow, error)
+	CountProductsBySeriesID(ctx context.Context, seriesID int32) (*CountProductsBySeriesIDRow, error)
+	CountProductsByTypeID(ctx context.Context, typeID int32) (*CountProductsByTypeIDRow, error)
 	CreateProduct(ctx context.Context, arg *CreateProductParams) (*Product, error)
+	CreateProductBrand(ctx context.Context, arg *CreateProductBrandParams) (*ProductBrand, error)
+	CreateProductName(ctx context.Context, arg *CreateProductNameParams) (*ProductName, error)
+	CreateProductSeries(ctx context.Context, arg *CreateProductSeriesParams) (*ProductSeries, error)
+	CreateProductType(ctx context.Context, arg *CreateProductTypeParams) (*ProductType, error)
+	GetProductBrandByID(ctx context.Context, id int32) (*ProductBrand, error)
 	GetProductByID(ctx context.Context, id int32) (*Product, error)
+	GetProductHierarchyByNameID(ctx context.Context, nameID int32) (*GetProductHierarchyByNameIDRow, error)
+	GetProductNameByID(ctx context.Context, id int32) (*ProductName, error)
+	GetProductSeriesByID(ctx context.Context, id int32) (*ProductSeries, error)
+	GetProductTypeByID(ctx context.Context, id int32) (*ProductType, error)
 	GetProducts(ctx context.Context) ([]*GetProductsRow, error)
-	ListProductBrands(ctx context.Context) ([]*ProductBrand, error)
-	ListProductNames(ctx context.Context) ([]*ProductName, error)
-	ListProductSeries(ctx context.Context) ([]*ProductSeries, error)
-	ListProductTypes(ctx context.Context) ([]*ProductType, error)
+	ListInconsistentProductHierarchies(ctx context.Context) ([]*ListInconsistentProductHierarchiesRow, error)
+	ListProductBrands(ctx context.Context, includeInactive bool) ([]*ProductBrand, error)
+	ListProductNames(ctx context.Context, includeInactive bool) ([]*ProductName, error)
+	ListProductSeries(ctx context.Context, includeInactive bool) ([]*ProductSeries, error)
+	ListProductTypes(ctx context.Context, includeInactive bool) ([]*ProductType, error)
+	SetProductBrandActive(ctx context.Context, arg *SetProductBrandActiveParams) (*ProductBrand, error)
+	SetProductNameActive(ctx context.Context, arg *SetProductNameActiveParams) (*ProductName, error)
+	SetProductSeriesActive(ctx context.Context, arg *SetProductSeriesActiveParams) (*ProductSeries, error)
+	SetProductTypeActive(ctx context.Context, arg *SetProductTypeActiveParams) (*ProductType, error)
 	UpdateProduct(ctx context.Context, arg *UpdateProductParams) (*Product, error)
+	UpdateProductBrand(ctx context.Context, arg *UpdateProductBrandParams) (*ProductBrand, error)
+	UpdateProductHierarchy(ctx context.Context, arg *UpdateProductHierarchyParams) error
+	UpdateProductName(ctx context.Context, arg *UpdateProductNameParams) (*ProductName, error)
+	UpdateProductSeries(ctx context.Context, arg *UpdateProductSeriesParams) (*ProductSeries, error)
+	UpdateProductType(ctx context.Context, arg *UpdateProductTypeParams) (*ProductType, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -113,7 +113,7 @@ func (q *Queries) GetMaxBranchCodeByStateCode(ctx context.Context, code string)
 
 const getMsiaStateByID = `-- name: GetMsiaStateByID :one
 SELECT
-    id, name, code, created_at, updated_at
+    id, name, code, created_at, updated_at, weekend_days
 FROM msia_states
 WHERE id = $1
 `
@@ -127,6 +127,7 @@ func (q *Queries) GetMsiaStateByID(ctx context.Context, id int32) (*MsiaState, e
 		&i.Code,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeekendDays,
 	)
 	return &i, err
 }
@@ -243,7 +244,8 @@ SELECT
     name,
     code,
     created_at,
-    updated_at
+    updated_at,
+    weekend_days
 FROM msia_states
 `
 
@@ -262,6 +264,7 @@ func (q *Queries) ListMsiaStates(ctx context.Context) ([]*MsiaState, error) {
 			&i.Code,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.WeekendDays,
 		); err != nil {
 			return nil, err
 		}
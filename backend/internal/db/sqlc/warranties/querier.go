@@ -13,17 +13,30 @@ type Querier interface {
 	CreateWarrantyPart(ctx context.Context, arg *CreateWarrantyPartParams) (*WarrantyPart, error)
 	DeleteWarrantyPart(ctx context.Context, id int32) error
 	GetCarParts(ctx context.Context) ([]*CarPart, error)
+	// The stock take, open or awaiting review, that is counting the allocation.
+	GetInProgressStockTakeNoByAllocationID(ctx context.Context, productAllocationID int32) (string, error)
+	// The open recall, if any, that freezes installs from the film of an allocation.
+	GetInstallFreezeRecallNo(ctx context.Context, productAllocationID int32) (string, error)
 	GetLatestWarrantyNoByPrefix(ctx context.Context, warrantyNo string) (string, error)
-	GetWarrantiesByExactSearch(ctx context.Context, lower string) ([]*GetWarrantiesByExactSearchRow, error)
+	// An allocation a warranty part installs film from, locked so that concurrent installs cannot
+	// overdraw it, with the conversion factors of its product. The film left must be read with
+	// GetProductAllocationRemainingQuantity once the lock is held, so that it sees the installs that
+	// committed while waiting for it.
+	GetProductAllocationForInstall(ctx context.Context, id int32) (*GetProductAllocationForInstallRow, error)
+	// The film left on an allocation as defined by product_allocation_balances_view.
+	GetProductAllocationRemainingQuantity(ctx context.Context, productAllocationID int32) (float64, error)
+	GetWarrantiesByExactSearch(ctx context.Context, arg *GetWarrantiesByExactSearchParams) ([]*GetWarrantiesByExactSearchRow, error)
 	GetWarrantiesByShopID(ctx context.Context, shopID int32) ([]*GetWarrantiesByShopIDRow, error)
 	GetWarrantyByID(ctx context.Context, id int32) (*Warranty, error)
 	GetWarrantyPartByID(ctx context.Context, id int32) (*WarrantyPart, error)
 	GetWarrantyPartsByWarrantyID(ctx context.Context, warrantyID int32) ([]*GetWarrantyPartsByWarrantyIDRow, error)
 	ListWarranties(ctx context.Context) ([]*ListWarrantiesRow, error)
+	ListWarrantyVehicles(ctx context.Context) ([]*ListWarrantyVehiclesRow, error)
 	UpdateWarranty(ctx context.Context, arg *UpdateWarrantyParams) (*Warranty, error)
 	UpdateWarrantyApproval(ctx context.Context, arg *UpdateWarrantyApprovalParams) (*Warranty, error)
 	UpdateWarrantyPart(ctx context.Context, arg *UpdateWarrantyPartParams) (*WarrantyPart, error)
 	UpdateWarrantyPartApproval(ctx context.Context, arg *UpdateWarrantyPartApprovalParams) (*WarrantyPart, error)
+	UpdateWarrantyVehicle(ctx context.Context, arg *UpdateWarrantyVehicleParams) error
 }
 
 var _ Querier = (*Queries)(nil)
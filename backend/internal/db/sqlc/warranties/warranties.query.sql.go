@@ -31,7 +31,7 @@ INSERT INTO warranties (
 ) VALUES (
     $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
-RETURNING id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at
+RETURNING id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at, car_plate_no_normalized, car_chassis_no_normalized, vehicle_id, customer_id
 `
 
 type CreateWarrantyParams struct {
@@ -89,6 +89,10 @@ func (q *Queries) CreateWarranty(ctx context.Context, arg *CreateWarrantyParams)
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CarPlateNoNormalized,
+		&i.CarChassisNoNormalized,
+		&i.VehicleID,
+		&i.CustomerID,
 	)
 	return &i, err
 }
@@ -99,10 +103,13 @@ INSERT INTO warranty_parts (
     car_part_id,
     product_allocation_id,
     installation_image_url,
-    remarks
+    remarks,
+    film_quantity,
+    unit_of_measure,
+    unit_quantity
 ) VALUES
-    ( $1, $2, $3, $4, $5 )
-RETURNING id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at
+    ( $1, $2, $3, $4, $5, $6, $7, $8 )
+RETURNING id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at, film_quantity, unit_of_measure, unit_quantity
 `
 
 type CreateWarrantyPartParams struct {
@@ -111,6 +118,9 @@ type CreateWarrantyPartParams struct {
 	ProductAllocationID  int32   `db:"product_allocation_id" json:"productAllocationId"`
 	InstallationImageUrl string  `db:"installation_image_url" json:"installationImageUrl"`
 	Remarks              *string `db:"remarks" json:"remarks"`
+	FilmQuantity         float64 `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure        string  `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity         float64 `db:"unit_quantity" json:"unitQuantity"`
 }
 
 func (q *Queries) CreateWarrantyPart(ctx context.Context, arg *CreateWarrantyPartParams) (*WarrantyPart, error) {
@@ -120,6 +130,9 @@ func (q *Queries) CreateWarrantyPart(ctx context.Context, arg *CreateWarrantyPar
 		arg.ProductAllocationID,
 		arg.InstallationImageUrl,
 		arg.Remarks,
+		arg.FilmQuantity,
+		arg.UnitOfMeasure,
+		arg.UnitQuantity,
 	)
 	var i WarrantyPart
 	err := row.Scan(
@@ -132,6 +145,9 @@ func (q *Queries) CreateWarrantyPart(ctx context.Context, arg *CreateWarrantyPar
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FilmQuantity,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
 	)
 	return &i, err
 }
@@ -180,6 +196,45 @@ func (q *Queries) GetCarParts(ctx context.Context) ([]*CarPart, error) {
 	return items, nil
 }
 
+const getInProgressStockTakeNoByAllocationID = `-- name: GetInProgressStockTakeNoByAllocationID :one
+SELECT
+    st.stock_take_no
+FROM stock_take_lines stl
+JOIN stock_takes st ON st.id = stl.stock_take_id
+WHERE stl.product_allocation_id = $1
+    AND st.status IN ('OPEN', 'SUBMITTED')
+LIMIT 1
+`
+
+// The stock take, open or awaiting review, that is counting the allocation.
+func (q *Queries) GetInProgressStockTakeNoByAllocationID(ctx context.Context, productAllocationID int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInProgressStockTakeNoByAllocationID, productAllocationID)
+	var stock_take_no string
+	err := row.Scan(&stock_take_no)
+	return stock_take_no, err
+}
+
+const getInstallFreezeRecallNo = `-- name: GetInstallFreezeRecallNo :one
+SELECT
+    r.recall_no
+FROM recalls r
+JOIN recall_products rp ON rp.recall_id = r.id
+JOIN product_allocations pa ON pa.product_id = rp.product_id
+WHERE pa.id = $1
+    AND r.status = 'OPEN'
+    AND r.freeze_installs
+ORDER BY r.id
+LIMIT 1
+`
+
+// The open recall, if any, that freezes installs from the film of an allocation.
+func (q *Queries) GetInstallFreezeRecallNo(ctx context.Context, productAllocationID int32) (string, error) {
+	row := q.db.QueryRow(ctx, getInstallFreezeRecallNo, productAllocationID)
+	var recall_no string
+	err := row.Scan(&recall_no)
+	return recall_no, err
+}
+
 const getLatestWarrantyNoByPrefix = `-- name: GetLatestWarrantyNoByPrefix :one
 SELECT
     warranty_no
@@ -196,45 +251,112 @@ func (q *Queries) GetLatestWarrantyNoByPrefix(ctx context.Context, warrantyNo st
 	return warranty_no, err
 }
 
+const getProductAllocationForInstall = `-- name: GetProductAllocationForInstall :one
+SELECT
+    pa.id,
+    pa.product_id,
+    pa.shop_id,
+    p.roll_length_metres,
+    p.sheets_per_roll
+FROM product_allocations pa
+JOIN products p ON p.id = pa.product_id
+WHERE pa.id = $1
+FOR UPDATE OF pa
+`
+
+type GetProductAllocationForInstallRow struct {
+	ID               int32    `db:"id" json:"id"`
+	ProductID        int32    `db:"product_id" json:"productId"`
+	ShopID           int32    `db:"shop_id" json:"shopId"`
+	RollLengthMetres *float64 `db:"roll_length_metres" json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `db:"sheets_per_roll" json:"sheetsPerRoll"`
+}
+
+// An allocation a warranty part installs film from, locked so that concurrent installs cannot
+// overdraw it, with the conversion factors of its product. The film left must be read with
+// GetProductAllocationRemainingQuantity once the lock is held, so that it sees the installs that
+// committed while waiting for it.
+func (q *Queries) GetProductAllocationForInstall(ctx context.Context, id int32) (*GetProductAllocationForInstallRow, error) {
+	row := q.db.QueryRow(ctx, getProductAllocationForInstall, id)
+	var i GetProductAllocationForInstallRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ShopID,
+		&i.RollLengthMetres,
+		&i.SheetsPerRoll,
+	)
+	return &i, err
+}
+
+const getProductAllocationRemainingQuantity = `-- name: GetProductAllocationRemainingQuantity :one
+SELECT
+    remaining_quantity
+FROM product_allocation_balances_view
+WHERE product_allocation_id = $1
+`
+
+// The film left on an allocation as defined by product_allocation_balances_view.
+func (q *Queries) GetProductAllocationRemainingQuantity(ctx context.Context, productAllocationID int32) (float64, error) {
+	row := q.db.QueryRow(ctx, getProductAllocationRemainingQuantity, productAllocationID)
+	var remaining_quantity float64
+	err := row.Scan(&remaining_quantity)
+	return remaining_quantity, err
+}
+
 const getWarrantiesByExactSearch = `-- name: GetWarrantiesByExactSearch :many
 SELECT DISTINCT
-    w.id, w.shop_id, w.client_name, w.client_contact, w.client_email, w.car_brand, w.car_model, w.car_colour, w.car_plate_no, w.car_chassis_no, w.installation_date, w.reference_no, w.warranty_no, w.invoice_attachment_url, w.is_active, w.approval_status, w.remarks, w.created_at, w.updated_at,
+    w.id, w.shop_id, w.client_name, w.client_contact, w.client_email, w.car_brand, w.car_model, w.car_colour, w.car_plate_no, w.car_chassis_no, w.installation_date, w.reference_no, w.warranty_no, w.invoice_attachment_url, w.is_active, w.approval_status, w.remarks, w.created_at, w.updated_at, w.car_plate_no_normalized, w.car_chassis_no_normalized, w.vehicle_id, w.customer_id,
     s.shop_name,
     s.branch_code
 FROM warranties w
 JOIN shops s ON w.shop_id = s.id
 LEFT JOIN warranty_parts wp ON w.id = wp.warranty_id
 WHERE LOWER(w.warranty_no) = LOWER($1)
-   OR LOWER(w.car_plate_no) = LOWER($1)
+   OR w.car_plate_no_normalized = $2
+   OR w.vehicle_id IN (
+       SELECT vp.vehicle_id
+       FROM vehicle_plates vp
+       WHERE vp.plate_no_normalized = $2
+   )
 ORDER BY w.created_at DESC
 `
 
+type GetWarrantiesByExactSearchParams struct {
+	SearchTerm string `db:"search_term" json:"searchTerm"`
+	SearchKey  string `db:"search_key" json:"searchKey"`
+}
+
 type GetWarrantiesByExactSearchRow struct {
-	ID                   int32                 `db:"id" json:"id"`
-	ShopID               int32                 `db:"shop_id" json:"shopId"`
-	ClientName           string                `db:"client_name" json:"clientName"`
-	ClientContact        string                `db:"client_contact" json:"clientContact"`
-	ClientEmail          string                `db:"client_email" json:"clientEmail"`
-	CarBrand             string                `db:"car_brand" json:"carBrand"`
-	CarModel             string                `db:"car_model" json:"carModel"`
-	CarColour            string                `db:"car_colour" json:"carColour"`
-	CarPlateNo           string                `db:"car_plate_no" json:"carPlateNo"`
-	CarChassisNo         string                `db:"car_chassis_no" json:"carChassisNo"`
-	InstallationDate     time.Time             `db:"installation_date" json:"installationDate"`
-	ReferenceNo          *string               `db:"reference_no" json:"referenceNo"`
-	WarrantyNo           string                `db:"warranty_no" json:"warrantyNo"`
-	InvoiceAttachmentUrl string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
-	IsActive             bool                  `db:"is_active" json:"isActive"`
-	ApprovalStatus       models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
-	Remarks              *string               `db:"remarks" json:"remarks"`
-	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
-	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
-	ShopName             string                `db:"shop_name" json:"shopName"`
-	BranchCode           string                `db:"branch_code" json:"branchCode"`
+	ID                     int32                 `db:"id" json:"id"`
+	ShopID                 int32                 `db:"shop_id" json:"shopId"`
+	ClientName             string                `db:"client_name" json:"clientName"`
+	ClientContact          string                `db:"client_contact" json:"clientContact"`
+	ClientEmail            string                `db:"client_email" json:"clientEmail"`
+	CarBrand               string                `db:"car_brand" json:"carBrand"`
+	CarModel               string                `db:"car_model" json:"carModel"`
+	CarColour              string                `db:"car_colour" json:"carColour"`
+	CarPlateNo             string                `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo           string                `db:"car_chassis_no" json:"carChassisNo"`
+	InstallationDate       time.Time             `db:"installation_date" json:"installationDate"`
+	ReferenceNo            *string               `db:"reference_no" json:"referenceNo"`
+	WarrantyNo             string                `db:"warranty_no" json:"warrantyNo"`
+	InvoiceAttachmentUrl   string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
+	IsActive               bool                  `db:"is_active" json:"isActive"`
+	ApprovalStatus         models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	Remarks                *string               `db:"remarks" json:"remarks"`
+	CreatedAt              time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt              time.Time             `db:"updated_at" json:"updatedAt"`
+	CarPlateNoNormalized   string                `db:"car_plate_no_normalized" json:"carPlateNoNormalized"`
+	CarChassisNoNormalized string                `db:"car_chassis_no_normalized" json:"carChassisNoNormalized"`
+	VehicleID              *int32                `db:"vehicle_id" json:"vehicleId"`
+	CustomerID             *int32                `db:"customer_id" json:"customerId"`
+	ShopName               string                `db:"shop_name" json:"shopName"`
+	BranchCode             string                `db:"branch_code" json:"branchCode"`
 }
 
-func (q *Queries) GetWarrantiesByExactSearch(ctx context.Context, lower string) ([]*GetWarrantiesByExactSearchRow, error) {
-	rows, err := q.db.Query(ctx, getWarrantiesByExactSearch, lower)
+func (q *Queries) GetWarrantiesByExactSearch(ctx context.Context, arg *GetWarrantiesByExactSearchParams) ([]*GetWarrantiesByExactSearchRow, error) {
+	rows, err := q.db.Query(ctx, getWarrantiesByExactSearch, arg.SearchTerm, arg.SearchKey)
 	if err != nil {
 		return nil, err
 	}
@@ -262,6 +384,10 @@ func (q *Queries) GetWarrantiesByExactSearch(ctx context.Context, lower string)
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CarPlateNoNormalized,
+			&i.CarChassisNoNormalized,
+			&i.VehicleID,
+			&i.CustomerID,
 			&i.ShopName,
 			&i.BranchCode,
 		); err != nil {
@@ -277,7 +403,7 @@ func (q *Queries) GetWarrantiesByExactSearch(ctx context.Context, lower string)
 
 const getWarrantiesByShopID = `-- name: GetWarrantiesByShopID :many
 SELECT
-    w.id, w.shop_id, w.client_name, w.client_contact, w.client_email, w.car_brand, w.car_model, w.car_colour, w.car_plate_no, w.car_chassis_no, w.installation_date, w.reference_no, w.warranty_no, w.invoice_attachment_url, w.is_active, w.approval_status, w.remarks, w.created_at, w.updated_at,
+    w.id, w.shop_id, w.client_name, w.client_contact, w.client_email, w.car_brand, w.car_model, w.car_colour, w.car_plate_no, w.car_chassis_no, w.installation_date, w.reference_no, w.warranty_no, w.invoice_attachment_url, w.is_active, w.approval_status, w.remarks, w.created_at, w.updated_at, w.car_plate_no_normalized, w.car_chassis_no_normalized, w.vehicle_id, w.customer_id,
     s.shop_name,
     s.branch_code
 FROM warranties w
@@ -287,27 +413,31 @@ ORDER BY w.created_at DESC
 `
 
 type GetWarrantiesByShopIDRow struct {
-	ID                   int32                 `db:"id" json:"id"`
-	ShopID               int32                 `db:"shop_id" json:"shopId"`
-	ClientName           string                `db:"client_name" json:"clientName"`
-	ClientContact        string                `db:"client_contact" json:"clientContact"`
-	ClientEmail          string                `db:"client_email" json:"clientEmail"`
-	CarBrand             string                `db:"car_brand" json:"carBrand"`
-	CarModel             string                `db:"car_model" json:"carModel"`
-	CarColour            string                `db:"car_colour" json:"carColour"`
-	CarPlateNo           string                `db:"car_plate_no" json:"carPlateNo"`
-	CarChassisNo         string                `db:"car_chassis_no" json:"carChassisNo"`
-	InstallationDate     time.Time             `db:"installation_date" json:"installationDate"`
-	ReferenceNo          *string               `db:"reference_no" json:"referenceNo"`
-	WarrantyNo           string                `db:"warranty_no" json:"warrantyNo"`
-	InvoiceAttachmentUrl string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
-	IsActive             bool                  `db:"is_active" json:"isActive"`
-	ApprovalStatus       models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
-	Remarks              *string               `db:"remarks" json:"remarks"`
-	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
-	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
-	ShopName             string                `db:"shop_name" json:"shopName"`
-	BranchCode           string                `db:"branch_code" json:"branchCode"`
+	ID                     int32                 `db:"id" json:"id"`
+	ShopID                 int32                 `db:"shop_id" json:"shopId"`
+	ClientName             string                `db:"client_name" json:"clientName"`
+	ClientContact          string                `db:"client_contact" json:"clientContact"`
+	ClientEmail            string                `db:"client_email" json:"clientEmail"`
+	CarBrand               string                `db:"car_brand" json:"carBrand"`
+	CarModel               string                `db:"car_model" json:"carModel"`
+	CarColour              string                `db:"car_colour" json:"carColour"`
+	CarPlateNo             string                `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo           string                `db:"car_chassis_no" json:"carChassisNo"`
+	InstallationDate       time.Time             `db:"installation_date" json:"installationDate"`
+	ReferenceNo            *string               `db:"reference_no" json:"referenceNo"`
+	WarrantyNo             string                `db:"warranty_no" json:"warrantyNo"`
+	InvoiceAttachmentUrl   string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
+	IsActive               bool                  `db:"is_active" json:"isActive"`
+	ApprovalStatus         models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	Remarks                *string               `db:"remarks" json:"remarks"`
+	CreatedAt              time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt              time.Time             `db:"updated_at" json:"updatedAt"`
+	CarPlateNoNormalized   string                `db:"car_plate_no_normalized" json:"carPlateNoNormalized"`
+	CarChassisNoNormalized string                `db:"car_chassis_no_normalized" json:"carChassisNoNormalized"`
+	VehicleID              *int32                `db:"vehicle_id" json:"vehicleId"`
+	CustomerID             *int32                `db:"customer_id" json:"customerId"`
+	ShopName               string                `db:"shop_name" json:"shopName"`
+	BranchCode             string                `db:"branch_code" json:"branchCode"`
 }
 
 func (q *Queries) GetWarrantiesByShopID(ctx context.Context, shopID int32) ([]*GetWarrantiesByShopIDRow, error) {
@@ -339,6 +469,10 @@ func (q *Queries) GetWarrantiesByShopID(ctx context.Context, shopID int32) ([]*G
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CarPlateNoNormalized,
+			&i.CarChassisNoNormalized,
+			&i.VehicleID,
+			&i.CustomerID,
 			&i.ShopName,
 			&i.BranchCode,
 		); err != nil {
@@ -354,7 +488,7 @@ func (q *Queries) GetWarrantiesByShopID(ctx context.Context, shopID int32) ([]*G
 
 const getWarrantyByID = `-- name: GetWarrantyByID :one
 SELECT
-    id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at
+    id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at, car_plate_no_normalized, car_chassis_no_normalized, vehicle_id, customer_id
 FROM warranties
 WHERE id = $1
 `
@@ -382,13 +516,17 @@ func (q *Queries) GetWarrantyByID(ctx context.Context, id int32) (*Warranty, err
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CarPlateNoNormalized,
+		&i.CarChassisNoNormalized,
+		&i.VehicleID,
+		&i.CustomerID,
 	)
 	return &i, err
 }
 
 const getWarrantyPartByID = `-- name: GetWarrantyPartByID :one
 SELECT
-    id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at
+    id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at, film_quantity, unit_of_measure, unit_quantity
 FROM warranty_parts
 WHERE id = $1
 `
@@ -406,13 +544,16 @@ func (q *Queries) GetWarrantyPartByID(ctx context.Context, id int32) (*WarrantyP
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FilmQuantity,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
 	)
 	return &i, err
 }
 
 const getWarrantyPartsByWarrantyID = `-- name: GetWarrantyPartsByWarrantyID :many
 SELECT
-    wp.id, wp.warranty_id, wp.product_allocation_id, wp.car_part_id, wp.installation_image_url, wp.approval_status, wp.remarks, wp.created_at, wp.updated_at,
+    wp.id, wp.warranty_id, wp.product_allocation_id, wp.car_part_id, wp.installation_image_url, wp.approval_status, wp.remarks, wp.created_at, wp.updated_at, wp.film_quantity, wp.unit_of_measure, wp.unit_quantity,
     cp.name AS car_part_name,
     cp.code AS car_part_code,
     p.film_serial_number,
@@ -442,6 +583,9 @@ type GetWarrantyPartsByWarrantyIDRow struct {
 	Remarks              *string               `db:"remarks" json:"remarks"`
 	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
 	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
+	FilmQuantity         float64               `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure        string                `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity         float64               `db:"unit_quantity" json:"unitQuantity"`
 	CarPartName          string                `db:"car_part_name" json:"carPartName"`
 	CarPartCode          string                `db:"car_part_code" json:"carPartCode"`
 	FilmSerialNumber     string                `db:"film_serial_number" json:"filmSerialNumber"`
@@ -471,6 +615,9 @@ func (q *Queries) GetWarrantyPartsByWarrantyID(ctx context.Context, warrantyID i
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.FilmQuantity,
+			&i.UnitOfMeasure,
+			&i.UnitQuantity,
 			&i.CarPartName,
 			&i.CarPartCode,
 			&i.FilmSerialNumber,
@@ -492,7 +639,7 @@ func (q *Queries) GetWarrantyPartsByWarrantyID(ctx context.Context, warrantyID i
 
 const listWarranties = `-- name: ListWarranties :many
 SELECT
-    w.id, w.shop_id, w.client_name, w.client_contact, w.client_email, w.car_brand, w.car_model, w.car_colour, w.car_plate_no, w.car_chassis_no, w.installation_date, w.reference_no, w.warranty_no, w.invoice_attachment_url, w.is_active, w.approval_status, w.remarks, w.created_at, w.updated_at,
+    w.id, w.shop_id, w.client_name, w.client_contact, w.client_email, w.car_brand, w.car_model, w.car_colour, w.car_plate_no, w.car_chassis_no, w.installation_date, w.reference_no, w.warranty_no, w.invoice_attachment_url, w.is_active, w.approval_status, w.remarks, w.created_at, w.updated_at, w.car_plate_no_normalized, w.car_chassis_no_normalized, w.vehicle_id, w.customer_id,
     s.shop_name,
     s.branch_code
 FROM warranties w
@@ -501,27 +648,31 @@ ORDER BY w.created_at DESC
 `
 
 type ListWarrantiesRow struct {
-	ID                   int32                 `db:"id" json:"id"`
-	ShopID               int32                 `db:"shop_id" json:"shopId"`
-	ClientName           string                `db:"client_name" json:"clientName"`
-	ClientContact        string                `db:"client_contact" json:"clientContact"`
-	ClientEmail          string                `db:"client_email" json:"clientEmail"`
-	CarBrand             string                `db:"car_brand" json:"carBrand"`
-	CarModel             string                `db:"car_model" json:"carModel"`
-	CarColour            string                `db:"car_colour" json:"carColour"`
-	CarPlateNo           string                `db:"car_plate_no" json:"carPlateNo"`
-	CarChassisNo         string                `db:"car_chassis_no" json:"carChassisNo"`
-	InstallationDate     time.Time             `db:"installation_date" json:"installationDate"`
-	ReferenceNo          *string               `db:"reference_no" json:"referenceNo"`
-	WarrantyNo           string                `db:"warranty_no" json:"warrantyNo"`
-	InvoiceAttachmentUrl string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
-	IsActive             bool                  `db:"is_active" json:"isActive"`
-	ApprovalStatus       models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
-	Remarks              *string               `db:"remarks" json:"remarks"`
-	CreatedAt            time.Time             `db:"created_at" json:"createdAt"`
-	UpdatedAt            time.Time             `db:"updated_at" json:"updatedAt"`
-	ShopName             string                `db:"shop_name" json:"shopName"`
-	BranchCode           string                `db:"branch_code" json:"branchCode"`
+	ID                     int32                 `db:"id" json:"id"`
+	ShopID                 int32                 `db:"shop_id" json:"shopId"`
+	ClientName             string                `db:"client_name" json:"clientName"`
+	ClientContact          string                `db:"client_contact" json:"clientContact"`
+	ClientEmail            string                `db:"client_email" json:"clientEmail"`
+	CarBrand               string                `db:"car_brand" json:"carBrand"`
+	CarModel               string                `db:"car_model" json:"carModel"`
+	CarColour              string                `db:"car_colour" json:"carColour"`
+	CarPlateNo             string                `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo           string                `db:"car_chassis_no" json:"carChassisNo"`
+	InstallationDate       time.Time             `db:"installation_date" json:"installationDate"`
+	ReferenceNo            *string               `db:"reference_no" json:"referenceNo"`
+	WarrantyNo             string                `db:"warranty_no" json:"warrantyNo"`
+	InvoiceAttachmentUrl   string                `db:"invoice_attachment_url" json:"invoiceAttachmentUrl"`
+	IsActive               bool                  `db:"is_active" json:"isActive"`
+	ApprovalStatus         models.ApprovalStatus `db:"approval_status" json:"approvalStatus"`
+	Remarks                *string               `db:"remarks" json:"remarks"`
+	CreatedAt              time.Time             `db:"created_at" json:"createdAt"`
+	UpdatedAt              time.Time             `db:"updated_at" json:"updatedAt"`
+	CarPlateNoNormalized   string                `db:"car_plate_no_normalized" json:"carPlateNoNormalized"`
+	CarChassisNoNormalized string                `db:"car_chassis_no_normalized" json:"carChassisNoNormalized"`
+	VehicleID              *int32                `db:"vehicle_id" json:"vehicleId"`
+	CustomerID             *int32                `db:"customer_id" json:"customerId"`
+	ShopName               string                `db:"shop_name" json:"shopName"`
+	BranchCode             string                `db:"branch_code" json:"branchCode"`
 }
 
 func (q *Queries) ListWarranties(ctx context.Context) ([]*ListWarrantiesRow, error) {
@@ -553,6 +704,10 @@ func (q *Queries) ListWarranties(ctx context.Context) ([]*ListWarrantiesRow, err
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.CarPlateNoNormalized,
+			&i.CarChassisNoNormalized,
+			&i.VehicleID,
+			&i.CustomerID,
 			&i.ShopName,
 			&i.BranchCode,
 		); err != nil {
@@ -566,6 +721,48 @@ func (q *Queries) ListWarranties(ctx context.Context) ([]*ListWarrantiesRow, err
 	return items, nil
 }
 
+const listWarrantyVehicles = `-- name: ListWarrantyVehicles :many
+SELECT
+    id,
+    warranty_no,
+    car_plate_no,
+    car_chassis_no
+FROM warranties
+ORDER BY id ASC
+`
+
+type ListWarrantyVehiclesRow struct {
+	ID           int32  `db:"id" json:"id"`
+	WarrantyNo   string `db:"warranty_no" json:"warrantyNo"`
+	CarPlateNo   string `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo string `db:"car_chassis_no" json:"carChassisNo"`
+}
+
+func (q *Queries) ListWarrantyVehicles(ctx context.Context) ([]*ListWarrantyVehiclesRow, error) {
+	rows, err := q.db.Query(ctx, listWarrantyVehicles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []*ListWarrantyVehiclesRow{}
+	for rows.Next() {
+		var i ListWarrantyVehiclesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WarrantyNo,
+			&i.CarPlateNo,
+			&i.CarChassisNo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateWarranty = `-- name: UpdateWarranty :one
 UPDATE warranties
 SET
@@ -585,7 +782,7 @@ SET
     remarks = $15,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at
+RETURNING id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at, car_plate_no_normalized, car_chassis_no_normalized, vehicle_id, customer_id
 `
 
 type UpdateWarrantyParams struct {
@@ -645,6 +842,10 @@ func (q *Queries) UpdateWarranty(ctx context.Context, arg *UpdateWarrantyParams)
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CarPlateNoNormalized,
+		&i.CarChassisNoNormalized,
+		&i.VehicleID,
+		&i.CustomerID,
 	)
 	return &i, err
 }
@@ -656,7 +857,7 @@ SET
     remarks = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at
+RETURNING id, shop_id, client_name, client_contact, client_email, car_brand, car_model, car_colour, car_plate_no, car_chassis_no, installation_date, reference_no, warranty_no, invoice_attachment_url, is_active, approval_status, remarks, created_at, updated_at, car_plate_no_normalized, car_chassis_no_normalized, vehicle_id, customer_id
 `
 
 type UpdateWarrantyApprovalParams struct {
@@ -688,6 +889,10 @@ func (q *Queries) UpdateWarrantyApproval(ctx context.Context, arg *UpdateWarrant
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.CarPlateNoNormalized,
+		&i.CarChassisNoNormalized,
+		&i.VehicleID,
+		&i.CustomerID,
 	)
 	return &i, err
 }
@@ -700,9 +905,12 @@ SET
     product_allocation_id = $4,
     installation_image_url = $5,
     remarks = $6,
+    film_quantity = $7,
+    unit_of_measure = $8,
+    unit_quantity = $9,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at
+RETURNING id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at, film_quantity, unit_of_measure, unit_quantity
 `
 
 type UpdateWarrantyPartParams struct {
@@ -712,6 +920,9 @@ type UpdateWarrantyPartParams struct {
 	ProductAllocationID  int32   `db:"product_allocation_id" json:"productAllocationId"`
 	InstallationImageUrl string  `db:"installation_image_url" json:"installationImageUrl"`
 	Remarks              *string `db:"remarks" json:"remarks"`
+	FilmQuantity         float64 `db:"film_quantity" json:"filmQuantity"`
+	UnitOfMeasure        string  `db:"unit_of_measure" json:"unitOfMeasure"`
+	UnitQuantity         float64 `db:"unit_quantity" json:"unitQuantity"`
 }
 
 func (q *Queries) UpdateWarrantyPart(ctx context.Context, arg *UpdateWarrantyPartParams) (*WarrantyPart, error) {
@@ -722,6 +933,9 @@ func (q *Queries) UpdateWarrantyPart(ctx context.Context, arg *UpdateWarrantyPar
 		arg.ProductAllocationID,
 		arg.InstallationImageUrl,
 		arg.Remarks,
+		arg.FilmQuantity,
+		arg.UnitOfMeasure,
+		arg.UnitQuantity,
 	)
 	var i WarrantyPart
 	err := row.Scan(
@@ -734,6 +948,9 @@ func (q *Queries) UpdateWarrantyPart(ctx context.Context, arg *UpdateWarrantyPar
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FilmQuantity,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
 	)
 	return &i, err
 }
@@ -745,7 +962,7 @@ SET
     remarks = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at
+RETURNING id, warranty_id, product_allocation_id, car_part_id, installation_image_url, approval_status, remarks, created_at, updated_at, film_quantity, unit_of_measure, unit_quantity
 `
 
 type UpdateWarrantyPartApprovalParams struct {
@@ -767,6 +984,29 @@ func (q *Queries) UpdateWarrantyPartApproval(ctx context.Context, arg *UpdateWar
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.FilmQuantity,
+		&i.UnitOfMeasure,
+		&i.UnitQuantity,
 	)
 	return &i, err
 }
+
+const updateWarrantyVehicle = `-- name: UpdateWarrantyVehicle :exec
+UPDATE warranties
+SET
+    car_plate_no = $2,
+    car_chassis_no = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+type UpdateWarrantyVehicleParams struct {
+	ID           int32  `db:"id" json:"id"`
+	CarPlateNo   string `db:"car_plate_no" json:"carPlateNo"`
+	CarChassisNo string `db:"car_chassis_no" json:"carChassisNo"`
+}
+
+func (q *Queries) UpdateWarrantyVehicle(ctx context.Context, arg *UpdateWarrantyVehicleParams) error {
+	_, err := q.db.Exec(ctx, updateWarrantyVehicle, arg.ID, arg.CarPlateNo, arg.CarChassisNo)
+	return err
+}
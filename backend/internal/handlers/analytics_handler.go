@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// AnalyticsHandler defines the HTTP contract for product quality report endpoints.
+type AnalyticsHandler interface {
+	// ListClaimRates returns claims per 100 installations grouped by a dimension.
+	ListClaimRates(w http.ResponseWriter, r *http.Request)
+
+	// ListTimeToFailure returns the time from installation to first claim grouped by a dimension.
+	ListTimeToFailure(w http.ResponseWriter, r *http.Request)
+}
+
+type analyticsHandler struct {
+	analyticsService services.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler instance.
+func NewAnalyticsHandler(analyticsService services.AnalyticsService) AnalyticsHandler {
+	return &analyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// parseQualityReportFilter reads the dimension, installedFrom, installedTo, claimedFrom and
+// claimedTo query parameters. The dimension defaults to product_name.
+func parseQualityReportFilter(r *http.Request) (*services.QualityReportFilter, error) {
+	query := r.URL.Query()
+	filter := &services.QualityReportFilter{Dimension: query.Get("dimension")}
+	if filter.Dimension == "" {
+		filter.Dimension = models.QualityDimensionProductName
+	}
+	dates := map[string]**time.Time{
+		"installedFrom": &filter.InstalledFrom,
+		"installedTo":   &filter.InstalledTo,
+		"claimedFrom":   &filter.ClaimedFrom,
+		"claimedTo":     &filter.ClaimedTo,
+	}
+	for param, target := range dates {
+		v := query.Get(param)
+		if v == "" {
+			continue
+		}
+		date, err := utils.ConvertDateStringToStandardFormat(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", param, err)
+		}
+		*target = &date
+	}
+	return filter, nil
+}
+
+// ListClaimRates returns claims per 100 installations grouped by the dimension query parameter.
+func (h *analyticsHandler) ListClaimRates(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseQualityReportFilter(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	rates, err := h.analyticsService.ListClaimRates(r.Context(), filter)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, rates)
+}
+
+// ListTimeToFailure returns the time from installation to first claim grouped by the dimension
+// query parameter.
+func (h *analyticsHandler) ListTimeToFailure(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseQualityReportFilter(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	distribution, err := h.analyticsService.ListTimeToFailure(r.Context(), filter)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, distribution)
+}
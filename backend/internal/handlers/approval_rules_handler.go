@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ApprovalRulesHandler defines the HTTP contract for approval rule endpoints.
+type ApprovalRulesHandler interface {
+	// ListApprovalRules returns the approval rules of warranties and claims.
+	ListApprovalRules(w http.ResponseWriter, r *http.Request)
+
+	// UpdateApprovalRule updates the approval rule of warranties or claims.
+	UpdateApprovalRule(w http.ResponseWriter, r *http.Request)
+}
+
+type approvalRulesHandler struct {
+	approvalRulesService services.ApprovalRulesService
+}
+
+// NewApprovalRulesHandler creates a new ApprovalRulesHandler instance.
+func NewApprovalRulesHandler(approvalRulesService services.ApprovalRulesService) ApprovalRulesHandler {
+	return &approvalRulesHandler{
+		approvalRulesService: approvalRulesService,
+	}
+}
+
+// ListApprovalRules returns the approval rules of warranties and claims.
+func (h *approvalRulesHandler) ListApprovalRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.approvalRulesService.ListApprovalRules(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list approval rules")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, rules)
+}
+
+// UpdateApprovalRule updates the approval rule of warranties or claims.
+func (h *approvalRulesHandler) UpdateApprovalRule(w http.ResponseWriter, r *http.Request) {
+	entityType := strings.ToUpper(chi.URLParam(r, "entity_type"))
+	var req dto.ApprovalRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	rule, err := h.approvalRulesService.UpdateApprovalRule(r.Context(), req.ToUpdateApprovalRuleParams(entityType))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Approval rule not found")
+			return
+		}
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, rule)
+}
@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// CarsHandler defines the HTTP contract for the car make and model catalog endpoints.
+type CarsHandler interface {
+	// ListCarMakes returns all car makes.
+	ListCarMakes(w http.ResponseWriter, r *http.Request)
+
+	// GetCarMakeByID returns a single car make by ID.
+	GetCarMakeByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateCarMake creates a new car make.
+	CreateCarMake(w http.ResponseWriter, r *http.Request)
+
+	// UpdateCarMake updates an existing car make.
+	UpdateCarMake(w http.ResponseWriter, r *http.Request)
+
+	// DeleteCarMake deletes a car make with its models and aliases.
+	DeleteCarMake(w http.ResponseWriter, r *http.Request)
+
+	// ListCarModelsByMakeID returns the models of a car make.
+	ListCarModelsByMakeID(w http.ResponseWriter, r *http.Request)
+
+	// ListCarMakeAliasesByMakeID returns the aliases of a car make.
+	ListCarMakeAliasesByMakeID(w http.ResponseWriter, r *http.Request)
+
+	// CreateCarMakeAlias adds an alias to a car make.
+	CreateCarMakeAlias(w http.ResponseWriter, r *http.Request)
+
+	// DeleteCarMakeAlias deletes a car make alias.
+	DeleteCarMakeAlias(w http.ResponseWriter, r *http.Request)
+
+	// GetCarModelByID returns a single car model by ID.
+	GetCarModelByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateCarModel creates a new car model.
+	CreateCarModel(w http.ResponseWriter, r *http.Request)
+
+	// UpdateCarModel updates an existing car model.
+	UpdateCarModel(w http.ResponseWriter, r *http.Request)
+
+	// DeleteCarModel deletes a car model with its aliases.
+	DeleteCarModel(w http.ResponseWriter, r *http.Request)
+
+	// ListCarModelAliasesByModelID returns the aliases of a car model.
+	ListCarModelAliasesByModelID(w http.ResponseWriter, r *http.Request)
+
+	// CreateCarModelAlias adds an alias to a car model.
+	CreateCarModelAlias(w http.ResponseWriter, r *http.Request)
+
+	// DeleteCarModelAlias deletes a car model alias.
+	DeleteCarModelAlias(w http.ResponseWriter, r *http.Request)
+
+	// ResolveCarMakeAndModel matches a free-text car make and model against the catalog.
+	ResolveCarMakeAndModel(w http.ResponseWriter, r *http.Request)
+}
+
+type carsHandler struct {
+	carsService services.CarsService
+}
+
+// NewCarsHandler creates a new CarsHandler instance.
+func NewCarsHandler(carsService services.CarsService) CarsHandler {
+	return &carsHandler{
+		carsService: carsService,
+	}
+}
+
+// ListCarMakes returns all car makes.
+func (h *carsHandler) ListCarMakes(w http.ResponseWriter, r *http.Request) {
+	makes, err := h.carsService.ListCarMakes(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list car makes")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, makes)
+}
+
+// GetCarMakeByID returns a single car make by ID.
+func (h *carsHandler) GetCarMakeByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car make ID")
+		return
+	}
+	carMake, err := h.carsService.GetCarMakeByID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Car make not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, carMake)
+}
+
+// CreateCarMake creates a new car make.
+func (h *carsHandler) CreateCarMake(w http.ResponseWriter, r *http.Request) {
+	var req *dto.CarMakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	carMake, err := h.carsService.CreateCarMake(r.Context(), req.ToCreateCarMakeParams())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, carMake)
+}
+
+// UpdateCarMake updates an existing car make.
+func (h *carsHandler) UpdateCarMake(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car make ID")
+		return
+	}
+	var req *dto.CarMakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	carMake, err := h.carsService.UpdateCarMake(r.Context(), req.ToUpdateCarMakeParams(id))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, carMake)
+}
+
+// DeleteCarMake deletes a car make with its models and aliases.
+func (h *carsHandler) DeleteCarMake(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car make ID")
+		return
+	}
+	if err := h.carsService.DeleteCarMake(r.Context(), id); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to delete car make")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Car make deleted"})
+}
+
+// ListCarModelsByMakeID returns the models of a car make.
+func (h *carsHandler) ListCarModelsByMakeID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car make ID")
+		return
+	}
+	carModels, err := h.carsService.ListCarModelsByMakeID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list car models")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, carModels)
+}
+
+// ListCarMakeAliasesByMakeID returns the aliases of a car make.
+func (h *carsHandler) ListCarMakeAliasesByMakeID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car make ID")
+		return
+	}
+	aliases, err := h.carsService.ListCarMakeAliasesByMakeID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list car make aliases")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, aliases)
+}
+
+// CreateCarMakeAlias adds an alias to a car make.
+func (h *carsHandler) CreateCarMakeAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car make ID")
+		return
+	}
+	var req *dto.CarAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	alias, err := h.carsService.CreateCarMakeAlias(r.Context(), req.ToCreateCarMakeAliasParams(id))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, alias)
+}
+
+// DeleteCarMakeAlias deletes a car make alias.
+func (h *carsHandler) DeleteCarMakeAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "alias_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid alias ID")
+		return
+	}
+	if err := h.carsService.DeleteCarMakeAlias(r.Context(), id); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to delete car make alias")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Car make alias deleted"})
+}
+
+// GetCarModelByID returns a single car model by ID.
+func (h *carsHandler) GetCarModelByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car model ID")
+		return
+	}
+	carModel, err := h.carsService.GetCarModelByID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Car model not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, carModel)
+}
+
+// CreateCarModel creates a new car model.
+func (h *carsHandler) CreateCarModel(w http.ResponseWriter, r *http.Request) {
+	var req *dto.CarModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	carModel, err := h.carsService.CreateCarModel(r.Context(), req.ToCreateCarModelParams())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, carModel)
+}
+
+// UpdateCarModel updates an existing car model.
+func (h *carsHandler) UpdateCarModel(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car model ID")
+		return
+	}
+	var req *dto.CarModelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	carModel, err := h.carsService.UpdateCarModel(r.Context(), req.ToUpdateCarModelParams(id))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, carModel)
+}
+
+// DeleteCarModel deletes a car model with its aliases.
+func (h *carsHandler) DeleteCarModel(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car model ID")
+		return
+	}
+	if err := h.carsService.DeleteCarModel(r.Context(), id); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to delete car model")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Car model deleted"})
+}
+
+// ListCarModelAliasesByModelID returns the aliases of a car model.
+func (h *carsHandler) ListCarModelAliasesByModelID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car model ID")
+		return
+	}
+	aliases, err := h.carsService.ListCarModelAliasesByModelID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list car model aliases")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, aliases)
+}
+
+// CreateCarModelAlias adds an alias to a car model.
+func (h *carsHandler) CreateCarModelAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid car model ID")
+		return
+	}
+	var req *dto.CarAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	alias, err := h.carsService.CreateCarModelAlias(r.Context(), req.ToCreateCarModelAliasParams(id))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, alias)
+}
+
+// DeleteCarModelAlias deletes a car model alias.
+func (h *carsHandler) DeleteCarModelAlias(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "alias_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid alias ID")
+		return
+	}
+	if err := h.carsService.DeleteCarModelAlias(r.Context(), id); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to delete car model alias")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Car model alias deleted"})
+}
+
+// ResolveCarMakeAndModel matches a free-text car make and model against the catalog.
+func (h *carsHandler) ResolveCarMakeAndModel(w http.ResponseWriter, r *http.Request) {
+	carMake := r.URL.Query().Get("make")
+	carModel := r.URL.Query().Get("model")
+	resolution, err := h.carsService.ResolveCarMakeAndModel(r.Context(), carMake, carModel)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, resolution)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ClaimSLAHandler defines the HTTP contract for claim SLA endpoints.
+type ClaimSLAHandler interface {
+	// ListClaimSlaPolicies returns the SLA policy of every tracked claim status.
+	ListClaimSlaPolicies(w http.ResponseWriter, r *http.Request)
+
+	// UpsertClaimSlaPolicy saves the SLA policy of a claim status.
+	UpsertClaimSlaPolicy(w http.ResponseWriter, r *http.Request)
+
+	// ListPublicHolidays returns the national and state holidays of a year.
+	ListPublicHolidays(w http.ResponseWriter, r *http.Request)
+
+	// CreatePublicHoliday creates a public holiday.
+	CreatePublicHoliday(w http.ResponseWriter, r *http.Request)
+
+	// UpdatePublicHoliday updates a public holiday.
+	UpdatePublicHoliday(w http.ResponseWriter, r *http.Request)
+
+	// DeletePublicHoliday deletes a public holiday.
+	DeletePublicHoliday(w http.ResponseWriter, r *http.Request)
+
+	// EscalateOverdueClaims escalates overdue claims to supervisors immediately.
+	EscalateOverdueClaims(w http.ResponseWriter, r *http.Request)
+}
+
+type claimSLAHandler struct {
+	claimSLAService services.ClaimSLAService
+}
+
+// NewClaimSLAHandler creates a new ClaimSLAHandler instance.
+func NewClaimSLAHandler(claimSLAService services.ClaimSLAService) ClaimSLAHandler {
+	return &claimSLAHandler{
+		claimSLAService: claimSLAService,
+	}
+}
+
+// ListClaimSlaPolicies returns the SLA policy of every tracked claim status.
+func (h *claimSLAHandler) ListClaimSlaPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.claimSLAService.ListClaimSlaPolicies(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list claim SLA policies")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, policies)
+}
+
+// UpsertClaimSlaPolicy saves the SLA policy of a claim status.
+func (h *claimSLAHandler) UpsertClaimSlaPolicy(w http.ResponseWriter, r *http.Request) {
+	status := strings.ToUpper(chi.URLParam(r, "status"))
+	var req dto.ClaimSlaPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	policy, err := h.claimSLAService.UpsertClaimSlaPolicy(r.Context(), req.ToUpsertClaimSlaPolicyParams(status))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, policy)
+}
+
+// ListPublicHolidays returns the national and state holidays of the year in the year query
+// parameter, defaulting to the current year.
+func (h *claimSLAHandler) ListPublicHolidays(w http.ResponseWriter, r *http.Request) {
+	year := time.Now().Year()
+	if v := r.URL.Query().Get("year"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid year")
+			return
+		}
+		year = parsed
+	}
+	holidays, err := h.claimSLAService.ListPublicHolidays(r.Context(), int32(year))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list public holidays")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, holidays)
+}
+
+// CreatePublicHoliday creates a public holiday.
+func (h *claimSLAHandler) CreatePublicHoliday(w http.ResponseWriter, r *http.Request) {
+	var req dto.PublicHolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	arg, err := req.ToCreatePublicHolidayParams()
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	holiday, err := h.claimSLAService.CreatePublicHoliday(r.Context(), arg)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, holiday)
+}
+
+// UpdatePublicHoliday updates a public holiday.
+func (h *claimSLAHandler) UpdatePublicHoliday(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid holiday ID")
+		return
+	}
+	var req dto.PublicHolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	arg, err := req.ToUpdatePublicHolidayParams(id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	holiday, err := h.claimSLAService.UpdatePublicHoliday(r.Context(), arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Public holiday not found")
+			return
+		}
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, holiday)
+}
+
+// DeletePublicHoliday deletes a public holiday.
+func (h *claimSLAHandler) DeletePublicHoliday(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid holiday ID")
+		return
+	}
+	if err := h.claimSLAService.DeletePublicHoliday(r.Context(), id); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to delete public holiday")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Public holiday deleted"})
+}
+
+// EscalateOverdueClaims escalates overdue claims to supervisors immediately instead of
+// waiting for the scheduler.
+func (h *claimSLAHandler) EscalateOverdueClaims(w http.ResponseWriter, r *http.Request) {
+	result, err := h.claimSLAService.EscalateOverdueClaims(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, result)
+}
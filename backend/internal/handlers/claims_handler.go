@@ -2,10 +2,16 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
 	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
 )
@@ -27,6 +33,9 @@ type ClaimsHandler interface {
 	// GenerateNextClaimNo returns the next claim number based on warranty number and claim date.
 	GenerateNextClaimNo(w http.ResponseWriter, r *http.Request)
 
+	// CheckClaimEligibility checks which warranty parts can be claimed without creating a claim.
+	CheckClaimEligibility(w http.ResponseWriter, r *http.Request)
+
 	// CreateClaimWithParts creates a new claim with its associated parts.
 	CreateClaimWithParts(w http.ResponseWriter, r *http.Request)
 
@@ -36,17 +45,35 @@ type ClaimsHandler interface {
 	// UpdateClaimApproval updates the approval status of an existing claim.
 	UpdateClaimApproval(w http.ResponseWriter, r *http.Request)
 
-	// UpdateClaimStatus updates the open/closed status of an existing claim.
+	// UpdateClaimStatus moves an existing claim to another lifecycle status.
 	UpdateClaimStatus(w http.ResponseWriter, r *http.Request)
 
-	// UpdateClaimWarrantyPartStatus updates the open/closed status of an existing claim warranty part.
-	UpdateClaimWarrantyPartStatus(w http.ResponseWriter, r *http.Request)
+	// ListClaimStatusTransitions returns the status history of a claim and the statuses the user may move it to.
+	ListClaimStatusTransitions(w http.ResponseWriter, r *http.Request)
+
+	// ListClaimResolutions returns the replacement film and repair cost recorded for the parts of a claim.
+	ListClaimResolutions(w http.ResponseWriter, r *http.Request)
+
+	// RecordClaimResolution records the replacement film and repair cost of a claim part.
+	RecordClaimResolution(w http.ResponseWriter, r *http.Request)
 
 	// ListClaimWarrantyPartsByClaimID returns a list of claim warranty parts by claim ID.
 	GetClaimWarrantyPartsByClaimID(w http.ResponseWriter, r *http.Request)
 
 	// UpdateClaimWarrantyPartApproval updates the approval status of an existing claim warranty part.
 	UpdateClaimWarrantyPartApproval(w http.ResponseWriter, r *http.Request)
+
+	// ListClaimExclusions returns all claim exclusions.
+	ListClaimExclusions(w http.ResponseWriter, r *http.Request)
+
+	// GetClaimExclusionByID returns a single claim exclusion by ID.
+	GetClaimExclusionByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateClaimExclusion creates a new claim exclusion.
+	CreateClaimExclusion(w http.ResponseWriter, r *http.Request)
+
+	// UpdateClaimExclusion updates an existing claim exclusion.
+	UpdateClaimExclusion(w http.ResponseWriter, r *http.Request)
 }
 
 type claimsHandler struct {
@@ -60,7 +87,8 @@ func NewClaimsHandler(claimsService services.ClaimsService) ClaimsHandler {
 	}
 }
 
-// GetClaimsByShopID returns claims associated with a specific shop ID.
+// GetClaimsByShopID returns claims associated with a specific shop ID, optionally filtered
+// by the status and sla query parameters.
 func (h *claimsHandler) GetClaimsByShopID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	shopIDStr := chi.URLParam(r, "shop_id")
@@ -69,7 +97,15 @@ func (h *claimsHandler) GetClaimsByShopID(w http.ResponseWriter, r *http.Request
 		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
 		return
 	}
-	claimsList, err := h.claimsService.GetClaimsByShopID(ctx, shopID)
+	status, slaState, ok := parseClaimListFilters(w, r)
+	if !ok {
+		return
+	}
+	claimsList, err := h.claimsService.GetClaimsByShopID(ctx, &claims.GetClaimsByShopIDParams{
+		ShopID:   shopID,
+		Status:   status,
+		SlaState: slaState,
+	})
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get claims by shop ID")
 		return
@@ -77,10 +113,18 @@ func (h *claimsHandler) GetClaimsByShopID(w http.ResponseWriter, r *http.Request
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, claimsList)
 }
 
-// ListClaims returns a list of claims from the view.
+// ListClaims returns a list of claims from the view, optionally filtered by the status and
+// sla query parameters.
 func (h *claimsHandler) ListClaims(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	claimsList, err := h.claimsService.GetClaims(ctx)
+	status, slaState, ok := parseClaimListFilters(w, r)
+	if !ok {
+		return
+	}
+	claimsList, err := h.claimsService.GetClaims(ctx, &claims.GetClaimsParams{
+		Status:   status,
+		SlaState: slaState,
+	})
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list claims")
 		return
@@ -88,6 +132,20 @@ func (h *claimsHandler) ListClaims(w http.ResponseWriter, r *http.Request) {
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, claimsList)
 }
 
+// parseClaimListFilters reads the status and sla query parameters of a claim list request.
+// It writes a 400 response and reports false when the SLA state is not recognised.
+func parseClaimListFilters(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	status := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("status")))
+	slaState := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sla")))
+	switch slaState {
+	case "", models.ClaimSLABreached, models.ClaimSLADueToday, models.ClaimSLAOnTrack:
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid sla filter, expected breached, due_today or on_track")
+		return "", "", false
+	}
+	return status, slaState, true
+}
+
 // GetClaimByID returns a single claim by ID.
 func (h *claimsHandler) GetClaimByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -176,6 +234,45 @@ func (h *claimsHandler) GenerateNextClaimNo(w http.ResponseWriter, r *http.Reque
 // 	utils.NewHTTPSuccessResponse(w, http.StatusCreated, claim)
 // }
 
+// CheckClaimEligibility checks which warranty parts can be claimed without creating a claim.
+func (h *claimsHandler) CheckClaimEligibility(w http.ResponseWriter, r *http.Request) {
+	var req dto.ClaimEligibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	claimDate, err := req.ParseClaimDate()
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(req.WarrantyPartIDs) == 0 {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "At least one warranty part is required")
+		return
+	}
+	eligibility, err := h.claimsService.CheckClaimEligibility(r.Context(), req.WarrantyID, claimDate, req.WarrantyPartIDs)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to check claim eligibility")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, eligibility)
+}
+
+// writeClaimIneligibleResponse responds with 422 and the eligibility of every part when err is a
+// *services.ClaimIneligibleError, and reports whether it did.
+func writeClaimIneligibleResponse(w http.ResponseWriter, err error) bool {
+	var ineligible *services.ClaimIneligibleError
+	if !errors.As(err, &ineligible) {
+		return false
+	}
+	response := map[string]any{
+		"error":       ineligible.Error(),
+		"eligibility": ineligible.Eligibility,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusUnprocessableEntity, response)
+	return true
+}
+
 // CreateClaimWithParts creates a new claim with its associated parts.
 func (h *claimsHandler) CreateClaimWithParts(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -189,8 +286,16 @@ func (h *claimsHandler) CreateClaimWithParts(w http.ResponseWriter, r *http.Requ
 		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	claim, err := h.claimsService.CreateClaimWithParts(ctx, params, partsParams)
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	claim, err := h.claimsService.CreateClaimWithParts(ctx, user.UserID, params, partsParams)
 	if err != nil {
+		if writeClaimIneligibleResponse(w, err) {
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to create claim with parts")
 		return
 	}
@@ -218,6 +323,17 @@ func (h *claimsHandler) UpdateClaimWithParts(w http.ResponseWriter, r *http.Requ
 	}
 	claim, err := h.claimsService.UpdateClaimWithParts(ctx, claimParams, partsParams)
 	if err != nil {
+		if writeClaimIneligibleResponse(w, err) {
+			return
+		}
+		if errors.Is(err, services.ErrClaimLocked) {
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+			return
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Claim not found")
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to update claim with parts")
 		return
 	}
@@ -242,60 +358,81 @@ func (h *claimsHandler) UpdateClaimApproval(w http.ResponseWriter, r *http.Reque
 	params.ID = id
 	claim, err := h.claimsService.UpdateClaimApproval(ctx, params)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidApprovalStatus) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to update claim approval")
 		return
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, claim)
 }
 
-// UpdateClaimStatus updates the open/closed status of an existing claim.
+// UpdateClaimStatus moves an existing claim to another lifecycle status.
 func (h *claimsHandler) UpdateClaimStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := chi.URLParam(r, "id")
-	id, err := utils.ConvertParamToInt32(idStr)
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim ID")
 		return
 	}
-	type requestBody struct {
-		IsOpen bool `json:"isOpen"`
-	}
-	var req requestBody
+	var req dto.UpdateClaimStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
-	claim, err := h.claimsService.UpdateClaimStatus(ctx, id, req.IsOpen)
+	claim, err := h.claimsService.TransitionClaimStatus(ctx, id, user.UserID, req.Status, req.Remarks)
 	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to update claim status")
+		switch {
+		case errors.Is(err, services.ErrInvalidClaimTransition):
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrClaimTransitionForbidden):
+			utils.NewHTTPErrorResponse(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrClaimTransitionIncomplete):
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Claim not found")
+		default:
+			utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to update claim status")
+		}
 		return
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, claim)
 }
 
-// UpdateClaimWarrantyPartStatus updates the open/closed status of an existing claim warranty part.
-func (h *claimsHandler) UpdateClaimWarrantyPartStatus(w http.ResponseWriter, r *http.Request) {
+// ListClaimStatusTransitions returns the status history of a claim and the statuses the user may move it to.
+func (h *claimsHandler) ListClaimStatusTransitions(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := chi.URLParam(r, "id")
-	id, err := utils.ConvertParamToInt32(idStr)
-	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim warranty part ID")
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
-	type requestBody struct {
-		IsOpen bool `json:"isOpen"`
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim ID")
+		return
 	}
-	var req requestBody
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+	allowed, err := h.claimsService.ListAllowedClaimStatuses(ctx, id, user.UserID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Claim not found")
 		return
 	}
-	part, err := h.claimsService.UpdateClaimWarrantyPartStatus(ctx, id, req.IsOpen)
+	transitions, err := h.claimsService.ListClaimStatusTransitions(ctx, id)
 	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to update claim warranty part status")
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list claim status transitions")
 		return
 	}
-	utils.NewHTTPSuccessResponse(w, http.StatusOK, part)
+	response := dto.ClaimStatusTransitionsResponse{
+		Transitions:     transitions,
+		AllowedStatuses: allowed,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
 }
 
 // GetClaimWarrantyPartsByClaimID returns a list of claim warranty parts by claim ID.
@@ -338,3 +475,121 @@ func (h *claimsHandler) UpdateClaimWarrantyPartApproval(w http.ResponseWriter, r
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, part)
 }
+
+// ListClaimExclusions returns all claim exclusions.
+func (h *claimsHandler) ListClaimExclusions(w http.ResponseWriter, r *http.Request) {
+	exclusions, err := h.claimsService.ListClaimExclusions(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list claim exclusions")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, exclusions)
+}
+
+// GetClaimExclusionByID returns a single claim exclusion by ID.
+func (h *claimsHandler) GetClaimExclusionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim exclusion ID")
+		return
+	}
+	exclusion, err := h.claimsService.GetClaimExclusionByID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Claim exclusion not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, exclusion)
+}
+
+// CreateClaimExclusion creates a new claim exclusion.
+func (h *claimsHandler) CreateClaimExclusion(w http.ResponseWriter, r *http.Request) {
+	var req dto.ClaimExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	exclusion, err := h.claimsService.CreateClaimExclusion(r.Context(), req.ToCreateClaimExclusionParams())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, exclusion)
+}
+
+// UpdateClaimExclusion updates an existing claim exclusion.
+func (h *claimsHandler) UpdateClaimExclusion(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim exclusion ID")
+		return
+	}
+	var req dto.ClaimExclusionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	exclusion, err := h.claimsService.UpdateClaimExclusion(r.Context(), req.ToUpdateClaimExclusionParams(id))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, exclusion)
+}
+
+// ListClaimResolutions returns the replacement film and repair cost recorded for the parts of a claim.
+func (h *claimsHandler) ListClaimResolutions(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim ID")
+		return
+	}
+	resolutions, err := h.claimsService.ListClaimResolutions(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list claim resolutions")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, resolutions)
+}
+
+// RecordClaimResolution records the replacement film and repair cost of a claim part.
+func (h *claimsHandler) RecordClaimResolution(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim warranty part ID")
+		return
+	}
+	var req dto.ClaimResolutionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	resolution, part, err := req.ToClaimResolutionParams(id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	record, err := h.claimsService.RecordClaimResolution(ctx, user.UserID, resolution, part)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrClaimResolutionForbidden):
+			utils.NewHTTPErrorResponse(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, services.ErrClaimResolutionLocked), errors.Is(err, services.ErrStockTakeInProgress):
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+		case errors.Is(err, services.ErrInsufficientAllocationBalance), errors.Is(err, services.ErrInsufficientStock),
+			errors.Is(err, services.ErrProductRecalled):
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Claim warranty part not found")
+		default:
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, record)
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// CommentsHandler defines the HTTP contract for warranty and claim comment threads.
+type CommentsHandler interface {
+	// ListWarrantyComments returns the comment thread of a warranty.
+	ListWarrantyComments(w http.ResponseWriter, r *http.Request)
+
+	// CreateWarrantyComment posts a comment or a reply on a warranty.
+	CreateWarrantyComment(w http.ResponseWriter, r *http.Request)
+
+	// MarkWarrantyCommentsRead marks the comment thread of a warranty as read.
+	MarkWarrantyCommentsRead(w http.ResponseWriter, r *http.Request)
+
+	// ListClaimComments returns the comment thread of a claim.
+	ListClaimComments(w http.ResponseWriter, r *http.Request)
+
+	// CreateClaimComment posts a comment or a reply on a claim.
+	CreateClaimComment(w http.ResponseWriter, r *http.Request)
+
+	// MarkClaimCommentsRead marks the comment thread of a claim as read.
+	MarkClaimCommentsRead(w http.ResponseWriter, r *http.Request)
+
+	// ListUnreadCommentCounts returns the user's unread comments per thread.
+	ListUnreadCommentCounts(w http.ResponseWriter, r *http.Request)
+}
+
+type commentsHandler struct {
+	commentsService services.CommentsService
+}
+
+// NewCommentsHandler creates a new CommentsHandler instance.
+func NewCommentsHandler(commentsService services.CommentsService) CommentsHandler {
+	return &commentsHandler{
+		commentsService: commentsService,
+	}
+}
+
+// warrantyThread reads the warranty comment thread from the id URL parameter.
+func warrantyThread(r *http.Request) (services.CommentThread, error) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	return services.CommentThread{WarrantyID: &id}, err
+}
+
+// claimThread reads the claim comment thread from the id URL parameter.
+func claimThread(r *http.Request) (services.CommentThread, error) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	return services.CommentThread{ClaimID: &id}, err
+}
+
+// writeCommentError maps a comments service error to an HTTP response.
+func writeCommentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrCommentForbidden):
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, services.ErrCommentParentNotFound):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Comment thread not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// ListWarrantyComments returns the comment thread of a warranty.
+func (h *commentsHandler) ListWarrantyComments(w http.ResponseWriter, r *http.Request) {
+	h.listComments(w, r, warrantyThread)
+}
+
+// CreateWarrantyComment posts a comment or a reply on a warranty.
+func (h *commentsHandler) CreateWarrantyComment(w http.ResponseWriter, r *http.Request) {
+	h.createComment(w, r, warrantyThread)
+}
+
+// MarkWarrantyCommentsRead marks the comment thread of a warranty as read.
+func (h *commentsHandler) MarkWarrantyCommentsRead(w http.ResponseWriter, r *http.Request) {
+	h.markCommentsRead(w, r, warrantyThread)
+}
+
+// ListClaimComments returns the comment thread of a claim.
+func (h *commentsHandler) ListClaimComments(w http.ResponseWriter, r *http.Request) {
+	h.listComments(w, r, claimThread)
+}
+
+// CreateClaimComment posts a comment or a reply on a claim.
+func (h *commentsHandler) CreateClaimComment(w http.ResponseWriter, r *http.Request) {
+	h.createComment(w, r, claimThread)
+}
+
+// MarkClaimCommentsRead marks the comment thread of a claim as read.
+func (h *commentsHandler) MarkClaimCommentsRead(w http.ResponseWriter, r *http.Request) {
+	h.markCommentsRead(w, r, claimThread)
+}
+
+func (h *commentsHandler) listComments(w http.ResponseWriter, r *http.Request, threadFromRequest func(*http.Request) (services.CommentThread, error)) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	thread, err := threadFromRequest(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	comments, err := h.commentsService.ListComments(ctx, user.UserID, thread)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, comments)
+}
+
+func (h *commentsHandler) createComment(w http.ResponseWriter, r *http.Request, threadFromRequest func(*http.Request) (services.CommentThread, error)) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	thread, err := threadFromRequest(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var req dto.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	params, attachments := req.ToCreateCommentParams()
+	comment, err := h.commentsService.CreateComment(ctx, user.UserID, thread, params, attachments)
+	if err != nil {
+		writeCommentError(w, err)
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, comment)
+}
+
+func (h *commentsHandler) markCommentsRead(w http.ResponseWriter, r *http.Request, threadFromRequest func(*http.Request) (services.CommentThread, error)) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	thread, err := threadFromRequest(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	if err := h.commentsService.MarkCommentsRead(ctx, user.UserID, thread); err != nil {
+		writeCommentError(w, err)
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Comments marked as read"})
+}
+
+// ListUnreadCommentCounts returns the user's unread comments per thread and in total.
+func (h *commentsHandler) ListUnreadCommentCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	threads, err := h.commentsService.ListUnreadCommentCounts(ctx, user.UserID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to count unread comments")
+		return
+	}
+	resp := &dto.UnreadCommentCountsResponse{Threads: threads}
+	for _, thread := range threads {
+		resp.Total += thread.UnreadCount
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, resp)
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/customers"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// CustomersHandler defines the HTTP contract for customer endpoints.
+type CustomersHandler interface {
+	// ListCustomers returns all customers that have not been merged. Shop users only see the
+	// customers their shop has installed for.
+	ListCustomers(w http.ResponseWriter, r *http.Request)
+
+	// GetCustomerDetailsByID returns a customer with their vehicles, warranties and claims.
+	// Shop users only see customers their shop has installed for, with their own shop's
+	// vehicles, warranties and claims.
+	GetCustomerDetailsByID(w http.ResponseWriter, r *http.Request)
+
+	// ListDuplicateCustomers returns pairs of customers that are likely the same person.
+	ListDuplicateCustomers(w http.ResponseWriter, r *http.Request)
+
+	// ListCustomerCandidatesByCustomerID returns the likely duplicates of a customer.
+	ListCustomerCandidatesByCustomerID(w http.ResponseWriter, r *http.Request)
+
+	// MergeCustomers merges customers into the customer in the URL.
+	MergeCustomers(w http.ResponseWriter, r *http.Request)
+}
+
+type customersHandler struct {
+	customersService services.CustomersService
+}
+
+// NewCustomersHandler creates a new CustomersHandler instance.
+func NewCustomersHandler(customersService services.CustomersService) CustomersHandler {
+	return &customersHandler{
+		customersService: customersService,
+	}
+}
+
+// ListCustomers returns all customers that have not been merged. Shop users only see the
+// customers their shop has installed for.
+func (h *customersHandler) ListCustomers(w http.ResponseWriter, r *http.Request) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var list []*customers.Customer
+	var err error
+	if user.ShopID != nil {
+		list, err = h.customersService.ListCustomersByShopID(r.Context(), *user.ShopID)
+	} else {
+		list, err = h.customersService.ListCustomers(r.Context())
+	}
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list customers")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, list)
+}
+
+// GetCustomerDetailsByID returns a customer with their vehicles, warranties and claims.
+// Shop users only see customers their shop has installed for, with their own shop's
+// vehicles, warranties and claims.
+func (h *customersHandler) GetCustomerDetailsByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	customer, err := h.customersService.GetCustomerByID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Customer not found")
+		return
+	}
+	allWarranties, err := h.customersService.ListWarrantiesByCustomerID(ctx, customer.ID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	warranties := make([]*customers.ListWarrantiesByCustomerIDRow, 0, len(allWarranties))
+	warrantyIDs := make(map[int32]bool, len(allWarranties))
+	vehicleIDs := make(map[int32]bool, len(allWarranties))
+	for _, warranty := range allWarranties {
+		if userCoversShop(user, warranty.ShopID) {
+			warranties = append(warranties, warranty)
+			warrantyIDs[warranty.ID] = true
+			if warranty.VehicleID != nil {
+				vehicleIDs[*warranty.VehicleID] = true
+			}
+		}
+	}
+	if user.ShopID != nil && len(warranties) == 0 {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Customer not found")
+		return
+	}
+	allVehicles, err := h.customersService.ListVehiclesByCustomerID(ctx, customer.ID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	vehicles := make([]*customers.Vehicle, 0, len(allVehicles))
+	for _, vehicle := range allVehicles {
+		if vehicleIDs[vehicle.ID] {
+			vehicles = append(vehicles, vehicle)
+		}
+	}
+	allClaims, err := h.customersService.ListClaimsByCustomerID(ctx, customer.ID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	claims := make([]*customers.ClaimView, 0, len(allClaims))
+	for _, claim := range allClaims {
+		if warrantyIDs[claim.WarrantyID] {
+			claims = append(claims, claim)
+		}
+	}
+	merges, err := h.customersService.ListCustomerMergesByTargetID(ctx, customer.ID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := dto.CustomerDetailsResponse{
+		Customer:   customer,
+		Vehicles:   vehicles,
+		Warranties: warranties,
+		Claims:     claims,
+		Merges:     merges,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
+}
+
+// ListDuplicateCustomers returns pairs of customers that are likely the same person.
+func (h *customersHandler) ListDuplicateCustomers(w http.ResponseWriter, r *http.Request) {
+	duplicates, err := h.customersService.ListDuplicateCustomers(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list duplicate customers")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, duplicates)
+}
+
+// ListCustomerCandidatesByCustomerID returns the likely duplicates of a customer.
+func (h *customersHandler) ListCustomerCandidatesByCustomerID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+	candidates, err := h.customersService.ListCustomerCandidatesByCustomerID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list customer candidates")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, candidates)
+}
+
+// MergeCustomers merges customers into the customer in the URL.
+func (h *customersHandler) MergeCustomers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+	var req dto.MergeCustomersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	var mergedBy *int32
+	if claims, ok := middlewares.GetUserFromContext(ctx); ok {
+		mergedBy = &claims.UserID
+	}
+
+	customer, err := h.customersService.MergeCustomers(ctx, id, req.SourceCustomerIDs, mergedBy)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, customer)
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/approvals"
+
+// ApprovalRuleRequest represents the request body for updating an approval rule
+type ApprovalRuleRequest struct {
+	MixedStatus         string `json:"mixedStatus" binding:"required"` // PARTIALLY_APPROVED, APPROVED, REJECTED or PENDING
+	PartialWhilePending bool   `json:"partialWhilePending"`
+}
+
+// ToUpdateApprovalRuleParams converts ApprovalRuleRequest to approvals.UpdateApprovalRuleParams
+func (r *ApprovalRuleRequest) ToUpdateApprovalRuleParams(entityType string) *approvals.UpdateApprovalRuleParams {
+	return &approvals.UpdateApprovalRuleParams{
+		EntityType:          entityType,
+		MixedStatus:         r.MixedStatus,
+		PartialWhilePending: r.PartialWhilePending,
+	}
+}
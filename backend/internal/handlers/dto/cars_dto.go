@@ -0,0 +1,73 @@
+package dto
+
+import "github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/cars"
+
+// CarMakeRequest represents the request body for creating or updating a car make
+type CarMakeRequest struct {
+	Name     string `json:"name" binding:"required"`
+	IsActive bool   `json:"isActive"`
+}
+
+// ToCreateCarMakeParams converts CarMakeRequest to cars.CreateCarMakeParams
+func (r *CarMakeRequest) ToCreateCarMakeParams() *cars.CreateCarMakeParams {
+	return &cars.CreateCarMakeParams{
+		Name:     r.Name,
+		IsActive: r.IsActive,
+	}
+}
+
+// ToUpdateCarMakeParams converts CarMakeRequest to cars.UpdateCarMakeParams
+func (r *CarMakeRequest) ToUpdateCarMakeParams(id int32) *cars.UpdateCarMakeParams {
+	return &cars.UpdateCarMakeParams{
+		ID:       id,
+		Name:     r.Name,
+		IsActive: r.IsActive,
+	}
+}
+
+// CarModelRequest represents the request body for creating or updating a car model
+type CarModelRequest struct {
+	CarMakeID int32  `json:"carMakeId" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	IsActive  bool   `json:"isActive"`
+}
+
+// ToCreateCarModelParams converts CarModelRequest to cars.CreateCarModelParams
+func (r *CarModelRequest) ToCreateCarModelParams() *cars.CreateCarModelParams {
+	return &cars.CreateCarModelParams{
+		CarMakeID: r.CarMakeID,
+		Name:      r.Name,
+		IsActive:  r.IsActive,
+	}
+}
+
+// ToUpdateCarModelParams converts CarModelRequest to cars.UpdateCarModelParams
+func (r *CarModelRequest) ToUpdateCarModelParams(id int32) *cars.UpdateCarModelParams {
+	return &cars.UpdateCarModelParams{
+		ID:        id,
+		CarMakeID: r.CarMakeID,
+		Name:      r.Name,
+		IsActive:  r.IsActive,
+	}
+}
+
+// CarAliasRequest represents the request body for adding an alias to a car make or model
+type CarAliasRequest struct {
+	Alias string `json:"alias" binding:"required"`
+}
+
+// ToCreateCarMakeAliasParams converts CarAliasRequest to cars.CreateCarMakeAliasParams
+func (r *CarAliasRequest) ToCreateCarMakeAliasParams(carMakeID int32) *cars.CreateCarMakeAliasParams {
+	return &cars.CreateCarMakeAliasParams{
+		CarMakeID: carMakeID,
+		Alias:     r.Alias,
+	}
+}
+
+// ToCreateCarModelAliasParams converts CarAliasRequest to cars.CreateCarModelAliasParams
+func (r *CarAliasRequest) ToCreateCarModelAliasParams(carModelID int32) *cars.CreateCarModelAliasParams {
+	return &cars.CreateCarModelAliasParams{
+		CarModelID: carModelID,
+		Alias:      r.Alias,
+	}
+}
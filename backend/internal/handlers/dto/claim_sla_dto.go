@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"fmt"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/slas"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ClaimSlaPolicyRequest represents the request body for saving the SLA policy of a claim status
+type ClaimSlaPolicyRequest struct {
+	WorkingDays int32 `json:"workingDays" binding:"required"`
+	IsActive    bool  `json:"isActive"`
+}
+
+// ToUpsertClaimSlaPolicyParams converts ClaimSlaPolicyRequest to slas.UpsertClaimSlaPolicyParams
+func (r *ClaimSlaPolicyRequest) ToUpsertClaimSlaPolicyParams(status string) *slas.UpsertClaimSlaPolicyParams {
+	return &slas.UpsertClaimSlaPolicyParams{
+		Status:      status,
+		WorkingDays: r.WorkingDays,
+		IsActive:    r.IsActive,
+	}
+}
+
+// PublicHolidayRequest represents the request body for creating or updating a public holiday
+type PublicHolidayRequest struct {
+	HolidayDate string `json:"holidayDate" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	MsiaStateID *int32 `json:"msiaStateId"` // empty for national holidays
+}
+
+// ToCreatePublicHolidayParams converts PublicHolidayRequest to slas.CreatePublicHolidayParams
+func (r *PublicHolidayRequest) ToCreatePublicHolidayParams() (*slas.CreatePublicHolidayParams, error) {
+	holidayDate, err := utils.ConvertDateStringToStandardFormat(r.HolidayDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid holiday date format: %w", err)
+	}
+	return &slas.CreatePublicHolidayParams{
+		HolidayDate: holidayDate,
+		Name:        r.Name,
+		MsiaStateID: r.MsiaStateID,
+	}, nil
+}
+
+// ToUpdatePublicHolidayParams converts PublicHolidayRequest to slas.UpdatePublicHolidayParams
+func (r *PublicHolidayRequest) ToUpdatePublicHolidayParams(id int32) (*slas.UpdatePublicHolidayParams, error) {
+	holidayDate, err := utils.ConvertDateStringToStandardFormat(r.HolidayDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid holiday date format: %w", err)
+	}
+	return &slas.UpdatePublicHolidayParams{
+		ID:          id,
+		HolidayDate: holidayDate,
+		Name:        r.Name,
+		MsiaStateID: r.MsiaStateID,
+	}, nil
+}
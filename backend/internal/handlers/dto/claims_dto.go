@@ -75,13 +75,10 @@ type UpdateClaimRequest struct {
 
 // UpdateClaimWarrantyPartRequest represents the request body for updating a claim warranty part
 type UpdateClaimWarrantyPartRequest struct {
-	ID                 int32   `json:"id" binding:"required"`
-	WarrantyPartID     int32   `json:"warrantyPartId" binding:"required"`
-	DamagedImageUrl    *string `json:"damagedImageUrl"`
-	Status             *string `json:"status"`
-	Remarks            *string `json:"remarks"`
-	ResolutionDate     *string `json:"resolutionDate"` // Format: YYYY-MM-DD, optional
-	ResolutionImageUrl *string `json:"resolutionImageUrl"`
+	ID              int32   `json:"id" binding:"required"`
+	WarrantyPartID  int32   `json:"warrantyPartId" binding:"required"`
+	DamagedImageUrl *string `json:"damagedImageUrl"`
+	Remarks         *string `json:"remarks"`
 }
 
 // UpdateClaimWithPartsRequest represents the request body for updating a claim with its associated parts
@@ -104,30 +101,15 @@ func (r *UpdateClaimWithPartsRequest) ToUpdateClaimParamsAndParts(id int32) (*cl
 	}
 	var partsParams []*claims.UpdateClaimWarrantyPartParams
 	for _, part := range r.Parts {
-		var resolutionDate *time.Time
-		if part.ResolutionDate != nil && *part.ResolutionDate != "" {
-			parsedDate, err := utils.ConvertDateStringToStandardFormat(*part.ResolutionDate)
-			if err != nil {
-				return nil, nil, fmt.Errorf("invalid resolution date format: %w", err)
-			}
-			resolutionDate = &parsedDate
-		}
 		var damagedImageUrl string
 		if part.DamagedImageUrl != nil {
 			damagedImageUrl = *part.DamagedImageUrl
 		}
-		var status string
-		if part.Status != nil {
-			status = *part.Status
-		}
 		partParam := &claims.UpdateClaimWarrantyPartParams{
-			ID:                 part.ID,
-			WarrantyPartID:     part.WarrantyPartID,
-			DamagedImageUrl:    damagedImageUrl,
-			Status:             status,
-			Remarks:            part.Remarks,
-			ResolutionDate:     resolutionDate,
-			ResolutionImageUrl: part.ResolutionImageUrl,
+			ID:              part.ID,
+			WarrantyPartID:  part.WarrantyPartID,
+			DamagedImageUrl: damagedImageUrl,
+			Remarks:         part.Remarks,
 		}
 		partsParams = append(partsParams, partParam)
 	}
@@ -254,3 +236,108 @@ type ClaimWithPartsResponse struct {
 	Claim *claims.ClaimView                `json:"claim"`
 	Parts []*claims.ClaimWarrantyPartsView `json:"parts"`
 }
+
+// ClaimEligibilityRequest represents the request body for checking claim eligibility without creating a claim
+type ClaimEligibilityRequest struct {
+	WarrantyID      int32   `json:"warrantyId" binding:"required"`
+	ClaimDate       string  `json:"claimDate"` // Format: YYYY-MM-DD, defaults to today
+	WarrantyPartIDs []int32 `json:"warrantyPartIds" binding:"required"`
+}
+
+// ParseClaimDate returns the claim date of the request, defaulting to today.
+func (r *ClaimEligibilityRequest) ParseClaimDate() (time.Time, error) {
+	if r.ClaimDate == "" {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	claimDate, err := utils.ConvertDateStringToStandardFormat(r.ClaimDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid claim date format: %w", err)
+	}
+	return claimDate, nil
+}
+
+// ClaimExclusionRequest represents the request body for creating or updating a claim exclusion
+type ClaimExclusionRequest struct {
+	Name            string `json:"name" binding:"required"`
+	Reason          string `json:"reason" binding:"required"`
+	ProductTypeID   *int32 `json:"productTypeId"`
+	ProductSeriesID *int32 `json:"productSeriesId"`
+	ProductNameID   *int32 `json:"productNameId"`
+	CarPartID       *int32 `json:"carPartId"`
+	IsActive        bool   `json:"isActive"`
+}
+
+// ToCreateClaimExclusionParams converts ClaimExclusionRequest to claims.CreateClaimExclusionParams
+func (r *ClaimExclusionRequest) ToCreateClaimExclusionParams() *claims.CreateClaimExclusionParams {
+	return &claims.CreateClaimExclusionParams{
+		Name:            r.Name,
+		Reason:          r.Reason,
+		ProductTypeID:   r.ProductTypeID,
+		ProductSeriesID: r.ProductSeriesID,
+		ProductNameID:   r.ProductNameID,
+		CarPartID:       r.CarPartID,
+		IsActive:        r.IsActive,
+	}
+}
+
+// ToUpdateClaimExclusionParams converts ClaimExclusionRequest to claims.UpdateClaimExclusionParams
+func (r *ClaimExclusionRequest) ToUpdateClaimExclusionParams(id int32) *claims.UpdateClaimExclusionParams {
+	return &claims.UpdateClaimExclusionParams{
+		ID:              id,
+		Name:            r.Name,
+		Reason:          r.Reason,
+		ProductTypeID:   r.ProductTypeID,
+		ProductSeriesID: r.ProductSeriesID,
+		ProductNameID:   r.ProductNameID,
+		CarPartID:       r.CarPartID,
+		IsActive:        r.IsActive,
+	}
+}
+
+// UpdateClaimStatusRequest represents the request body for moving a claim to another lifecycle status
+type UpdateClaimStatusRequest struct {
+	Status  string  `json:"status" binding:"required"`
+	Remarks *string `json:"remarks"`
+}
+
+// ClaimStatusTransitionsResponse represents the status history of a claim
+type ClaimStatusTransitionsResponse struct {
+	Transitions     []*claims.ListClaimStatusTransitionsByClaimIDRow `json:"transitions"`
+	AllowedStatuses []string                                         `json:"allowedStatuses"`
+}
+
+// ClaimResolutionRequest represents the request body for recording the repair of a claim part
+type ClaimResolutionRequest struct {
+	ProductAllocationID int32   `json:"productAllocationId" binding:"required"`
+	QuantityUsed        float64 `json:"quantityUsed" binding:"required"`
+	UnitOfMeasure       string  `json:"unitOfMeasure"` // Unit of QuantityUsed: ROLL, METRE or SHEET, defaults to ROLL
+	LabourCost          float64 `json:"labourCost"`
+	MaterialCost        float64 `json:"materialCost"`
+	Remarks             *string `json:"remarks"`
+	ResolutionDate      string  `json:"resolutionDate" binding:"required"` // Format: YYYY-MM-DD
+	ResolutionImageUrl  *string `json:"resolutionImageUrl"`
+}
+
+// ToClaimResolutionParams converts ClaimResolutionRequest to claims.UpsertClaimResolutionParams and claims.UpdateClaimWarrantyPartResolutionParams
+func (r *ClaimResolutionRequest) ToClaimResolutionParams(claimWarrantyPartID int32) (*claims.UpsertClaimResolutionParams, *claims.UpdateClaimWarrantyPartResolutionParams, error) {
+	resolutionDate, err := utils.ConvertDateStringToStandardFormat(r.ResolutionDate)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid resolution date format: %w", err)
+	}
+	resolution := &claims.UpsertClaimResolutionParams{
+		ClaimWarrantyPartID: claimWarrantyPartID,
+		ProductAllocationID: r.ProductAllocationID,
+		UnitOfMeasure:       r.UnitOfMeasure,
+		UnitQuantity:        r.QuantityUsed,
+		LabourCost:          r.LabourCost,
+		MaterialCost:        r.MaterialCost,
+		Remarks:             r.Remarks,
+	}
+	part := &claims.UpdateClaimWarrantyPartResolutionParams{
+		ID:                 claimWarrantyPartID,
+		ResolutionDate:     &resolutionDate,
+		ResolutionImageUrl: r.ResolutionImageUrl,
+	}
+	return resolution, part, nil
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/comments"
+)
+
+// CommentAttachmentRequest represents a file uploaded through the uploads endpoint and attached to a comment
+type CommentAttachmentRequest struct {
+	FileUrl  string `json:"fileUrl" binding:"required"`
+	FileName string `json:"fileName" binding:"required"`
+}
+
+// CreateCommentRequest represents the request body for posting a comment or a reply to a thread
+type CreateCommentRequest struct {
+	Body            string                     `json:"body" binding:"required"`
+	ParentCommentID *int32                     `json:"parentCommentId"` // set when replying
+	IsInternal      bool                       `json:"isInternal"`      // HQ only
+	Attachments     []CommentAttachmentRequest `json:"attachments"`
+}
+
+// ToCreateCommentParams converts CreateCommentRequest to comments.CreateCommentParams and a slice of comments.CreateCommentAttachmentParams
+func (r *CreateCommentRequest) ToCreateCommentParams() (*comments.CreateCommentParams, []*comments.CreateCommentAttachmentParams) {
+	params := &comments.CreateCommentParams{
+		ParentCommentID: r.ParentCommentID,
+		Body:            r.Body,
+		IsInternal:      r.IsInternal,
+	}
+	attachments := make([]*comments.CreateCommentAttachmentParams, 0, len(r.Attachments))
+	for _, attachment := range r.Attachments {
+		attachments = append(attachments, &comments.CreateCommentAttachmentParams{
+			FileUrl:  attachment.FileUrl,
+			FileName: attachment.FileName,
+		})
+	}
+	return params, attachments
+}
+
+// UnreadCommentCountsResponse represents the unread comments of a user per thread
+type UnreadCommentCountsResponse struct {
+	Total   int32                                  `json:"total"`
+	Threads []*comments.ListUnreadCommentCountsRow `json:"threads"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/customers"
+
+// MergeCustomersRequest represents the request body for merging customers into another customer
+type MergeCustomersRequest struct {
+	SourceCustomerIDs []int32 `json:"sourceCustomerIds" binding:"required"`
+}
+
+// CustomerDetailsResponse represents a customer with all of their vehicles, warranties and claims
+type CustomerDetailsResponse struct {
+	Customer   *customers.Customer                        `json:"customer"`
+	Vehicles   []*customers.Vehicle                       `json:"vehicles"`
+	Warranties []*customers.ListWarrantiesByCustomerIDRow `json:"warranties"`
+	Claims     []*customers.ClaimView                     `json:"claims"`
+	Merges     []*customers.CustomerMerge                 `json:"merges"`
+}
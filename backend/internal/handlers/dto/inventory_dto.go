@@ -0,0 +1,24 @@
+package dto
+
+import (
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+)
+
+// InventoryMovementRequest represents the request body for adjusting or writing off film stock.
+// Leave ShopID empty for HQ stock.
+type InventoryMovementRequest struct {
+	ProductID int32   `json:"productId" binding:"required"`
+	ShopID    *int32  `json:"shopId"`
+	Quantity  float64 `json:"quantity" binding:"required"` // In rolls
+	Remarks   *string `json:"remarks"`
+}
+
+// ToCreateInventoryMovementParams converts InventoryMovementRequest to inventory.CreateInventoryMovementParams
+func (r *InventoryMovementRequest) ToCreateInventoryMovementParams() *inventory.CreateInventoryMovementParams {
+	return &inventory.CreateInventoryMovementParams{
+		ProductID: r.ProductID,
+		ShopID:    r.ShopID,
+		Quantity:  r.Quantity,
+		Remarks:   r.Remarks,
+	}
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/notifications"
+
+// NotificationWithAttemptsResponse represents a notification along with its delivery attempts
+type NotificationWithAttemptsResponse struct {
+	Notification *notifications.Notification          `json:"notification"`
+	Attempts     []*notifications.NotificationAttempt `json:"attempts"`
+}
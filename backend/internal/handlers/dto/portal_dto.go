@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/portal"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
+)
+
+// PortalLoginCodeRequest represents the request body for sending a portal login code
+type PortalLoginCodeRequest struct {
+	Contact string `json:"contact" binding:"required"` // phone number or e-mail address on file
+}
+
+// PortalVerifyLoginCodeRequest represents the request body for logging in with a portal login code
+type PortalVerifyLoginCodeRequest struct {
+	Contact string `json:"contact" binding:"required"`
+	Code    string `json:"code" binding:"required"`
+}
+
+// PortalClaimPartRequest represents a damaged warranty part in a customer claim
+type PortalClaimPartRequest struct {
+	WarrantyPartID  int32   `json:"warrantyPartId" binding:"required"`
+	DamagedImageUrl string  `json:"damagedImageUrl" binding:"required"`
+	Remarks         *string `json:"remarks"`
+}
+
+// PortalClaimRequest represents the request body for submitting a claim from the customer portal
+type PortalClaimRequest struct {
+	WarrantyID int32                    `json:"warrantyId" binding:"required"`
+	Parts      []PortalClaimPartRequest `json:"parts" binding:"required,dive"`
+}
+
+// ToCreateClaimWarrantyPartParams converts PortalClaimRequest parts to claims.CreateClaimWarrantyPartParams
+func (r *PortalClaimRequest) ToCreateClaimWarrantyPartParams() []*claims.CreateClaimWarrantyPartParams {
+	var partsParams []*claims.CreateClaimWarrantyPartParams
+	for _, part := range r.Parts {
+		partsParams = append(partsParams, &claims.CreateClaimWarrantyPartParams{
+			WarrantyPartID:  part.WarrantyPartID,
+			DamagedImageUrl: part.DamagedImageUrl,
+			Remarks:         part.Remarks,
+		})
+	}
+	return partsParams
+}
+
+// PortalWarrantyDetailsResponse represents a customer's warranty with its parts
+type PortalWarrantyDetailsResponse struct {
+	Warranty *portal.GetPortalWarrantyByIDRow              `json:"warranty"`
+	Parts    []*warranties.GetWarrantyPartsByWarrantyIDRow `json:"parts"`
+}
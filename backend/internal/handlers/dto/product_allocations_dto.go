@@ -9,10 +9,11 @@ import (
 
 // CreateProductAllocationRequest represents the request body for creating a product allocation
 type CreateProductAllocationRequest struct {
-	ProductID      int32  `json:"productId" binding:"required"`
-	ShopID         int32  `json:"shopId" binding:"required"`
-	FilmQuantity   int32  `json:"filmQuantity" binding:"required"`
-	AllocationDate string `json:"allocationDate" binding:"required"` // Format: YYYY-MM-DD
+	ProductID      int32   `json:"productId" binding:"required"`
+	ShopID         int32   `json:"shopId" binding:"required"`
+	FilmQuantity   float64 `json:"filmQuantity" binding:"required"`
+	UnitOfMeasure  string  `json:"unitOfMeasure"`                     // Unit of FilmQuantity: ROLL, METRE or SHEET, defaults to ROLL
+	AllocationDate string  `json:"allocationDate" binding:"required"` // Format: YYYY-MM-DD
 }
 
 // ToCreateProductAllocationParams converts CreateProductAllocationRequest to productallocations.CreateProductAllocationParams
@@ -25,17 +26,19 @@ func (r *CreateProductAllocationRequest) ToCreateProductAllocationParams() (*pro
 	return &productallocations.CreateProductAllocationParams{
 		ProductID:      r.ProductID,
 		ShopID:         r.ShopID,
-		FilmQuantity:   r.FilmQuantity,
+		UnitOfMeasure:  r.UnitOfMeasure,
+		UnitQuantity:   r.FilmQuantity,
 		AllocationDate: allocationDate,
 	}, nil
 }
 
 // UpdateProductAllocationRequest represents the request body for updating a product allocation
 type UpdateProductAllocationRequest struct {
-	ProductID      int32  `json:"productId" binding:"required"`
-	ShopID         int32  `json:"shopId" binding:"required"`
-	FilmQuantity   int32  `json:"filmQuantity" binding:"required"`
-	AllocationDate string `json:"allocationDate" binding:"required"` // Format: YYYY-MM-DD
+	ProductID      int32   `json:"productId" binding:"required"`
+	ShopID         int32   `json:"shopId" binding:"required"`
+	FilmQuantity   float64 `json:"filmQuantity" binding:"required"`
+	UnitOfMeasure  string  `json:"unitOfMeasure"`                     // Unit of FilmQuantity: ROLL, METRE or SHEET, defaults to ROLL
+	AllocationDate string  `json:"allocationDate" binding:"required"` // Format: YYYY-MM-DD
 }
 
 // ToUpdateProductAllocationParams converts UpdateProductAllocationRequest to productallocations.UpdateProductAllocationParams
@@ -49,7 +52,8 @@ func (r *UpdateProductAllocationRequest) ToUpdateProductAllocationParams(id int3
 		ID:             id,
 		ProductID:      r.ProductID,
 		ShopID:         r.ShopID,
-		FilmQuantity:   r.FilmQuantity,
+		UnitOfMeasure:  r.UnitOfMeasure,
+		UnitQuantity:   r.FilmQuantity,
 		AllocationDate: allocationDate,
 	}, nil
 }
@@ -63,3 +67,28 @@ type ProductAllocationResponse struct {
 type ProductAllocationViewResponse struct {
 	*productallocations.ListProductAllocationsViewRow
 }
+
+// ProductAllocationTransferRequest represents the request body for returning film of an
+// allocation to HQ or transferring it to another shop. ToShopID is only used by transfers.
+type ProductAllocationTransferRequest struct {
+	FilmQuantity float64 `json:"filmQuantity" binding:"required"` // In rolls
+	ToShopID     *int32  `json:"toShopId"`
+	TransferDate string  `json:"transferDate" binding:"required"` // Format: YYYY-MM-DD
+	Remarks      *string `json:"remarks"`
+}
+
+// ToCreateProductAllocationTransferParams converts ProductAllocationTransferRequest to productallocations.CreateProductAllocationTransferParams
+func (r *ProductAllocationTransferRequest) ToCreateProductAllocationTransferParams(allocationID int32) (*productallocations.CreateProductAllocationTransferParams, error) {
+	transferDate, err := time.Parse("2006-01-02", r.TransferDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer date format: %w", err)
+	}
+
+	return &productallocations.CreateProductAllocationTransferParams{
+		ProductAllocationID: allocationID,
+		FilmQuantity:        r.FilmQuantity,
+		ToShopID:            r.ToShopID,
+		TransferDate:        transferDate,
+		Remarks:             r.Remarks,
+	}, nil
+}
@@ -8,15 +8,18 @@ import (
 
 // CreateProductRequest represents the request body for creating a product
 type CreateProductRequest struct {
-	BrandID          int32  `json:"brandId" binding:"required"`
-	TypeID           int32  `json:"typeId" binding:"required"`
-	SeriesID         int32  `json:"seriesId" binding:"required"`
-	NameID           int32  `json:"nameId" binding:"required"`
-	WarrantyInMonths int32  `json:"warrantyInMonths" binding:"required"`
-	FilmSerialNumber string `json:"filmSerialNumber" binding:"required"`
-	FilmQuantity     int32  `json:"filmQuantity" binding:"required"`
-	ShipmentNumber   string `json:"shipmentNumber" binding:"required"`
-	Description      string `json:"description"`
+	BrandID          int32    `json:"brandId"`
+	TypeID           int32    `json:"typeId"`
+	SeriesID         int32    `json:"seriesId"`
+	NameID           int32    `json:"nameId" binding:"required"`
+	WarrantyInMonths int32    `json:"warrantyInMonths" binding:"required"`
+	FilmSerialNumber string   `json:"filmSerialNumber" binding:"required"`
+	FilmQuantity     float64  `json:"filmQuantity" binding:"required"` // In rolls
+	ShipmentNumber   string   `json:"shipmentNumber" binding:"required"`
+	Description      string   `json:"description"`
+	UnitOfMeasure    string   `json:"unitOfMeasure"` // ROLL, METRE or SHEET, defaults to ROLL
+	RollLengthMetres *float64 `json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `json:"sheetsPerRoll"`
 }
 
 // ToCreateProductParams converts CreateProductRequest to products.CreateProductParams
@@ -31,21 +34,27 @@ func (r *CreateProductRequest) ToCreateProductParams() *products.CreateProductPa
 		FilmQuantity:     r.FilmQuantity,
 		ShipmentNumber:   r.ShipmentNumber,
 		Description:      r.Description,
+		UnitOfMeasure:    r.UnitOfMeasure,
+		RollLengthMetres: r.RollLengthMetres,
+		SheetsPerRoll:    r.SheetsPerRoll,
 	}
 }
 
 // UpdateProductRequest represents the request body for updating a product
 type UpdateProductRequest struct {
-	BrandID          int32  `json:"brandId" binding:"required"`
-	TypeID           int32  `json:"typeId" binding:"required"`
-	SeriesID         int32  `json:"seriesId" binding:"required"`
-	NameID           int32  `json:"nameId" binding:"required"`
-	WarrantyInMonths int32  `json:"warrantyInMonths" binding:"required"`
-	FilmSerialNumber string `json:"filmSerialNumber" binding:"required"`
-	FilmQuantity     int32  `json:"filmQuantity" binding:"required"`
-	ShipmentNumber   string `json:"shipmentNumber" binding:"required"`
-	Description      string `json:"description"`
-	IsActive         bool   `json:"isActive"`
+	BrandID          int32    `json:"brandId"`
+	TypeID           int32    `json:"typeId"`
+	SeriesID         int32    `json:"seriesId"`
+	NameID           int32    `json:"nameId" binding:"required"`
+	WarrantyInMonths int32    `json:"warrantyInMonths" binding:"required"`
+	FilmSerialNumber string   `json:"filmSerialNumber" binding:"required"`
+	FilmQuantity     float64  `json:"filmQuantity" binding:"required"` // In rolls
+	ShipmentNumber   string   `json:"shipmentNumber" binding:"required"`
+	Description      string   `json:"description"`
+	IsActive         bool     `json:"isActive"`
+	UnitOfMeasure    string   `json:"unitOfMeasure"` // ROLL, METRE or SHEET, defaults to ROLL
+	RollLengthMetres *float64 `json:"rollLengthMetres"`
+	SheetsPerRoll    *int32   `json:"sheetsPerRoll"`
 }
 
 // ToUpdateProductParams converts UpdateProductRequest to products.UpdateProductParams
@@ -62,6 +71,9 @@ func (r *UpdateProductRequest) ToUpdateProductParams(id int32) *products.UpdateP
 		ShipmentNumber:   r.ShipmentNumber,
 		Description:      r.Description,
 		IsActive:         r.IsActive,
+		UnitOfMeasure:    r.UnitOfMeasure,
+		RollLengthMetres: r.RollLengthMetres,
+		SheetsPerRoll:    r.SheetsPerRoll,
 	}
 }
 
@@ -70,7 +82,7 @@ type ProductDetialResponse struct {
 	ID               int32     `json:"id"`
 	WarrantyInMonths int32     `json:"warrantyInMonths"`
 	FilmSerialNumber string    `json:"filmSerialNumber"`
-	FilmQuantity     int32     `json:"filmQuantity"`
+	FilmQuantity     float64   `json:"filmQuantity"`
 	ShipmentNumber   string    `json:"shipmentNumber"`
 	Description      string    `json:"description"`
 	IsActive         bool      `json:"isActive"`
@@ -80,6 +92,9 @@ type ProductDetialResponse struct {
 	TypeName         string    `json:"typeName"`
 	SeriesName       string    `json:"seriesName"`
 	ProductName      string    `json:"productName"`
+	UnitOfMeasure    string    `json:"unitOfMeasure"`
+	RollLengthMetres *float64  `json:"rollLengthMetres"`
+	SheetsPerRoll    *int32    `json:"sheetsPerRoll"`
 }
 
 // ProductResponse wraps the products.Product model for API responses
@@ -106,3 +121,109 @@ type ProductSeriesResponse struct {
 type ProductNameResponse struct {
 	*products.ProductName
 }
+
+// ProductBrandRequest represents the request body for creating or updating a product brand
+type ProductBrandRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ToCreateProductBrandParams converts ProductBrandRequest to products.CreateProductBrandParams
+func (r *ProductBrandRequest) ToCreateProductBrandParams() *products.CreateProductBrandParams {
+	return &products.CreateProductBrandParams{
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ToUpdateProductBrandParams converts ProductBrandRequest to products.UpdateProductBrandParams
+func (r *ProductBrandRequest) ToUpdateProductBrandParams(id int32) *products.UpdateProductBrandParams {
+	return &products.UpdateProductBrandParams{
+		ID:          id,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ProductTypeRequest represents the request body for creating or updating a product type
+type ProductTypeRequest struct {
+	BrandID     int32  `json:"brandId" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ToCreateProductTypeParams converts ProductTypeRequest to products.CreateProductTypeParams
+func (r *ProductTypeRequest) ToCreateProductTypeParams() *products.CreateProductTypeParams {
+	return &products.CreateProductTypeParams{
+		BrandID:     r.BrandID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ToUpdateProductTypeParams converts ProductTypeRequest to products.UpdateProductTypeParams
+func (r *ProductTypeRequest) ToUpdateProductTypeParams(id int32) *products.UpdateProductTypeParams {
+	return &products.UpdateProductTypeParams{
+		ID:          id,
+		BrandID:     r.BrandID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ProductSeriesRequest represents the request body for creating or updating a product series
+type ProductSeriesRequest struct {
+	TypeID      int32  `json:"typeId" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ToCreateProductSeriesParams converts ProductSeriesRequest to products.CreateProductSeriesParams
+func (r *ProductSeriesRequest) ToCreateProductSeriesParams() *products.CreateProductSeriesParams {
+	return &products.CreateProductSeriesParams{
+		TypeID:      r.TypeID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ToUpdateProductSeriesParams converts ProductSeriesRequest to products.UpdateProductSeriesParams
+func (r *ProductSeriesRequest) ToUpdateProductSeriesParams(id int32) *products.UpdateProductSeriesParams {
+	return &products.UpdateProductSeriesParams{
+		ID:          id,
+		TypeID:      r.TypeID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ProductNameRequest represents the request body for creating or updating a product name
+type ProductNameRequest struct {
+	SeriesID    int32  `json:"seriesId" binding:"required"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// ToCreateProductNameParams converts ProductNameRequest to products.CreateProductNameParams
+func (r *ProductNameRequest) ToCreateProductNameParams() *products.CreateProductNameParams {
+	return &products.CreateProductNameParams{
+		SeriesID:    r.SeriesID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// ToUpdateProductNameParams converts ProductNameRequest to products.UpdateProductNameParams
+func (r *ProductNameRequest) ToUpdateProductNameParams(id int32) *products.UpdateProductNameParams {
+	return &products.UpdateProductNameParams{
+		ID:          id,
+		SeriesID:    r.SeriesID,
+		Name:        r.Name,
+		Description: r.Description,
+	}
+}
+
+// CatalogActiveRequest represents the request body for activating or deactivating a catalog level
+type CatalogActiveRequest struct {
+	IsActive bool `json:"isActive"`
+}
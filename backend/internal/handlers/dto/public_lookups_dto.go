@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// PublicWarranty is the warranty shown by the unauthenticated lookup. Personal data and the
+// chassis number are masked; internal fields such as invoices and remarks are left out.
+type PublicWarranty struct {
+	ID               int32     `json:"id"`
+	WarrantyNo       string    `json:"warrantyNo"`
+	InstallationDate time.Time `json:"installationDate"`
+	IsActive         bool      `json:"isActive"`
+	ApprovalStatus   string    `json:"approvalStatus"`
+	ShopName         string    `json:"shopName"`
+	BranchCode       string    `json:"branchCode"`
+	ClientName       string    `json:"clientName"`
+	ClientContact    string    `json:"clientContact"`
+	ClientEmail      string    `json:"clientEmail"`
+	CarBrand         string    `json:"carBrand"`
+	CarModel         string    `json:"carModel"`
+	CarColour        string    `json:"carColour"`
+	CarPlateNo       string    `json:"carPlateNo"`
+	CarChassisNo     string    `json:"carChassisNo"`
+}
+
+// PublicWarrantyPart is a warranty part shown by the unauthenticated lookup
+type PublicWarrantyPart struct {
+	ID                   int32  `json:"id"`
+	CarPartName          string `json:"carPartName"`
+	InstallationImageUrl string `json:"installationImageUrl"`
+	ProductBrand         string `json:"productBrand"`
+	ProductType          string `json:"productType"`
+	ProductSeries        string `json:"productSeries"`
+	ProductName          string `json:"productName"`
+	FilmSerialNumber     string `json:"filmSerialNumber"`
+	WarrantyInMonths     int32  `json:"warrantyInMonths"`
+}
+
+// PublicWarrantySearchResponse represents a warranty found by the unauthenticated lookup
+type PublicWarrantySearchResponse struct {
+	Warranty PublicWarranty       `json:"warranty"`
+	Parts    []PublicWarrantyPart `json:"parts"`
+}
+
+// NewPublicWarrantySearchResponse builds the redacted lookup response of a warranty and its parts
+func NewPublicWarrantySearchResponse(w *warranties.GetWarrantiesByExactSearchRow, parts []*warranties.GetWarrantyPartsByWarrantyIDRow) PublicWarrantySearchResponse {
+	resp := PublicWarrantySearchResponse{
+		Warranty: PublicWarranty{
+			ID:               w.ID,
+			WarrantyNo:       w.WarrantyNo,
+			InstallationDate: w.InstallationDate,
+			IsActive:         w.IsActive,
+			ApprovalStatus:   string(w.ApprovalStatus),
+			ShopName:         w.ShopName,
+			BranchCode:       w.BranchCode,
+			ClientName:       utils.MaskName(w.ClientName),
+			ClientContact:    utils.MaskPhone(w.ClientContact),
+			ClientEmail:      utils.MaskEmail(w.ClientEmail),
+			CarBrand:         w.CarBrand,
+			CarModel:         w.CarModel,
+			CarColour:        w.CarColour,
+			CarPlateNo:       w.CarPlateNo,
+			CarChassisNo:     utils.MaskChassisNo(w.CarChassisNo),
+		},
+		Parts: make([]PublicWarrantyPart, 0, len(parts)),
+	}
+	for _, p := range parts {
+		resp.Parts = append(resp.Parts, PublicWarrantyPart{
+			ID:                   p.ID,
+			CarPartName:          p.CarPartName,
+			InstallationImageUrl: p.InstallationImageUrl,
+			ProductBrand:         p.ProductBrand,
+			ProductType:          p.ProductType,
+			ProductSeries:        p.ProductSeries,
+			ProductName:          p.ProductName,
+			FilmSerialNumber:     p.FilmSerialNumber,
+			WarrantyInMonths:     p.WarrantyInMonths,
+		})
+	}
+	return resp
+}
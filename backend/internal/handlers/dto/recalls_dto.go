@@ -0,0 +1,21 @@
+package dto
+
+// CreateRecallRequest represents the request body for recalling a film roll or a shipment.
+// Exactly one of FilmSerialNumber and ShipmentID must be given.
+type CreateRecallRequest struct {
+	FilmSerialNumber string `json:"filmSerialNumber"` // Raw serial or scanned label
+	ShipmentID       *int32 `json:"shipmentId"`
+	Reason           string `json:"reason" binding:"required"`
+	FreezeInstalls   *bool  `json:"freezeInstalls"` // Defaults to true
+}
+
+// FreezeInstallsOrDefault reports whether the recall freezes new installs, which it does unless
+// the request turns it off.
+func (r *CreateRecallRequest) FreezeInstallsOrDefault() bool {
+	return r.FreezeInstalls == nil || *r.FreezeInstalls
+}
+
+// NotifyRecallCustomersResponse represents the result of queuing recall notices
+type NotifyRecallCustomersResponse struct {
+	WarrantiesNotified int `json:"warrantiesNotified"`
+}
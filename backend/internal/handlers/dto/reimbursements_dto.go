@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reimbursements"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// CreateReimbursementStatementRequest represents the request body for billing a shop's claim resolutions over a period
+type CreateReimbursementStatementRequest struct {
+	ShopID      int32  `json:"shopId" binding:"required"`
+	PeriodStart string `json:"periodStart" binding:"required"` // Format: YYYY-MM-DD
+	PeriodEnd   string `json:"periodEnd" binding:"required"`   // Format: YYYY-MM-DD
+}
+
+// ToCreateReimbursementStatementParams converts CreateReimbursementStatementRequest to reimbursements.CreateReimbursementStatementParams
+func (r *CreateReimbursementStatementRequest) ToCreateReimbursementStatementParams() (*reimbursements.CreateReimbursementStatementParams, error) {
+	periodStart, err := utils.ConvertDateStringToStandardFormat(r.PeriodStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period start format: %w", err)
+	}
+	periodEnd, err := utils.ConvertDateStringToStandardFormat(r.PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("invalid period end format: %w", err)
+	}
+	return &reimbursements.CreateReimbursementStatementParams{
+		ShopID:      r.ShopID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+	}, nil
+}
+
+// ReviewReimbursementStatementRequest represents the request body for approving or rejecting a reimbursement statement
+type ReviewReimbursementStatementRequest struct {
+	Status  string  `json:"status" binding:"required"` // APPROVED or REJECTED
+	Remarks *string `json:"remarks"`
+}
+
+// ToReviewReimbursementStatementParams converts ReviewReimbursementStatementRequest to reimbursements.ReviewReimbursementStatementParams
+func (r *ReviewReimbursementStatementRequest) ToReviewReimbursementStatementParams(id, userID int32) *reimbursements.ReviewReimbursementStatementParams {
+	return &reimbursements.ReviewReimbursementStatementParams{
+		ID:               id,
+		Status:           strings.ToUpper(r.Status),
+		Remarks:          r.Remarks,
+		ReviewedByUserID: &userID,
+	}
+}
+
+// ReimbursementStatementResponse represents a reimbursement statement with the claim resolutions billed on it
+type ReimbursementStatementResponse struct {
+	Statement *reimbursements.GetReimbursementStatementByIDRow     `json:"statement"`
+	Lines     []*reimbursements.ListReimbursementStatementLinesRow `json:"lines"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reminders"
+
+// ReminderRuleRequest represents the request body for creating or updating a reminder rule
+type ReminderRuleRequest struct {
+	Name          string `json:"name" binding:"required"`
+	ProductTypeID int32  `json:"productTypeId" binding:"required"`
+	ReminderType  string `json:"reminderType" binding:"required"` // WARRANTY_EXPIRY or INSPECTION
+	OffsetDays    int32  `json:"offsetDays"`
+	OffsetMonths  int32  `json:"offsetMonths"`
+	WindowDays    int32  `json:"windowDays"`
+	IsActive      bool   `json:"isActive"`
+}
+
+// ToCreateReminderRuleParams converts ReminderRuleRequest to reminders.CreateReminderRuleParams
+func (r *ReminderRuleRequest) ToCreateReminderRuleParams() *reminders.CreateReminderRuleParams {
+	return &reminders.CreateReminderRuleParams{
+		Name:          r.Name,
+		ProductTypeID: r.ProductTypeID,
+		ReminderType:  r.ReminderType,
+		OffsetDays:    r.OffsetDays,
+		OffsetMonths:  r.OffsetMonths,
+		WindowDays:    r.WindowDays,
+		IsActive:      r.IsActive,
+	}
+}
+
+// ToUpdateReminderRuleParams converts ReminderRuleRequest to reminders.UpdateReminderRuleParams
+func (r *ReminderRuleRequest) ToUpdateReminderRuleParams(id int32) *reminders.UpdateReminderRuleParams {
+	return &reminders.UpdateReminderRuleParams{
+		ID:            id,
+		Name:          r.Name,
+		ProductTypeID: r.ProductTypeID,
+		ReminderType:  r.ReminderType,
+		OffsetDays:    r.OffsetDays,
+		OffsetMonths:  r.OffsetMonths,
+		WindowDays:    r.WindowDays,
+		IsActive:      r.IsActive,
+	}
+}
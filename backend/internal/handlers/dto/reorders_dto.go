@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reorders"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// UpsertStockThresholdRequest represents the request body for setting a shop's threshold of a
+// product name
+type UpsertStockThresholdRequest struct {
+	ShopID          int32   `json:"shopId" binding:"required"`
+	NameID          int32   `json:"nameId" binding:"required"`
+	MinQuantity     float64 `json:"minQuantity"`     // In rolls
+	ReorderQuantity float64 `json:"reorderQuantity"` // In rolls
+	IsActive        *bool   `json:"isActive"`        // Defaults to true
+}
+
+// ToUpsertStockThresholdParams converts UpsertStockThresholdRequest to reorders.UpsertStockThresholdParams
+func (r *UpsertStockThresholdRequest) ToUpsertStockThresholdParams() *reorders.UpsertStockThresholdParams {
+	return &reorders.UpsertStockThresholdParams{
+		ShopID:          r.ShopID,
+		NameID:          r.NameID,
+		MinQuantity:     r.MinQuantity,
+		ReorderQuantity: r.ReorderQuantity,
+		IsActive:        r.IsActive == nil || *r.IsActive,
+	}
+}
+
+// CreateReorderRequestRequest represents the request body for a shop asking HQ for more film.
+// Shop users always request for their own shop; HQ users must give the shop.
+type CreateReorderRequestRequest struct {
+	ShopID            *int32  `json:"shopId"`
+	NameID            int32   `json:"nameId" binding:"required"`
+	RequestedQuantity float64 `json:"requestedQuantity" binding:"required"` // In rolls
+	Remarks           *string `json:"remarks"`
+}
+
+// ToCreateReorderRequestParams converts CreateReorderRequestRequest to reorders.CreateReorderRequestParams
+// for the given shop
+func (r *CreateReorderRequestRequest) ToCreateReorderRequestParams(shopID int32) *reorders.CreateReorderRequestParams {
+	return &reorders.CreateReorderRequestParams{
+		ShopID:            shopID,
+		NameID:            r.NameID,
+		RequestedQuantity: r.RequestedQuantity,
+		Remarks:           r.Remarks,
+	}
+}
+
+// RejectReorderRequestRequest represents the request body for rejecting a reorder request
+type RejectReorderRequestRequest struct {
+	Remarks *string `json:"remarks"`
+}
+
+// ApproveReorderRequestRequest represents the request body for filling a reorder request with an
+// allocation of a roll
+type ApproveReorderRequestRequest struct {
+	ProductID      int32   `json:"productId" binding:"required"`
+	FilmQuantity   float64 `json:"filmQuantity"`   // In rolls, defaults to the quantity requested
+	AllocationDate string  `json:"allocationDate"` // Format: YYYY-MM-DD, defaults to today
+	Remarks        *string `json:"remarks"`
+}
+
+// AllocationDateOrToday parses the allocation date, falling back to today when it is empty
+func (r *ApproveReorderRequestRequest) AllocationDateOrToday() (time.Time, error) {
+	if r.AllocationDate == "" {
+		return time.Now(), nil
+	}
+	allocationDate, err := utils.ConvertDateStringToStandardFormat(r.AllocationDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid allocation date format: %w", err)
+	}
+	return allocationDate, nil
+}
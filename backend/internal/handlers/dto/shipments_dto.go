@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/shipments"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ShipmentLineRequest represents a film roll on a shipment's packing list
+type ShipmentLineRequest struct {
+	NameID           int32   `json:"nameId" binding:"required"`
+	FilmSerialNumber string  `json:"filmSerialNumber" binding:"required"`
+	FilmQuantity     float64 `json:"filmQuantity" binding:"required"` // In rolls
+	WarrantyInMonths int32   `json:"warrantyInMonths" binding:"required"`
+	Description      string  `json:"description"`
+}
+
+// CreateShipmentRequest represents the request body for creating a shipment with its lines
+type CreateShipmentRequest struct {
+	ShipmentNumber string                `json:"shipmentNumber" binding:"required"`
+	SupplierName   string                `json:"supplierName"`
+	ShippedDate    *string               `json:"shippedDate"` // Format: YYYY-MM-DD, optional
+	Remarks        *string               `json:"remarks"`
+	Lines          []ShipmentLineRequest `json:"lines" binding:"required,dive"`
+}
+
+// ToCreateShipmentParamsAndLines converts CreateShipmentRequest to shipments.CreateShipmentParams and a slice of shipments.CreateShipmentLineParams
+func (r *CreateShipmentRequest) ToCreateShipmentParamsAndLines() (*shipments.CreateShipmentParams, []*shipments.CreateShipmentLineParams, error) {
+	var shippedDate *time.Time
+	if r.ShippedDate != nil && *r.ShippedDate != "" {
+		parsedDate, err := utils.ConvertDateStringToStandardFormat(*r.ShippedDate)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid shipped date format: %w", err)
+		}
+		shippedDate = &parsedDate
+	}
+	params := &shipments.CreateShipmentParams{
+		ShipmentNumber: r.ShipmentNumber,
+		SupplierName:   r.SupplierName,
+		ShippedDate:    shippedDate,
+		Remarks:        r.Remarks,
+	}
+	lines := make([]*shipments.CreateShipmentLineParams, 0, len(r.Lines))
+	for _, line := range r.Lines {
+		lines = append(lines, &shipments.CreateShipmentLineParams{
+			NameID:           line.NameID,
+			FilmSerialNumber: line.FilmSerialNumber,
+			FilmQuantity:     line.FilmQuantity,
+			WarrantyInMonths: line.WarrantyInMonths,
+			Description:      line.Description,
+		})
+	}
+	return params, lines, nil
+}
+
+// ReceiveShipmentRequest represents the request body for receiving a shipment
+type ReceiveShipmentRequest struct {
+	ReceivedDate string `json:"receivedDate"` // Format: YYYY-MM-DD, defaults to today
+}
+
+// ReceivedDateOrToday parses the received date, falling back to today when it is empty
+func (r *ReceiveShipmentRequest) ReceivedDateOrToday() (time.Time, error) {
+	if r.ReceivedDate == "" {
+		return time.Now(), nil
+	}
+	receivedDate, err := utils.ConvertDateStringToStandardFormat(r.ReceivedDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid received date format: %w", err)
+	}
+	return receivedDate, nil
+}
+
+// ShipmentResponse represents a shipment with the film rolls on its packing list
+type ShipmentResponse struct {
+	Shipment *shipments.Shipment               `json:"shipment"`
+	Lines    []*shipments.ListShipmentLinesRow `json:"lines"`
+}
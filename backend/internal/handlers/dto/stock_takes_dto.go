@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/stocktakes"
+)
+
+// defaultStockTakeTolerancePercent is the variance, as a percentage of the system quantity, a
+// stock take accepts without HQ review unless the request sets its own.
+const defaultStockTakeTolerancePercent = 2
+
+// OpenStockTakeRequest represents the request body for starting a shop's monthly stock take.
+// Shop users always open their own shop's stock take; HQ users must give the shop.
+type OpenStockTakeRequest struct {
+	ShopID           *int32   `json:"shopId"`
+	Period           string   `json:"period"`           // Format: YYYY-MM, defaults to the current month
+	TolerancePercent *float64 `json:"tolerancePercent"` // Defaults to 2
+	Remarks          *string  `json:"remarks"`
+}
+
+// ToCreateStockTakeParams converts OpenStockTakeRequest to stocktakes.CreateStockTakeParams for
+// the given shop
+func (r *OpenStockTakeRequest) ToCreateStockTakeParams(shopID int32) (*stocktakes.CreateStockTakeParams, error) {
+	period := time.Now()
+	if r.Period != "" {
+		var err error
+		period, err = time.Parse("2006-01", r.Period)
+		if err != nil {
+			return nil, fmt.Errorf("invalid period format: %w", err)
+		}
+	}
+	tolerancePercent := float64(defaultStockTakeTolerancePercent)
+	if r.TolerancePercent != nil {
+		tolerancePercent = *r.TolerancePercent
+	}
+	return &stocktakes.CreateStockTakeParams{
+		ShopID:           shopID,
+		Period:           period,
+		TolerancePercent: tolerancePercent,
+		Remarks:          r.Remarks,
+	}, nil
+}
+
+// StockTakeCountRequest represents a physical count of one line of a stock take. Either
+// ProductAllocationID or FilmSerialNumber picks the line.
+type StockTakeCountRequest struct {
+	ProductAllocationID *int32  `json:"productAllocationId"`
+	FilmSerialNumber    string  `json:"filmSerialNumber"` // Raw serial or scanned label
+	UnitOfMeasure       string  `json:"unitOfMeasure"`    // ROLL, METRE or SHEET, defaults to ROLL
+	Quantity            float64 `json:"quantity"`
+	Remarks             *string `json:"remarks"`
+}
+
+// RecordStockTakeCountsRequest represents the request body for recording physical counts
+type RecordStockTakeCountsRequest struct {
+	Counts []*StockTakeCountRequest `json:"counts" binding:"required"`
+}
+
+// ReviewStockTakeLineRequest represents the request body for HQ's decision on a flagged variance
+type ReviewStockTakeLineRequest struct {
+	Approve bool    `json:"approve"`
+	Remarks *string `json:"remarks"`
+}
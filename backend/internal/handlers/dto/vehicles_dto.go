@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/vehicles"
+
+// VehicleDetailsResponse represents a vehicle with its plate history, warranties and claims
+type VehicleDetailsResponse struct {
+	Vehicle    *vehicles.Vehicle                        `json:"vehicle"`
+	Plates     []*vehicles.VehiclePlate                 `json:"plates"`
+	Warranties []*vehicles.ListWarrantiesByVehicleIDRow `json:"warranties"`
+	Claims     []*vehicles.ClaimView                    `json:"claims"`
+}
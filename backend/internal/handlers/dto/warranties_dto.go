@@ -105,10 +105,12 @@ func (r *UpdateWarrantyApprovalRequest) ToUpdateWarrantyApprovalParams(id int32)
 
 // CreateWarrantyPartRequest represents the request body for creating a warranty part
 type CreateWarrantyPartRequest struct {
-	WarrantyID           int32  `json:"warrantyId" binding:"required"`
-	ProductAllocationID  int32  `json:"productAllocationId" binding:"required"`
-	CarPartID            int32  `json:"carPartId" binding:"required"`
-	InstallationImageUrl string `json:"installationImageUrl"`
+	WarrantyID           int32   `json:"warrantyId" binding:"required"`
+	ProductAllocationID  int32   `json:"productAllocationId" binding:"required"`
+	CarPartID            int32   `json:"carPartId" binding:"required"`
+	InstallationImageUrl string  `json:"installationImageUrl"`
+	UnitOfMeasure        string  `json:"unitOfMeasure"` // ROLL, METRE or SHEET, defaults to ROLL
+	UnitQuantity         float64 `json:"unitQuantity"`  // Film used by the install
 }
 
 // ToCreateWarrantyPartParams converts CreateWarrantyPartRequest to warranties.CreateWarrantyPartParams
@@ -118,16 +120,20 @@ func (r *CreateWarrantyPartRequest) ToCreateWarrantyPartParams() *warranties.Cre
 		ProductAllocationID:  r.ProductAllocationID,
 		CarPartID:            r.CarPartID,
 		InstallationImageUrl: r.InstallationImageUrl,
+		UnitOfMeasure:        r.UnitOfMeasure,
+		UnitQuantity:         r.UnitQuantity,
 	}
 }
 
 // UpdateWarrantyPartRequest represents the request body for updating a warranty part
 type UpdateWarrantyPartRequest struct {
-	ID                   int32  `json:"id" binding:"required"`
-	WarrantyID           int32  `json:"warrantyId" binding:"required"`
-	CarPartID            int32  `json:"carPartId" binding:"required"`
-	ProductAllocationID  int32  `json:"productAllocationId" binding:"required"`
-	InstallationImageUrl string `json:"installationImageUrl"`
+	ID                   int32   `json:"id" binding:"required"`
+	WarrantyID           int32   `json:"warrantyId" binding:"required"`
+	CarPartID            int32   `json:"carPartId" binding:"required"`
+	ProductAllocationID  int32   `json:"productAllocationId" binding:"required"`
+	InstallationImageUrl string  `json:"installationImageUrl"`
+	UnitOfMeasure        string  `json:"unitOfMeasure"` // ROLL, METRE or SHEET, defaults to ROLL
+	UnitQuantity         float64 `json:"unitQuantity"`  // Film used by the install
 }
 
 // ToUpdateWarrantyPartParams converts UpdateWarrantyPartRequest to warranties.UpdateWarrantyPartParams
@@ -138,6 +144,8 @@ func (r *UpdateWarrantyPartRequest) ToUpdateWarrantyPartParams(id int32) *warran
 		CarPartID:            r.CarPartID,
 		ProductAllocationID:  r.ProductAllocationID,
 		InstallationImageUrl: r.InstallationImageUrl,
+		UnitOfMeasure:        r.UnitOfMeasure,
+		UnitQuantity:         r.UnitQuantity,
 	}
 }
 
@@ -202,11 +210,6 @@ type WarrantyDetailsResponse struct {
 	Parts    []*warranties.GetWarrantyPartsByWarrantyIDRow `json:"parts"`
 }
 
-type WarrantyByExactSearchResponse struct {
-	Warranty *warranties.GetWarrantiesByExactSearchRow     `json:"warranty"`
-	Parts    []*warranties.GetWarrantyPartsByWarrantyIDRow `json:"parts"`
-}
-
 type WarrantyWithPartsResponse struct {
 	Warranty *warranties.Warranty                          `json:"warranty"`
 	Parts    []*warranties.GetWarrantyPartsByWarrantyIDRow `json:"parts"`
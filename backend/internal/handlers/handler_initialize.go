@@ -10,6 +10,23 @@ type HandlerInitializeParams struct {
 	ClaimsHandler             ClaimsHandler
 	UsersHandler              UsersHandler
 	UploadsHandler            UploadsHandler
+	NotificationsHandler      NotificationsHandler
+	RemindersHandler          RemindersHandler
+	CarsHandler               CarsHandler
+	VehiclesHandler           VehiclesHandler
+	CustomersHandler          CustomersHandler
+	PortalHandler             PortalHandler
+	PublicLookupsHandler      PublicLookupsHandler
+	ApprovalRulesHandler      ApprovalRulesHandler
+	ClaimSLAHandler           ClaimSLAHandler
+	ReimbursementsHandler     ReimbursementsHandler
+	CommentsHandler           CommentsHandler
+	AnalyticsHandler          AnalyticsHandler
+	InventoryHandler          InventoryHandler
+	ShipmentsHandler          ShipmentsHandler
+	RecallsHandler            RecallsHandler
+	ReordersHandler           ReordersHandler
+	StockTakesHandler         StockTakesHandler
 }
 
 func NewHandlerInitializeParams(service *services.ServiceInitializeParams) *HandlerInitializeParams {
@@ -21,5 +38,22 @@ func NewHandlerInitializeParams(service *services.ServiceInitializeParams) *Hand
 		ClaimsHandler:             NewClaimsHandler(service.ClaimsService),
 		UsersHandler:              NewUsersHandler(service.UsersService),
 		UploadsHandler:            NewUploadsHandler(service.UploadsService),
+		NotificationsHandler:      NewNotificationsHandler(service.NotificationsService),
+		RemindersHandler:          NewRemindersHandler(service.RemindersService),
+		CarsHandler:               NewCarsHandler(service.CarsService),
+		VehiclesHandler:           NewVehiclesHandler(service.VehiclesService),
+		CustomersHandler:          NewCustomersHandler(service.CustomersService),
+		PortalHandler:             NewPortalHandler(service.PortalService, service.UploadsService),
+		PublicLookupsHandler:      NewPublicLookupsHandler(service.PublicLookupsService, service.WarrantiesService),
+		ApprovalRulesHandler:      NewApprovalRulesHandler(service.ApprovalRulesService),
+		ClaimSLAHandler:           NewClaimSLAHandler(service.ClaimSLAService),
+		ReimbursementsHandler:     NewReimbursementsHandler(service.ReimbursementsService),
+		CommentsHandler:           NewCommentsHandler(service.CommentsService),
+		AnalyticsHandler:          NewAnalyticsHandler(service.AnalyticsService),
+		InventoryHandler:          NewInventoryHandler(service.InventoryService),
+		ShipmentsHandler:          NewShipmentsHandler(service.ShipmentsService),
+		RecallsHandler:            NewRecallsHandler(service.RecallsService),
+		ReordersHandler:           NewReordersHandler(service.ReordersService),
+		StockTakesHandler:         NewStockTakesHandler(service.StockTakesService),
 	}
 }
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// InventoryHandler defines the HTTP contract for film stock ledger endpoints.
+type InventoryHandler interface {
+	// ListInventoryBalances returns the on-hand film of every product at HQ and each shop.
+	ListInventoryBalances(w http.ResponseWriter, r *http.Request)
+
+	// ListHQInventoryBalances returns the on-hand film of every product at HQ.
+	ListHQInventoryBalances(w http.ResponseWriter, r *http.Request)
+
+	// ListShopInventoryBalances returns the on-hand film of every product at a shop.
+	ListShopInventoryBalances(w http.ResponseWriter, r *http.Request)
+
+	// ListInventoryMovements returns stock movements, optionally filtered by product and location.
+	ListInventoryMovements(w http.ResponseWriter, r *http.Request)
+
+	// ListShopInventoryMovements returns the stock movements of a shop.
+	ListShopInventoryMovements(w http.ResponseWriter, r *http.Request)
+
+	// AdjustInventory records a signed correction of on-hand film.
+	AdjustInventory(w http.ResponseWriter, r *http.Request)
+
+	// WriteOffInventory records damaged or lost film.
+	WriteOffInventory(w http.ResponseWriter, r *http.Request)
+}
+
+type inventoryHandler struct {
+	inventoryService services.InventoryService
+}
+
+// NewInventoryHandler creates a new InventoryHandler instance.
+func NewInventoryHandler(inventoryService services.InventoryService) InventoryHandler {
+	return &inventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// ListInventoryBalances returns the on-hand film of every product at HQ and each shop.
+func (h *inventoryHandler) ListInventoryBalances(w http.ResponseWriter, r *http.Request) {
+	balances, err := h.inventoryService.ListInventoryBalances(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list inventory balances")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, balances)
+}
+
+// ListHQInventoryBalances returns the on-hand film of every product at HQ.
+func (h *inventoryHandler) ListHQInventoryBalances(w http.ResponseWriter, r *http.Request) {
+	balances, err := h.inventoryService.ListHQInventoryBalances(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list inventory balances")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, balances)
+}
+
+// ListShopInventoryBalances returns the on-hand film of every product at a shop. Shop users
+// only see their own shop.
+func (h *inventoryHandler) ListShopInventoryBalances(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := h.shopParam(w, r)
+	if !ok {
+		return
+	}
+	balances, err := h.inventoryService.ListShopInventoryBalances(r.Context(), shopID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list inventory balances")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, balances)
+}
+
+// ListInventoryMovements returns stock movements newest first. The productId and shopId query
+// parameters narrow the list, and location=hq keeps only HQ movements.
+func (h *inventoryHandler) ListInventoryMovements(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	arg := &inventory.ListInventoryMovementsParams{HqOnly: query.Get("location") == "hq"}
+	if v := query.Get("productId"); v != "" {
+		productID, err := utils.ConvertParamToInt32(v)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product ID")
+			return
+		}
+		arg.ProductID = &productID
+	}
+	if v := query.Get("shopId"); v != "" {
+		shopID, err := utils.ConvertParamToInt32(v)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+			return
+		}
+		arg.ShopID = &shopID
+	}
+	movements, err := h.inventoryService.ListInventoryMovements(r.Context(), arg)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list inventory movements")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, movements)
+}
+
+// ListShopInventoryMovements returns the stock movements of a shop. Shop users only see their
+// own shop.
+func (h *inventoryHandler) ListShopInventoryMovements(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := h.shopParam(w, r)
+	if !ok {
+		return
+	}
+	movements, err := h.inventoryService.ListInventoryMovements(r.Context(), &inventory.ListInventoryMovementsParams{ShopID: &shopID})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list inventory movements")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, movements)
+}
+
+// AdjustInventory records a signed correction of on-hand film at HQ or a shop.
+func (h *inventoryHandler) AdjustInventory(w http.ResponseWriter, r *http.Request) {
+	h.recordMovement(w, r, h.inventoryService.AdjustInventory)
+}
+
+// WriteOffInventory records damaged or lost film at HQ or a shop.
+func (h *inventoryHandler) WriteOffInventory(w http.ResponseWriter, r *http.Request) {
+	h.recordMovement(w, r, h.inventoryService.WriteOffInventory)
+}
+
+func (h *inventoryHandler) recordMovement(w http.ResponseWriter, r *http.Request, record func(ctx context.Context, userID int32, arg *inventory.CreateInventoryMovementParams) (*inventory.InventoryMovement, error)) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.InventoryMovementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	movement, err := record(ctx, user.UserID, req.ToCreateInventoryMovementParams())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInsufficientStock), errors.Is(err, services.ErrInvalidInventoryQuantity):
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Product not found")
+		default:
+			utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to record inventory movement")
+		}
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, movement)
+}
+
+// shopParam reads the shop_id URL parameter and checks that the user may see that shop. It
+// writes the error response and returns false otherwise.
+func (h *inventoryHandler) shopParam(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, false
+	}
+	shopID, err := utils.ConvertParamToInt32(chi.URLParam(r, "shop_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return 0, false
+	}
+	if !userCoversShop(user, shopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's inventory")
+		return 0, false
+	}
+	return shopID, true
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// NotificationsHandler defines the HTTP contract for customer notification endpoints.
+type NotificationsHandler interface {
+	// ListNotifications returns notifications, optionally filtered by the status query parameter.
+	ListNotifications(w http.ResponseWriter, r *http.Request)
+
+	// GetNotificationByID returns a notification along with its delivery attempts.
+	GetNotificationByID(w http.ResponseWriter, r *http.Request)
+
+	// GetNotificationsByWarrantyID returns notifications sent for a warranty.
+	GetNotificationsByWarrantyID(w http.ResponseWriter, r *http.Request)
+
+	// GetNotificationsByClaimID returns notifications sent for a claim.
+	GetNotificationsByClaimID(w http.ResponseWriter, r *http.Request)
+
+	// RetryNotification queues a failed or skipped notification for delivery again.
+	RetryNotification(w http.ResponseWriter, r *http.Request)
+}
+
+type notificationsHandler struct {
+	notificationsService services.NotificationsService
+}
+
+// NewNotificationsHandler creates a new NotificationsHandler instance.
+func NewNotificationsHandler(notificationsService services.NotificationsService) NotificationsHandler {
+	return &notificationsHandler{
+		notificationsService: notificationsService,
+	}
+}
+
+// ListNotifications returns notifications, optionally filtered by the status query parameter.
+func (h *notificationsHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	status := r.URL.Query().Get("status")
+	notificationsList, err := h.notificationsService.ListNotifications(ctx, status)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list notifications")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, notificationsList)
+}
+
+// GetNotificationByID returns a notification along with its delivery attempts.
+func (h *notificationsHandler) GetNotificationByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+	notification, err := h.notificationsService.GetNotificationByID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get notification")
+		return
+	}
+	attempts, err := h.notificationsService.GetNotificationAttemptsByNotificationID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get notification attempts")
+		return
+	}
+	response := dto.NotificationWithAttemptsResponse{
+		Notification: notification,
+		Attempts:     attempts,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
+}
+
+// GetNotificationsByWarrantyID returns notifications sent for a warranty.
+func (h *notificationsHandler) GetNotificationsByWarrantyID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	warrantyID, err := utils.ConvertParamToInt32(chi.URLParam(r, "warranty_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid warranty ID")
+		return
+	}
+	notificationsList, err := h.notificationsService.GetNotificationsByWarrantyID(ctx, warrantyID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get notifications by warranty ID")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, notificationsList)
+}
+
+// GetNotificationsByClaimID returns notifications sent for a claim.
+func (h *notificationsHandler) GetNotificationsByClaimID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	claimID, err := utils.ConvertParamToInt32(chi.URLParam(r, "claim_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid claim ID")
+		return
+	}
+	notificationsList, err := h.notificationsService.GetNotificationsByClaimID(ctx, claimID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get notifications by claim ID")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, notificationsList)
+}
+
+// RetryNotification queues a failed or skipped notification for delivery again.
+func (h *notificationsHandler) RetryNotification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+	notification, err := h.notificationsService.RetryNotification(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotificationNotRetryable):
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Notification not found")
+		default:
+			utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to retry notification")
+		}
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, notification)
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// portalPhotoMaxSize is the maximum size of a damage photo uploaded from the customer portal.
+const portalPhotoMaxSize = 10 << 20
+
+// PortalHandler defines the HTTP contract for customer portal endpoints.
+type PortalHandler interface {
+	// RequestLoginCode sends a one-time login code to the customer's phone number or e-mail address.
+	RequestLoginCode(w http.ResponseWriter, r *http.Request)
+
+	// VerifyLoginCode exchanges a one-time login code for a customer token.
+	VerifyLoginCode(w http.ResponseWriter, r *http.Request)
+
+	// ListWarranties returns the logged in customer's warranties.
+	ListWarranties(w http.ResponseWriter, r *http.Request)
+
+	// GetWarrantyDetailsByID returns one of the customer's warranties with its parts.
+	GetWarrantyDetailsByID(w http.ResponseWriter, r *http.Request)
+
+	// DownloadWarrantyCertificate returns the printable certificate of an approved warranty.
+	DownloadWarrantyCertificate(w http.ResponseWriter, r *http.Request)
+
+	// ListClaims returns the claims on the customer's warranties.
+	ListClaims(w http.ResponseWriter, r *http.Request)
+
+	// SubmitClaim submits a claim on one of the customer's warranties.
+	SubmitClaim(w http.ResponseWriter, r *http.Request)
+
+	// UploadClaimPhoto uploads a photo of the damage for a claim.
+	UploadClaimPhoto(w http.ResponseWriter, r *http.Request)
+}
+
+type portalHandler struct {
+	portalService  services.PortalService
+	uploadsService services.UploadsService
+}
+
+// NewPortalHandler creates a new PortalHandler instance.
+func NewPortalHandler(portalService services.PortalService, uploadsService services.UploadsService) PortalHandler {
+	return &portalHandler{
+		portalService:  portalService,
+		uploadsService: uploadsService,
+	}
+}
+
+// RequestLoginCode sends a one-time login code to the customer's phone number or e-mail address.
+// The response is the same whether or not the contact is on file.
+func (h *portalHandler) RequestLoginCode(w http.ResponseWriter, r *http.Request) {
+	var req dto.PortalLoginCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if strings.TrimSpace(req.Contact) == "" {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Phone number or e-mail address is required")
+		return
+	}
+	if err := h.portalService.RequestLoginCode(r.Context(), req.Contact); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{
+		"message": "If the contact is on file, a login code has been sent",
+	})
+}
+
+// VerifyLoginCode exchanges a one-time login code for a customer token.
+func (h *portalHandler) VerifyLoginCode(w http.ResponseWriter, r *http.Request) {
+	var req dto.PortalVerifyLoginCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	if req.Contact == "" || req.Code == "" {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Contact and code are required")
+		return
+	}
+
+	login, err := h.portalService.VerifyLoginCode(r.Context(), req.Contact, req.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidLoginCode) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to verify login code")
+		return
+	}
+
+	accessToken, err := middlewares.GenerateCustomerToken(login.Customer.ID, login.Customer.Name, login.Contact)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to generate access token")
+		return
+	}
+
+	response := map[string]any{
+		"accessToken": accessToken,
+		"customer": map[string]any{
+			"id":      login.Customer.ID,
+			"name":    login.Customer.Name,
+			"channel": login.Channel,
+			"contact": login.Contact,
+		},
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
+}
+
+// portalContact returns the channel and verified contact of the customer token on the request.
+func portalContact(r *http.Request) (string, string, bool) {
+	claims, ok := middlewares.GetCustomerFromContext(r.Context())
+	if !ok || claims.Contact == "" {
+		return "", "", false
+	}
+	if strings.Contains(claims.Contact, "@") {
+		return string(notifier.ChannelEmail), claims.Contact, true
+	}
+	return string(notifier.ChannelSMS), claims.Contact, true
+}
+
+// ListWarranties returns the logged in customer's warranties.
+func (h *portalHandler) ListWarranties(w http.ResponseWriter, r *http.Request) {
+	channel, contact, ok := portalContact(r)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	warranties, err := h.portalService.ListWarranties(r.Context(), channel, contact)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list warranties")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, warranties)
+}
+
+// GetWarrantyDetailsByID returns one of the customer's warranties with its parts.
+func (h *portalHandler) GetWarrantyDetailsByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel, contact, ok := portalContact(r)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid warranty ID")
+		return
+	}
+
+	warranty, err := h.portalService.GetWarrantyByID(ctx, channel, contact, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Warranty not found")
+		return
+	}
+	parts, err := h.portalService.ListWarrantyPartsByWarrantyID(ctx, channel, contact, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get warranty parts")
+		return
+	}
+
+	response := dto.PortalWarrantyDetailsResponse{
+		Warranty: warranty,
+		Parts:    parts,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
+}
+
+// DownloadWarrantyCertificate returns the printable certificate of an approved warranty.
+func (h *portalHandler) DownloadWarrantyCertificate(w http.ResponseWriter, r *http.Request) {
+	channel, contact, ok := portalContact(r)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid warranty ID")
+		return
+	}
+
+	certificate, err := h.portalService.RenderWarrantyCertificate(r.Context(), channel, contact, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="warranty-certificate-%d.html"`, id))
+	w.WriteHeader(http.StatusOK)
+	w.Write(certificate)
+}
+
+// ListClaims returns the claims on the customer's warranties.
+func (h *portalHandler) ListClaims(w http.ResponseWriter, r *http.Request) {
+	channel, contact, ok := portalContact(r)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	claims, err := h.portalService.ListClaims(r.Context(), channel, contact)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list claims")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, claims)
+}
+
+// SubmitClaim submits a claim on one of the customer's warranties.
+func (h *portalHandler) SubmitClaim(w http.ResponseWriter, r *http.Request) {
+	channel, contact, ok := portalContact(r)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.PortalClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	claim, err := h.portalService.SubmitClaim(r.Context(), channel, contact, req.WarrantyID, req.ToCreateClaimWarrantyPartParams())
+	if err != nil {
+		if writeClaimIneligibleResponse(w, err) {
+			return
+		}
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, claim)
+}
+
+// UploadClaimPhoto uploads a photo of the damage for a claim.
+func (h *portalHandler) UploadClaimPhoto(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseMultipartForm(portalPhotoMaxSize); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Failed to parse multipart form")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Failed to get file from form")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > portalPhotoMaxSize {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "File size exceeds 10MB limit")
+		return
+	}
+
+	// Only photos are accepted from customers
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	allowedExts := map[string]bool{
+		".jpg":  true,
+		".jpeg": true,
+		".png":  true,
+	}
+	if !allowedExts[ext] {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Only JPG and PNG photos are allowed")
+		return
+	}
+
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to read file")
+		return
+	}
+
+	uniqueFileName := generateUniqueFileName(header.Filename)
+	fileURL, err := h.uploadsService.UploadFile(r.Context(), fileBytes, uniqueFileName, services.FolderDamagedImages)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to upload file")
+		return
+	}
+
+	response := UploadFileResponse{
+		URL:      fileURL,
+		FileName: uniqueFileName,
+		Size:     header.Size,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
+}
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/productallocations"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
 	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
 )
@@ -22,6 +27,16 @@ type ProductAllocationsHandler interface {
 	UpdateProductAllocation(w http.ResponseWriter, r *http.Request)
 	// GetProductsFromProductAllocationsByShopID returns products associated with a specific shop ID.
 	GetProductsFromProductAllocationsByShopID(w http.ResponseWriter, r *http.Request)
+	// ListProductAllocationBalancesByShopID returns the remaining film of each allocation of a shop.
+	ListProductAllocationBalancesByShopID(w http.ResponseWriter, r *http.Request)
+	// ListProductAllocationTransfers returns returns and transfers of allocations.
+	ListProductAllocationTransfers(w http.ResponseWriter, r *http.Request)
+	// ReturnProductAllocation sends film of an allocation back to HQ.
+	ReturnProductAllocation(w http.ResponseWriter, r *http.Request)
+	// TransferProductAllocation sends film of an allocation to another shop.
+	TransferProductAllocation(w http.ResponseWriter, r *http.Request)
+	// LookupFilmLabel returns the product and shop allocations matching a scanned film roll label.
+	LookupFilmLabel(w http.ResponseWriter, r *http.Request)
 }
 
 type productAllocationsHandler struct {
@@ -64,7 +79,7 @@ func (h *productAllocationsHandler) CreateProductAllocation(w http.ResponseWrite
 
 	allocation, err := h.productAllocationsService.CreateProductAllocation(ctx, params)
 	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		writeProductAllocationError(w, err)
 		return
 	}
 
@@ -119,7 +134,7 @@ func (h *productAllocationsHandler) UpdateProductAllocation(w http.ResponseWrite
 	params.ID = int32(id)
 	allocation, err := h.productAllocationsService.UpdateProductAllocation(ctx, params)
 	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		writeProductAllocationError(w, err)
 		return
 	}
 
@@ -143,3 +158,166 @@ func (h *productAllocationsHandler) GetProductsFromProductAllocationsByShopID(w
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, products)
 }
+
+// ListProductAllocationBalancesByShopID returns the remaining film of each allocation of a shop.
+func (h *productAllocationsHandler) ListProductAllocationBalancesByShopID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "shop_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return
+	}
+	balances, err := h.productAllocationsService.ListProductAllocationBalancesByShopID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to get allocation balances")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, balances)
+}
+
+// ListProductAllocationTransfers returns returns and transfers of allocations newest first. The
+// shopId query parameter keeps those leaving or reaching a shop and allocationId those of one
+// allocation. Shop users only see their own shop.
+func (h *productAllocationsHandler) ListProductAllocationTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	query := r.URL.Query()
+	arg := &productallocations.ListProductAllocationTransfersParams{ShopID: user.ShopID}
+	if v := query.Get("shopId"); v != "" {
+		shopID, err := utils.ConvertParamToInt32(v)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+			return
+		}
+		if !userCoversShop(user, shopID) {
+			utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's transfers")
+			return
+		}
+		arg.ShopID = &shopID
+	}
+	if v := query.Get("allocationId"); v != "" {
+		allocationID, err := utils.ConvertParamToInt32(v)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid allocation ID")
+			return
+		}
+		arg.ProductAllocationID = &allocationID
+	}
+	transfers, err := h.productAllocationsService.ListProductAllocationTransfers(ctx, arg)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list allocation transfers")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, transfers)
+}
+
+// ReturnProductAllocation sends film of an allocation back to HQ. The allocation itself is kept.
+func (h *productAllocationsHandler) ReturnProductAllocation(w http.ResponseWriter, r *http.Request) {
+	h.releaseProductAllocation(w, r, h.productAllocationsService.ReturnProductAllocation)
+}
+
+// TransferProductAllocation sends film of an allocation to another shop, where it becomes a new
+// allocation. The original allocation is kept.
+func (h *productAllocationsHandler) TransferProductAllocation(w http.ResponseWriter, r *http.Request) {
+	h.releaseProductAllocation(w, r, h.productAllocationsService.TransferProductAllocation)
+}
+
+func (h *productAllocationsHandler) releaseProductAllocation(w http.ResponseWriter, r *http.Request, release func(ctx context.Context, userID int32, arg *productallocations.CreateProductAllocationTransferParams) (*productallocations.ProductAllocationTransfer, error)) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+	var req dto.ProductAllocationTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	params, err := req.ToCreateProductAllocationTransferParams(id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	transfer, err := release(ctx, user.UserID, params)
+	if err != nil {
+		writeProductAllocationError(w, err)
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, transfer)
+}
+
+// LookupFilmLabel returns the product and shop allocations matching a scanned film roll label.
+// The code query parameter takes a raw serial or a GS1-128 or DataMatrix payload. Shop users
+// look up their own shop; HQ users pass shopId.
+func (h *productAllocationsHandler) LookupFilmLabel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	query := r.URL.Query()
+	code := query.Get("code")
+	if code == "" {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Barcode is required")
+		return
+	}
+	var shopID int32
+	if v := query.Get("shopId"); v != "" {
+		id, err := utils.ConvertParamToInt32(v)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+			return
+		}
+		if !userCoversShop(user, id) {
+			utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's allocations")
+			return
+		}
+		shopID = id
+	} else if user.ShopID != nil {
+		shopID = *user.ShopID
+	} else {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Shop ID is required")
+		return
+	}
+
+	lookup, err := h.productAllocationsService.LookupFilmLabel(ctx, code, shopID)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidBarcode):
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, services.ErrFilmSerialNotFound):
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, err.Error())
+		default:
+			utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to look up film label")
+		}
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, lookup)
+}
+
+// writeProductAllocationError maps a product allocation service error to an HTTP error response.
+func writeProductAllocationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, services.ErrInsufficientStock), errors.Is(err, services.ErrInvalidInventoryQuantity),
+		errors.Is(err, services.ErrInsufficientAllocationBalance), errors.Is(err, services.ErrInvalidAllocationTransfer),
+		errors.Is(err, services.ErrInvalidUnitOfMeasure), errors.Is(err, services.ErrProductRecalled):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrAllocationInUse), errors.Is(err, services.ErrStockTakeInProgress):
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Product allocation not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/products"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
 	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
@@ -25,7 +29,7 @@ type ProductsHandler interface {
 	// UpdateProduct updates an existing product.
 	UpdateProduct(w http.ResponseWriter, r *http.Request)
 
-	// ListProductBrands lists all product brands.
+	// ListProductBrands lists product brands.
 	ListProductBrands(w http.ResponseWriter, r *http.Request)
 
 	// GetProductTypes lists product types.
@@ -36,6 +40,42 @@ type ProductsHandler interface {
 
 	// GetProductNames lists product names.
 	GetProductNames(w http.ResponseWriter, r *http.Request)
+
+	// CreateProductBrand creates a new product brand.
+	CreateProductBrand(w http.ResponseWriter, r *http.Request)
+
+	// UpdateProductBrand updates an existing product brand.
+	UpdateProductBrand(w http.ResponseWriter, r *http.Request)
+
+	// SetProductBrandActive activates or deactivates a product brand.
+	SetProductBrandActive(w http.ResponseWriter, r *http.Request)
+
+	// CreateProductType creates a new product type.
+	CreateProductType(w http.ResponseWriter, r *http.Request)
+
+	// UpdateProductType updates an existing product type.
+	UpdateProductType(w http.ResponseWriter, r *http.Request)
+
+	// SetProductTypeActive activates or deactivates a product type.
+	SetProductTypeActive(w http.ResponseWriter, r *http.Request)
+
+	// CreateProductSeries creates a new product series.
+	CreateProductSeries(w http.ResponseWriter, r *http.Request)
+
+	// UpdateProductSeries updates an existing product series.
+	UpdateProductSeries(w http.ResponseWriter, r *http.Request)
+
+	// SetProductSeriesActive activates or deactivates a product series.
+	SetProductSeriesActive(w http.ResponseWriter, r *http.Request)
+
+	// CreateProductName creates a new product name.
+	CreateProductName(w http.ResponseWriter, r *http.Request)
+
+	// UpdateProductName updates an existing product name.
+	UpdateProductName(w http.ResponseWriter, r *http.Request)
+
+	// SetProductNameActive activates or deactivates a product name.
+	SetProductNameActive(w http.ResponseWriter, r *http.Request)
 }
 
 type productsHandler struct {
@@ -72,6 +112,9 @@ func (h *productsHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
 			TypeName:         p.TypeName,
 			SeriesName:       p.SeriesName,
 			ProductName:      p.ProductName,
+			UnitOfMeasure:    p.UnitOfMeasure,
+			RollLengthMetres: p.RollLengthMetres,
+			SheetsPerRoll:    p.SheetsPerRoll,
 		}
 		productsResponse = append(productsResponse, productResp)
 	}
@@ -109,7 +152,7 @@ func (h *productsHandler) CreateProduct(w http.ResponseWriter, r *http.Request)
 
 	product, err := h.productsService.CreateProduct(r.Context(), params)
 	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to create product")
+		writeProductError(w, err, "Failed to create product")
 		return
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusCreated, product)
@@ -133,15 +176,15 @@ func (h *productsHandler) UpdateProduct(w http.ResponseWriter, r *http.Request)
 
 	product, err := h.productsService.UpdateProduct(r.Context(), params)
 	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to update product")
+		writeProductError(w, err, "Failed to update product")
 		return
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, product)
 }
 
-// ListProductBrands lists all product brands.
+// ListProductBrands lists product brands.
 func (h *productsHandler) ListProductBrands(w http.ResponseWriter, r *http.Request) {
-	brands, err := h.productsService.ListProductBrands(r.Context())
+	brands, err := h.productsService.ListProductBrands(r.Context(), includeInactive(r))
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list product brands")
 		return
@@ -149,9 +192,9 @@ func (h *productsHandler) ListProductBrands(w http.ResponseWriter, r *http.Reque
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, brands)
 }
 
-// GetProductTypes lists product types for the given brand.
+// GetProductTypes lists product types.
 func (h *productsHandler) GetProductTypes(w http.ResponseWriter, r *http.Request) {
-	types, err := h.productsService.ListProductTypes(r.Context())
+	types, err := h.productsService.ListProductTypes(r.Context(), includeInactive(r))
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list product types")
 		return
@@ -161,7 +204,7 @@ func (h *productsHandler) GetProductTypes(w http.ResponseWriter, r *http.Request
 
 // GetProductSeries lists product series.
 func (h *productsHandler) GetProductSeries(w http.ResponseWriter, r *http.Request) {
-	series, err := h.productsService.ListProductSeries(r.Context())
+	series, err := h.productsService.ListProductSeries(r.Context(), includeInactive(r))
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list product series")
 		return
@@ -172,10 +215,288 @@ func (h *productsHandler) GetProductSeries(w http.ResponseWriter, r *http.Reques
 
 // GetProductNames lists product names.
 func (h *productsHandler) GetProductNames(w http.ResponseWriter, r *http.Request) {
-	names, err := h.productsService.ListProductNames(r.Context())
+	names, err := h.productsService.ListProductNames(r.Context(), includeInactive(r))
 	if err != nil {
 		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid series ID")
 		return
 	}
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, names)
 }
+
+// CreateProductBrand creates a new product brand.
+func (h *productsHandler) CreateProductBrand(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProductBrandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.CreateProductBrand(r.Context(), req.ToCreateProductBrandParams())
+	if err != nil {
+		writeCatalogError(w, err, "Product brand not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, level)
+}
+
+// UpdateProductBrand updates an existing product brand.
+func (h *productsHandler) UpdateProductBrand(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product brand ID")
+		return
+	}
+	var req dto.ProductBrandRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.UpdateProductBrand(r.Context(), req.ToUpdateProductBrandParams(id))
+	if err != nil {
+		writeCatalogError(w, err, "Product brand not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// SetProductBrandActive activates or deactivates a product brand.
+func (h *productsHandler) SetProductBrandActive(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product brand ID")
+		return
+	}
+	var req dto.CatalogActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.SetProductBrandActive(r.Context(), &products.SetProductBrandActiveParams{
+		ID:       id,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		writeCatalogError(w, err, "Product brand not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// CreateProductType creates a new product type.
+func (h *productsHandler) CreateProductType(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProductTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.CreateProductType(r.Context(), req.ToCreateProductTypeParams())
+	if err != nil {
+		writeCatalogError(w, err, "Product type not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, level)
+}
+
+// UpdateProductType updates an existing product type.
+func (h *productsHandler) UpdateProductType(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product type ID")
+		return
+	}
+	var req dto.ProductTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.UpdateProductType(r.Context(), req.ToUpdateProductTypeParams(id))
+	if err != nil {
+		writeCatalogError(w, err, "Product type not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// SetProductTypeActive activates or deactivates a product type.
+func (h *productsHandler) SetProductTypeActive(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product type ID")
+		return
+	}
+	var req dto.CatalogActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.SetProductTypeActive(r.Context(), &products.SetProductTypeActiveParams{
+		ID:       id,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		writeCatalogError(w, err, "Product type not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// CreateProductSeries creates a new product series.
+func (h *productsHandler) CreateProductSeries(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProductSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.CreateProductSeries(r.Context(), req.ToCreateProductSeriesParams())
+	if err != nil {
+		writeCatalogError(w, err, "Product series not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, level)
+}
+
+// UpdateProductSeries updates an existing product series.
+func (h *productsHandler) UpdateProductSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product series ID")
+		return
+	}
+	var req dto.ProductSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.UpdateProductSeries(r.Context(), req.ToUpdateProductSeriesParams(id))
+	if err != nil {
+		writeCatalogError(w, err, "Product series not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// SetProductSeriesActive activates or deactivates a product series.
+func (h *productsHandler) SetProductSeriesActive(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product series ID")
+		return
+	}
+	var req dto.CatalogActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.SetProductSeriesActive(r.Context(), &products.SetProductSeriesActiveParams{
+		ID:       id,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		writeCatalogError(w, err, "Product series not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// CreateProductName creates a new product name.
+func (h *productsHandler) CreateProductName(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProductNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.CreateProductName(r.Context(), req.ToCreateProductNameParams())
+	if err != nil {
+		writeCatalogError(w, err, "Product name not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, level)
+}
+
+// UpdateProductName updates an existing product name.
+func (h *productsHandler) UpdateProductName(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product name ID")
+		return
+	}
+	var req dto.ProductNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.UpdateProductName(r.Context(), req.ToUpdateProductNameParams(id))
+	if err != nil {
+		writeCatalogError(w, err, "Product name not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// SetProductNameActive activates or deactivates a product name.
+func (h *productsHandler) SetProductNameActive(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid product name ID")
+		return
+	}
+	var req dto.CatalogActiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	level, err := h.productsService.SetProductNameActive(r.Context(), &products.SetProductNameActiveParams{
+		ID:       id,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		writeCatalogError(w, err, "Product name not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, level)
+}
+
+// includeInactive reports whether the includeInactive query parameter asks for deactivated
+// catalog levels as well.
+func includeInactive(r *http.Request) bool {
+	v, err := strconv.ParseBool(r.URL.Query().Get("includeInactive"))
+	return err == nil && v
+}
+
+// writeCatalogError maps a catalog service error to an HTTP error response.
+func writeCatalogError(w http.ResponseWriter, err error, notFound string) {
+	switch {
+	case errors.Is(err, services.ErrCatalogNameTaken), errors.Is(err, services.ErrCatalogLevelInUse):
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrCatalogNameRequired), errors.Is(err, services.ErrCatalogParentInvalid):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, notFound)
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to save product catalog")
+	}
+}
+
+// writeProductError maps a product service error to an HTTP error response.
+func writeProductError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrProductHierarchyMismatch), errors.Is(err, services.ErrCatalogParentInvalid),
+		errors.Is(err, services.ErrInsufficientStock), errors.Is(err, services.ErrInvalidUnitOfMeasure):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Product not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, message)
+	}
+}
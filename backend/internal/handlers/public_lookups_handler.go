@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/publiclookups"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// PublicLookupsHandler defines the HTTP contract for the unauthenticated warranty lookup and its logs.
+type PublicLookupsHandler interface {
+	// SearchWarranties returns redacted warranties matching a warranty number or plate number.
+	SearchWarranties(w http.ResponseWriter, r *http.Request)
+
+	// RecordBlockedLookup logs a lookup rejected by the public lookup limiter.
+	RecordBlockedLookup(r *http.Request, outcome string)
+
+	// ListPublicLookupLogs returns recent public lookups, optionally for a single IP address.
+	ListPublicLookupLogs(w http.ResponseWriter, r *http.Request)
+
+	// ListPublicLookupSummaries returns public lookup counts per IP address.
+	ListPublicLookupSummaries(w http.ResponseWriter, r *http.Request)
+}
+
+type publicLookupsHandler struct {
+	publicLookupsService services.PublicLookupsService
+	warrantiesService    services.WarrantiesService
+}
+
+// NewPublicLookupsHandler creates a new PublicLookupsHandler instance.
+func NewPublicLookupsHandler(publicLookupsService services.PublicLookupsService, warrantiesService services.WarrantiesService) PublicLookupsHandler {
+	return &publicLookupsHandler{
+		publicLookupsService: publicLookupsService,
+		warrantiesService:    warrantiesService,
+	}
+}
+
+// SearchWarranties returns redacted warranties matching a warranty number or plate number.
+func (h *publicLookupsHandler) SearchWarranties(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	result := []dto.PublicWarrantySearchResponse{}
+
+	searchTerm := strings.TrimSpace(chi.URLParam(r, "search_term"))
+	if searchTerm == "" {
+		// return empty list if search term is empty
+		utils.NewHTTPSuccessResponse(w, http.StatusOK, result)
+		return
+	}
+
+	warranties, err := h.warrantiesService.GetWarrantiesByExactSearch(ctx, searchTerm)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to search warranties")
+		return
+	}
+	for _, warranty := range warranties {
+		parts, err := h.warrantiesService.GetWarrantyPartsByWarrantyID(ctx, warranty.ID)
+		if err != nil {
+			utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to search warranties")
+			return
+		}
+		result = append(result, dto.NewPublicWarrantySearchResponse(warranty, parts))
+	}
+
+	h.recordLookup(r, searchTerm, int32(len(result)), models.PublicLookupOutcomeOK)
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, result)
+}
+
+// RecordBlockedLookup logs a lookup rejected by the public lookup limiter.
+func (h *publicLookupsHandler) RecordBlockedLookup(r *http.Request, outcome string) {
+	h.recordLookup(r, strings.TrimSpace(chi.URLParam(r, "search_term")), 0, outcome)
+}
+
+// recordLookup stores a public lookup. Failures are only logged so that lookups keep working.
+func (h *publicLookupsHandler) recordLookup(r *http.Request, searchTerm string, resultCount int32, outcome string) {
+	err := h.publicLookupsService.RecordPublicLookup(r.Context(), &publiclookups.CreatePublicLookupLogParams{
+		IpAddress:   middlewares.ClientIP(r),
+		UserAgent:   truncate(r.UserAgent(), 512),
+		SearchTerm:  truncate(searchTerm, 255),
+		ResultCount: resultCount,
+		Outcome:     outcome,
+	})
+	if err != nil {
+		log.Printf("Failed to record public lookup: %v", err)
+	}
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
+
+// lookupLogSince parses the optional "since" query parameter (YYYY-MM-DD), defaulting to the last 24 hours.
+func lookupLogSince(r *http.Request) (time.Time, error) {
+	since := r.URL.Query().Get("since")
+	if since == "" {
+		return time.Now().Add(-24 * time.Hour), nil
+	}
+	return utils.ConvertDateStringToStandardFormat(since)
+}
+
+// ListPublicLookupLogs returns recent public lookups, optionally for a single IP address.
+func (h *publicLookupsHandler) ListPublicLookupLogs(w http.ResponseWriter, r *http.Request) {
+	since, err := lookupLogSince(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid since date, expected YYYY-MM-DD")
+		return
+	}
+	logs, err := h.publicLookupsService.ListPublicLookupLogs(r.Context(), since, r.URL.Query().Get("ip"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list public lookups")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, logs)
+}
+
+// ListPublicLookupSummaries returns public lookup counts per IP address.
+func (h *publicLookupsHandler) ListPublicLookupSummaries(w http.ResponseWriter, r *http.Request) {
+	since, err := lookupLogSince(r)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid since date, expected YYYY-MM-DD")
+		return
+	}
+	summaries, err := h.publicLookupsService.ListPublicLookupSummaries(r.Context(), since)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list public lookup summaries")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, summaries)
+}
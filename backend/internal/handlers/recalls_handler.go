@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// RecallsHandler defines the HTTP contract for film recall endpoints.
+type RecallsHandler interface {
+	// ListRecalls returns all recalls with the number of rolls and warranties they cover.
+	ListRecalls(w http.ResponseWriter, r *http.Request)
+
+	// GetRecallImpact returns a recall traced to its allocations, warranty parts, shops and customers.
+	GetRecallImpact(w http.ResponseWriter, r *http.Request)
+
+	// ExportRecallImpact downloads the affected warranty parts of a recall as CSV.
+	ExportRecallImpact(w http.ResponseWriter, r *http.Request)
+
+	// CreateRecall recalls a film roll by serial number or every roll of a shipment.
+	CreateRecall(w http.ResponseWriter, r *http.Request)
+
+	// CloseRecall closes a recall and lifts its install freeze.
+	CloseRecall(w http.ResponseWriter, r *http.Request)
+
+	// NotifyRecallCustomers queues a recall notice for every affected customer.
+	NotifyRecallCustomers(w http.ResponseWriter, r *http.Request)
+}
+
+type recallsHandler struct {
+	recallsService services.RecallsService
+}
+
+// NewRecallsHandler creates a new RecallsHandler instance.
+func NewRecallsHandler(recallsService services.RecallsService) RecallsHandler {
+	return &recallsHandler{
+		recallsService: recallsService,
+	}
+}
+
+// ListRecalls returns all recalls with the number of rolls and warranties they cover.
+func (h *recallsHandler) ListRecalls(w http.ResponseWriter, r *http.Request) {
+	recalls, err := h.recallsService.ListRecalls(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list recalls")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, recalls)
+}
+
+// GetRecallImpact returns a recall traced to its allocations, warranty parts, shops and customers.
+func (h *recallsHandler) GetRecallImpact(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid recall ID")
+		return
+	}
+	impact, err := h.recallsService.GetRecallImpact(r.Context(), id)
+	if err != nil {
+		writeRecallError(w, err, "Failed to trace recall")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, impact)
+}
+
+// ExportRecallImpact downloads the affected warranty parts of a recall as CSV, one row per part,
+// followed by the allocations that still hold unused recalled film.
+func (h *recallsHandler) ExportRecallImpact(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid recall ID")
+		return
+	}
+	impact, err := h.recallsService.GetRecallImpact(r.Context(), id)
+	if err != nil {
+		writeRecallError(w, err, "Failed to trace recall")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="recall-%s-impact.csv"`, impact.Recall.RecallNo))
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Recall No", "Shop", "Warranty No", "Installation Date", "Warranty Status", "Active", "Customer", "Contact", "Email", "Car", "Car Plate No", "Car Part", "Film Serial Number", "Allocation ID"})
+	for _, part := range impact.WarrantyParts {
+		cw.Write([]string{
+			impact.Recall.RecallNo,
+			part.ShopName,
+			part.WarrantyNo,
+			part.InstallationDate.Format(time.DateOnly),
+			string(part.ApprovalStatus),
+			strconv.FormatBool(part.IsActive),
+			part.ClientName,
+			part.ClientContact,
+			part.ClientEmail,
+			part.CarBrand + " " + part.CarModel,
+			part.CarPlateNo,
+			part.CarPartName,
+			part.FilmSerialNumber,
+			strconv.Itoa(int(part.ProductAllocationID)),
+		})
+	}
+
+	cw.Write(nil)
+	cw.Write([]string{"Recall No", "Shop", "Branch Code", "Film Serial Number", "Allocation ID", "Allocation Date", "Allocated Quantity", "Remaining Quantity", "Warranty Parts"})
+	for _, allocation := range impact.Allocations {
+		cw.Write([]string{
+			impact.Recall.RecallNo,
+			allocation.ShopName,
+			allocation.BranchCode,
+			allocation.FilmSerialNumber,
+			strconv.Itoa(int(allocation.ID)),
+			allocation.AllocationDate.Format(time.DateOnly),
+			strconv.FormatFloat(allocation.FilmQuantity, 'f', -1, 64),
+			strconv.FormatFloat(allocation.RemainingQuantity, 'f', -1, 64),
+			strconv.Itoa(int(allocation.WarrantyPartCount)),
+		})
+	}
+	cw.Flush()
+}
+
+// CreateRecall recalls a film roll by serial number or every roll of a shipment. New installs
+// from the recalled film are frozen unless freezeInstalls is false.
+func (h *recallsHandler) CreateRecall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.CreateRecallRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	recall, err := h.recallsService.CreateRecall(ctx, user.UserID, req.FilmSerialNumber, req.ShipmentID, req.Reason, req.FreezeInstallsOrDefault())
+	if err != nil {
+		writeRecallError(w, err, "Failed to create recall")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, recall)
+}
+
+// CloseRecall closes a recall and lifts its install freeze.
+func (h *recallsHandler) CloseRecall(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid recall ID")
+		return
+	}
+	recall, err := h.recallsService.CloseRecall(ctx, user.UserID, id)
+	if err != nil {
+		writeRecallError(w, err, "Failed to close recall")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, recall)
+}
+
+// NotifyRecallCustomers queues a recall notice for every affected customer. Customers are
+// notified once per recall.
+func (h *recallsHandler) NotifyRecallCustomers(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid recall ID")
+		return
+	}
+	notified, err := h.recallsService.NotifyRecallCustomers(r.Context(), id)
+	if err != nil {
+		writeRecallError(w, err, "Failed to notify recall customers")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, &dto.NotifyRecallCustomersResponse{
+		WarrantiesNotified: notified,
+	})
+}
+
+// writeRecallError maps recall service errors to HTTP responses.
+func writeRecallError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidRecall):
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrRecallTargetNotFound):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrRecallClosed), errors.Is(err, services.ErrRecallCustomersNotified):
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Recall not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
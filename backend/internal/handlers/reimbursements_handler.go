@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ReimbursementsHandler defines the HTTP contract for shop reimbursement statement endpoints.
+type ReimbursementsHandler interface {
+	// ListReimbursementStatements returns the reimbursement statements of all shops.
+	ListReimbursementStatements(w http.ResponseWriter, r *http.Request)
+
+	// ListReimbursementStatementsByShopID returns the reimbursement statements of a shop.
+	ListReimbursementStatementsByShopID(w http.ResponseWriter, r *http.Request)
+
+	// GetReimbursementStatementByID returns a reimbursement statement with its lines.
+	GetReimbursementStatementByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateReimbursementStatement bills a shop's claim resolutions over a period.
+	CreateReimbursementStatement(w http.ResponseWriter, r *http.Request)
+
+	// ReviewReimbursementStatement approves or rejects a reimbursement statement.
+	ReviewReimbursementStatement(w http.ResponseWriter, r *http.Request)
+
+	// ExportReimbursementStatement downloads an approved reimbursement statement as CSV.
+	ExportReimbursementStatement(w http.ResponseWriter, r *http.Request)
+}
+
+type reimbursementsHandler struct {
+	reimbursementsService services.ReimbursementsService
+}
+
+// NewReimbursementsHandler creates a new ReimbursementsHandler instance.
+func NewReimbursementsHandler(reimbursementsService services.ReimbursementsService) ReimbursementsHandler {
+	return &reimbursementsHandler{
+		reimbursementsService: reimbursementsService,
+	}
+}
+
+// userCoversShop reports whether the user works for HQ or for the shop.
+func userCoversShop(user *middlewares.Claims, shopID int32) bool {
+	return user.ShopID == nil || *user.ShopID == shopID
+}
+
+// ListReimbursementStatements returns the reimbursement statements of all shops.
+func (h *reimbursementsHandler) ListReimbursementStatements(w http.ResponseWriter, r *http.Request) {
+	statements, err := h.reimbursementsService.ListReimbursementStatements(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reimbursement statements")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, statements)
+}
+
+// ListReimbursementStatementsByShopID returns the reimbursement statements of a shop. Shop users
+// only see their own shop's statements.
+func (h *reimbursementsHandler) ListReimbursementStatementsByShopID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	shopID, err := utils.ConvertParamToInt32(chi.URLParam(r, "shop_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return
+	}
+	if !userCoversShop(user, shopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's statements")
+		return
+	}
+	statements, err := h.reimbursementsService.ListReimbursementStatementsByShopID(ctx, shopID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reimbursement statements")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, statements)
+}
+
+// GetReimbursementStatementByID returns a reimbursement statement with the claim resolutions
+// billed on it.
+func (h *reimbursementsHandler) GetReimbursementStatementByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid statement ID")
+		return
+	}
+	statement, err := h.reimbursementsService.GetReimbursementStatementByID(ctx, id)
+	if err != nil || !userCoversShop(user, statement.ShopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reimbursement statement not found")
+		return
+	}
+	lines, err := h.reimbursementsService.ListReimbursementStatementLines(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reimbursement statement lines")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, &dto.ReimbursementStatementResponse{
+		Statement: statement,
+		Lines:     lines,
+	})
+}
+
+// CreateReimbursementStatement bills a shop's claim resolutions over a period. Shop users may
+// only bill their own shop.
+func (h *reimbursementsHandler) CreateReimbursementStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.CreateReimbursementStatementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	arg, err := req.ToCreateReimbursementStatementParams()
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !userCoversShop(user, arg.ShopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot create statements for another shop")
+		return
+	}
+	statement, err := h.reimbursementsService.CreateReimbursementStatement(ctx, user.UserID, arg)
+	if err != nil {
+		if errors.Is(err, services.ErrReimbursementStatementEmpty) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, statement)
+}
+
+// ReviewReimbursementStatement approves or rejects a pending reimbursement statement.
+func (h *reimbursementsHandler) ReviewReimbursementStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid statement ID")
+		return
+	}
+	var req dto.ReviewReimbursementStatementRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	statement, err := h.reimbursementsService.ReviewReimbursementStatement(ctx, req.ToReviewReimbursementStatementParams(id, user.UserID))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrReimbursementStatementReviewed):
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+		case errors.Is(err, pgx.ErrNoRows):
+			utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reimbursement statement not found")
+		default:
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, statement)
+}
+
+// ExportReimbursementStatement downloads the lines of an approved reimbursement statement as CSV
+// for payment processing.
+func (h *reimbursementsHandler) ExportReimbursementStatement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid statement ID")
+		return
+	}
+	statement, err := h.reimbursementsService.GetReimbursementStatementByID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reimbursement statement not found")
+		return
+	}
+	if statement.Status != models.ReimbursementStatusApproved {
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, "Only approved statements can be exported")
+		return
+	}
+	lines, err := h.reimbursementsService.ListReimbursementStatementLines(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reimbursement statement lines")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="reimbursement-%s-%s.csv"`, statement.BranchCode, statement.PeriodEnd.Format("200601")))
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Shop", "Branch Code", "Claim No", "Warranty No", "Car Plate No", "Car Part", "Resolution Date", "Film Serial Number", "Product", "Quantity Used", "Labour Cost", "Material Cost"})
+	for _, line := range lines {
+		resolutionDate := ""
+		if line.ResolutionDate != nil {
+			resolutionDate = line.ResolutionDate.Format(time.DateOnly)
+		}
+		cw.Write([]string{
+			statement.ShopName,
+			statement.BranchCode,
+			line.ClaimNo,
+			line.WarrantyNo,
+			line.CarPlateNo,
+			line.CarPartName,
+			resolutionDate,
+			line.FilmSerialNumber,
+			line.ProductName,
+			strconv.FormatFloat(line.QuantityUsed, 'f', -1, 64),
+			strconv.FormatFloat(line.LabourCost, 'f', 2, 64),
+			strconv.FormatFloat(line.MaterialCost, 'f', 2, 64),
+		})
+	}
+	cw.Flush()
+}
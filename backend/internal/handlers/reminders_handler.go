@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// RemindersHandler defines the HTTP contract for reminder rule endpoints.
+type RemindersHandler interface {
+	// ListReminderRules returns all reminder rules.
+	ListReminderRules(w http.ResponseWriter, r *http.Request)
+
+	// GetReminderRuleByID returns a single reminder rule by ID.
+	GetReminderRuleByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateReminderRule creates a new reminder rule.
+	CreateReminderRule(w http.ResponseWriter, r *http.Request)
+
+	// UpdateReminderRule updates an existing reminder rule.
+	UpdateReminderRule(w http.ResponseWriter, r *http.Request)
+
+	// ListReminderDeliveriesByRuleID returns the reminders already queued for a rule.
+	ListReminderDeliveriesByRuleID(w http.ResponseWriter, r *http.Request)
+
+	// RunReminderRules runs all active reminder rules immediately.
+	RunReminderRules(w http.ResponseWriter, r *http.Request)
+
+	// ListRenewalLeads returns warranties that received an expiry reminder.
+	ListRenewalLeads(w http.ResponseWriter, r *http.Request)
+
+	// ListRenewalLeadsByShopID returns a shop's warranties that received an expiry reminder.
+	ListRenewalLeadsByShopID(w http.ResponseWriter, r *http.Request)
+}
+
+type remindersHandler struct {
+	remindersService services.RemindersService
+}
+
+// NewRemindersHandler creates a new RemindersHandler instance.
+func NewRemindersHandler(remindersService services.RemindersService) RemindersHandler {
+	return &remindersHandler{
+		remindersService: remindersService,
+	}
+}
+
+// ListReminderRules returns all reminder rules.
+func (h *remindersHandler) ListReminderRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.remindersService.ListReminderRules(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reminder rules")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, rules)
+}
+
+// GetReminderRuleByID returns a single reminder rule by ID.
+func (h *remindersHandler) GetReminderRuleByID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reminder rule ID")
+		return
+	}
+	rule, err := h.remindersService.GetReminderRuleByID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reminder rule not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, rule)
+}
+
+// CreateReminderRule creates a new reminder rule.
+func (h *remindersHandler) CreateReminderRule(w http.ResponseWriter, r *http.Request) {
+	var req *dto.ReminderRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	rule, err := h.remindersService.CreateReminderRule(r.Context(), req.ToCreateReminderRuleParams())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, rule)
+}
+
+// UpdateReminderRule updates an existing reminder rule.
+func (h *remindersHandler) UpdateReminderRule(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reminder rule ID")
+		return
+	}
+	var req *dto.ReminderRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	rule, err := h.remindersService.UpdateReminderRule(r.Context(), req.ToUpdateReminderRuleParams(id))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, rule)
+}
+
+// ListReminderDeliveriesByRuleID returns the reminders already queued for a rule.
+func (h *remindersHandler) ListReminderDeliveriesByRuleID(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reminder rule ID")
+		return
+	}
+	deliveries, err := h.remindersService.ListReminderDeliveriesByRuleID(r.Context(), id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reminder deliveries")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, deliveries)
+}
+
+// RunReminderRules runs all active reminder rules immediately.
+func (h *remindersHandler) RunReminderRules(w http.ResponseWriter, r *http.Request) {
+	results, err := h.remindersService.RunReminderRules(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to run reminder rules")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, results)
+}
+
+// ListRenewalLeads returns warranties that received an expiry reminder.
+func (h *remindersHandler) ListRenewalLeads(w http.ResponseWriter, r *http.Request) {
+	leads, err := h.remindersService.ListRenewalLeads(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list renewal leads")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, leads)
+}
+
+// ListRenewalLeadsByShopID returns a shop's warranties that received an expiry reminder. Shop
+// users may only list their own shop's leads.
+func (h *remindersHandler) ListRenewalLeadsByShopID(w http.ResponseWriter, r *http.Request) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	shopID, err := utils.ConvertParamToInt32(chi.URLParam(r, "shop_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return
+	}
+	if !userCoversShop(user, shopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's renewal leads")
+		return
+	}
+	leads, err := h.remindersService.ListRenewalLeadsByShopID(r.Context(), shopID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list renewal leads by shop ID")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, leads)
+}
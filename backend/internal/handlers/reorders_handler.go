@@ -0,0 +1,408 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reorders"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ReordersHandler defines the HTTP contract for stock threshold, low-stock alert and reorder
+// request endpoints.
+type ReordersHandler interface {
+	// ListStockThresholds returns the stock thresholds of all shops with their current balance.
+	ListStockThresholds(w http.ResponseWriter, r *http.Request)
+
+	// ListShopStockThresholds returns a shop's stock thresholds with its current balance.
+	ListShopStockThresholds(w http.ResponseWriter, r *http.Request)
+
+	// UpsertStockThreshold sets a shop's threshold of a product name.
+	UpsertStockThreshold(w http.ResponseWriter, r *http.Request)
+
+	// DeleteStockThreshold removes a stock threshold and its alerts.
+	DeleteStockThreshold(w http.ResponseWriter, r *http.Request)
+
+	// ListLowStockAlerts returns the low-stock alerts of all shops.
+	ListLowStockAlerts(w http.ResponseWriter, r *http.Request)
+
+	// ListShopLowStockAlerts returns a shop's low-stock alerts.
+	ListShopLowStockAlerts(w http.ResponseWriter, r *http.Request)
+
+	// CheckLowStock runs the low-stock check immediately.
+	CheckLowStock(w http.ResponseWriter, r *http.Request)
+
+	// ListReorderRequests returns the reorder requests of all shops.
+	ListReorderRequests(w http.ResponseWriter, r *http.Request)
+
+	// ListShopReorderRequests returns a shop's reorder requests.
+	ListShopReorderRequests(w http.ResponseWriter, r *http.Request)
+
+	// GetReorderRequestByID returns a reorder request.
+	GetReorderRequestByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateReorderRequest records a shop's request for more film.
+	CreateReorderRequest(w http.ResponseWriter, r *http.Request)
+
+	// CancelReorderRequest withdraws a pending reorder request.
+	CancelReorderRequest(w http.ResponseWriter, r *http.Request)
+
+	// RejectReorderRequest declines a pending reorder request.
+	RejectReorderRequest(w http.ResponseWriter, r *http.Request)
+
+	// ApproveReorderRequest fills a pending reorder request with a product allocation.
+	ApproveReorderRequest(w http.ResponseWriter, r *http.Request)
+}
+
+type reordersHandler struct {
+	reordersService services.ReordersService
+}
+
+// NewReordersHandler creates a new ReordersHandler instance.
+func NewReordersHandler(reordersService services.ReordersService) ReordersHandler {
+	return &reordersHandler{
+		reordersService: reordersService,
+	}
+}
+
+// ListStockThresholds returns the stock thresholds of all shops with their current balance. The
+// shopId query parameter narrows the list to a shop.
+func (h *reordersHandler) ListStockThresholds(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := optionalShopQuery(w, r)
+	if !ok {
+		return
+	}
+	thresholds, err := h.reordersService.ListStockThresholds(r.Context(), shopID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list stock thresholds")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, thresholds)
+}
+
+// ListShopStockThresholds returns a shop's stock thresholds with its current balance. Shop users
+// only see their own shop.
+func (h *reordersHandler) ListShopStockThresholds(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := h.shopParam(w, r)
+	if !ok {
+		return
+	}
+	thresholds, err := h.reordersService.ListStockThresholds(r.Context(), &shopID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list stock thresholds")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, thresholds)
+}
+
+// UpsertStockThreshold sets a shop's threshold of a product name, replacing any existing one.
+func (h *reordersHandler) UpsertStockThreshold(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpsertStockThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	threshold, err := h.reordersService.UpsertStockThreshold(r.Context(), req.ToUpsertStockThresholdParams())
+	if err != nil {
+		writeReorderError(w, err, "Failed to save stock threshold")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, threshold)
+}
+
+// DeleteStockThreshold removes a stock threshold and its alerts.
+func (h *reordersHandler) DeleteStockThreshold(w http.ResponseWriter, r *http.Request) {
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid threshold ID")
+		return
+	}
+	if err := h.reordersService.DeleteStockThreshold(r.Context(), id); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to delete stock threshold")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, map[string]string{"message": "Stock threshold deleted"})
+}
+
+// ListLowStockAlerts returns the low-stock alerts of all shops, newest first. The shopId query
+// parameter narrows the list to a shop and open=true keeps only open alerts.
+func (h *reordersHandler) ListLowStockAlerts(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := optionalShopQuery(w, r)
+	if !ok {
+		return
+	}
+	openOnly, _ := strconv.ParseBool(r.URL.Query().Get("open"))
+	alerts, err := h.reordersService.ListLowStockAlerts(r.Context(), &reorders.ListLowStockAlertsParams{ShopID: shopID, OpenOnly: openOnly})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list low-stock alerts")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, alerts)
+}
+
+// ListShopLowStockAlerts returns a shop's low-stock alerts, newest first. Shop users only see
+// their own shop.
+func (h *reordersHandler) ListShopLowStockAlerts(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := h.shopParam(w, r)
+	if !ok {
+		return
+	}
+	openOnly, _ := strconv.ParseBool(r.URL.Query().Get("open"))
+	alerts, err := h.reordersService.ListLowStockAlerts(r.Context(), &reorders.ListLowStockAlertsParams{ShopID: &shopID, OpenOnly: openOnly})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list low-stock alerts")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, alerts)
+}
+
+// CheckLowStock runs the low-stock check immediately.
+func (h *reordersHandler) CheckLowStock(w http.ResponseWriter, r *http.Request) {
+	result, err := h.reordersService.CheckLowStock(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to check low stock")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, result)
+}
+
+// ListReorderRequests returns the reorder requests of all shops, newest first. The shopId and
+// status query parameters narrow the list.
+func (h *reordersHandler) ListReorderRequests(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := optionalShopQuery(w, r)
+	if !ok {
+		return
+	}
+	requests, err := h.reordersService.ListReorderRequests(r.Context(), &reorders.ListReorderRequestsParams{
+		ShopID: shopID,
+		Status: reorderStatusQuery(r),
+	})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reorder requests")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, requests)
+}
+
+// ListShopReorderRequests returns a shop's reorder requests, newest first. Shop users only see
+// their own shop.
+func (h *reordersHandler) ListShopReorderRequests(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := h.shopParam(w, r)
+	if !ok {
+		return
+	}
+	requests, err := h.reordersService.ListReorderRequests(r.Context(), &reorders.ListReorderRequestsParams{
+		ShopID: &shopID,
+		Status: reorderStatusQuery(r),
+	})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list reorder requests")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, requests)
+}
+
+// GetReorderRequestByID returns a reorder request. Shop users only see their own shop's requests.
+func (h *reordersHandler) GetReorderRequestByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reorder request ID")
+		return
+	}
+	request, err := h.reordersService.GetReorderRequestByID(ctx, id)
+	if err != nil || !userCoversShop(user, request.ShopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reorder request not found")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, request)
+}
+
+// CreateReorderRequest records a shop's request for more film. Shop users always request for
+// their own shop; HQ users must give the shop.
+func (h *reordersHandler) CreateReorderRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.CreateReorderRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	shopID := req.ShopID
+	if user.ShopID != nil {
+		shopID = user.ShopID
+	}
+	if shopID == nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Shop ID is required")
+		return
+	}
+	request, err := h.reordersService.CreateReorderRequest(ctx, user.UserID, req.ToCreateReorderRequestParams(*shopID))
+	if err != nil {
+		writeReorderError(w, err, "Failed to create reorder request")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, request)
+}
+
+// CancelReorderRequest withdraws a pending reorder request. Shop users may only cancel their own
+// shop's requests.
+func (h *reordersHandler) CancelReorderRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reorder request ID")
+		return
+	}
+	existing, err := h.reordersService.GetReorderRequestByID(ctx, id)
+	if err != nil || !userCoversShop(user, existing.ShopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reorder request not found")
+		return
+	}
+	request, err := h.reordersService.CancelReorderRequest(ctx, user.UserID, id)
+	if err != nil {
+		writeReorderError(w, err, "Failed to cancel reorder request")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, request)
+}
+
+// RejectReorderRequest declines a pending reorder request.
+func (h *reordersHandler) RejectReorderRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reorder request ID")
+		return
+	}
+	var req dto.RejectReorderRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	request, err := h.reordersService.RejectReorderRequest(ctx, user.UserID, id, req.Remarks)
+	if err != nil {
+		writeReorderError(w, err, "Failed to reject reorder request")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, request)
+}
+
+// ApproveReorderRequest fills a pending reorder request by allocating film of the chosen roll to
+// the shop. The quantity defaults to the quantity requested and the allocation date to today.
+func (h *reordersHandler) ApproveReorderRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid reorder request ID")
+		return
+	}
+	var req dto.ApproveReorderRequestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	allocationDate, err := req.AllocationDateOrToday()
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	request, err := h.reordersService.ApproveReorderRequest(ctx, user.UserID, id, &services.ReorderApproval{
+		ProductID:      req.ProductID,
+		FilmQuantity:   req.FilmQuantity,
+		AllocationDate: allocationDate,
+		Remarks:        req.Remarks,
+	})
+	if err != nil {
+		writeReorderError(w, err, "Failed to approve reorder request")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, request)
+}
+
+// shopParam reads the shop_id URL parameter and checks that the user may access the shop.
+func (h *reordersHandler) shopParam(w http.ResponseWriter, r *http.Request) (int32, bool) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, false
+	}
+	shopID, err := utils.ConvertParamToInt32(chi.URLParam(r, "shop_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return 0, false
+	}
+	if !userCoversShop(user, shopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's stock")
+		return 0, false
+	}
+	return shopID, true
+}
+
+// optionalShopQuery reads the optional shopId query parameter.
+func optionalShopQuery(w http.ResponseWriter, r *http.Request) (*int32, bool) {
+	v := r.URL.Query().Get("shopId")
+	if v == "" {
+		return nil, true
+	}
+	shopID, err := utils.ConvertParamToInt32(v)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return nil, false
+	}
+	return &shopID, true
+}
+
+// reorderStatusQuery reads the optional status query parameter.
+func reorderStatusQuery(r *http.Request) *string {
+	if status := r.URL.Query().Get("status"); status != "" {
+		return &status
+	}
+	return nil
+}
+
+// writeReorderError maps reorder service errors to HTTP responses.
+func writeReorderError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidStockThreshold), errors.Is(err, services.ErrInvalidReorderRequest),
+		errors.Is(err, services.ErrInvalidReorderApproval), errors.Is(err, services.ErrInvalidInventoryQuantity),
+		errors.Is(err, services.ErrInsufficientStock), errors.Is(err, services.ErrProductRecalled):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrReorderRequestReviewed):
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Reorder request not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// shipmentImportMaxSize limits the size of an uploaded packing list.
+const shipmentImportMaxSize = 10 << 20
+
+// ShipmentsHandler defines the HTTP contract for supplier shipment endpoints.
+type ShipmentsHandler interface {
+	// ListShipments returns all shipments with their line counts.
+	ListShipments(w http.ResponseWriter, r *http.Request)
+
+	// GetShipmentByID returns a shipment with its lines.
+	GetShipmentByID(w http.ResponseWriter, r *http.Request)
+
+	// CreateShipment records a pending shipment with its lines.
+	CreateShipment(w http.ResponseWriter, r *http.Request)
+
+	// ReceiveShipment turns the lines of a pending shipment into products in HQ stock.
+	ReceiveShipment(w http.ResponseWriter, r *http.Request)
+
+	// ImportShipments loads a supplier packing list uploaded as CSV.
+	ImportShipments(w http.ResponseWriter, r *http.Request)
+}
+
+type shipmentsHandler struct {
+	shipmentsService services.ShipmentsService
+}
+
+// NewShipmentsHandler creates a new ShipmentsHandler instance.
+func NewShipmentsHandler(shipmentsService services.ShipmentsService) ShipmentsHandler {
+	return &shipmentsHandler{
+		shipmentsService: shipmentsService,
+	}
+}
+
+// ListShipments returns all shipments with their line counts.
+func (h *shipmentsHandler) ListShipments(w http.ResponseWriter, r *http.Request) {
+	shipments, err := h.shipmentsService.ListShipments(r.Context())
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list shipments")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, shipments)
+}
+
+// GetShipmentByID returns a shipment with its lines.
+func (h *shipmentsHandler) GetShipmentByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shipment ID")
+		return
+	}
+	shipment, err := h.shipmentsService.GetShipmentByID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Shipment not found")
+		return
+	}
+	lines, err := h.shipmentsService.ListShipmentLines(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list shipment lines")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, &dto.ShipmentResponse{
+		Shipment: shipment,
+		Lines:    lines,
+	})
+}
+
+// CreateShipment records a pending shipment with its lines.
+func (h *shipmentsHandler) CreateShipment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.CreateShipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	arg, lines, err := req.ToCreateShipmentParamsAndLines()
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	shipment, err := h.shipmentsService.CreateShipment(ctx, user.UserID, arg, lines)
+	if err != nil {
+		writeShipmentError(w, err, "Failed to create shipment")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, shipment)
+}
+
+// ReceiveShipment turns the lines of a pending shipment into products in HQ stock. The received
+// date defaults to today.
+func (h *shipmentsHandler) ReceiveShipment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shipment ID")
+		return
+	}
+	var req dto.ReceiveShipmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	receivedDate, err := req.ReceivedDateOrToday()
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	shipment, err := h.shipmentsService.ReceiveShipment(ctx, user.UserID, id, receivedDate)
+	if err != nil {
+		writeShipmentError(w, err, "Failed to receive shipment")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, shipment)
+}
+
+// ImportShipments loads a supplier packing list uploaded as the "file" form field. With the
+// dryRun query parameter set to true the rows are only validated. The report is returned
+// with 422 when any row was rejected, in which case nothing is imported.
+func (h *shipmentsHandler) ImportShipments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	if err := r.ParseMultipartForm(shipmentImportMaxSize); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Failed to parse multipart form")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Failed to get file from form")
+		return
+	}
+	defer file.Close()
+	if header.Size > shipmentImportMaxSize {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "File size exceeds 10MB limit")
+		return
+	}
+
+	report, err := h.shipmentsService.ImportShipments(ctx, user.UserID, file, dryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidShipmentImport) {
+			utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to import shipments")
+		return
+	}
+	if report.Errors > 0 {
+		utils.NewHTTPSuccessResponse(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, report)
+}
+
+// writeShipmentError maps shipment service errors to HTTP responses.
+func writeShipmentError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrShipmentNumberTaken), errors.Is(err, services.ErrShipmentSerialTaken),
+		errors.Is(err, services.ErrShipmentReceived):
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInvalidShipment), errors.Is(err, services.ErrInvalidShipmentLine),
+		errors.Is(err, services.ErrCatalogParentInvalid), errors.Is(err, services.ErrProductHierarchyMismatch):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Shipment not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/stocktakes"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// StockTakesHandler defines the HTTP contract for shop stock-take endpoints.
+type StockTakesHandler interface {
+	// ListStockTakes returns the stock takes of all shops.
+	ListStockTakes(w http.ResponseWriter, r *http.Request)
+
+	// ListShopStockTakes returns a shop's stock takes.
+	ListShopStockTakes(w http.ResponseWriter, r *http.Request)
+
+	// GetStockTakeByID returns a stock take with its lines and variances.
+	GetStockTakeByID(w http.ResponseWriter, r *http.Request)
+
+	// ExportStockTakeVariances downloads the variance report of a stock take as CSV.
+	ExportStockTakeVariances(w http.ResponseWriter, r *http.Request)
+
+	// OpenStockTake starts a shop's monthly stock take.
+	OpenStockTake(w http.ResponseWriter, r *http.Request)
+
+	// RecordStockTakeCounts records physical counts on an open stock take.
+	RecordStockTakeCounts(w http.ResponseWriter, r *http.Request)
+
+	// SubmitStockTake hands a counted stock take to HQ for review.
+	SubmitStockTake(w http.ResponseWriter, r *http.Request)
+
+	// CancelStockTake abandons an open stock take.
+	CancelStockTake(w http.ResponseWriter, r *http.Request)
+
+	// DiscardStockTake abandons a submitted stock take.
+	DiscardStockTake(w http.ResponseWriter, r *http.Request)
+
+	// ReviewStockTakeLine approves or rejects a flagged variance.
+	ReviewStockTakeLine(w http.ResponseWriter, r *http.Request)
+
+	// PostStockTake posts the approved variances of a stock take as adjustments.
+	PostStockTake(w http.ResponseWriter, r *http.Request)
+}
+
+type stockTakesHandler struct {
+	stockTakesService services.StockTakesService
+}
+
+// NewStockTakesHandler creates a new StockTakesHandler instance.
+func NewStockTakesHandler(stockTakesService services.StockTakesService) StockTakesHandler {
+	return &stockTakesHandler{
+		stockTakesService: stockTakesService,
+	}
+}
+
+// ListStockTakes returns the stock takes of all shops, newest period first. The shopId and
+// status query parameters narrow the list.
+func (h *stockTakesHandler) ListStockTakes(w http.ResponseWriter, r *http.Request) {
+	shopID, ok := optionalShopQuery(w, r)
+	if !ok {
+		return
+	}
+	stockTakes, err := h.stockTakesService.ListStockTakes(r.Context(), &stocktakes.ListStockTakesParams{
+		ShopID: shopID,
+		Status: reorderStatusQuery(r),
+	})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list stock takes")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, stockTakes)
+}
+
+// ListShopStockTakes returns a shop's stock takes, newest period first. Shop users only see
+// their own shop.
+func (h *stockTakesHandler) ListShopStockTakes(w http.ResponseWriter, r *http.Request) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	shopID, err := utils.ConvertParamToInt32(chi.URLParam(r, "shop_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid shop ID")
+		return
+	}
+	if !userCoversShop(user, shopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusForbidden, "Cannot access another shop's stock takes")
+		return
+	}
+	stockTakes, err := h.stockTakesService.ListStockTakes(r.Context(), &stocktakes.ListStockTakesParams{
+		ShopID: &shopID,
+		Status: reorderStatusQuery(r),
+	})
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, "Failed to list stock takes")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, stockTakes)
+}
+
+// GetStockTakeByID returns a stock take with its lines, counts and variances. Shop users only
+// see their own shop's stock takes.
+func (h *stockTakesHandler) GetStockTakeByID(w http.ResponseWriter, r *http.Request) {
+	report, ok := h.stockTakeReport(w, r)
+	if !ok {
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, report)
+}
+
+// ExportStockTakeVariances downloads the variance report of a stock take as CSV, one row per
+// counted allocation. Quantities are in rolls; the count as entered is given alongside.
+func (h *stockTakesHandler) ExportStockTakeVariances(w http.ResponseWriter, r *http.Request) {
+	report, ok := h.stockTakeReport(w, r)
+	if !ok {
+		return
+	}
+	stockTake := report.StockTake
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="stock-take-%s-variances.csv"`, stockTake.StockTakeNo))
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"Stock Take No", "Period", "Status", "Film Serial Number", "Product", "Allocation ID", "Allocation Date", "System Quantity", "Counted Quantity", "Counted As", "Variance", "Variance %", "Flagged", "Review Status", "Adjusted Quantity", "Remarks", "Review Remarks"})
+	for _, line := range report.Lines {
+		countedAs := ""
+		if line.UnitQuantity != nil && line.UnitOfMeasure != nil {
+			countedAs = strconv.FormatFloat(*line.UnitQuantity, 'f', -1, 64) + " " + *line.UnitOfMeasure
+		}
+		variancePercent := ""
+		if line.VarianceQuantity != nil && line.SystemQuantity != 0 {
+			variancePercent = strconv.FormatFloat(*line.VarianceQuantity/line.SystemQuantity*100, 'f', 2, 64)
+		}
+		cw.Write([]string{
+			stockTake.StockTakeNo,
+			stockTake.Period.Format("2006-01"),
+			stockTake.Status,
+			line.FilmSerialNumber,
+			line.ProductName,
+			strconv.Itoa(int(line.ProductAllocationID)),
+			line.AllocationDate.Format(time.DateOnly),
+			strconv.FormatFloat(line.SystemQuantity, 'f', -1, 64),
+			optionalQuantity(line.CountedQuantity),
+			countedAs,
+			optionalQuantity(line.VarianceQuantity),
+			variancePercent,
+			strconv.FormatBool(line.IsFlagged),
+			line.ReviewStatus,
+			optionalQuantity(line.AdjustedQuantity),
+			optionalText(line.Remarks),
+			optionalText(line.ReviewRemarks),
+		})
+	}
+	cw.Flush()
+}
+
+// OpenStockTake starts a shop's stock take of a month, snapshotting the film left on each of its
+// allocations. Shop users always open their own shop's stock take; HQ users must give the shop.
+func (h *stockTakesHandler) OpenStockTake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	var req dto.OpenStockTakeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	shopID := req.ShopID
+	if user.ShopID != nil {
+		shopID = user.ShopID
+	}
+	if shopID == nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Shop ID is required")
+		return
+	}
+	arg, err := req.ToCreateStockTakeParams(*shopID)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	stockTake, err := h.stockTakesService.OpenStockTake(ctx, user.UserID, arg)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to open stock take")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusCreated, stockTake)
+}
+
+// RecordStockTakeCounts records physical counts on an open stock take, each picked by its
+// allocation or film serial number. Shop users may only count their own shop's stock takes.
+func (h *stockTakesHandler) RecordStockTakeCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, id, _, ok := h.stockTakeParam(w, r)
+	if !ok {
+		return
+	}
+	var req dto.RecordStockTakeCountsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	counts := make([]*services.StockTakeCount, 0, len(req.Counts))
+	for _, c := range req.Counts {
+		counts = append(counts, &services.StockTakeCount{
+			ProductAllocationID: c.ProductAllocationID,
+			FilmSerialNumber:    c.FilmSerialNumber,
+			UnitOfMeasure:       c.UnitOfMeasure,
+			Quantity:            c.Quantity,
+			Remarks:             c.Remarks,
+		})
+	}
+	lines, err := h.stockTakesService.RecordStockTakeCounts(ctx, user.UserID, id, counts)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to record stock take counts")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, lines)
+}
+
+// SubmitStockTake hands a fully counted stock take to HQ. Shop users may only submit their own
+// shop's stock takes.
+func (h *stockTakesHandler) SubmitStockTake(w http.ResponseWriter, r *http.Request) {
+	user, id, _, ok := h.stockTakeParam(w, r)
+	if !ok {
+		return
+	}
+	stockTake, err := h.stockTakesService.SubmitStockTake(r.Context(), user.UserID, id)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to submit stock take")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, stockTake)
+}
+
+// CancelStockTake abandons an open stock take. Shop users may only cancel their own shop's
+// stock takes; once submitted, only HQ can discard them.
+func (h *stockTakesHandler) CancelStockTake(w http.ResponseWriter, r *http.Request) {
+	user, id, _, ok := h.stockTakeParam(w, r)
+	if !ok {
+		return
+	}
+	stockTake, err := h.stockTakesService.CancelStockTake(r.Context(), user.UserID, id)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to cancel stock take")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, stockTake)
+}
+
+// DiscardStockTake abandons a submitted stock take instead of posting it.
+func (h *stockTakesHandler) DiscardStockTake(w http.ResponseWriter, r *http.Request) {
+	user, id, _, ok := h.stockTakeParam(w, r)
+	if !ok {
+		return
+	}
+	stockTake, err := h.stockTakesService.DiscardStockTake(r.Context(), user.UserID, id)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to discard stock take")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, stockTake)
+}
+
+// ReviewStockTakeLine approves or rejects the flagged variance of a submitted stock take.
+func (h *stockTakesHandler) ReviewStockTakeLine(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+	lineID, err := utils.ConvertParamToInt32(chi.URLParam(r, "line_id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid stock take line ID")
+		return
+	}
+	var req dto.ReviewStockTakeLineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	line, err := h.stockTakesService.ReviewStockTakeLine(ctx, user.UserID, id, lineID, req.Approve, req.Remarks)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to review stock take line")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, line)
+}
+
+// PostStockTake posts the approved variances of a reviewed stock take as inventory adjustments.
+func (h *stockTakesHandler) PostStockTake(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid stock take ID")
+		return
+	}
+	stockTake, err := h.stockTakesService.PostStockTake(ctx, user.UserID, id)
+	if err != nil {
+		writeStockTakeError(w, err, "Failed to post stock take")
+		return
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, stockTake)
+}
+
+// stockTakeParam reads the id URL parameter and checks that the user may access the stock take's
+// shop.
+func (h *stockTakesHandler) stockTakeParam(w http.ResponseWriter, r *http.Request) (*middlewares.Claims, int32, *stocktakes.StockTake, bool) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, 0, nil, false
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid stock take ID")
+		return nil, 0, nil, false
+	}
+	stockTake, err := h.stockTakesService.GetStockTakeByID(r.Context(), id)
+	if err != nil || !userCoversShop(user, stockTake.ShopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Stock take not found")
+		return nil, 0, nil, false
+	}
+	return user, id, stockTake, true
+}
+
+// stockTakeReport loads the stock take of the id URL parameter with its lines, for a user who
+// may access its shop.
+func (h *stockTakesHandler) stockTakeReport(w http.ResponseWriter, r *http.Request) (*services.StockTakeReport, bool) {
+	user, ok := middlewares.GetUserFromContext(r.Context())
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return nil, false
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid stock take ID")
+		return nil, false
+	}
+	report, err := h.stockTakesService.GetStockTakeReport(r.Context(), id)
+	if err != nil || !userCoversShop(user, report.StockTake.ShopID) {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Stock take not found")
+		return nil, false
+	}
+	return report, true
+}
+
+// optionalQuantity formats a quantity that may not be recorded yet.
+func optionalQuantity(quantity *float64) string {
+	if quantity == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*quantity, 'f', -1, 64)
+}
+
+// optionalText returns the text or an empty string.
+func optionalText(text *string) string {
+	if text == nil {
+		return ""
+	}
+	return *text
+}
+
+// writeStockTakeError maps stock take service errors to HTTP responses.
+func writeStockTakeError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidStockTake), errors.Is(err, services.ErrInvalidStockTakeCount),
+		errors.Is(err, services.ErrStockTakeIncomplete), errors.Is(err, services.ErrInvalidUnitOfMeasure),
+		errors.Is(err, services.ErrInsufficientStock):
+		utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrStockTakeExists), errors.Is(err, services.ErrStockTakeLocked):
+		utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, pgx.ErrNoRows):
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Stock take not found")
+	default:
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, fallback)
+	}
+}
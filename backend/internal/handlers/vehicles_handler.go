@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/vehicles"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/handlers/dto"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/middlewares"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/services"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// VehiclesHandler defines the HTTP contract for vehicle endpoints.
+type VehiclesHandler interface {
+	// GetVehicleDetailsByID returns a vehicle with its plate history, warranties and claims.
+	// Shop users only see vehicles their shop has installed on, with their own shop's warranties
+	// and claims.
+	GetVehicleDetailsByID(w http.ResponseWriter, r *http.Request)
+}
+
+type vehiclesHandler struct {
+	vehiclesService services.VehiclesService
+}
+
+// NewVehiclesHandler creates a new VehiclesHandler instance.
+func NewVehiclesHandler(vehiclesService services.VehiclesService) VehiclesHandler {
+	return &vehiclesHandler{
+		vehiclesService: vehiclesService,
+	}
+}
+
+// GetVehicleDetailsByID returns a vehicle with its plate history, warranties and claims.
+// Shop users only see vehicles their shop has installed on, with their own shop's warranties
+// and claims.
+func (h *vehiclesHandler) GetVehicleDetailsByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := middlewares.GetUserFromContext(ctx)
+	if !ok {
+		utils.NewHTTPErrorResponse(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	id, err := utils.ConvertParamToInt32(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusBadRequest, "Invalid vehicle ID")
+		return
+	}
+
+	vehicle, err := h.vehiclesService.GetVehicleByID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Vehicle not found")
+		return
+	}
+	all, err := h.vehiclesService.ListWarrantiesByVehicleID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	warranties := make([]*vehicles.ListWarrantiesByVehicleIDRow, 0, len(all))
+	warrantyIDs := make(map[int32]bool, len(all))
+	for _, warranty := range all {
+		if userCoversShop(user, warranty.ShopID) {
+			warranties = append(warranties, warranty)
+			warrantyIDs[warranty.ID] = true
+		}
+	}
+	if user.ShopID != nil && len(warranties) == 0 {
+		utils.NewHTTPErrorResponse(w, http.StatusNotFound, "Vehicle not found")
+		return
+	}
+	plates, err := h.vehiclesService.ListVehiclePlatesByVehicleID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	allClaims, err := h.vehiclesService.ListClaimsByVehicleID(ctx, id)
+	if err != nil {
+		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	claims := make([]*vehicles.ClaimView, 0, len(allClaims))
+	for _, claim := range allClaims {
+		if warrantyIDs[claim.WarrantyID] {
+			claims = append(claims, claim)
+		}
+	}
+
+	response := dto.VehicleDetailsResponse{
+		Vehicle:    vehicle,
+		Plates:     plates,
+		Warranties: warranties,
+		Claims:     claims,
+	}
+	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
+}
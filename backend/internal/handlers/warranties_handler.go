@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -17,7 +18,6 @@ type WarrantiesHandler interface {
 	// CreateWarranty(w http.ResponseWriter, r *http.Request)
 	UpdateWarrantyApproval(w http.ResponseWriter, r *http.Request)
 
-	GetWarrantiesByExactSearch(w http.ResponseWriter, r *http.Request)
 	GetWarrantyWithPartsByID(w http.ResponseWriter, r *http.Request)
 
 	GetWarrantiesWithPartsByShopID(w http.ResponseWriter, r *http.Request)
@@ -117,6 +117,10 @@ func (h *warrantiesHandler) UpdateWarrantyApproval(w http.ResponseWriter, r *htt
 	params := req.ToUpdateWarrantyApprovalParams(id)
 	warranty, err := h.warrantiesService.UpdateWarrantyApproval(ctx, params)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidApprovalStatus) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -152,44 +156,6 @@ func (h *warrantiesHandler) GetWarrantyWithPartsByID(w http.ResponseWriter, r *h
 	utils.NewHTTPSuccessResponse(w, http.StatusOK, response)
 }
 
-// GetWarrantiesByExactSearch returns warranties matching an exact search term.
-func (h *warrantiesHandler) GetWarrantiesByExactSearch(w http.ResponseWriter, r *http.Request) {
-	var result []dto.WarrantyByExactSearchResponse
-	warrantyMap := make(map[int32]*dto.WarrantyByExactSearchResponse)
-	ctx := r.Context()
-	// Get search term from URL query parameter
-	searchTerm := chi.URLParam(r, "search_term")
-	if searchTerm == "" {
-		// return empty list if search term is empty
-		utils.NewHTTPSuccessResponse(w, http.StatusOK, result)
-		return
-	}
-	warranties, err := h.warrantiesService.GetWarrantiesByExactSearch(ctx, searchTerm)
-	if err != nil {
-		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	// Return the warranties with parts
-	for _, warranty := range warranties {
-		warrantyResp, exists := warrantyMap[warranty.ID]
-		if !exists {
-			parts, err := h.warrantiesService.GetWarrantyPartsByWarrantyID(ctx, warranty.ID)
-			if err != nil {
-				utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-			warrantyResp = &dto.WarrantyByExactSearchResponse{
-				Warranty: warranty,
-				Parts:    parts,
-			}
-			warrantyMap[warranty.ID] = warrantyResp
-			result = append(result, *warrantyResp)
-		}
-	}
-	utils.NewHTTPSuccessResponse(w, http.StatusOK, result)
-}
-
 // GetWarrantiesWithPartsByShopID returns warranties by shop ID.
 func (h *warrantiesHandler) GetWarrantiesWithPartsByShopID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -243,6 +209,16 @@ func (h *warrantiesHandler) CreateWarrantyPart(w http.ResponseWriter, r *http.Re
 	params := req.ToCreateWarrantyPartParams()
 	warrantyPart, err := h.warrantiesService.CreateWarrantyPart(ctx, params)
 	if err != nil {
+		if errors.Is(err, services.ErrInsufficientAllocationBalance) || errors.Is(err, services.ErrProductRecalled) ||
+			errors.Is(err, services.ErrInvalidInventoryQuantity) || errors.Is(err, services.ErrInvalidUnitOfMeasure) ||
+			errors.Is(err, services.ErrInsufficientStock) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrStockTakeInProgress) {
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -328,6 +304,16 @@ func (h *warrantiesHandler) CreateWarrantyWithParts(w http.ResponseWriter, r *ht
 
 	warranty, err := h.warrantiesService.CreateWarrantyWithParts(ctx, req.Warranty, req.Parts)
 	if err != nil {
+		if errors.Is(err, services.ErrInsufficientAllocationBalance) || errors.Is(err, services.ErrProductRecalled) ||
+			errors.Is(err, services.ErrInvalidInventoryQuantity) || errors.Is(err, services.ErrInvalidUnitOfMeasure) ||
+			errors.Is(err, services.ErrInsufficientStock) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrStockTakeInProgress) {
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -381,6 +367,16 @@ func (h *warrantiesHandler) UpdateWarrantyWithParts(w http.ResponseWriter, r *ht
 
 	warranty, err := h.warrantiesService.UpdateWarrantyWithParts(ctx, req.Warranty, req.Parts)
 	if err != nil {
+		if errors.Is(err, services.ErrInsufficientAllocationBalance) || errors.Is(err, services.ErrProductRecalled) ||
+			errors.Is(err, services.ErrInvalidInventoryQuantity) || errors.Is(err, services.ErrInvalidUnitOfMeasure) ||
+			errors.Is(err, services.ErrInsufficientStock) {
+			utils.NewHTTPErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrStockTakeInProgress) {
+			utils.NewHTTPErrorResponse(w, http.StatusConflict, err.Error())
+			return
+		}
 		utils.NewHTTPErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
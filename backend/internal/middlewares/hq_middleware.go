@@ -0,0 +1,20 @@
+package middlewares
+
+import "net/http"
+
+// HQOnlyMiddleware restricts a route to HQ users, i.e. users that are not assigned to a shop.
+// It must be used after JWTMiddleware.
+func HQOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := GetUserFromContext(r.Context())
+		if !ok {
+			http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		if claims.ShopID != nil {
+			http.Error(w, `{"error":"Only HQ users can access this resource"}`, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
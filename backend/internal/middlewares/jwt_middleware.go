@@ -18,14 +18,19 @@ type Claims struct {
 	UserID    int32  `json:"userId"`
 	Username  string `json:"username"`
 	ShopID    *int32 `json:"shopId,omitempty"`
-	TokenType string `json:"tokenType"` // "access" or "refresh"
+	TokenType string `json:"tokenType"` // "access", "refresh" or "customer"
 	Exp       int64  `json:"exp"`
 	Iat       int64  `json:"iat"`
+
+	// Customer portal tokens only
+	CustomerID *int32 `json:"customerId,omitempty"`
+	Contact    string `json:"contact,omitempty"` // verified phone number (E.164) or e-mail address
 }
 
 type contextKey string
 
 const UserContextKey contextKey = "user"
+const CustomerContextKey contextKey = "customer"
 
 var jwtSecret []byte
 var refreshSecret []byte
@@ -72,6 +77,21 @@ func GenerateRefreshToken(userID int32, username string, shopID *int32) (string,
 	return generateToken(claims, refreshSecret)
 }
 
+// GenerateCustomerToken generates a customer portal token after a one-time code was verified
+// for the given contact (expires in 2 hours). Customer tokens cannot be used on staff routes.
+func GenerateCustomerToken(customerID int32, name, contact string) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		Username:   name,
+		TokenType:  "customer",
+		CustomerID: &customerID,
+		Contact:    contact,
+		Iat:        now.Unix(),
+		Exp:        now.Add(2 * time.Hour).Unix(), // Customer token expires in 2 hours
+	}
+	return generateToken(claims, jwtSecret)
+}
+
 // GenerateJWT generates a JWT token for a user (for backward compatibility)
 // Deprecated: Use GenerateAccessToken instead
 func GenerateJWT(userID int32, username string, shopID *int32) (string, error) {
@@ -119,6 +139,11 @@ func ValidateRefreshToken(tokenString string) (*Claims, error) {
 	return validateToken(tokenString, refreshSecret, "refresh")
 }
 
+// ValidateCustomerToken validates a customer portal token
+func ValidateCustomerToken(tokenString string) (*Claims, error) {
+	return validateToken(tokenString, jwtSecret, "customer")
+}
+
 // ValidateJWT validates a JWT token and returns the claims (for backward compatibility)
 // Deprecated: Use ValidateAccessToken or ValidateRefreshToken instead
 func ValidateJWT(tokenString string) (*Claims, error) {
@@ -178,22 +203,11 @@ func createSignatureWithSecret(message string, secret []byte) string {
 // JWTMiddleware is a middleware that validates JWT tokens
 func JWTMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get token from Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, `{"error":"Missing authorization header"}`, http.StatusUnauthorized)
+		token, ok := bearerToken(w, r)
+		if !ok {
 			return
 		}
 
-		// Check if it's a Bearer token
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, `{"error":"Invalid authorization header format"}`, http.StatusUnauthorized)
-			return
-		}
-
-		token := parts[1]
-
 		// Validate access token
 		claims, err := ValidateAccessToken(token)
 		if err != nil {
@@ -212,3 +226,48 @@ func GetUserFromContext(ctx context.Context) (*Claims, bool) {
 	claims, ok := ctx.Value(UserContextKey).(*Claims)
 	return claims, ok
 }
+
+// CustomerJWTMiddleware is a middleware that validates customer portal tokens
+func CustomerJWTMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(w, r)
+		if !ok {
+			return
+		}
+
+		claims, err := ValidateCustomerToken(token)
+		if err != nil {
+			http.Error(w, fmt.Sprintf(`{"error":"Invalid token: %s"}`, err.Error()), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), CustomerContextKey, claims)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// GetCustomerFromContext retrieves customer portal claims from request context
+func GetCustomerFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(CustomerContextKey).(*Claims)
+	return claims, ok
+}
+
+// bearerToken extracts the Bearer token from the Authorization header.
+// It writes an unauthorized response and returns false when the header is missing or malformed.
+func bearerToken(w http.ResponseWriter, r *http.Request) (string, bool) {
+	// Get token from Authorization header
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		http.Error(w, `{"error":"Missing authorization header"}`, http.StatusUnauthorized)
+		return "", false
+	}
+
+	// Check if it's a Bearer token
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		http.Error(w, `{"error":"Invalid authorization header format"}`, http.StatusUnauthorized)
+		return "", false
+	}
+
+	return parts[1], true
+}
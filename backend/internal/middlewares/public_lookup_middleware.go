@@ -0,0 +1,291 @@
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// Headers used by clients to answer a proof-of-work challenge.
+const (
+	LookupChallengeHeader = "X-Lookup-Challenge"
+	LookupSolutionHeader  = "X-Lookup-Solution"
+)
+
+// lookupWindow is the period over which lookups per client are counted.
+const lookupWindow = time.Minute
+
+// lookupChallengeTTL is how long an issued challenge can be answered.
+const lookupChallengeTTL = 5 * time.Minute
+
+// LookupBlockedFunc is called when a lookup is blocked, at most once per client, window and outcome.
+type LookupBlockedFunc func(r *http.Request, outcome string)
+
+// PublicLookupLimiter protects unauthenticated lookups against scraping. Every client IP may
+// make a few lookups per minute freely; bursts above that must answer a proof-of-work challenge
+// (finding a nonce whose SHA-256 with the challenge starts with a number of zero bits) and
+// clients above the hard limit are rejected until the window ends. State is kept in memory,
+// so limits apply per API instance.
+type PublicLookupLimiter struct {
+	freePerWindow int
+	maxPerWindow  int
+	difficulty    int
+	onBlocked     LookupBlockedFunc
+
+	mu        sync.Mutex
+	clients   map[string]*lookupClient
+	used      map[string]time.Time // answered challenges, until they expire
+	lastSweep time.Time
+}
+
+type lookupClient struct {
+	windowStart time.Time
+	count       int
+	allowance   int
+	blocked     map[string]bool
+}
+
+// NewPublicLookupLimiter creates a limiter configured from PUBLIC_LOOKUP_FREE_PER_MINUTE (default 5),
+// PUBLIC_LOOKUP_MAX_PER_MINUTE (default 30) and PUBLIC_LOOKUP_POW_DIFFICULTY (default 18 bits).
+func NewPublicLookupLimiter(onBlocked LookupBlockedFunc) *PublicLookupLimiter {
+	return &PublicLookupLimiter{
+		freePerWindow: envInt("PUBLIC_LOOKUP_FREE_PER_MINUTE", 5),
+		maxPerWindow:  envInt("PUBLIC_LOOKUP_MAX_PER_MINUTE", 30),
+		difficulty:    envInt("PUBLIC_LOOKUP_POW_DIFFICULTY", 18),
+		onBlocked:     onBlocked,
+		clients:       make(map[string]*lookupClient),
+		used:          make(map[string]time.Time),
+		lastSweep:     time.Now(),
+	}
+}
+
+func envInt(key string, fallback int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil && v > 0 {
+		return v
+	}
+	return fallback
+}
+
+// ClientIP returns the address of the client. The X-Real-IP header set by the nginx reverse
+// proxy is only believed when the connection comes from a proxy listed in TRUSTED_PROXIES;
+// anyone else could set it to dodge the per-client limits.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !isTrustedProxy(host) {
+		return host
+	}
+	if ip := net.ParseIP(strings.TrimSpace(r.Header.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return host
+}
+
+var (
+	trustedProxiesOnce sync.Once
+	trustedProxies     []*net.IPNet
+)
+
+// isTrustedProxy reports whether the address is in TRUSTED_PROXIES, a comma separated list of
+// CIDR ranges or single addresses. No proxy is trusted when it is unset.
+func isTrustedProxy(host string) bool {
+	trustedProxiesOnce.Do(func() {
+		for _, entry := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if !strings.Contains(entry, "/") {
+				if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+					entry += "/32"
+				} else {
+					entry += "/128"
+				}
+			}
+			if _, network, err := net.ParseCIDR(entry); err == nil {
+				trustedProxies = append(trustedProxies, network)
+			}
+		}
+	})
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware applies the lookup limits to the wrapped handler.
+func (l *PublicLookupLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := ClientIP(r)
+		now := time.Now()
+
+		l.mu.Lock()
+		l.sweep(now)
+		client := l.clients[ip]
+		if client == nil || now.Sub(client.windowStart) >= lookupWindow {
+			client = &lookupClient{windowStart: now, allowance: l.freePerWindow, blocked: map[string]bool{}}
+			l.clients[ip] = client
+		}
+		retryAfter := int(client.windowStart.Add(lookupWindow).Sub(now).Seconds()) + 1
+
+		if client.count >= l.maxPerWindow {
+			firstBlock := l.markBlocked(client, models.PublicLookupOutcomeRateLimited)
+			l.mu.Unlock()
+			l.blocked(r, firstBlock, models.PublicLookupOutcomeRateLimited)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, `{"error":"Too many lookups, please try again later"}`, http.StatusTooManyRequests)
+			return
+		}
+
+		if client.count >= client.allowance {
+			challenge := r.Header.Get(LookupChallengeHeader)
+			solution := r.Header.Get(LookupSolutionHeader)
+			if challenge == "" || solution == "" {
+				firstBlock := l.markBlocked(client, models.PublicLookupOutcomeChallengeRequired)
+				l.mu.Unlock()
+				l.blocked(r, firstBlock, models.PublicLookupOutcomeChallengeRequired)
+				l.writeChallenge(w, ip, now)
+				return
+			}
+			if err := l.verifyChallenge(ip, challenge, solution, now); err != nil {
+				firstBlock := l.markBlocked(client, models.PublicLookupOutcomeChallengeFailed)
+				l.mu.Unlock()
+				l.blocked(r, firstBlock, models.PublicLookupOutcomeChallengeFailed)
+				http.Error(w, fmt.Sprintf(`{"error":"Invalid challenge solution: %s"}`, err.Error()), http.StatusForbidden)
+				return
+			}
+			// a solved challenge buys another batch of free lookups
+			client.allowance = client.count + l.freePerWindow
+		}
+
+		client.count++
+		l.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// markBlocked reports whether this is the first time the client is blocked for the outcome in
+// the current window, so that floods are logged once instead of on every request.
+func (l *PublicLookupLimiter) markBlocked(client *lookupClient, outcome string) bool {
+	if client.blocked[outcome] {
+		return false
+	}
+	client.blocked[outcome] = true
+	return true
+}
+
+func (l *PublicLookupLimiter) blocked(r *http.Request, first bool, outcome string) {
+	if first && l.onBlocked != nil {
+		l.onBlocked(r, outcome)
+	}
+}
+
+// sweep drops expired clients and answered challenges. It must be called with the lock held.
+func (l *PublicLookupLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < lookupWindow {
+		return
+	}
+	for ip, client := range l.clients {
+		if now.Sub(client.windowStart) >= lookupWindow {
+			delete(l.clients, ip)
+		}
+	}
+	for challenge, expiresAt := range l.used {
+		if now.After(expiresAt) {
+			delete(l.used, challenge)
+		}
+	}
+	l.lastSweep = now
+}
+
+// writeChallenge responds with 428 Precondition Required and a new challenge. The client must
+// find a solution string such that SHA-256(challenge + solution) starts with `difficulty` zero
+// bits, then repeat the request with the challenge and solution headers.
+func (l *PublicLookupLimiter) writeChallenge(w http.ResponseWriter, ip string, now time.Time) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		http.Error(w, `{"error":"Failed to create challenge"}`, http.StatusInternalServerError)
+		return
+	}
+	payload := fmt.Sprintf("%d.%s.%d", now.Add(lookupChallengeTTL).Unix(), hex.EncodeToString(nonce), l.difficulty)
+	challenge := payload + "." + signLookupChallenge(ip, payload)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusPreconditionRequired)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error":      "Too many lookups, please solve the challenge to continue",
+		"challenge":  challenge,
+		"difficulty": l.difficulty,
+		"algorithm":  "sha256",
+	})
+}
+
+// verifyChallenge checks that the challenge was issued to this client, has not expired or been
+// used before, and that the solution has enough leading zero bits. It must be called with the
+// lock held.
+func (l *PublicLookupLimiter) verifyChallenge(ip, challenge, solution string, now time.Time) error {
+	parts := strings.Split(challenge, ".")
+	if len(parts) != 4 {
+		return fmt.Errorf("malformed challenge")
+	}
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(signLookupChallenge(ip, payload))) {
+		return fmt.Errorf("challenge was not issued to this client")
+	}
+	expiresAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return fmt.Errorf("challenge has expired")
+	}
+	difficulty, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed challenge")
+	}
+	if _, ok := l.used[challenge]; ok {
+		return fmt.Errorf("challenge has already been used")
+	}
+	if leadingZeroBits(sha256.Sum256([]byte(challenge+solution))) < difficulty {
+		return fmt.Errorf("solution does not meet the difficulty")
+	}
+	l.used[challenge] = time.Unix(expiresAt, 0)
+	return nil
+}
+
+// signLookupChallenge binds a challenge to the client address with the JWT secret.
+func signLookupChallenge(ip, payload string) string {
+	h := hmac.New(sha256.New, jwtSecret)
+	h.Write([]byte("lookup-challenge:" + ip + ":" + payload))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
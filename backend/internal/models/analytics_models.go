@@ -0,0 +1,21 @@
+package models
+
+// Dimensions that product quality analytics can be grouped by.
+const (
+	QualityDimensionProductName = "product_name"
+	QualityDimensionSeries      = "series"
+	QualityDimensionShipment    = "shipment"
+	QualityDimensionCarPart     = "car_part"
+	QualityDimensionShop        = "shop"
+	QualityDimensionAll         = "all"
+)
+
+// QualityDimensions lists the supported quality analytics dimensions.
+var QualityDimensions = []string{
+	QualityDimensionProductName,
+	QualityDimensionSeries,
+	QualityDimensionShipment,
+	QualityDimensionCarPart,
+	QualityDimensionShop,
+	QualityDimensionAll,
+}
@@ -3,7 +3,19 @@ package models
 type ApprovalStatus string
 
 const (
-	ApprovalStatusPending  ApprovalStatus = "PENDING"
-	ApprovalStatusApproved ApprovalStatus = "APPROVED"
-	ApprovalStatusRejected ApprovalStatus = "REJECTED"
+	ApprovalStatusPending           ApprovalStatus = "PENDING"
+	ApprovalStatusApproved          ApprovalStatus = "APPROVED"
+	ApprovalStatusPartiallyApproved ApprovalStatus = "PARTIALLY_APPROVED"
+	ApprovalStatusRejected          ApprovalStatus = "REJECTED"
+)
+
+// IsGranted reports whether the status approves the warranty or claim in full or in part.
+func (s ApprovalStatus) IsGranted() bool {
+	return s == ApprovalStatusApproved || s == ApprovalStatusPartiallyApproved
+}
+
+// Entities whose approval status is derived from their parts by an approval rule.
+const (
+	ApprovalEntityWarranty = "WARRANTY"
+	ApprovalEntityClaim    = "CLAIM"
 )
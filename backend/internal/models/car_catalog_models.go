@@ -0,0 +1,8 @@
+package models
+
+const (
+	CarNormalizationStatusUnchanged      = "UNCHANGED"
+	CarNormalizationStatusNormalized     = "NORMALIZED"
+	CarNormalizationStatusUnmatchedMake  = "UNMATCHED_MAKE"
+	CarNormalizationStatusUnmatchedModel = "UNMATCHED_MODEL"
+)
@@ -0,0 +1,15 @@
+package models
+
+// Reasons a warranty part cannot be claimed.
+const (
+	ClaimIneligibleDuplicatePart       = "DUPLICATE_PART"
+	ClaimIneligiblePartNotFound        = "PART_NOT_FOUND"
+	ClaimIneligiblePartNotOnWarranty   = "PART_NOT_ON_WARRANTY"
+	ClaimIneligibleWarrantyNotApproved = "WARRANTY_NOT_APPROVED"
+	ClaimIneligibleWarrantyVoid        = "WARRANTY_VOID"
+	ClaimIneligiblePartNotApproved     = "PART_NOT_APPROVED"
+	ClaimIneligibleBeforeInstallation  = "CLAIM_BEFORE_INSTALLATION"
+	ClaimIneligibleCoverageExpired     = "COVERAGE_EXPIRED"
+	ClaimIneligibleOpenClaim           = "OPEN_CLAIM"
+	ClaimIneligibleExcluded            = "EXCLUDED"
+)
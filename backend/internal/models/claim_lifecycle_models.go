@@ -0,0 +1,26 @@
+package models
+
+// Claim lifecycle statuses, used for both claims and their parts.
+const (
+	ClaimStatusSubmitted     = "SUBMITTED"
+	ClaimStatusUnderReview   = "UNDER_REVIEW"
+	ClaimStatusApproved      = "APPROVED"
+	ClaimStatusAwaitingParts = "AWAITING_PARTS"
+	ClaimStatusInRepair      = "IN_REPAIR"
+	ClaimStatusResolved      = "RESOLVED"
+	ClaimStatusClosed        = "CLOSED"
+	ClaimStatusReopened      = "REOPENED"
+)
+
+// User roles, as stored in users.role.
+const (
+	UserRoleAdmin     = "admin"
+	UserRoleShopAdmin = "shop_admin"
+	UserRoleUser      = "user"
+)
+
+// Actors recorded on claim status transitions that are not users.
+const (
+	ClaimActorCustomer = "customer"
+	ClaimActorSystem   = "system"
+)
@@ -0,0 +1,8 @@
+package models
+
+// SLA states used to filter claim lists.
+const (
+	ClaimSLABreached = "breached"
+	ClaimSLADueToday = "due_today"
+	ClaimSLAOnTrack  = "on_track"
+)
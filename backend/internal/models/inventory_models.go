@@ -0,0 +1,26 @@
+package models
+
+// Types of an inventory movement.
+const (
+	InventoryMovementReceipt     = "RECEIPT"
+	InventoryMovementAllocation  = "ALLOCATION"
+	InventoryMovementReturn      = "RETURN"
+	InventoryMovementTransfer    = "TRANSFER"
+	InventoryMovementConsumption = "CONSUMPTION"
+	InventoryMovementAdjustment  = "ADJUSTMENT"
+	InventoryMovementWriteOff    = "WRITE_OFF"
+)
+
+// Ways film can leave a shop allocation.
+const (
+	AllocationTransferReturn   = "RETURN"
+	AllocationTransferTransfer = "TRANSFER"
+)
+
+// Units film quantities can be given in. Inventory, allocations and consumption are kept in
+// rolls; metres and sheets are converted with the product's roll length and sheets per roll.
+const (
+	UnitOfMeasureRoll  = "ROLL"
+	UnitOfMeasureMetre = "METRE"
+	UnitOfMeasureSheet = "SHEET"
+)
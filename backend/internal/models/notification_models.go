@@ -0,0 +1,8 @@
+package models
+
+const (
+	NotificationStatusPending = "PENDING"
+	NotificationStatusSent    = "SENT"
+	NotificationStatusFailed  = "FAILED"
+	NotificationStatusSkipped = "SKIPPED"
+)
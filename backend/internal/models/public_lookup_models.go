@@ -0,0 +1,9 @@
+package models
+
+// Outcomes recorded for unauthenticated warranty lookups
+const (
+	PublicLookupOutcomeOK                = "OK"
+	PublicLookupOutcomeRateLimited       = "RATE_LIMITED"
+	PublicLookupOutcomeChallengeRequired = "CHALLENGE_REQUIRED"
+	PublicLookupOutcomeChallengeFailed   = "CHALLENGE_FAILED"
+)
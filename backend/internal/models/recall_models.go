@@ -0,0 +1,13 @@
+package models
+
+// What a recall covers: a single film roll or every roll of a shipment.
+const (
+	RecallScopeSerial   = "SERIAL"
+	RecallScopeShipment = "SHIPMENT"
+)
+
+// Statuses of a recall.
+const (
+	RecallStatusOpen   = "OPEN"
+	RecallStatusClosed = "CLOSED"
+)
@@ -0,0 +1,8 @@
+package models
+
+// Statuses of a shop reimbursement statement.
+const (
+	ReimbursementStatusPending  = "PENDING"
+	ReimbursementStatusApproved = "APPROVED"
+	ReimbursementStatusRejected = "REJECTED"
+)
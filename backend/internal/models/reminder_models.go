@@ -0,0 +1,6 @@
+package models
+
+const (
+	ReminderTypeWarrantyExpiry = "WARRANTY_EXPIRY"
+	ReminderTypeInspection     = "INSPECTION"
+)
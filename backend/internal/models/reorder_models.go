@@ -0,0 +1,9 @@
+package models
+
+// Statuses of a shop's reorder request.
+const (
+	ReorderRequestStatusPending   = "PENDING"
+	ReorderRequestStatusApproved  = "APPROVED"
+	ReorderRequestStatusRejected  = "REJECTED"
+	ReorderRequestStatusCancelled = "CANCELLED"
+)
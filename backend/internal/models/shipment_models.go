@@ -0,0 +1,15 @@
+package models
+
+// Statuses of a supplier shipment.
+const (
+	ShipmentStatusPending  = "PENDING"
+	ShipmentStatusReceived = "RECEIVED"
+)
+
+// Outcomes of a row in a supplier packing list import.
+const (
+	ShipmentImportStatusNew       = "NEW"
+	ShipmentImportStatusUpdated   = "UPDATED"
+	ShipmentImportStatusUnchanged = "UNCHANGED"
+	ShipmentImportStatusError     = "ERROR"
+)
@@ -0,0 +1,16 @@
+package models
+
+// Statuses of a shop's stock take.
+const (
+	StockTakeStatusOpen      = "OPEN"
+	StockTakeStatusSubmitted = "SUBMITTED"
+	StockTakeStatusPosted    = "POSTED"
+	StockTakeStatusCancelled = "CANCELLED"
+)
+
+// Review statuses of a stock-take line's variance.
+const (
+	StockTakeLineReviewPending  = "PENDING"
+	StockTakeLineReviewApproved = "APPROVED"
+	StockTakeLineReviewRejected = "REJECTED"
+)
@@ -0,0 +1,6 @@
+package models
+
+const (
+	VehicleNormalizationStatusNormalized = "NORMALIZED"
+	VehicleNormalizationStatusInvalid    = "INVALID"
+)
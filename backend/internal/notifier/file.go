@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type fileNotifier struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileNotifier creates a notifier that appends messages as JSON lines to
+// <dir>/<channel>.jsonl. It is intended for staging and local testing.
+func NewFileNotifier(dir string) (Notifier, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create notification directory: %w", err)
+	}
+	return &fileNotifier{dir: dir}, nil
+}
+
+func (n *fileNotifier) Name() string {
+	return "file"
+}
+
+// Send appends the message to the channel's JSON lines file.
+func (n *fileNotifier) Send(ctx context.Context, msg *Message) error {
+	line, err := json.Marshal(map[string]any{
+		"channel":   msg.Channel,
+		"recipient": msg.Recipient,
+		"subject":   msg.Subject,
+		"body":      msg.Body,
+		"sentAt":    time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(filepath.Join(n.dir, string(msg.Channel)+".jsonl"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
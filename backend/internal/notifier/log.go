@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"context"
+	"log"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier creates a notifier that only writes messages to the application log.
+// It is intended for development environments.
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Name() string {
+	return "log"
+}
+
+// Send writes the message to the application log.
+func (n *logNotifier) Send(ctx context.Context, msg *Message) error {
+	log.Printf("[notifier] %s to %s: %s\n%s", msg.Channel, msg.Recipient, msg.Subject, msg.Body)
+	return nil
+}
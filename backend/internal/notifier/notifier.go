@@ -0,0 +1,47 @@
+package notifier
+
+import "context"
+
+// Channel represents the delivery channel of a notification
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+// Event represents the business event that triggers a customer notification
+type Event string
+
+const (
+	EventWarrantyApproved   Event = "warranty_approved"
+	EventClaimCreated       Event = "claim_created"
+	EventClaimStatusChanged Event = "claim_status_changed"
+
+	EventWarrantyExpiryReminder Event = "warranty_expiry_reminder"
+	EventInspectionReminder     Event = "inspection_reminder"
+
+	EventCustomerLoginCode Event = "customer_login_code"
+
+	EventClaimSLABreached Event = "claim_sla_breached"
+	EventCommentPosted    Event = "comment_posted"
+
+	EventProductRecall Event = "product_recall"
+	EventLowStock      Event = "low_stock"
+)
+
+// Message is a rendered notification ready to be delivered by a Notifier.
+type Message struct {
+	Channel   Channel
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// Notifier delivers rendered messages through a single provider.
+type Notifier interface {
+	// Name returns the provider name recorded against each delivery attempt.
+	Name() string
+	// Send delivers the message or returns an error if the provider rejected it.
+	Send(ctx context.Context, msg *Message) error
+}
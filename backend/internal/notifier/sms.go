@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+type httpSMSNotifier struct {
+	endpoint string
+	apiKey   string
+	sender   string
+	client   *http.Client
+}
+
+// NewHTTPSMSNotifier creates an SMS notifier that posts messages to an HTTP SMS gateway.
+// The gateway receives a JSON body of {"to", "from", "message"} with a bearer API key.
+func NewHTTPSMSNotifier(endpoint, apiKey, sender string) Notifier {
+	return &httpSMSNotifier{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		sender:   sender,
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (n *httpSMSNotifier) Name() string {
+	return "sms_http"
+}
+
+// Send delivers an SMS message through the configured gateway.
+func (n *httpSMSNotifier) Send(ctx context.Context, msg *Message) error {
+	if msg.Channel != ChannelSMS {
+		return fmt.Errorf("sms notifier cannot send %s messages", msg.Channel)
+	}
+
+	to, err := utils.NormalizeMalaysianPhone(msg.Recipient)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"to":      to,
+		"from":    n.sender,
+		"message": msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+n.apiKey)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sms gateway returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return nil
+}
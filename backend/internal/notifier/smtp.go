@@ -0,0 +1,71 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type smtpNotifier struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPNotifier creates an email notifier that delivers through an SMTP server.
+func NewSMTPNotifier(host, port, username, password, from string) Notifier {
+	return &smtpNotifier{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (n *smtpNotifier) Name() string {
+	return "smtp"
+}
+
+// Send delivers an email message through the configured SMTP server.
+func (n *smtpNotifier) Send(ctx context.Context, msg *Message) error {
+	if msg.Channel != ChannelEmail {
+		return fmt.Errorf("smtp notifier cannot send %s messages", msg.Channel)
+	}
+
+	var auth smtp.Auth
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	headers := []string{
+		"From: " + n.from,
+		"To: " + msg.Recipient,
+		"Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: 8bit",
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + strings.ReplaceAll(msg.Body, "\n", "\r\n")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(n.host+":"+n.port, auth, n.from, []string{msg.Recipient}, []byte(body))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
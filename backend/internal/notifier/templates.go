@@ -0,0 +1,409 @@
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultLocale is used when a notification has no locale or the locale has no templates.
+const DefaultLocale = "en"
+
+// TemplateData is the snapshot of warranty/claim details stored with a notification
+// and used to render its subject and body.
+type TemplateData struct {
+	CustomerName string  `json:"customerName"`
+	WarrantyNo   string  `json:"warrantyNo"`
+	CarBrand     string  `json:"carBrand"`
+	CarModel     string  `json:"carModel"`
+	CarPlateNo   string  `json:"carPlateNo"`
+	ClaimNo      string  `json:"claimNo,omitempty"`
+	ClaimStatus  string  `json:"claimStatus,omitempty"`
+	ExpiryDate   string  `json:"expiryDate,omitempty"`
+	LoginCode    string  `json:"loginCode,omitempty"`
+	CodeMinutes  int     `json:"codeMinutes,omitempty"`
+	DueDate      string  `json:"dueDate,omitempty"`
+	ShopName     string  `json:"shopName,omitempty"`
+	Author       string  `json:"author,omitempty"`
+	CommentBody  string  `json:"commentBody,omitempty"`
+	RecallNo     string  `json:"recallNo,omitempty"`
+	ProductName  string  `json:"productName,omitempty"`
+	StockBalance float64 `json:"stockBalance,omitempty"`
+	StockMinimum float64 `json:"stockMinimum,omitempty"`
+}
+
+type messageTemplate struct {
+	Subject   string
+	EmailBody string
+	SMSBody   string
+}
+
+// templates holds the localized message templates keyed by locale and event.
+var templates = map[string]map[Event]messageTemplate{
+	"en": {
+		EventWarrantyApproved: {
+			Subject: "Your warranty {{.WarrantyNo}} has been approved",
+			EmailBody: `Dear {{.CustomerName}},
+
+Your warranty {{.WarrantyNo}} for {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) has been approved.
+
+Please keep this warranty number for future reference.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Warranty {{.WarrantyNo}} for {{.CarPlateNo}} has been approved.",
+		},
+		EventClaimCreated: {
+			Subject: "Warranty claim {{.ClaimNo}} received",
+			EmailBody: `Dear {{.CustomerName}},
+
+We have received your warranty claim {{.ClaimNo}} for warranty {{.WarrantyNo}} ({{.CarPlateNo}}).
+
+We will notify you when the status of your claim changes.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Claim {{.ClaimNo}} for {{.CarPlateNo}} has been received.",
+		},
+		EventClaimStatusChanged: {
+			Subject: "Warranty claim {{.ClaimNo}} is now {{.ClaimStatus}}",
+			EmailBody: `Dear {{.CustomerName}},
+
+The status of your warranty claim {{.ClaimNo}} for warranty {{.WarrantyNo}} ({{.CarPlateNo}}) is now: {{.ClaimStatus}}.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Claim {{.ClaimNo}} for {{.CarPlateNo}} is now {{.ClaimStatus}}.",
+		},
+		EventWarrantyExpiryReminder: {
+			Subject: "Your warranty {{.WarrantyNo}} expires on {{.ExpiryDate}}",
+			EmailBody: `Dear {{.CustomerName}},
+
+Your warranty {{.WarrantyNo}} for {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) expires on {{.ExpiryDate}}.
+
+Visit any Profilm authorised shop to renew your protection before it expires.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Warranty {{.WarrantyNo}} for {{.CarPlateNo}} expires on {{.ExpiryDate}}. Visit an authorised shop to renew.",
+		},
+		EventInspectionReminder: {
+			Subject: "Time for your paint protection film inspection",
+			EmailBody: `Dear {{.CustomerName}},
+
+It is time for the scheduled inspection of the paint protection film on your {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}), warranty {{.WarrantyNo}}.
+
+Please book an inspection with your installer to keep your warranty in good standing.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Your PPF inspection for {{.CarPlateNo}} (warranty {{.WarrantyNo}}) is due. Please book with your installer.",
+		},
+		EventCustomerLoginCode: {
+			Subject: "Your Profilm warranty portal login code",
+			EmailBody: `Dear {{.CustomerName}},
+
+Your login code for the Profilm warranty portal is {{.LoginCode}}. It expires in {{.CodeMinutes}} minutes.
+
+If you did not request this code, you can ignore this message.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Your warranty portal login code is {{.LoginCode}}. It expires in {{.CodeMinutes}} minutes. Do not share this code.",
+		},
+		EventClaimSLABreached: {
+			Subject: "Overdue claim {{.ClaimNo}} ({{.ClaimStatus}}) was due on {{.DueDate}}",
+			EmailBody: `Dear supervisor,
+
+Warranty claim {{.ClaimNo}} from {{.ShopName}} for warranty {{.WarrantyNo}} ({{.CarPlateNo}}) has been {{.ClaimStatus}} past its due date of {{.DueDate}}.
+
+Please follow up so that the claim can move forward.`,
+			SMSBody: "Profilm: Claim {{.ClaimNo}} ({{.ShopName}}) is overdue in {{.ClaimStatus}} since {{.DueDate}}.",
+		},
+		EventCommentPosted: {
+			Subject: "New comment on {{if .ClaimNo}}claim {{.ClaimNo}}{{else}}warranty {{.WarrantyNo}}{{end}}",
+			EmailBody: `Hello,
+
+{{.Author}} commented on {{if .ClaimNo}}claim {{.ClaimNo}} for {{end}}warranty {{.WarrantyNo}} ({{.CarPlateNo}}) from {{.ShopName}}:
+
+{{.CommentBody}}
+
+Sign in to the Profilm e-warranty system to reply.`,
+			SMSBody: "Profilm: {{.Author}} commented on {{if .ClaimNo}}claim {{.ClaimNo}}{{else}}warranty {{.WarrantyNo}}{{end}} ({{.CarPlateNo}}).",
+		},
+		EventLowStock: {
+			Subject: "Low stock of {{.ProductName}} at {{.ShopName}}",
+			EmailBody: `Hello,
+
+{{.ShopName}} has {{.StockBalance}} of {{.ProductName}} left, below its minimum of {{.StockMinimum}}.
+
+Sign in to the Profilm e-warranty system to request more film.`,
+			SMSBody: "Profilm: {{.ShopName}} has {{.StockBalance}} of {{.ProductName}} left (minimum {{.StockMinimum}}).",
+		},
+		EventProductRecall: {
+			Subject: "Important notice about the film on {{.CarPlateNo}}",
+			EmailBody: `Dear {{.CustomerName}},
+
+A batch of film used on your {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) under warranty {{.WarrantyNo}} has been recalled (reference {{.RecallNo}}).
+
+Please contact {{.ShopName}} to arrange an inspection. Your warranty remains valid.
+
+Thank you for choosing Profilm.`,
+			SMSBody: "Profilm: Film on {{.CarPlateNo}} (warranty {{.WarrantyNo}}) is under recall {{.RecallNo}}. Please contact {{.ShopName}} for an inspection.",
+		},
+	},
+	"ms": {
+		EventWarrantyApproved: {
+			Subject: "Waranti {{.WarrantyNo}} anda telah diluluskan",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Waranti {{.WarrantyNo}} untuk {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) telah diluluskan.
+
+Sila simpan nombor waranti ini untuk rujukan masa hadapan.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Waranti {{.WarrantyNo}} untuk {{.CarPlateNo}} telah diluluskan.",
+		},
+		EventClaimCreated: {
+			Subject: "Tuntutan waranti {{.ClaimNo}} diterima",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Kami telah menerima tuntutan waranti {{.ClaimNo}} untuk waranti {{.WarrantyNo}} ({{.CarPlateNo}}).
+
+Kami akan memaklumkan anda apabila status tuntutan anda berubah.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Tuntutan {{.ClaimNo}} untuk {{.CarPlateNo}} telah diterima.",
+		},
+		EventClaimStatusChanged: {
+			Subject: "Status tuntutan waranti {{.ClaimNo}}: {{.ClaimStatus}}",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Status tuntutan waranti {{.ClaimNo}} untuk waranti {{.WarrantyNo}} ({{.CarPlateNo}}) kini: {{.ClaimStatus}}.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Status tuntutan {{.ClaimNo}} untuk {{.CarPlateNo}} kini {{.ClaimStatus}}.",
+		},
+		EventWarrantyExpiryReminder: {
+			Subject: "Waranti {{.WarrantyNo}} anda tamat pada {{.ExpiryDate}}",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Waranti {{.WarrantyNo}} untuk {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) akan tamat pada {{.ExpiryDate}}.
+
+Sila kunjungi mana-mana kedai sah Profilm untuk memperbaharui perlindungan anda sebelum tamat tempoh.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Waranti {{.WarrantyNo}} untuk {{.CarPlateNo}} tamat pada {{.ExpiryDate}}. Kunjungi kedai sah untuk memperbaharui.",
+		},
+		EventInspectionReminder: {
+			Subject: "Masa untuk pemeriksaan filem pelindung cat anda",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Sudah tiba masanya untuk pemeriksaan berjadual filem pelindung cat pada {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) anda, waranti {{.WarrantyNo}}.
+
+Sila buat temujanji pemeriksaan dengan pemasang anda untuk mengekalkan waranti anda.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Pemeriksaan PPF untuk {{.CarPlateNo}} (waranti {{.WarrantyNo}}) telah tiba. Sila buat temujanji dengan pemasang anda.",
+		},
+		EventCustomerLoginCode: {
+			Subject: "Kod log masuk portal waranti Profilm anda",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Kod log masuk portal waranti Profilm anda ialah {{.LoginCode}}. Kod ini tamat tempoh dalam {{.CodeMinutes}} minit.
+
+Jika anda tidak meminta kod ini, sila abaikan mesej ini.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Kod log masuk portal waranti anda ialah {{.LoginCode}}. Tamat tempoh dalam {{.CodeMinutes}} minit. Jangan kongsi kod ini.",
+		},
+		EventClaimSLABreached: {
+			Subject: "Tuntutan {{.ClaimNo}} ({{.ClaimStatus}}) melepasi tarikh akhir {{.DueDate}}",
+			EmailBody: `Yang dihormati penyelia,
+
+Tuntutan waranti {{.ClaimNo}} dari {{.ShopName}} untuk waranti {{.WarrantyNo}} ({{.CarPlateNo}}) masih berstatus {{.ClaimStatus}} selepas tarikh akhir {{.DueDate}}.
+
+Sila susuli supaya tuntutan ini dapat diteruskan.`,
+			SMSBody: "Profilm: Tuntutan {{.ClaimNo}} ({{.ShopName}}) tertunggak dalam status {{.ClaimStatus}} sejak {{.DueDate}}.",
+		},
+		EventCommentPosted: {
+			Subject: "Komen baharu pada {{if .ClaimNo}}tuntutan {{.ClaimNo}}{{else}}waranti {{.WarrantyNo}}{{end}}",
+			EmailBody: `Salam,
+
+{{.Author}} telah memberi komen pada {{if .ClaimNo}}tuntutan {{.ClaimNo}} untuk {{end}}waranti {{.WarrantyNo}} ({{.CarPlateNo}}) dari {{.ShopName}}:
+
+{{.CommentBody}}
+
+Log masuk ke sistem e-waranti Profilm untuk membalas.`,
+			SMSBody: "Profilm: {{.Author}} memberi komen pada {{if .ClaimNo}}tuntutan {{.ClaimNo}}{{else}}waranti {{.WarrantyNo}}{{end}} ({{.CarPlateNo}}).",
+		},
+		EventLowStock: {
+			Subject: "Stok {{.ProductName}} di {{.ShopName}} rendah",
+			EmailBody: `Salam,
+
+{{.ShopName}} mempunyai baki {{.StockBalance}} {{.ProductName}}, di bawah minimum {{.StockMinimum}}.
+
+Log masuk ke sistem e-waranti Profilm untuk memohon filem tambahan.`,
+			SMSBody: "Profilm: {{.ShopName}} mempunyai baki {{.StockBalance}} {{.ProductName}} (minimum {{.StockMinimum}}).",
+		},
+		EventProductRecall: {
+			Subject: "Notis penting mengenai filem pada {{.CarPlateNo}}",
+			EmailBody: `Yang dihormati {{.CustomerName}},
+
+Satu kelompok filem yang digunakan pada {{.CarBrand}} {{.CarModel}} ({{.CarPlateNo}}) anda di bawah waranti {{.WarrantyNo}} telah ditarik balik (rujukan {{.RecallNo}}).
+
+Sila hubungi {{.ShopName}} untuk mengatur pemeriksaan. Waranti anda kekal sah.
+
+Terima kasih kerana memilih Profilm.`,
+			SMSBody: "Profilm: Filem pada {{.CarPlateNo}} (waranti {{.WarrantyNo}}) ditarik balik di bawah {{.RecallNo}}. Sila hubungi {{.ShopName}} untuk pemeriksaan.",
+		},
+	},
+	"zh": {
+		EventWarrantyApproved: {
+			Subject: "您的保修 {{.WarrantyNo}} 已获批准",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+您的 {{.CarBrand}} {{.CarModel}}（{{.CarPlateNo}}）保修 {{.WarrantyNo}} 已获批准。
+
+请保存此保修编号以备日后查询。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：{{.CarPlateNo}} 的保修 {{.WarrantyNo}} 已获批准。",
+		},
+		EventClaimCreated: {
+			Subject: "已收到保修索赔 {{.ClaimNo}}",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+我们已收到保修 {{.WarrantyNo}}（{{.CarPlateNo}}）的索赔 {{.ClaimNo}}。
+
+索赔状态更新时我们会通知您。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：已收到 {{.CarPlateNo}} 的索赔 {{.ClaimNo}}。",
+		},
+		EventClaimStatusChanged: {
+			Subject: "保修索赔 {{.ClaimNo}} 状态：{{.ClaimStatus}}",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+保修 {{.WarrantyNo}}（{{.CarPlateNo}}）的索赔 {{.ClaimNo}} 当前状态为：{{.ClaimStatus}}。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：{{.CarPlateNo}} 的索赔 {{.ClaimNo}} 当前状态为 {{.ClaimStatus}}。",
+		},
+		EventWarrantyExpiryReminder: {
+			Subject: "您的保修 {{.WarrantyNo}} 将于 {{.ExpiryDate}} 到期",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+您的 {{.CarBrand}} {{.CarModel}}（{{.CarPlateNo}}）保修 {{.WarrantyNo}} 将于 {{.ExpiryDate}} 到期。
+
+请在到期前前往任何 Profilm 授权店续保。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：{{.CarPlateNo}} 的保修 {{.WarrantyNo}} 将于 {{.ExpiryDate}} 到期，请前往授权店续保。",
+		},
+		EventInspectionReminder: {
+			Subject: "漆面保护膜定期检查提醒",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+您的 {{.CarBrand}} {{.CarModel}}（{{.CarPlateNo}}）漆面保护膜（保修 {{.WarrantyNo}}）已到定期检查时间。
+
+请联系您的安装店预约检查，以确保保修有效。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：{{.CarPlateNo}}（保修 {{.WarrantyNo}}）的漆面保护膜已到检查时间，请联系安装店预约。",
+		},
+		EventCustomerLoginCode: {
+			Subject: "您的 Profilm 保修门户登录验证码",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+您的 Profilm 保修门户登录验证码为 {{.LoginCode}}，{{.CodeMinutes}} 分钟内有效。
+
+如果您没有申请此验证码，请忽略此信息。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：您的保修门户登录验证码为 {{.LoginCode}}，{{.CodeMinutes}} 分钟内有效，请勿泄露。",
+		},
+		EventClaimSLABreached: {
+			Subject: "索赔 {{.ClaimNo}}（{{.ClaimStatus}}）已超过截止日期 {{.DueDate}}",
+			EmailBody: `主管您好：
+
+{{.ShopName}} 提交的保修 {{.WarrantyNo}}（{{.CarPlateNo}}）索赔 {{.ClaimNo}} 在 {{.ClaimStatus}} 状态已超过截止日期 {{.DueDate}}。
+
+请跟进以推进此索赔。`,
+			SMSBody: "Profilm：索赔 {{.ClaimNo}}（{{.ShopName}}）自 {{.DueDate}} 起在 {{.ClaimStatus}} 状态逾期。",
+		},
+		EventCommentPosted: {
+			Subject: "{{if .ClaimNo}}索赔 {{.ClaimNo}}{{else}}保修 {{.WarrantyNo}}{{end}} 有新评论",
+			EmailBody: `您好：
+
+{{.Author}} 在 {{.ShopName}} 的{{if .ClaimNo}}索赔 {{.ClaimNo}}（{{end}}保修 {{.WarrantyNo}}，{{.CarPlateNo}}{{if .ClaimNo}}）{{end}}上发表了评论：
+
+{{.CommentBody}}
+
+请登录 Profilm 电子保修系统回复。`,
+			SMSBody: "Profilm：{{.Author}} 在{{if .ClaimNo}}索赔 {{.ClaimNo}}{{else}}保修 {{.WarrantyNo}}{{end}}（{{.CarPlateNo}}）上发表了评论。",
+		},
+		EventLowStock: {
+			Subject: "{{.ShopName}} 的 {{.ProductName}} 库存不足",
+			EmailBody: `您好：
+
+{{.ShopName}} 的 {{.ProductName}} 仅剩 {{.StockBalance}}，低于最低库存 {{.StockMinimum}}。
+
+请登录 Profilm 电子保修系统申请补货。`,
+			SMSBody: "Profilm：{{.ShopName}} 的 {{.ProductName}} 仅剩 {{.StockBalance}}（最低 {{.StockMinimum}}）。",
+		},
+		EventProductRecall: {
+			Subject: "关于 {{.CarPlateNo}} 所贴膜的重要通知",
+			EmailBody: `尊敬的 {{.CustomerName}}：
+
+您的 {{.CarBrand}} {{.CarModel}}（{{.CarPlateNo}}，保修 {{.WarrantyNo}}）所使用的一批膜已被召回（编号 {{.RecallNo}}）。
+
+请联系 {{.ShopName}} 安排检查。您的保修依然有效。
+
+感谢您选择 Profilm。`,
+			SMSBody: "Profilm：{{.CarPlateNo}}（保修 {{.WarrantyNo}}）所贴的膜已被召回（{{.RecallNo}}），请联系 {{.ShopName}} 安排检查。",
+		},
+	},
+}
+
+// Render renders the message for the given event, channel and locale.
+// It falls back to DefaultLocale when the locale has no templates and returns the locale used.
+func Render(event Event, channel Channel, locale string, data *TemplateData) (*Message, string, error) {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if _, ok := templates[locale]; !ok {
+		locale = DefaultLocale
+	}
+
+	tmpl, ok := templates[locale][event]
+	if !ok {
+		return nil, locale, fmt.Errorf("no template for event %q", event)
+	}
+
+	msg := &Message{Channel: channel}
+
+	var err error
+	switch channel {
+	case ChannelEmail:
+		if msg.Subject, err = execute(tmpl.Subject, data); err != nil {
+			return nil, locale, err
+		}
+		msg.Body, err = execute(tmpl.EmailBody, data)
+	case ChannelSMS:
+		msg.Body, err = execute(tmpl.SMSBody, data)
+	default:
+		err = fmt.Errorf("unsupported channel %q", channel)
+	}
+	if err != nil {
+		return nil, locale, err
+	}
+
+	return msg, locale, nil
+}
+
+func execute(text string, data *TemplateData) (string, error) {
+	t, err := template.New("message").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -24,8 +24,8 @@ func (rt *Routes) RegisterRoutes(router chi.Router) {
 		AllowedOrigins: []string{"https://*", "http://*"},
 		// AllowOriginFunc:  func(r *http.Request, origin string) bool { return true },
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", middlewares.LookupChallengeHeader, middlewares.LookupSolutionHeader},
+		ExposedHeaders:   []string{"Link", "Retry-After"},
 		AllowCredentials: false,
 		MaxAge:           300, // Maximum value not ignored by any of major browsers
 	}))
@@ -37,8 +37,27 @@ func (rt *Routes) RegisterRoutes(router chi.Router) {
 			r.Post("/refresh", rt.handler.UsersHandler.RefreshToken)
 		})
 
-		// Public warranty search routes (for home page)
-		r.Get("/warranties/search/{search_term}", rt.handler.WarrantiesHandler.GetWarrantiesByExactSearch)
+		// Public warranty search routes (for home page), rate limited per IP with a
+		// proof-of-work challenge for bursts
+		lookupLimiter := middlewares.NewPublicLookupLimiter(rt.handler.PublicLookupsHandler.RecordBlockedLookup)
+		r.With(lookupLimiter.Middleware).Get("/warranties/search/{search_term}", rt.handler.PublicLookupsHandler.SearchWarranties)
+
+		// Customer self-service portal (login with a one-time code sent to the contact on file)
+		r.Route("/portal", func(r chi.Router) {
+			r.Post("/auth/request-code", rt.handler.PortalHandler.RequestLoginCode)
+			r.Post("/auth/verify-code", rt.handler.PortalHandler.VerifyLoginCode)
+
+			r.Group(func(r chi.Router) {
+				r.Use(middlewares.CustomerJWTMiddleware)
+
+				r.Get("/warranties", rt.handler.PortalHandler.ListWarranties)
+				r.Get("/warranties/{id}", rt.handler.PortalHandler.GetWarrantyDetailsByID)
+				r.Get("/warranties/{id}/certificate", rt.handler.PortalHandler.DownloadWarrantyCertificate)
+				r.Get("/claims", rt.handler.PortalHandler.ListClaims)
+				r.Post("/claims", rt.handler.PortalHandler.SubmitClaim)
+				r.Post("/uploads", rt.handler.PortalHandler.UploadClaimPhoto)
+			})
+		})
 
 		// Protected routes (require JWT authentication)
 		r.Group(func(r chi.Router) {
@@ -63,6 +82,22 @@ func (rt *Routes) RegisterRoutes(router chi.Router) {
 				r.Get("/types", rt.handler.ProductsHandler.GetProductTypes)
 				r.Get("/series", rt.handler.ProductsHandler.GetProductSeries)
 				r.Get("/names", rt.handler.ProductsHandler.GetProductNames)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Post("/brands", rt.handler.ProductsHandler.CreateProductBrand)
+					r.Put("/brands/{id}", rt.handler.ProductsHandler.UpdateProductBrand)
+					r.Put("/brands/{id}/active", rt.handler.ProductsHandler.SetProductBrandActive)
+					r.Post("/types", rt.handler.ProductsHandler.CreateProductType)
+					r.Put("/types/{id}", rt.handler.ProductsHandler.UpdateProductType)
+					r.Put("/types/{id}/active", rt.handler.ProductsHandler.SetProductTypeActive)
+					r.Post("/series", rt.handler.ProductsHandler.CreateProductSeries)
+					r.Put("/series/{id}", rt.handler.ProductsHandler.UpdateProductSeries)
+					r.Put("/series/{id}/active", rt.handler.ProductsHandler.SetProductSeriesActive)
+					r.Post("/names", rt.handler.ProductsHandler.CreateProductName)
+					r.Put("/names/{id}", rt.handler.ProductsHandler.UpdateProductName)
+					r.Put("/names/{id}/active", rt.handler.ProductsHandler.SetProductNameActive)
+				})
 			})
 
 			r.Route("/shops", func(r chi.Router) {
@@ -83,6 +118,15 @@ func (rt *Routes) RegisterRoutes(router chi.Router) {
 				r.Put("/{id}", rt.handler.ProductAllocationsHandler.UpdateProductAllocation)
 
 				r.Get("/products-by-shop/{shop_id}", rt.handler.ProductAllocationsHandler.GetProductsFromProductAllocationsByShopID)
+				r.Get("/balances/by-shop/{shop_id}", rt.handler.ProductAllocationsHandler.ListProductAllocationBalancesByShopID)
+				r.Get("/transfers", rt.handler.ProductAllocationsHandler.ListProductAllocationTransfers)
+				r.Get("/lookup", rt.handler.ProductAllocationsHandler.LookupFilmLabel)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Post("/{id}/returns", rt.handler.ProductAllocationsHandler.ReturnProductAllocation)
+					r.Post("/{id}/transfers", rt.handler.ProductAllocationsHandler.TransferProductAllocation)
+				})
 			})
 
 			r.Route("/warranties", func(r chi.Router) {
@@ -107,29 +151,245 @@ func (rt *Routes) RegisterRoutes(router chi.Router) {
 				})
 			})
 
+			r.Route("/vehicles", func(r chi.Router) {
+				r.Get("/{id}", rt.handler.VehiclesHandler.GetVehicleDetailsByID)
+			})
+
+			r.Route("/public-lookups", func(r chi.Router) {
+				r.Use(middlewares.HQOnlyMiddleware)
+				r.Get("/", rt.handler.PublicLookupsHandler.ListPublicLookupLogs)
+				r.Get("/summary", rt.handler.PublicLookupsHandler.ListPublicLookupSummaries)
+			})
+
+			r.Route("/approval-rules", func(r chi.Router) {
+				r.Get("/", rt.handler.ApprovalRulesHandler.ListApprovalRules)
+				r.With(middlewares.HQOnlyMiddleware).Put("/{entity_type}", rt.handler.ApprovalRulesHandler.UpdateApprovalRule)
+			})
+
+			r.Route("/claim-sla", func(r chi.Router) {
+				r.Get("/policies", rt.handler.ClaimSLAHandler.ListClaimSlaPolicies)
+				r.Get("/holidays", rt.handler.ClaimSLAHandler.ListPublicHolidays)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Put("/policies/{status}", rt.handler.ClaimSLAHandler.UpsertClaimSlaPolicy)
+					r.Post("/holidays", rt.handler.ClaimSLAHandler.CreatePublicHoliday)
+					r.Put("/holidays/{id}", rt.handler.ClaimSLAHandler.UpdatePublicHoliday)
+					r.Delete("/holidays/{id}", rt.handler.ClaimSLAHandler.DeletePublicHoliday)
+					r.Post("/escalations", rt.handler.ClaimSLAHandler.EscalateOverdueClaims)
+				})
+			})
+
+			r.Route("/reimbursement-statements", func(r chi.Router) {
+				r.With(middlewares.HQOnlyMiddleware).Get("/", rt.handler.ReimbursementsHandler.ListReimbursementStatements)
+				r.Get("/by-shop/{shop_id}", rt.handler.ReimbursementsHandler.ListReimbursementStatementsByShopID)
+				r.Get("/{id}", rt.handler.ReimbursementsHandler.GetReimbursementStatementByID)
+				r.Post("/", rt.handler.ReimbursementsHandler.CreateReimbursementStatement)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Put("/{id}/review", rt.handler.ReimbursementsHandler.ReviewReimbursementStatement)
+					r.Get("/{id}/export", rt.handler.ReimbursementsHandler.ExportReimbursementStatement)
+				})
+			})
+
+			r.Route("/inventory", func(r chi.Router) {
+				r.Get("/balances/by-shop/{shop_id}", rt.handler.InventoryHandler.ListShopInventoryBalances)
+				r.Get("/movements/by-shop/{shop_id}", rt.handler.InventoryHandler.ListShopInventoryMovements)
+
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Get("/balances", rt.handler.InventoryHandler.ListInventoryBalances)
+					r.Get("/balances/hq", rt.handler.InventoryHandler.ListHQInventoryBalances)
+					r.Get("/movements", rt.handler.InventoryHandler.ListInventoryMovements)
+					r.Post("/adjustments", rt.handler.InventoryHandler.AdjustInventory)
+					r.Post("/write-offs", rt.handler.InventoryHandler.WriteOffInventory)
+				})
+			})
+
+			r.Route("/shipments", func(r chi.Router) {
+				r.Use(middlewares.HQOnlyMiddleware)
+				r.Get("/", rt.handler.ShipmentsHandler.ListShipments)
+				r.Post("/", rt.handler.ShipmentsHandler.CreateShipment)
+				r.Post("/import", rt.handler.ShipmentsHandler.ImportShipments)
+				r.Get("/{id}", rt.handler.ShipmentsHandler.GetShipmentByID)
+				r.Post("/{id}/receive", rt.handler.ShipmentsHandler.ReceiveShipment)
+			})
+
+			r.Route("/recalls", func(r chi.Router) {
+				r.Use(middlewares.HQOnlyMiddleware)
+				r.Get("/", rt.handler.RecallsHandler.ListRecalls)
+				r.Post("/", rt.handler.RecallsHandler.CreateRecall)
+				r.Get("/{id}", rt.handler.RecallsHandler.GetRecallImpact)
+				r.Get("/{id}/export", rt.handler.RecallsHandler.ExportRecallImpact)
+				r.Post("/{id}/close", rt.handler.RecallsHandler.CloseRecall)
+				r.Post("/{id}/notify", rt.handler.RecallsHandler.NotifyRecallCustomers)
+			})
+
+			r.Route("/reorders", func(r chi.Router) {
+				r.Get("/thresholds/by-shop/{shop_id}", rt.handler.ReordersHandler.ListShopStockThresholds)
+				r.Get("/alerts/by-shop/{shop_id}", rt.handler.ReordersHandler.ListShopLowStockAlerts)
+				r.Get("/requests/by-shop/{shop_id}", rt.handler.ReordersHandler.ListShopReorderRequests)
+				r.Get("/requests/{id}", rt.handler.ReordersHandler.GetReorderRequestByID)
+				r.Post("/requests", rt.handler.ReordersHandler.CreateReorderRequest)
+				r.Post("/requests/{id}/cancel", rt.handler.ReordersHandler.CancelReorderRequest)
+
+				// Thresholds and the review of requests are reserved for HQ
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Get("/thresholds", rt.handler.ReordersHandler.ListStockThresholds)
+					r.Put("/thresholds", rt.handler.ReordersHandler.UpsertStockThreshold)
+					r.Delete("/thresholds/{id}", rt.handler.ReordersHandler.DeleteStockThreshold)
+					r.Get("/alerts", rt.handler.ReordersHandler.ListLowStockAlerts)
+					r.Post("/alerts/check", rt.handler.ReordersHandler.CheckLowStock)
+					r.Get("/requests", rt.handler.ReordersHandler.ListReorderRequests)
+					r.Post("/requests/{id}/reject", rt.handler.ReordersHandler.RejectReorderRequest)
+					r.Post("/requests/{id}/approve", rt.handler.ReordersHandler.ApproveReorderRequest)
+				})
+			})
+
+			r.Route("/stock-takes", func(r chi.Router) {
+				r.Get("/by-shop/{shop_id}", rt.handler.StockTakesHandler.ListShopStockTakes)
+				r.Post("/", rt.handler.StockTakesHandler.OpenStockTake)
+				r.Get("/{id}", rt.handler.StockTakesHandler.GetStockTakeByID)
+				r.Get("/{id}/export", rt.handler.StockTakesHandler.ExportStockTakeVariances)
+				r.Post("/{id}/counts", rt.handler.StockTakesHandler.RecordStockTakeCounts)
+				r.Post("/{id}/submit", rt.handler.StockTakesHandler.SubmitStockTake)
+				r.Post("/{id}/cancel", rt.handler.StockTakesHandler.CancelStockTake)
+
+				// The review, posting and discarding of submitted stock takes are reserved for HQ
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Get("/", rt.handler.StockTakesHandler.ListStockTakes)
+					r.Post("/{id}/lines/{line_id}/review", rt.handler.StockTakesHandler.ReviewStockTakeLine)
+					r.Post("/{id}/post", rt.handler.StockTakesHandler.PostStockTake)
+					r.Post("/{id}/discard", rt.handler.StockTakesHandler.DiscardStockTake)
+				})
+			})
+
+			r.Route("/customers", func(r chi.Router) {
+				r.Get("/", rt.handler.CustomersHandler.ListCustomers)
+				r.Get("/{id}", rt.handler.CustomersHandler.GetCustomerDetailsByID)
+
+				// Deduplication is reserved for HQ
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Get("/duplicates", rt.handler.CustomersHandler.ListDuplicateCustomers)
+					r.Get("/{id}/candidates", rt.handler.CustomersHandler.ListCustomerCandidatesByCustomerID)
+					r.Post("/{id}/merge", rt.handler.CustomersHandler.MergeCustomers)
+				})
+			})
+
+			r.Route("/car-makes", func(r chi.Router) {
+				r.Get("/", rt.handler.CarsHandler.ListCarMakes)
+				r.Get("/resolve", rt.handler.CarsHandler.ResolveCarMakeAndModel)
+				r.Get("/{id}", rt.handler.CarsHandler.GetCarMakeByID)
+				r.Get("/{id}/models", rt.handler.CarsHandler.ListCarModelsByMakeID)
+
+				// Maintaining makes and their aliases is reserved for HQ
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Post("/", rt.handler.CarsHandler.CreateCarMake)
+					r.Put("/{id}", rt.handler.CarsHandler.UpdateCarMake)
+					r.Delete("/{id}", rt.handler.CarsHandler.DeleteCarMake)
+					r.Get("/{id}/aliases", rt.handler.CarsHandler.ListCarMakeAliasesByMakeID)
+					r.Post("/{id}/aliases", rt.handler.CarsHandler.CreateCarMakeAlias)
+					r.Delete("/aliases/{alias_id}", rt.handler.CarsHandler.DeleteCarMakeAlias)
+				})
+			})
+
+			r.Route("/car-models", func(r chi.Router) {
+				r.Get("/{id}", rt.handler.CarsHandler.GetCarModelByID)
+
+				// Maintaining models and their aliases is reserved for HQ
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Post("/", rt.handler.CarsHandler.CreateCarModel)
+					r.Put("/{id}", rt.handler.CarsHandler.UpdateCarModel)
+					r.Delete("/{id}", rt.handler.CarsHandler.DeleteCarModel)
+					r.Get("/{id}/aliases", rt.handler.CarsHandler.ListCarModelAliasesByModelID)
+					r.Post("/{id}/aliases", rt.handler.CarsHandler.CreateCarModelAlias)
+					r.Delete("/aliases/{alias_id}", rt.handler.CarsHandler.DeleteCarModelAlias)
+				})
+			})
+
 			r.Route("/claims", func(r chi.Router) {
 				r.Get("/by-shop/{shop_id}", rt.handler.ClaimsHandler.GetClaimsByShopID)
 				r.Get("/", rt.handler.ClaimsHandler.ListClaims)
 				r.Get("/{id}", rt.handler.ClaimsHandler.GetClaimByID)
 				r.Post("/generate-claim-no", rt.handler.ClaimsHandler.GenerateNextClaimNo)
+				r.Post("/eligibility", rt.handler.ClaimsHandler.CheckClaimEligibility)
 				r.Post("/", rt.handler.ClaimsHandler.CreateClaimWithParts)
 				r.Put("/{id}", rt.handler.ClaimsHandler.UpdateClaimWithParts)
 				r.Put("/{id}/approval", rt.handler.ClaimsHandler.UpdateClaimApproval)
 				r.Put("/{id}/status", rt.handler.ClaimsHandler.UpdateClaimStatus)
+				r.Get("/{id}/transitions", rt.handler.ClaimsHandler.ListClaimStatusTransitions)
+				r.Get("/{id}/resolutions", rt.handler.ClaimsHandler.ListClaimResolutions)
 
 				r.Get("/{id}/details", rt.handler.ClaimsHandler.GetClaimWithPartsByID)
 
 				r.Route("/claim-warranty-parts", func(r chi.Router) {
 					r.Get("/{id}", rt.handler.ClaimsHandler.GetClaimWarrantyPartsByClaimID)
 					r.Put("/{id}/approval", rt.handler.ClaimsHandler.UpdateClaimWarrantyPartApproval)
-					r.Put("/{id}/status", rt.handler.ClaimsHandler.UpdateClaimWarrantyPartStatus)
+					r.Put("/{id}/resolution", rt.handler.ClaimsHandler.RecordClaimResolution)
+				})
+
+				r.Route("/exclusions", func(r chi.Router) {
+					r.Get("/", rt.handler.ClaimsHandler.ListClaimExclusions)
+					r.Get("/{id}", rt.handler.ClaimsHandler.GetClaimExclusionByID)
+					r.With(middlewares.HQOnlyMiddleware).Post("/", rt.handler.ClaimsHandler.CreateClaimExclusion)
+					r.With(middlewares.HQOnlyMiddleware).Put("/{id}", rt.handler.ClaimsHandler.UpdateClaimExclusion)
 				})
 			})
 
+			r.Route("/comments", func(r chi.Router) {
+				r.Get("/unread", rt.handler.CommentsHandler.ListUnreadCommentCounts)
+
+				r.Get("/warranties/{id}", rt.handler.CommentsHandler.ListWarrantyComments)
+				r.Post("/warranties/{id}", rt.handler.CommentsHandler.CreateWarrantyComment)
+				r.Post("/warranties/{id}/read", rt.handler.CommentsHandler.MarkWarrantyCommentsRead)
+
+				r.Get("/claims/{id}", rt.handler.CommentsHandler.ListClaimComments)
+				r.Post("/claims/{id}", rt.handler.CommentsHandler.CreateClaimComment)
+				r.Post("/claims/{id}/read", rt.handler.CommentsHandler.MarkClaimCommentsRead)
+			})
+
+			r.Route("/analytics", func(r chi.Router) {
+				r.Use(middlewares.HQOnlyMiddleware)
+				r.Get("/quality/claim-rates", rt.handler.AnalyticsHandler.ListClaimRates)
+				r.Get("/quality/time-to-failure", rt.handler.AnalyticsHandler.ListTimeToFailure)
+			})
+
 			r.Route("/uploads", func(r chi.Router) {
 				r.Post("/file", rt.handler.UploadsHandler.UploadFile)
 				r.Post("/files", rt.handler.UploadsHandler.UploadMultipleFiles)
 			})
+
+			r.Route("/notifications", func(r chi.Router) {
+				r.Use(middlewares.HQOnlyMiddleware)
+				r.Get("/", rt.handler.NotificationsHandler.ListNotifications)
+				r.Get("/{id}", rt.handler.NotificationsHandler.GetNotificationByID)
+				r.Post("/{id}/retry", rt.handler.NotificationsHandler.RetryNotification)
+				r.Get("/by-warranty/{warranty_id}", rt.handler.NotificationsHandler.GetNotificationsByWarrantyID)
+				r.Get("/by-claim/{claim_id}", rt.handler.NotificationsHandler.GetNotificationsByClaimID)
+			})
+
+			r.Route("/reminders", func(r chi.Router) {
+				r.Get("/rules", rt.handler.RemindersHandler.ListReminderRules)
+				r.Get("/rules/{id}", rt.handler.RemindersHandler.GetReminderRuleByID)
+				r.Get("/renewal-leads/by-shop/{shop_id}", rt.handler.RemindersHandler.ListRenewalLeadsByShopID)
+
+				// Reminder rules, running them, and the deliveries and renewal leads of all shops
+				// are reserved for HQ
+				r.Group(func(r chi.Router) {
+					r.Use(middlewares.HQOnlyMiddleware)
+					r.Post("/rules", rt.handler.RemindersHandler.CreateReminderRule)
+					r.Put("/rules/{id}", rt.handler.RemindersHandler.UpdateReminderRule)
+					r.Get("/rules/{id}/deliveries", rt.handler.RemindersHandler.ListReminderDeliveriesByRuleID)
+					r.Post("/run", rt.handler.RemindersHandler.RunReminderRules)
+					r.Get("/renewal-leads", rt.handler.RemindersHandler.ListRenewalLeads)
+				})
+			})
 		})
 	})
 }
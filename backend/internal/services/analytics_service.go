@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/analytics"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// QualityReportFilter selects the installations and claims a quality report covers. Nil dates
+// leave the range open.
+type QualityReportFilter struct {
+	Dimension     string
+	InstalledFrom *time.Time
+	InstalledTo   *time.Time
+	ClaimedFrom   *time.Time
+	ClaimedTo     *time.Time
+}
+
+type AnalyticsService interface {
+	ListClaimRates(ctx context.Context, filter *QualityReportFilter) ([]*analytics.ListClaimRatesByDimensionRow, error)
+	ListTimeToFailure(ctx context.Context, filter *QualityReportFilter) ([]*analytics.ListTimeToFailureByDimensionRow, error)
+}
+
+type analyticsService struct {
+	db *pgxpool.Pool
+	q  *analytics.Queries
+}
+
+func NewAnalyticsService(db *pgxpool.Pool) AnalyticsService {
+	return &analyticsService{
+		db: db,
+		q:  analytics.New(db),
+	}
+}
+
+// ListClaimRates retrieves the claims per 100 installations of each group of the dimension from the database.
+func (s *analyticsService) ListClaimRates(ctx context.Context, filter *QualityReportFilter) ([]*analytics.ListClaimRatesByDimensionRow, error) {
+	if err := validateQualityReportFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.q.ListClaimRatesByDimension(ctx, &analytics.ListClaimRatesByDimensionParams{
+		Dimension:     filter.Dimension,
+		InstalledFrom: filter.InstalledFrom,
+		InstalledTo:   filter.InstalledTo,
+		ClaimedFrom:   filter.ClaimedFrom,
+		ClaimedTo:     filter.ClaimedTo,
+	})
+}
+
+// ListTimeToFailure retrieves the time from installation to first claim of each group of the dimension from the database.
+func (s *analyticsService) ListTimeToFailure(ctx context.Context, filter *QualityReportFilter) ([]*analytics.ListTimeToFailureByDimensionRow, error) {
+	if err := validateQualityReportFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.q.ListTimeToFailureByDimension(ctx, &analytics.ListTimeToFailureByDimensionParams{
+		Dimension:     filter.Dimension,
+		InstalledFrom: filter.InstalledFrom,
+		InstalledTo:   filter.InstalledTo,
+		ClaimedFrom:   filter.ClaimedFrom,
+		ClaimedTo:     filter.ClaimedTo,
+	})
+}
+
+// validateQualityReportFilter checks the dimension and that no date range ends before it starts.
+func validateQualityReportFilter(filter *QualityReportFilter) error {
+	if !slices.Contains(models.QualityDimensions, filter.Dimension) {
+		return fmt.Errorf("invalid dimension: %s", filter.Dimension)
+	}
+	if filter.InstalledFrom != nil && filter.InstalledTo != nil && filter.InstalledTo.Before(*filter.InstalledFrom) {
+		return fmt.Errorf("installation range ends before it starts")
+	}
+	if filter.ClaimedFrom != nil && filter.ClaimedTo != nil && filter.ClaimedTo.Before(*filter.ClaimedFrom) {
+		return fmt.Errorf("claim range ends before it starts")
+	}
+	return nil
+}
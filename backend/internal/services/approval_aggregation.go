@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/approvals"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// ErrInvalidApprovalStatus is returned when a warranty or claim is given an approval status that
+// cannot be set on it directly.
+var ErrInvalidApprovalStatus = errors.New("invalid approval status")
+
+// checkParentApprovalStatus validates the approval status set directly on a warranty or claim,
+// which is then applied to all of its parts. PARTIALLY_APPROVED is rejected because it is only
+// ever derived from the parts by aggregateApproval and has no meaning for a single part.
+func checkParentApprovalStatus(status models.ApprovalStatus) error {
+	switch status {
+	case models.ApprovalStatusApproved, models.ApprovalStatusRejected, models.ApprovalStatusPending:
+		return nil
+	}
+	return fmt.Errorf("%w: %q cannot be set directly", ErrInvalidApprovalStatus, status)
+}
+
+// loadApprovalRule returns the approval rule for the entity type using the given connection or
+// transaction.
+func loadApprovalRule(ctx context.Context, db approvals.DBTX, entityType string) (*approvals.ApprovalRule, error) {
+	return approvals.New(db).GetApprovalRuleByEntityType(ctx, entityType)
+}
+
+// aggregateApproval derives the approval status of a warranty or claim from the approval
+// status of its parts. A parent without parts stays pending. When all parts agree the parent
+// takes their status; otherwise the rule decides between pending, partially approved and the
+// configured status for a mix of approved and rejected parts.
+func aggregateApproval(rule *approvals.ApprovalRule, parts []models.ApprovalStatus) models.ApprovalStatus {
+	var approved, rejected, pending int
+	for _, status := range parts {
+		switch status {
+		case models.ApprovalStatusApproved:
+			approved++
+		case models.ApprovalStatusRejected:
+			rejected++
+		default:
+			pending++
+		}
+	}
+
+	switch {
+	case len(parts) == 0:
+		return models.ApprovalStatusPending
+	case approved == len(parts):
+		return models.ApprovalStatusApproved
+	case rejected == len(parts):
+		return models.ApprovalStatusRejected
+	case pending > 0:
+		if approved > 0 && rule.PartialWhilePending {
+			return models.ApprovalStatusPartiallyApproved
+		}
+		return models.ApprovalStatusPending
+	default:
+		return models.ApprovalStatus(rule.MixedStatus)
+	}
+}
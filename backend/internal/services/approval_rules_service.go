@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/approvals"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+type ApprovalRulesService interface {
+	ListApprovalRules(ctx context.Context) ([]*approvals.ApprovalRule, error)
+	UpdateApprovalRule(ctx context.Context, arg *approvals.UpdateApprovalRuleParams) (*approvals.ApprovalRule, error)
+}
+
+type approvalRulesService struct {
+	db *pgxpool.Pool
+	q  *approvals.Queries
+}
+
+func NewApprovalRulesService(db *pgxpool.Pool) ApprovalRulesService {
+	return &approvalRulesService{
+		db: db,
+		q:  approvals.New(db),
+	}
+}
+
+// ListApprovalRules retrieves the approval rules of warranties and claims from the database.
+func (s *approvalRulesService) ListApprovalRules(ctx context.Context) ([]*approvals.ApprovalRule, error) {
+	return s.q.ListApprovalRules(ctx)
+}
+
+// UpdateApprovalRule updates the approval rule of warranties or claims in the database. The new
+// rule applies the next time a part approval changes.
+func (s *approvalRulesService) UpdateApprovalRule(ctx context.Context, arg *approvals.UpdateApprovalRuleParams) (*approvals.ApprovalRule, error) {
+	switch models.ApprovalStatus(arg.MixedStatus) {
+	case models.ApprovalStatusPartiallyApproved, models.ApprovalStatusApproved, models.ApprovalStatusRejected, models.ApprovalStatusPending:
+	default:
+		return nil, fmt.Errorf("invalid mixed status %q", arg.MixedStatus)
+	}
+	return s.q.UpdateApprovalRule(ctx, arg)
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/cars"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// CarResolution is the catalog make and model matched by a free-text car make and model.
+type CarResolution struct {
+	CarMake  *cars.CarMake  `json:"carMake"`
+	CarModel *cars.CarModel `json:"carModel"`
+}
+
+// CarNormalizationResult describes how a distinct warranty car make and model was normalized.
+type CarNormalizationResult struct {
+	CarBrand      string `json:"carBrand"`
+	CarModel      string `json:"carModel"`
+	WarrantyCount int64  `json:"warrantyCount"`
+	NewCarBrand   string `json:"newCarBrand"`
+	NewCarModel   string `json:"newCarModel"`
+	Status        string `json:"status"`
+}
+
+type CarsService interface {
+	ListCarMakes(ctx context.Context) ([]*cars.CarMake, error)
+	GetCarMakeByID(ctx context.Context, id int32) (*cars.CarMake, error)
+	CreateCarMake(ctx context.Context, arg *cars.CreateCarMakeParams) (*cars.CarMake, error)
+	UpdateCarMake(ctx context.Context, arg *cars.UpdateCarMakeParams) (*cars.CarMake, error)
+	DeleteCarMake(ctx context.Context, id int32) error
+
+	ListCarModelsByMakeID(ctx context.Context, carMakeID int32) ([]*cars.CarModel, error)
+	GetCarModelByID(ctx context.Context, id int32) (*cars.CarModel, error)
+	CreateCarModel(ctx context.Context, arg *cars.CreateCarModelParams) (*cars.CarModel, error)
+	UpdateCarModel(ctx context.Context, arg *cars.UpdateCarModelParams) (*cars.CarModel, error)
+	DeleteCarModel(ctx context.Context, id int32) error
+
+	ListCarMakeAliasesByMakeID(ctx context.Context, carMakeID int32) ([]*cars.CarMakeAlias, error)
+	CreateCarMakeAlias(ctx context.Context, arg *cars.CreateCarMakeAliasParams) (*cars.CarMakeAlias, error)
+	DeleteCarMakeAlias(ctx context.Context, id int32) error
+
+	ListCarModelAliasesByModelID(ctx context.Context, carModelID int32) ([]*cars.CarModelAlias, error)
+	CreateCarModelAlias(ctx context.Context, arg *cars.CreateCarModelAliasParams) (*cars.CarModelAlias, error)
+	DeleteCarModelAlias(ctx context.Context, id int32) error
+
+	ResolveCarMakeAndModel(ctx context.Context, carMake, carModel string) (*CarResolution, error)
+	NormalizeWarrantyCarMakesAndModels(ctx context.Context, dryRun bool) ([]*CarNormalizationResult, error)
+}
+
+type carsService struct {
+	db *pgxpool.Pool
+	q  *cars.Queries
+}
+
+func NewCarsService(db *pgxpool.Pool) CarsService {
+	return &carsService{
+		db: db,
+		q:  cars.New(db),
+	}
+}
+
+// ListCarMakes retrieves all car makes from the database.
+func (s *carsService) ListCarMakes(ctx context.Context) ([]*cars.CarMake, error) {
+	return s.q.ListCarMakes(ctx)
+}
+
+// GetCarMakeByID retrieves a car make by its ID from the database.
+func (s *carsService) GetCarMakeByID(ctx context.Context, id int32) (*cars.CarMake, error) {
+	return s.q.GetCarMakeByID(ctx, id)
+}
+
+// CreateCarMake validates and creates a new car make in the database.
+func (s *carsService) CreateCarMake(ctx context.Context, arg *cars.CreateCarMakeParams) (*cars.CarMake, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+	if err := s.checkCarMakeName(ctx, 0, arg.Name); err != nil {
+		return nil, err
+	}
+	return s.q.CreateCarMake(ctx, arg)
+}
+
+// UpdateCarMake validates and updates an existing car make in the database.
+func (s *carsService) UpdateCarMake(ctx context.Context, arg *cars.UpdateCarMakeParams) (*cars.CarMake, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+	if err := s.checkCarMakeName(ctx, arg.ID, arg.Name); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateCarMake(ctx, arg)
+}
+
+// DeleteCarMake deletes a car make along with its models and aliases from the database.
+func (s *carsService) DeleteCarMake(ctx context.Context, id int32) error {
+	return s.q.DeleteCarMake(ctx, id)
+}
+
+// ListCarModelsByMakeID retrieves the models of a car make from the database.
+func (s *carsService) ListCarModelsByMakeID(ctx context.Context, carMakeID int32) ([]*cars.CarModel, error) {
+	return s.q.ListCarModelsByMakeID(ctx, carMakeID)
+}
+
+// GetCarModelByID retrieves a car model by its ID from the database.
+func (s *carsService) GetCarModelByID(ctx context.Context, id int32) (*cars.CarModel, error) {
+	return s.q.GetCarModelByID(ctx, id)
+}
+
+// CreateCarModel validates and creates a new car model in the database.
+func (s *carsService) CreateCarModel(ctx context.Context, arg *cars.CreateCarModelParams) (*cars.CarModel, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+	if err := s.checkCarModelName(ctx, 0, arg.CarMakeID, arg.Name); err != nil {
+		return nil, err
+	}
+	return s.q.CreateCarModel(ctx, arg)
+}
+
+// UpdateCarModel validates and updates an existing car model in the database.
+func (s *carsService) UpdateCarModel(ctx context.Context, arg *cars.UpdateCarModelParams) (*cars.CarModel, error) {
+	arg.Name = strings.TrimSpace(arg.Name)
+	if err := s.checkCarModelName(ctx, arg.ID, arg.CarMakeID, arg.Name); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateCarModel(ctx, arg)
+}
+
+// DeleteCarModel deletes a car model along with its aliases from the database.
+func (s *carsService) DeleteCarModel(ctx context.Context, id int32) error {
+	return s.q.DeleteCarModel(ctx, id)
+}
+
+// ListCarMakeAliasesByMakeID retrieves the aliases of a car make from the database.
+func (s *carsService) ListCarMakeAliasesByMakeID(ctx context.Context, carMakeID int32) ([]*cars.CarMakeAlias, error) {
+	return s.q.ListCarMakeAliasesByMakeID(ctx, carMakeID)
+}
+
+// CreateCarMakeAlias validates and creates a new car make alias in the database.
+func (s *carsService) CreateCarMakeAlias(ctx context.Context, arg *cars.CreateCarMakeAliasParams) (*cars.CarMakeAlias, error) {
+	arg.Alias = strings.TrimSpace(arg.Alias)
+	if _, err := s.q.GetCarMakeByID(ctx, arg.CarMakeID); err != nil {
+		return nil, fmt.Errorf("car make %d not found", arg.CarMakeID)
+	}
+	if err := s.checkCarMakeName(ctx, arg.CarMakeID, arg.Alias); err != nil {
+		return nil, err
+	}
+	return s.q.CreateCarMakeAlias(ctx, arg)
+}
+
+// DeleteCarMakeAlias deletes a car make alias from the database.
+func (s *carsService) DeleteCarMakeAlias(ctx context.Context, id int32) error {
+	return s.q.DeleteCarMakeAlias(ctx, id)
+}
+
+// ListCarModelAliasesByModelID retrieves the aliases of a car model from the database.
+func (s *carsService) ListCarModelAliasesByModelID(ctx context.Context, carModelID int32) ([]*cars.CarModelAlias, error) {
+	return s.q.ListCarModelAliasesByModelID(ctx, carModelID)
+}
+
+// CreateCarModelAlias validates and creates a new car model alias in the database.
+func (s *carsService) CreateCarModelAlias(ctx context.Context, arg *cars.CreateCarModelAliasParams) (*cars.CarModelAlias, error) {
+	arg.Alias = strings.TrimSpace(arg.Alias)
+	model, err := s.q.GetCarModelByID(ctx, arg.CarModelID)
+	if err != nil {
+		return nil, fmt.Errorf("car model %d not found", arg.CarModelID)
+	}
+	if err := s.checkCarModelName(ctx, arg.CarModelID, model.CarMakeID, arg.Alias); err != nil {
+		return nil, err
+	}
+	return s.q.CreateCarModelAlias(ctx, arg)
+}
+
+// DeleteCarModelAlias deletes a car model alias from the database.
+func (s *carsService) DeleteCarModelAlias(ctx context.Context, id int32) error {
+	return s.q.DeleteCarModelAlias(ctx, id)
+}
+
+// ResolveCarMakeAndModel matches a free-text car make and model against the catalog.
+func (s *carsService) ResolveCarMakeAndModel(ctx context.Context, carMake, carModel string) (*CarResolution, error) {
+	catalog, err := loadCarCatalog(ctx, s.q)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.resolve(carMake, carModel)
+}
+
+// NormalizeWarrantyCarMakesAndModels rewrites the free-text car make and model of existing
+// warranties to their catalog names. Values that do not match the catalog are left untouched
+// and reported so that the catalog or its aliases can be extended. With dryRun set the
+// changes are rolled back.
+func (s *carsService) NormalizeWarrantyCarMakesAndModels(ctx context.Context, dryRun bool) ([]*CarNormalizationResult, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := cars.New(tx)
+
+	catalog, err := loadCarCatalog(ctx, qtx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	rows, err := qtx.ListWarrantyCarMakesAndModels(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	results := make([]*CarNormalizationResult, 0, len(rows))
+	for _, row := range rows {
+		result := &CarNormalizationResult{
+			CarBrand:      row.CarBrand,
+			CarModel:      row.CarModel,
+			WarrantyCount: row.WarrantyCount,
+			NewCarBrand:   row.CarBrand,
+			NewCarModel:   row.CarModel,
+		}
+		results = append(results, result)
+
+		carMake := catalog.makes[carCatalogKey(row.CarBrand)]
+		if carMake == nil {
+			result.Status = models.CarNormalizationStatusUnmatchedMake
+			continue
+		}
+		result.NewCarBrand = carMake.Name
+		result.Status = models.CarNormalizationStatusNormalized
+		if carModel := catalog.models[carMake.ID][carCatalogKey(row.CarModel)]; carModel != nil {
+			result.NewCarModel = carModel.Name
+		} else {
+			result.Status = models.CarNormalizationStatusUnmatchedModel
+		}
+
+		if result.NewCarBrand == row.CarBrand && result.NewCarModel == row.CarModel {
+			if result.Status == models.CarNormalizationStatusNormalized {
+				result.Status = models.CarNormalizationStatusUnchanged
+			}
+			continue
+		}
+
+		_, err := qtx.UpdateWarrantiesCarMakeAndModel(ctx, &cars.UpdateWarrantiesCarMakeAndModelParams{
+			NewCarBrand: result.NewCarBrand,
+			NewCarModel: result.NewCarModel,
+			CarBrand:    row.CarBrand,
+			CarModel:    row.CarModel,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		tx.Rollback(ctx)
+		return results, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// checkCarMakeName ensures a make name or alias does not already identify a different make.
+func (s *carsService) checkCarMakeName(ctx context.Context, carMakeID int32, name string) error {
+	if carCatalogKey(name) == "" {
+		return fmt.Errorf("car make name is required")
+	}
+	catalog, err := loadCarCatalog(ctx, s.q)
+	if err != nil {
+		return err
+	}
+	if existing := catalog.makes[carCatalogKey(name)]; existing != nil && existing.ID != carMakeID {
+		return fmt.Errorf("%q already refers to car make %s", name, existing.Name)
+	}
+	return nil
+}
+
+// checkCarModelName ensures a model name or alias does not already identify a different model of the make.
+func (s *carsService) checkCarModelName(ctx context.Context, carModelID, carMakeID int32, name string) error {
+	if carCatalogKey(name) == "" {
+		return fmt.Errorf("car model name is required")
+	}
+	if _, err := s.q.GetCarMakeByID(ctx, carMakeID); err != nil {
+		return fmt.Errorf("car make %d not found", carMakeID)
+	}
+	catalog, err := loadCarCatalog(ctx, s.q)
+	if err != nil {
+		return err
+	}
+	if existing := catalog.models[carMakeID][carCatalogKey(name)]; existing != nil && existing.ID != carModelID {
+		return fmt.Errorf("%q already refers to car model %s", name, existing.Name)
+	}
+	return nil
+}
+
+// carCatalog indexes the car makes and models by their names and aliases.
+type carCatalog struct {
+	makes  map[string]*cars.CarMake
+	models map[int32]map[string]*cars.CarModel
+}
+
+// loadCarCatalog loads the car makes, models and aliases into a carCatalog.
+func loadCarCatalog(ctx context.Context, q *cars.Queries) (*carCatalog, error) {
+	makes, err := q.ListCarMakes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	makeAliases, err := q.ListCarMakeAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	carModels, err := q.ListCarModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	modelAliases, err := q.ListCarModelAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &carCatalog{
+		makes:  make(map[string]*cars.CarMake),
+		models: make(map[int32]map[string]*cars.CarModel),
+	}
+	makesByID := make(map[int32]*cars.CarMake, len(makes))
+	for _, m := range makes {
+		makesByID[m.ID] = m
+		catalog.makes[carCatalogKey(m.Name)] = m
+	}
+	for _, a := range makeAliases {
+		if m, ok := makesByID[a.CarMakeID]; ok {
+			catalog.makes[carCatalogKey(a.Alias)] = m
+		}
+	}
+
+	modelsByID := make(map[int32]*cars.CarModel, len(carModels))
+	for _, m := range carModels {
+		modelsByID[m.ID] = m
+		if catalog.models[m.CarMakeID] == nil {
+			catalog.models[m.CarMakeID] = make(map[string]*cars.CarModel)
+		}
+		catalog.models[m.CarMakeID][carCatalogKey(m.Name)] = m
+	}
+	for _, a := range modelAliases {
+		if m, ok := modelsByID[a.CarModelID]; ok {
+			catalog.models[m.CarMakeID][carCatalogKey(a.Alias)] = m
+		}
+	}
+	return catalog, nil
+}
+
+// resolve returns the active catalog make and model matching the given names.
+func (c *carCatalog) resolve(carMake, carModel string) (*CarResolution, error) {
+	m := c.makes[carCatalogKey(carMake)]
+	if m == nil {
+		return nil, fmt.Errorf("unknown car make %q", carMake)
+	}
+	if !m.IsActive {
+		return nil, fmt.Errorf("car make %s is inactive", m.Name)
+	}
+	model := c.models[m.ID][carCatalogKey(carModel)]
+	if model == nil {
+		return nil, fmt.Errorf("unknown car model %q for %s", carModel, m.Name)
+	}
+	if !model.IsActive {
+		return nil, fmt.Errorf("car model %s %s is inactive", m.Name, model.Name)
+	}
+	return &CarResolution{CarMake: m, CarModel: model}, nil
+}
+
+// resolveCarMakeAndModel validates a warranty's car make and model against the catalog and
+// returns their catalog names.
+func resolveCarMakeAndModel(ctx context.Context, db cars.DBTX, carMake, carModel string) (string, string, error) {
+	catalog, err := loadCarCatalog(ctx, cars.New(db))
+	if err != nil {
+		return "", "", err
+	}
+	resolution, err := catalog.resolve(carMake, carModel)
+	if err != nil {
+		return "", "", err
+	}
+	return resolution.CarMake.Name, resolution.CarModel.Name, nil
+}
+
+// carCatalogKey reduces a make or model name to lower-case letters and digits so that
+// "Mercedes Benz", "mercedes-benz" and "MERCEDES-BENZ" match the same catalog entry.
+func carCatalogKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// ClaimEligibilityReason explains why a warranty part cannot be claimed.
+type ClaimEligibilityReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ClaimPartEligibility is the eligibility of a single requested warranty part.
+type ClaimPartEligibility struct {
+	WarrantyPartID     int32                    `json:"warrantyPartId"`
+	CarPartName        string                   `json:"carPartName"`
+	CoverageExpiryDate *time.Time               `json:"coverageExpiryDate"`
+	Eligible           bool                     `json:"eligible"`
+	Reasons            []ClaimEligibilityReason `json:"reasons"`
+}
+
+// ClaimEligibility is the outcome of checking the warranty parts of a claim. The claim is only
+// eligible when every part is.
+type ClaimEligibility struct {
+	WarrantyID int32                   `json:"warrantyId"`
+	ClaimDate  time.Time               `json:"claimDate"`
+	Eligible   bool                    `json:"eligible"`
+	Parts      []*ClaimPartEligibility `json:"parts"`
+}
+
+// ClaimIneligibleError is returned when a claim includes warranty parts that cannot be claimed.
+type ClaimIneligibleError struct {
+	Eligibility *ClaimEligibility
+}
+
+func (e *ClaimIneligibleError) Error() string {
+	for _, part := range e.Eligibility.Parts {
+		if !part.Eligible {
+			return fmt.Sprintf("warranty part %d cannot be claimed: %s", part.WarrantyPartID, part.Reasons[0].Message)
+		}
+	}
+	return "claim is not eligible"
+}
+
+// checkClaimEligibility checks every requested warranty part against the warranty it must belong
+// to, the approval and void status of the warranty and the part, the coverage period on the
+// claim date, claims that are still open on the part and the active claim exclusions. All
+// failing rules are reported, not only the first. When an existing claim is edited, excludeClaimID
+// keeps it from counting as an open claim on its own parts.
+func checkClaimEligibility(ctx context.Context, q *claims.Queries, warrantyID int32, claimDate time.Time, warrantyPartIDs []int32, excludeClaimID *int32) (*ClaimEligibility, error) {
+	if len(warrantyPartIDs) == 0 {
+		return nil, fmt.Errorf("at least one warranty part is required")
+	}
+
+	rows, err := q.ListClaimEligibilityParts(ctx, &claims.ListClaimEligibilityPartsParams{
+		ExcludeClaimID:  excludeClaimID,
+		WarrantyPartIds: warrantyPartIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	partsByID := make(map[int32]*claims.ListClaimEligibilityPartsRow, len(rows))
+	for _, row := range rows {
+		partsByID[row.ID] = row
+	}
+	exclusions, err := q.ListActiveClaimExclusions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ClaimEligibility{
+		WarrantyID: warrantyID,
+		ClaimDate:  claimDate,
+		Eligible:   true,
+		Parts:      make([]*ClaimPartEligibility, 0, len(warrantyPartIDs)),
+	}
+	seen := make(map[int32]bool, len(warrantyPartIDs))
+	for _, id := range warrantyPartIDs {
+		part := &ClaimPartEligibility{
+			WarrantyPartID: id,
+			Reasons:        []ClaimEligibilityReason{},
+		}
+		row, ok := partsByID[id]
+		switch {
+		case seen[id]:
+			part.reject(models.ClaimIneligibleDuplicatePart, "warranty part is included more than once")
+		case !ok:
+			part.reject(models.ClaimIneligiblePartNotFound, "warranty part does not exist")
+		default:
+			part.CarPartName = row.CarPartName
+			part.CoverageExpiryDate = &row.CoverageExpiryDate
+			checkClaimPartEligibility(part, row, warrantyID, claimDate, exclusions)
+		}
+		seen[id] = true
+
+		part.Eligible = len(part.Reasons) == 0
+		if !part.Eligible {
+			result.Eligible = false
+		}
+		result.Parts = append(result.Parts, part)
+	}
+	return result, nil
+}
+
+// checkClaimPartEligibility applies the eligibility rules to an existing warranty part.
+func checkClaimPartEligibility(part *ClaimPartEligibility, row *claims.ListClaimEligibilityPartsRow, warrantyID int32, claimDate time.Time, exclusions []*claims.ClaimExclusion) {
+	if row.WarrantyID != warrantyID {
+		part.reject(models.ClaimIneligiblePartNotOnWarranty, fmt.Sprintf("warranty part belongs to warranty %s", row.WarrantyNo))
+		// the remaining rules are about another customer's warranty
+		return
+	}
+	if !row.WarrantyApprovalStatus.IsGranted() {
+		part.reject(models.ClaimIneligibleWarrantyNotApproved, fmt.Sprintf("warranty %s is %s", row.WarrantyNo, row.WarrantyApprovalStatus))
+	}
+	if !row.WarrantyIsActive {
+		part.reject(models.ClaimIneligibleWarrantyVoid, fmt.Sprintf("warranty %s has been voided", row.WarrantyNo))
+	}
+	if row.ApprovalStatus != models.ApprovalStatusApproved {
+		part.reject(models.ClaimIneligiblePartNotApproved, fmt.Sprintf("warranty part is %s", row.ApprovalStatus))
+	}
+	if claimDate.Before(row.InstallationDate) {
+		part.reject(models.ClaimIneligibleBeforeInstallation, fmt.Sprintf("claim date is before the installation date %s", row.InstallationDate.Format("2006-01-02")))
+	}
+	if claimDate.After(row.CoverageExpiryDate) {
+		part.reject(models.ClaimIneligibleCoverageExpired, fmt.Sprintf("coverage expired on %s", row.CoverageExpiryDate.Format("2006-01-02")))
+	}
+	if row.OpenClaimNo != nil {
+		part.reject(models.ClaimIneligibleOpenClaim, fmt.Sprintf("warranty part already has open claim %s", *row.OpenClaimNo))
+	}
+	for _, exclusion := range exclusions {
+		if claimExclusionMatches(exclusion, row) {
+			part.reject(models.ClaimIneligibleExcluded, exclusion.Reason)
+		}
+	}
+}
+
+// claimExclusionMatches reports whether every criterion set on the exclusion matches the part.
+func claimExclusionMatches(exclusion *claims.ClaimExclusion, row *claims.ListClaimEligibilityPartsRow) bool {
+	if exclusion.ProductTypeID != nil && *exclusion.ProductTypeID != row.ProductTypeID {
+		return false
+	}
+	if exclusion.ProductSeriesID != nil && *exclusion.ProductSeriesID != row.ProductSeriesID {
+		return false
+	}
+	if exclusion.ProductNameID != nil && *exclusion.ProductNameID != row.ProductNameID {
+		return false
+	}
+	if exclusion.CarPartID != nil && *exclusion.CarPartID != row.CarPartID {
+		return false
+	}
+	return true
+}
+
+func (p *ClaimPartEligibility) reject(code, message string) {
+	p.Reasons = append(p.Reasons, ClaimEligibilityReason{Code: code, Message: message})
+}
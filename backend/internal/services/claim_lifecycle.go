@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+var (
+	// ErrInvalidClaimTransition is returned when the lifecycle does not allow the status change.
+	ErrInvalidClaimTransition = errors.New("invalid claim status transition")
+	// ErrClaimTransitionForbidden is returned when the user may not make the status change.
+	ErrClaimTransitionForbidden = errors.New("claim status transition not permitted")
+	// ErrClaimTransitionIncomplete is returned when data required for the new status is missing.
+	ErrClaimTransitionIncomplete = errors.New("claim is missing data required for the status")
+	// ErrClaimLocked is returned when a claim is edited after it has left submission.
+	ErrClaimLocked = errors.New("claim can no longer be edited")
+)
+
+var (
+	anyClaimUser   = []string{models.UserRoleAdmin, models.UserRoleShopAdmin, models.UserRoleUser}
+	claimShopAdmin = []string{models.UserRoleAdmin, models.UserRoleShopAdmin}
+	claimHQAdmin   = []string{models.UserRoleAdmin}
+)
+
+// claimStatusOrder lists the claim statuses in lifecycle order.
+var claimStatusOrder = []string{
+	models.ClaimStatusSubmitted,
+	models.ClaimStatusUnderReview,
+	models.ClaimStatusApproved,
+	models.ClaimStatusAwaitingParts,
+	models.ClaimStatusInRepair,
+	models.ClaimStatusResolved,
+	models.ClaimStatusClosed,
+	models.ClaimStatusReopened,
+}
+
+// claimTransitions lists, per status, the statuses a claim may move to and the user roles
+// allowed to make each change. Reviewing, approving and closing is done by HQ; shops carry
+// out the repair.
+var claimTransitions = map[string]map[string][]string{
+	models.ClaimStatusSubmitted: {
+		models.ClaimStatusUnderReview: claimHQAdmin,
+		models.ClaimStatusClosed:      claimShopAdmin,
+	},
+	models.ClaimStatusUnderReview: {
+		models.ClaimStatusApproved: claimHQAdmin,
+		models.ClaimStatusClosed:   claimHQAdmin,
+	},
+	models.ClaimStatusApproved: {
+		models.ClaimStatusAwaitingParts: anyClaimUser,
+		models.ClaimStatusInRepair:      anyClaimUser,
+		models.ClaimStatusClosed:        claimHQAdmin,
+	},
+	models.ClaimStatusAwaitingParts: {
+		models.ClaimStatusInRepair: anyClaimUser,
+		models.ClaimStatusClosed:   claimHQAdmin,
+	},
+	models.ClaimStatusInRepair: {
+		models.ClaimStatusAwaitingParts: anyClaimUser,
+		models.ClaimStatusResolved:      anyClaimUser,
+	},
+	models.ClaimStatusResolved: {
+		models.ClaimStatusClosed:   claimHQAdmin,
+		models.ClaimStatusReopened: claimHQAdmin,
+	},
+	models.ClaimStatusClosed: {
+		models.ClaimStatusReopened: claimHQAdmin,
+	},
+	models.ClaimStatusReopened: {
+		models.ClaimStatusUnderReview: claimHQAdmin,
+		models.ClaimStatusInRepair:    anyClaimUser,
+		models.ClaimStatusClosed:      claimHQAdmin,
+	},
+}
+
+// allowedClaimStatuses returns the statuses the user may move the claim to, in lifecycle order.
+func allowedClaimStatuses(claim *claims.ClaimView, actor *claims.GetClaimActorByUserIDRow) []string {
+	statuses := []string{}
+	if !claimActorCoversShop(claim, actor) {
+		return statuses
+	}
+	for _, status := range claimStatusOrder {
+		if slices.Contains(claimTransitions[claim.Status][status], actor.Role) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// claimActorCoversShop reports whether the user works for HQ or for the shop of the claim.
+func claimActorCoversShop(claim *claims.ClaimView, actor *claims.GetClaimActorByUserIDRow) bool {
+	return actor.ShopID == nil || *actor.ShopID == claim.ShopID
+}
+
+// checkClaimTransition checks that the lifecycle allows the claim to move to the status, that
+// the user may make the change and that the data the status requires is present.
+func checkClaimTransition(ctx context.Context, q *claims.Queries, claim *claims.ClaimView, toStatus string, actor *claims.GetClaimActorByUserIDRow, remarks *string) error {
+	roles, ok := claimTransitions[claim.Status][toStatus]
+	if !ok {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidClaimTransition, claim.Status, toStatus)
+	}
+	if !slices.Contains(roles, actor.Role) || !claimActorCoversShop(claim, actor) {
+		return fmt.Errorf("%w: %s cannot move claim %s to %s", ErrClaimTransitionForbidden, actor.Role, claim.ClaimNo, toStatus)
+	}
+
+	switch toStatus {
+	case models.ClaimStatusApproved:
+		if !claim.ApprovalStatus.IsGranted() {
+			return fmt.Errorf("%w: the claim has not been approved", ErrClaimTransitionIncomplete)
+		}
+	case models.ClaimStatusResolved:
+		parts, err := q.GetClaimWarrantyPartsByClaimID(ctx, claim.ID)
+		if err != nil {
+			return err
+		}
+		resolutions, err := q.ListClaimResolutionsByClaimID(ctx, claim.ID)
+		if err != nil {
+			return err
+		}
+		recorded := map[int32]bool{}
+		for _, resolution := range resolutions {
+			recorded[resolution.ClaimWarrantyPartID] = true
+		}
+		resolved := 0
+		for _, part := range parts {
+			if part.ApprovalStatus != models.ApprovalStatusApproved {
+				continue
+			}
+			if part.ResolutionDate == nil || part.ResolutionImageUrl == nil || strings.TrimSpace(*part.ResolutionImageUrl) == "" {
+				return fmt.Errorf("%w: resolution date and resolution image are required for %s", ErrClaimTransitionIncomplete, part.CarPartName)
+			}
+			if !recorded[part.ID] {
+				return fmt.Errorf("%w: the replacement film and repair cost are required for %s", ErrClaimTransitionIncomplete, part.CarPartName)
+			}
+			resolved++
+		}
+		if resolved == 0 {
+			return fmt.Errorf("%w: the claim has no approved parts to resolve", ErrClaimTransitionIncomplete)
+		}
+	case models.ClaimStatusReopened:
+		if remarks == nil || strings.TrimSpace(*remarks) == "" {
+			return fmt.Errorf("%w: a reason is required to reopen a claim", ErrClaimTransitionIncomplete)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+var (
+	// ErrClaimResolutionForbidden is returned when the user does not work for HQ or the claim's shop.
+	ErrClaimResolutionForbidden = errors.New("claim resolution not permitted")
+	// ErrClaimResolutionLocked is returned when the claim or its resolution can no longer be changed.
+	ErrClaimResolutionLocked = errors.New("claim resolution cannot be changed")
+	// ErrInsufficientAllocationBalance is returned when the allocation has too little film left.
+	ErrInsufficientAllocationBalance = errors.New("insufficient allocation balance")
+)
+
+// claimResolutionStatuses are the claim statuses in which a repair may be recorded.
+var claimResolutionStatuses = []string{
+	models.ClaimStatusApproved,
+	models.ClaimStatusAwaitingParts,
+	models.ClaimStatusInRepair,
+	models.ClaimStatusReopened,
+}
+
+// RecordClaimResolution records the replacement film and repair cost of an approved claim part
+// on behalf of the user, together with the part's resolution date and image, in the database.
+// Recording again corrects the previous resolution until it is billed on a reimbursement
+// statement. The film is taken from an allocation of the claim's shop, may not exceed what
+// is left of it, and is posted to the inventory ledger as consumption at the shop. The quantity
+// used is given in the resolution's unit of measure and converted to rolls. An allocation being
+// counted by a stock take cannot be drawn on until the stock take is posted or cancelled, and
+// film frozen by an open recall cannot be used.
+func (s *claimsService) RecordClaimResolution(ctx context.Context, userID int32, resolution *claims.UpsertClaimResolutionParams, part *claims.UpdateClaimWarrantyPartResolutionParams) (*claims.ClaimResolution, error) {
+	unit, err := normalizeUnitOfMeasure(resolution.UnitOfMeasure)
+	if err != nil {
+		return nil, err
+	}
+	resolution.UnitOfMeasure = unit
+	if resolution.UnitQuantity <= 0 {
+		return nil, fmt.Errorf("quantity used must be greater than zero")
+	}
+	if resolution.LabourCost < 0 || resolution.MaterialCost < 0 {
+		return nil, fmt.Errorf("costs cannot be negative")
+	}
+	if part.ResolutionDate == nil {
+		return nil, fmt.Errorf("resolution date is required")
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := claims.New(tx)
+
+	claimPart, err := qtx.GetClaimWarrantyPartByID(ctx, resolution.ClaimWarrantyPartID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	claim, err := qtx.GetClaimByID(ctx, claimPart.ClaimID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	actor, err := qtx.GetClaimActorByUserID(ctx, userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if !claimActorCoversShop(claim, actor) {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: claim %s belongs to another shop", ErrClaimResolutionForbidden, claim.ClaimNo)
+	}
+	if !slices.Contains(claimResolutionStatuses, claim.Status) {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: claim %s is %s", ErrClaimResolutionLocked, claim.ClaimNo, claim.Status)
+	}
+	if claimPart.ApprovalStatus != models.ApprovalStatusApproved {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: the claim part has not been approved", ErrClaimResolutionLocked)
+	}
+
+	existing, err := qtx.GetClaimResolutionByClaimWarrantyPartID(ctx, claimPart.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err == nil && existing.ReimbursementStatementID != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: the resolution is on reimbursement statement %d", ErrClaimResolutionLocked, *existing.ReimbursementStatementID)
+	}
+
+	allocation, err := qtx.GetProductAllocationForUpdate(ctx, resolution.ProductAllocationID)
+	if err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("product allocation %d not found", resolution.ProductAllocationID)
+		}
+		return nil, err
+	}
+	if allocation.ShopID != claim.ShopID {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("product allocation %d does not belong to the claim's shop", allocation.ID)
+	}
+	if err := stockTakeLock(qtx.GetInProgressStockTakeNoByAllocationID(ctx, allocation.ID)); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := recallFreeze(qtx.GetInstallFreezeRecallNo(ctx, allocation.ID)); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	resolution.QuantityUsed, err = toRolls(resolution.UnitQuantity, resolution.UnitOfMeasure, allocation.RollLengthMetres, allocation.SheetsPerRoll)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if resolution.QuantityUsed <= 0 {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("quantity used is less than the smallest part roll")
+	}
+	// read once the allocation is locked, so that resolutions committed meanwhile are counted
+	remaining, err := qtx.GetProductAllocationRemainingQuantity(ctx, &claims.GetProductAllocationRemainingQuantityParams{
+		ClaimWarrantyPartID: claimPart.ID,
+		ProductAllocationID: allocation.ID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if remaining = roundQuantity(remaining); resolution.QuantityUsed > remaining {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %g rolls left on allocation %d, %g requested", ErrInsufficientAllocationBalance, remaining, allocation.ID, resolution.QuantityUsed)
+	}
+
+	resolution.RecordedByUserID = &actor.ID
+	record, err := qtx.UpsertClaimResolution(ctx, resolution)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := postClaimResolutionConsumption(ctx, inventory.New(tx), record, allocation.ProductID, allocation.ShopID, actor.ID); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	part.ID = claimPart.ID
+	if _, err := qtx.UpdateClaimWarrantyPartResolution(ctx, part); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// postClaimResolutionConsumption books the film used by a claim resolution as consumption at
+// the shop in the inventory ledger. When the resolution is corrected, whatever the ledger holds
+// for it is reversed first.
+func postClaimResolutionConsumption(ctx context.Context, q *inventory.Queries, record *claims.ClaimResolution, productID, shopID, userID int32) error {
+	posted, err := q.ListInventoryNetByClaimResolutionID(ctx, record.ID)
+	if err != nil {
+		return err
+	}
+	if len(posted) == 1 && posted[0].ProductAllocationID != nil &&
+		*posted[0].ProductAllocationID == record.ProductAllocationID &&
+		posted[0].Quantity == -record.QuantityUsed {
+		return nil
+	}
+
+	remarks := "Claim resolution"
+	var movements []*inventory.CreateInventoryMovementParams
+	for _, p := range posted {
+		movements = append(movements, &inventory.CreateInventoryMovementParams{
+			ProductID:           p.ProductID,
+			ShopID:              p.ShopID,
+			MovementType:        models.InventoryMovementConsumption,
+			Quantity:            -p.Quantity,
+			ProductAllocationID: p.ProductAllocationID,
+			ClaimResolutionID:   &record.ID,
+			Remarks:             &remarks,
+			CreatedByUserID:     &userID,
+		})
+	}
+	movements = append(movements, &inventory.CreateInventoryMovementParams{
+		ProductID:           productID,
+		ShopID:              &shopID,
+		MovementType:        models.InventoryMovementConsumption,
+		Quantity:            -record.QuantityUsed,
+		ProductAllocationID: &record.ProductAllocationID,
+		ClaimResolutionID:   &record.ID,
+		Remarks:             &remarks,
+		CreatedByUserID:     &userID,
+	})
+	_, err = postInventoryMovements(ctx, q, movements...)
+	return err
+}
+
+// ListClaimResolutions retrieves the resolutions recorded for the parts of a claim from the database.
+func (s *claimsService) ListClaimResolutions(ctx context.Context, claimID int32) ([]*claims.ListClaimResolutionsByClaimIDRow, error) {
+	return s.q.ListClaimResolutionsByClaimID(ctx, claimID)
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/slas"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+)
+
+// malaysiaTime is the offset SLA dates are counted in. Malaysia does not observe daylight saving.
+var malaysiaTime = time.FixedZone("MYT", 8*60*60)
+
+// ClaimSLAEscalationResult summarises an escalation run.
+type ClaimSLAEscalationResult struct {
+	// Started is the number of older claims whose SLA clock was started.
+	Started int `json:"started"`
+	// Escalated is the number of overdue claims reported to supervisors.
+	Escalated int `json:"escalated"`
+	// Errors describes each claim whose SLA clock could not be started or that could not be
+	// escalated; the run carries on with the other claims.
+	Errors []string `json:"errors,omitempty"`
+}
+
+type ClaimSLAService interface {
+	ListClaimSlaPolicies(ctx context.Context) ([]*slas.ClaimSlaPolicy, error)
+	UpsertClaimSlaPolicy(ctx context.Context, arg *slas.UpsertClaimSlaPolicyParams) (*slas.ClaimSlaPolicy, error)
+
+	ListPublicHolidays(ctx context.Context, year int32) ([]*slas.ListPublicHolidaysRow, error)
+	GetPublicHolidayByID(ctx context.Context, id int32) (*slas.PublicHoliday, error)
+	CreatePublicHoliday(ctx context.Context, arg *slas.CreatePublicHolidayParams) (*slas.PublicHoliday, error)
+	UpdatePublicHoliday(ctx context.Context, arg *slas.UpdatePublicHolidayParams) (*slas.PublicHoliday, error)
+	DeletePublicHoliday(ctx context.Context, id int32) error
+
+	EscalateOverdueClaims(ctx context.Context) (*ClaimSLAEscalationResult, error)
+}
+
+type claimSLAService struct {
+	db *pgxpool.Pool
+	q  *slas.Queries
+}
+
+func NewClaimSLAService(db *pgxpool.Pool) ClaimSLAService {
+	return &claimSLAService{
+		db: db,
+		q:  slas.New(db),
+	}
+}
+
+// ListClaimSlaPolicies retrieves the SLA policy of every tracked claim status from the database.
+func (s *claimSLAService) ListClaimSlaPolicies(ctx context.Context) ([]*slas.ClaimSlaPolicy, error) {
+	return s.q.ListClaimSlaPolicies(ctx)
+}
+
+// UpsertClaimSlaPolicy validates and saves the SLA policy of a claim status in the database.
+// The policy applies to claims entering the status from now on.
+func (s *claimSLAService) UpsertClaimSlaPolicy(ctx context.Context, arg *slas.UpsertClaimSlaPolicyParams) (*slas.ClaimSlaPolicy, error) {
+	if !slices.Contains(claimStatusOrder, arg.Status) {
+		return nil, fmt.Errorf("invalid claim status: %s", arg.Status)
+	}
+	if arg.WorkingDays <= 0 {
+		return nil, fmt.Errorf("working days must be greater than zero")
+	}
+	return s.q.UpsertClaimSlaPolicy(ctx, arg)
+}
+
+// ListPublicHolidays retrieves the national and state holidays of a year from the database.
+func (s *claimSLAService) ListPublicHolidays(ctx context.Context, year int32) ([]*slas.ListPublicHolidaysRow, error) {
+	return s.q.ListPublicHolidays(ctx, year)
+}
+
+// GetPublicHolidayByID retrieves a public holiday by its ID from the database.
+func (s *claimSLAService) GetPublicHolidayByID(ctx context.Context, id int32) (*slas.PublicHoliday, error) {
+	return s.q.GetPublicHolidayByID(ctx, id)
+}
+
+// CreatePublicHoliday validates and creates a public holiday in the database.
+func (s *claimSLAService) CreatePublicHoliday(ctx context.Context, arg *slas.CreatePublicHolidayParams) (*slas.PublicHoliday, error) {
+	if strings.TrimSpace(arg.Name) == "" {
+		return nil, fmt.Errorf("holiday name is required")
+	}
+	return s.q.CreatePublicHoliday(ctx, arg)
+}
+
+// UpdatePublicHoliday validates and updates a public holiday in the database.
+func (s *claimSLAService) UpdatePublicHoliday(ctx context.Context, arg *slas.UpdatePublicHolidayParams) (*slas.PublicHoliday, error) {
+	if strings.TrimSpace(arg.Name) == "" {
+		return nil, fmt.Errorf("holiday name is required")
+	}
+	return s.q.UpdatePublicHoliday(ctx, arg)
+}
+
+// DeletePublicHoliday deletes a public holiday from the database.
+func (s *claimSLAService) DeletePublicHoliday(ctx context.Context, id int32) error {
+	return s.q.DeletePublicHoliday(ctx, id)
+}
+
+// EscalateOverdueClaims starts the SLA clock of claims created before SLA tracking, then
+// emails the supervisors in CLAIM_SLA_SUPERVISOR_EMAILS about every claim that is past its
+// due date. A claim is escalated once per status; each claim is escalated in its own
+// transaction so that a failing claim does not block the others, and its failure is recorded
+// on the result.
+func (s *claimSLAService) EscalateOverdueClaims(ctx context.Context) (*ClaimSLAEscalationResult, error) {
+	result := &ClaimSLAEscalationResult{}
+	cq := claims.New(s.db)
+
+	pending, err := cq.ListClaimsWithoutSLA(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range pending {
+		if _, err := startClaimSLA(ctx, s.db, c.ID, c.Status, c.StatusSince); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to start SLA for claim %d: %v", c.ID, err))
+			continue
+		}
+		result.Started++
+	}
+
+	supervisors := staffEmailsFromEnv("CLAIM_SLA_SUPERVISOR_EMAILS")
+	if len(supervisors) == 0 {
+		return result, fmt.Errorf("CLAIM_SLA_SUPERVISOR_EMAILS is not set")
+	}
+
+	overdue, err := cq.ListClaimsDueForEscalation(ctx)
+	if err != nil {
+		return result, err
+	}
+	for _, c := range overdue {
+		escalated, err := s.escalateClaim(ctx, c, supervisors)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to escalate claim %s: %v", c.ClaimNo, err))
+			continue
+		}
+		if escalated {
+			result.Escalated++
+		}
+	}
+	return result, nil
+}
+
+// escalateClaim marks the claim as escalated and queues an email to each supervisor.
+// It reports false when a concurrent run already escalated the claim.
+func (s *claimSLAService) escalateClaim(ctx context.Context, c *claims.ListClaimsDueForEscalationRow, supervisors []string) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	if _, err := claims.New(tx).MarkClaimSLAEscalated(ctx, c.ID); err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	data := &notifier.TemplateData{
+		WarrantyNo:  c.WarrantyNo,
+		CarPlateNo:  c.CarPlateNo,
+		ClaimNo:     c.ClaimNo,
+		ClaimStatus: c.Status,
+		ShopName:    c.ShopName,
+	}
+	if c.SlaDueDate != nil {
+		data.DueDate = c.SlaDueDate.Format("2006-01-02")
+	}
+	if err := enqueueStaffEmails(ctx, tx, notifier.EventClaimSLABreached, supervisors, data, &c.WarrantyID, &c.ID); err != nil {
+		tx.Rollback(ctx)
+		return false, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// claimSLAToday returns the current date in Malaysia.
+func claimSLAToday() time.Time {
+	y, m, d := time.Now().In(malaysiaTime).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// startClaimSLA starts the SLA clock of the claim's status on the caller's transaction. The
+// due date is counted in working days of the shop's state; statuses without an active policy
+// are not tracked and have no due date.
+func startClaimSLA(ctx context.Context, db claims.DBTX, claimID int32, status string, start time.Time) (*claims.Claim, error) {
+	sq := slas.New(db)
+
+	var dueDate *time.Time
+	policy, err := sq.GetClaimSlaPolicyByStatus(ctx, status)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if err == nil && policy.IsActive {
+		calendar, err := loadClaimCalendar(ctx, sq, claimID, start)
+		if err != nil {
+			return nil, err
+		}
+		due := calendar.addWorkingDays(start, int(policy.WorkingDays))
+		dueDate = &due
+	}
+
+	return claims.New(db).UpdateClaimSLA(ctx, &claims.UpdateClaimSLAParams{
+		ID:           claimID,
+		SlaStartDate: &start,
+		SlaDueDate:   dueDate,
+	})
+}
+
+// workingCalendar counts working days, skipping weekend days and public holidays.
+type workingCalendar struct {
+	weekend  map[time.Weekday]bool
+	holidays map[string]bool
+}
+
+// loadClaimCalendar loads the working-day calendar of the shop that owns the claim, with the
+// holidays from the start date onwards.
+func loadClaimCalendar(ctx context.Context, q *slas.Queries, claimID int32, start time.Time) (*workingCalendar, error) {
+	shop, err := q.GetClaimCalendar(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := q.ListPublicHolidayDatesFrom(ctx, &slas.ListPublicHolidayDatesFromParams{
+		MsiaStateID: shop.MsiaStateID,
+		HolidayDate: start,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &workingCalendar{
+		weekend:  map[time.Weekday]bool{},
+		holidays: map[string]bool{},
+	}
+	for _, day := range shop.WeekendDays {
+		calendar.weekend[time.Weekday(day)] = true
+	}
+	if len(calendar.weekend) >= 7 {
+		return nil, fmt.Errorf("the calendar of claim %d has no working days", claimID)
+	}
+	for _, day := range holidays {
+		calendar.holidays[day.Format("2006-01-02")] = true
+	}
+	return calendar, nil
+}
+
+// isWorkingDay reports whether the date is neither a weekend day nor a public holiday.
+func (c *workingCalendar) isWorkingDay(day time.Time) bool {
+	return !c.weekend[day.Weekday()] && !c.holidays[day.Format("2006-01-02")]
+}
+
+// addWorkingDays returns the date n working days after start. The start date itself is not
+// counted, so a claim submitted on a Friday with a 3 day SLA is due on Wednesday.
+func (c *workingCalendar) addWorkingDays(start time.Time, n int) time.Time {
+	day := start
+	for n > 0 {
+		day = day.AddDate(0, 0, 1)
+		if c.isWorkingDay(day) {
+			n--
+		}
+	}
+	return day
+}
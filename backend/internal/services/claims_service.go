@@ -2,27 +2,49 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
 )
 
+// claimEditableStatuses are the statuses in which the claim and its parts can still be edited.
+var claimEditableStatuses = []string{
+	models.ClaimStatusSubmitted,
+	models.ClaimStatusReopened,
+}
+
 type ClaimsService interface {
-	GetClaims(ctx context.Context) ([]*claims.ClaimView, error)
-	GetClaimsByShopID(ctx context.Context, shopID int32) ([]*claims.ClaimView, error)
+	GetClaims(ctx context.Context, arg *claims.GetClaimsParams) ([]*claims.ClaimView, error)
+	GetClaimsByShopID(ctx context.Context, arg *claims.GetClaimsByShopIDParams) ([]*claims.ClaimView, error)
 	GetClaimByID(ctx context.Context, id int32) (*claims.ClaimView, error)
 	GetClaimWarrantyPartsByClaimID(ctx context.Context, claimID int32) ([]*claims.ClaimWarrantyPartsView, error)
 	GenerateNextClaimNo(ctx context.Context, warrantyNo, claimDate string) (string, error)
+	CheckClaimEligibility(ctx context.Context, warrantyID int32, claimDate time.Time, warrantyPartIDs []int32) (*ClaimEligibility, error)
 
-	CreateClaimWithParts(ctx context.Context, arg *claims.CreateClaimParams, partsArgs []*claims.CreateClaimWarrantyPartParams) (*claims.Claim, error)
+	CreateClaimWithParts(ctx context.Context, userID int32, arg *claims.CreateClaimParams, partsArgs []*claims.CreateClaimWarrantyPartParams) (*claims.Claim, error)
 	UpdateClaimWithParts(ctx context.Context, claimArg *claims.UpdateClaimParams, partsArgs []*claims.UpdateClaimWarrantyPartParams) (*claims.Claim, error)
 
 	UpdateClaimApproval(ctx context.Context, arg *claims.UpdateClaimApprovalParams) (*claims.Claim, error)
 	UpdateClaimWarrantyPartApproval(ctx context.Context, arg *claims.UpdateClaimWarrantyPartApprovalParams) (*claims.ClaimWarrantyPart, error)
-	UpdateClaimStatus(ctx context.Context, claimID int32, isOpen bool) (*claims.Claim, error)
-	UpdateClaimWarrantyPartStatus(ctx context.Context, partID int32, isOpen bool) (*claims.ClaimWarrantyPart, error)
+	TransitionClaimStatus(ctx context.Context, claimID, userID int32, toStatus string, remarks *string) (*claims.Claim, error)
+	ListClaimStatusTransitions(ctx context.Context, claimID int32) ([]*claims.ListClaimStatusTransitionsByClaimIDRow, error)
+	ListAllowedClaimStatuses(ctx context.Context, claimID, userID int32) ([]string, error)
+
+	RecordClaimResolution(ctx context.Context, userID int32, resolution *claims.UpsertClaimResolutionParams, part *claims.UpdateClaimWarrantyPartResolutionParams) (*claims.ClaimResolution, error)
+	ListClaimResolutions(ctx context.Context, claimID int32) ([]*claims.ListClaimResolutionsByClaimIDRow, error)
+
+	ListClaimExclusions(ctx context.Context) ([]*claims.ClaimExclusion, error)
+	GetClaimExclusionByID(ctx context.Context, id int32) (*claims.ClaimExclusion, error)
+	CreateClaimExclusion(ctx context.Context, arg *claims.CreateClaimExclusionParams) (*claims.ClaimExclusion, error)
+	UpdateClaimExclusion(ctx context.Context, arg *claims.UpdateClaimExclusionParams) (*claims.ClaimExclusion, error)
 }
 
 type claimsService struct {
@@ -37,14 +59,15 @@ func NewClaimsService(db *pgxpool.Pool) ClaimsService {
 	}
 }
 
-// GetClaims retrieves a list of claims from the database.
-func (s *claimsService) GetClaims(ctx context.Context) ([]*claims.ClaimView, error) {
-	return s.q.GetClaims(ctx)
+// GetClaims retrieves a list of claims, filtered by status and SLA state, from the database.
+func (s *claimsService) GetClaims(ctx context.Context, arg *claims.GetClaimsParams) ([]*claims.ClaimView, error) {
+	return s.q.GetClaims(ctx, arg)
 }
 
-// GetClaimsByShopID retrieves claims associated with a specific shop ID from the database.
-func (s *claimsService) GetClaimsByShopID(ctx context.Context, shopID int32) ([]*claims.ClaimView, error) {
-	return s.q.GetClaimsByShopID(ctx, shopID)
+// GetClaimsByShopID retrieves claims associated with a specific shop ID, filtered by status
+// and SLA state, from the database.
+func (s *claimsService) GetClaimsByShopID(ctx context.Context, arg *claims.GetClaimsByShopIDParams) ([]*claims.ClaimView, error) {
+	return s.q.GetClaimsByShopID(ctx, arg)
 }
 
 // GetClaimByID retrieves a claim by its ID from the database.
@@ -57,13 +80,33 @@ func (s *claimsService) GetClaimWarrantyPartsByClaimID(ctx context.Context, clai
 	return s.q.GetClaimWarrantyPartsByClaimID(ctx, claimID)
 }
 
+// CheckClaimEligibility checks whether the warranty parts can be claimed on the warranty on the
+// claim date without creating a claim.
+func (s *claimsService) CheckClaimEligibility(ctx context.Context, warrantyID int32, claimDate time.Time, warrantyPartIDs []int32) (*ClaimEligibility, error) {
+	return checkClaimEligibility(ctx, s.q, warrantyID, claimDate, warrantyPartIDs, nil)
+}
+
 // CreateClaimWithParts creates a new claim along with its associated warranty parts in the database.
-func (s *claimsService) CreateClaimWithParts(ctx context.Context, arg *claims.CreateClaimParams, partsArgs []*claims.CreateClaimWarrantyPartParams) (*claims.Claim, error) {
+// A *ClaimIneligibleError is returned when any of the parts cannot be claimed.
+func (s *claimsService) CreateClaimWithParts(ctx context.Context, userID int32, arg *claims.CreateClaimParams, partsArgs []*claims.CreateClaimWarrantyPartParams) (*claims.Claim, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	qtx := claims.New(tx)
+	warrantyPartIDs := make([]int32, 0, len(partsArgs))
+	for _, partArg := range partsArgs {
+		warrantyPartIDs = append(warrantyPartIDs, partArg.WarrantyPartID)
+	}
+	eligibility, err := checkClaimEligibility(ctx, qtx, arg.WarrantyID, arg.ClaimDate, warrantyPartIDs, nil)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if !eligibility.Eligible {
+		tx.Rollback(ctx)
+		return nil, &ClaimIneligibleError{Eligibility: eligibility}
+	}
 	claim, err := qtx.CreateClaim(ctx, arg)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -77,6 +120,35 @@ func (s *claimsService) CreateClaimWithParts(ctx context.Context, arg *claims.Cr
 			return nil, err
 		}
 	}
+	actor, err := qtx.GetClaimActorByUserID(ctx, userID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if _, err := qtx.CreateClaimStatusTransition(ctx, &claims.CreateClaimStatusTransitionParams{
+		ClaimID:         claim.ID,
+		ToStatus:        claim.Status,
+		ChangedByUserID: &actor.ID,
+		ActorRole:       actor.Role,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	claim, err = startClaimSLA(ctx, tx, claim.ID, claim.Status, claimSLAToday())
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	// notify the customer that the claim has been received
+	claimView, err := qtx.GetClaimByID(ctx, claim.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := enqueueClaimNotifications(ctx, tx, notifier.EventClaimCreated, claimView); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
@@ -84,29 +156,72 @@ func (s *claimsService) CreateClaimWithParts(ctx context.Context, arg *claims.Cr
 }
 
 // UpdateClaimWithParts updates an existing claim along with its associated warranty parts in the database.
+// Only a submitted or reopened claim can be edited, and resolutions are recorded through the claim
+// lifecycle instead. The edited claim must still pass the eligibility rules on its warranty, claim
+// date and parts; a *ClaimIneligibleError is returned otherwise.
 func (s *claimsService) UpdateClaimWithParts(ctx context.Context, claimArg *claims.UpdateClaimParams, partsArgs []*claims.UpdateClaimWarrantyPartParams) (*claims.Claim, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	qtx := claims.New(tx)
+	current, err := qtx.GetClaimByID(ctx, claimArg.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if !slices.Contains(claimEditableStatuses, current.Status) {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: claim %s is %s", ErrClaimLocked, current.ClaimNo, current.Status)
+	}
 	claim, err := qtx.UpdateClaim(ctx, claimArg)
 	if err != nil {
 		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the claim moved on since it was read
+			return nil, fmt.Errorf("%w: claim %s is no longer submitted", ErrClaimLocked, current.ClaimNo)
+		}
 		return nil, err
 	}
 	for _, partArg := range partsArgs {
-		_, err := qtx.UpdateClaimWarrantyPart(ctx, partArg)
+		part, err := qtx.UpdateClaimWarrantyPart(ctx, partArg)
 		if err != nil {
 			tx.Rollback(ctx)
 			return nil, err
 		}
+		if part.ClaimID != claim.ID {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("claim warranty part %d belongs to another claim", part.ID)
+		}
+	}
+	// check the claim as it is after the update, with all of its parts
+	parts, err := qtx.GetClaimWarrantyPartsByClaimID(ctx, claim.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	warrantyPartIDs := make([]int32, 0, len(parts))
+	for _, part := range parts {
+		warrantyPartIDs = append(warrantyPartIDs, part.WarrantyPartID)
+	}
+	eligibility, err := checkClaimEligibility(ctx, qtx, claim.WarrantyID, claim.ClaimDate, warrantyPartIDs, &claim.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if !eligibility.Eligible {
+		tx.Rollback(ctx)
+		return nil, &ClaimIneligibleError{Eligibility: eligibility}
 	}
 	// Set claim approval status to pending when parts are updated
 	_, err = qtx.UpdateClaimApproval(ctx, &claims.UpdateClaimApprovalParams{
 		ID:             claim.ID,
 		ApprovalStatus: models.ApprovalStatusPending,
 	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
@@ -126,6 +241,9 @@ func (s *claimsService) UpdateClaim(ctx context.Context, arg *claims.UpdateClaim
 // UpdateClaimApproval updates the approval status of a claim in the database.
 func (s *claimsService) UpdateClaimApproval(ctx context.Context, arg *claims.UpdateClaimApprovalParams) (*claims.Claim, error) {
 	// if claim is approved, all claim warranty parts need to be approved
+	if err := checkParentApprovalStatus(arg.ApprovalStatus); err != nil {
+		return nil, err
+	}
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -159,88 +277,118 @@ func (s *claimsService) UpdateClaimApproval(ctx context.Context, arg *claims.Upd
 	return claim, nil
 }
 
-// UpdateClaimWarrantyPartApproval updates the approval status of a claim warranty part in the database.
+// UpdateClaimWarrantyPartApproval updates the approval status of a claim warranty part in the database
+// and derives the claim approval status from all of its parts using the claim approval rule.
 func (s *claimsService) UpdateClaimWarrantyPartApproval(ctx context.Context, arg *claims.UpdateClaimWarrantyPartApprovalParams) (*claims.ClaimWarrantyPart, error) {
-	// if claim warranty part is approved, check if all parts are approved to update claim approval status
-	part, err := s.q.UpdateClaimWarrantyPartApproval(ctx, arg)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if arg.ApprovalStatus == models.ApprovalStatusApproved {
-		parts, err := s.q.GetClaimWarrantyPartsByClaimID(ctx, part.ClaimID)
-		if err != nil {
+	qtx := claims.New(tx)
+	part, err := qtx.UpdateClaimWarrantyPartApproval(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	claim, err := qtx.GetClaimByID(ctx, part.ClaimID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	parts, err := qtx.GetClaimWarrantyPartsByClaimID(ctx, part.ClaimID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	rule, err := loadApprovalRule(ctx, tx, models.ApprovalEntityClaim)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	statuses := make([]models.ApprovalStatus, 0, len(parts))
+	for _, p := range parts {
+		statuses = append(statuses, p.ApprovalStatus)
+	}
+	approvalStatus := aggregateApproval(rule, statuses)
+	if approvalStatus != claim.ApprovalStatus {
+		if _, err := qtx.UpdateClaimApproval(ctx, &claims.UpdateClaimApprovalParams{
+			ID:             claim.ID,
+			ApprovalStatus: approvalStatus,
+			Remarks:        claim.Remarks,
+		}); err != nil {
+			tx.Rollback(ctx)
 			return nil, err
 		}
-		allApproved := true
-		for _, p := range parts {
-			if p.ApprovalStatus != models.ApprovalStatusApproved {
-				allApproved = false
-				break
-			}
-		}
-		if allApproved {
-			claimArg := &claims.UpdateClaimApprovalParams{
-				ID:             part.ClaimID,
-				ApprovalStatus: models.ApprovalStatusApproved,
-			}
-			_, err := s.q.UpdateClaimApproval(ctx, claimArg)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			claimArg := &claims.UpdateClaimApprovalParams{
-				ID:             part.ClaimID,
-				ApprovalStatus: models.ApprovalStatusRejected,
-			}
-			_, err := s.q.UpdateClaimApproval(ctx, claimArg)
-			if err != nil {
-				return nil, err
-			}
-		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
 	}
 	return part, nil
 }
 
-// UpdateClaimStatus updates the status of a claim in the database.
-func (s *claimsService) UpdateClaimStatus(ctx context.Context, claimID int32, isOpen bool) (*claims.Claim, error) {
-	var status string
-	if isOpen {
-		status = "Open"
-	} else {
-		status = "Closed"
-	}
-	// if closed and claim warranty part all need to be closed
+// TransitionClaimStatus moves a claim and its parts to a new lifecycle status on behalf of the
+// user and records the change in the database. ErrInvalidClaimTransition,
+// ErrClaimTransitionForbidden or ErrClaimTransitionIncomplete is returned when the change is
+// not allowed.
+func (s *claimsService) TransitionClaimStatus(ctx context.Context, claimID, userID int32, toStatus string, remarks *string) (*claims.Claim, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	qtx := claims.New(tx)
-	arg := &claims.UpdateClaimStatusParams{
-		ID:     claimID,
-		Status: status,
+	previous, err := qtx.GetClaimByID(ctx, claimID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
 	}
-	claim, err := qtx.UpdateClaimStatus(ctx, arg)
+	actor, err := qtx.GetClaimActorByUserID(ctx, userID)
 	if err != nil {
 		tx.Rollback(ctx)
 		return nil, err
 	}
-	// update all claim warranty parts status if claim is closed
-	parts, err := qtx.GetClaimWarrantyPartsByClaimID(ctx, claimID)
+	if err := checkClaimTransition(ctx, qtx, previous, toStatus, actor, remarks); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	claim, err := qtx.UpdateClaimStatus(ctx, &claims.UpdateClaimStatusParams{
+		ID:     claimID,
+		Status: toStatus,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := qtx.UpdateClaimWarrantyPartsStatusByClaimID(ctx, &claims.UpdateClaimWarrantyPartsStatusByClaimIDParams{
+		Status:  toStatus,
+		ClaimID: claimID,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if _, err := qtx.CreateClaimStatusTransition(ctx, &claims.CreateClaimStatusTransitionParams{
+		ClaimID:         claimID,
+		FromStatus:      &previous.Status,
+		ToStatus:        toStatus,
+		ChangedByUserID: &actor.ID,
+		ActorRole:       actor.Role,
+		Remarks:         remarks,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	claim, err = startClaimSLA(ctx, tx, claimID, toStatus, claimSLAToday())
 	if err != nil {
 		tx.Rollback(ctx)
 		return nil, err
 	}
 
-	for _, part := range parts {
-		partArg := &claims.UpdateClaimWarrantyPartStatusParams{
-			ID:     part.ID,
-			Status: status,
-		}
-		_, err := qtx.UpdateClaimWarrantyPartStatus(ctx, partArg)
-		if err != nil {
-			tx.Rollback(ctx)
-			return nil, err
-		}
+	// notify the customer when the claim status changes
+	previous.Status = toStatus
+	if err := enqueueClaimNotifications(ctx, tx, notifier.EventClaimStatusChanged, previous); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
 	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
@@ -248,70 +396,81 @@ func (s *claimsService) UpdateClaimStatus(ctx context.Context, claimID int32, is
 	return claim, nil
 }
 
-// UpdateClaimWarrantyPartStatus updates the status of a claim warranty part in the database.
-func (s *claimsService) UpdateClaimWarrantyPartStatus(ctx context.Context, partID int32, isOpen bool) (*claims.ClaimWarrantyPart, error) {
-	// if closed, check if all parts are closed to update claim status
-	var status string
-	if isOpen {
-		status = "Open"
-	} else {
-		status = "Closed"
-	}
-	arg := &claims.UpdateClaimWarrantyPartStatusParams{
-		ID:     partID,
-		Status: status,
+// ListClaimStatusTransitions retrieves the status history of a claim from the database.
+func (s *claimsService) ListClaimStatusTransitions(ctx context.Context, claimID int32) ([]*claims.ListClaimStatusTransitionsByClaimIDRow, error) {
+	return s.q.ListClaimStatusTransitionsByClaimID(ctx, claimID)
+}
+
+// ListAllowedClaimStatuses retrieves the claim and user from the database and returns the
+// statuses the user may move the claim to.
+func (s *claimsService) ListAllowedClaimStatuses(ctx context.Context, claimID, userID int32) ([]string, error) {
+	claim, err := s.q.GetClaimByID(ctx, claimID)
+	if err != nil {
+		return nil, err
 	}
-	part, err := s.q.UpdateClaimWarrantyPartStatus(ctx, arg)
+	actor, err := s.q.GetClaimActorByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
-	if !isOpen {
-		parts, err := s.q.GetClaimWarrantyPartsByClaimID(ctx, part.ClaimID)
-		if err != nil {
-			return nil, err
-		}
-		allClosed := true
-		for _, p := range parts {
-			if p.Status != "Closed" {
-				allClosed = false
-				break
-			}
-		}
-		if allClosed {
-			claimArg := &claims.UpdateClaimStatusParams{
-				ID:     part.ClaimID,
-				Status: "Closed",
-			}
-			_, err := s.q.UpdateClaimStatus(ctx, claimArg)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			claimArg := &claims.UpdateClaimStatusParams{
-				ID:     part.ClaimID,
-				Status: "Open",
-			}
-			_, err := s.q.UpdateClaimStatus(ctx, claimArg)
-			if err != nil {
-				return nil, err
-			}
-		}
+	return allowedClaimStatuses(claim, actor), nil
+}
+
+// ListClaimExclusions retrieves all claim exclusions from the database.
+func (s *claimsService) ListClaimExclusions(ctx context.Context) ([]*claims.ClaimExclusion, error) {
+	return s.q.ListClaimExclusions(ctx)
+}
+
+// GetClaimExclusionByID retrieves a claim exclusion by its ID from the database.
+func (s *claimsService) GetClaimExclusionByID(ctx context.Context, id int32) (*claims.ClaimExclusion, error) {
+	return s.q.GetClaimExclusionByID(ctx, id)
+}
+
+// CreateClaimExclusion creates a new claim exclusion in the database.
+func (s *claimsService) CreateClaimExclusion(ctx context.Context, arg *claims.CreateClaimExclusionParams) (*claims.ClaimExclusion, error) {
+	if err := validateClaimExclusion(arg.Name, arg.Reason, arg.ProductTypeID, arg.ProductSeriesID, arg.ProductNameID, arg.CarPartID); err != nil {
+		return nil, err
 	}
-	return part, nil
+	return s.q.CreateClaimExclusion(ctx, arg)
+}
+
+// UpdateClaimExclusion updates an existing claim exclusion in the database.
+func (s *claimsService) UpdateClaimExclusion(ctx context.Context, arg *claims.UpdateClaimExclusionParams) (*claims.ClaimExclusion, error) {
+	if err := validateClaimExclusion(arg.Name, arg.Reason, arg.ProductTypeID, arg.ProductSeriesID, arg.ProductNameID, arg.CarPartID); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateClaimExclusion(ctx, arg)
+}
 
+// validateClaimExclusion checks that an exclusion is named, explains itself to the shop and
+// does not exclude every warranty part.
+func validateClaimExclusion(name, reason string, productTypeID, productSeriesID, productNameID, carPartID *int32) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if productTypeID == nil && productSeriesID == nil && productNameID == nil && carPartID == nil {
+		return fmt.Errorf("at least one of product type, product series, product name or car part is required")
+	}
+	return nil
 }
 
 // GenerateNextClaimNo generates the next claim number based on the warranty number.
 // C + Claim Date - Warranty No. - Claim Sequence
 // E.g. C251031-PJ01-24112501-01
 func (s *claimsService) GenerateNextClaimNo(ctx context.Context, warrantyNo, claimDate string) (string, error) {
-	// This is a placeholder implementation. You should implement the logic to generate the claim number based on your requirements.
-	// For example, you might want to query the database to find the last claim number for the given warranty and increment it.
+	return nextClaimNo(ctx, s.q, warrantyNo, claimDate), nil
+}
+
+// nextClaimNo returns the next claim number for the warranty and claim date (YYYYMMDD)
+// using the given queries, so that it can also run on a caller's transaction.
+func nextClaimNo(ctx context.Context, q *claims.Queries, warrantyNo, claimDate string) string {
 	prefix := "C" + claimDate + "-" + warrantyNo + "-"
-	latestClaimNo, err := s.q.GetLatestWarrantyNoByPrefix(ctx, prefix+"%")
+	latestClaimNo, err := q.GetLatestWarrantyNoByPrefix(ctx, prefix+"%")
 	if err != nil {
 		// If no existing claim found, start with sequence 01
-		return prefix + "01", nil
+		return prefix + "01"
 	}
 	// Extract the sequence number from the latest claim number and increment it
 	sequencePart := latestClaimNo[len(prefix):]
@@ -319,5 +478,20 @@ func (s *claimsService) GenerateNextClaimNo(ctx context.Context, warrantyNo, cla
 	var nextSequence int
 	fmt.Sscanf(sequencePart, "%02d", &nextSequence)
 	nextSequence++
-	return fmt.Sprintf("%s%02d", prefix, nextSequence), nil
+	return fmt.Sprintf("%s%02d", prefix, nextSequence)
+}
+
+// enqueueClaimNotifications queues the given claim event notifications for the customer.
+func enqueueClaimNotifications(ctx context.Context, db claims.DBTX, event notifier.Event, claim *claims.ClaimView) error {
+	data := &notifier.TemplateData{
+		CustomerName: claim.ClientName,
+		WarrantyNo:   claim.WarrantyNo,
+		CarBrand:     claim.CarBrand,
+		CarModel:     claim.CarModel,
+		CarPlateNo:   claim.CarPlateNo,
+		ClaimNo:      claim.ClaimNo,
+		ClaimStatus:  claim.Status,
+	}
+	to := customerContact{Email: claim.ClientEmail, Contact: claim.ClientContact}
+	return enqueueCustomerNotifications(ctx, db, event, to, data, &claim.WarrantyID, &claim.ID)
 }
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/comments"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+)
+
+// commentMaxAttachments is the maximum number of files attached to a single comment.
+const commentMaxAttachments = 10
+
+var (
+	// ErrCommentForbidden is returned when the user may not read or post in the thread.
+	ErrCommentForbidden = errors.New("comment not permitted")
+	// ErrCommentParentNotFound is returned when a reply points at a comment outside the thread.
+	ErrCommentParentNotFound = errors.New("parent comment not found in the thread")
+)
+
+// CommentThread identifies the comment thread of a warranty or of a claim. Exactly one of the
+// IDs is set.
+type CommentThread struct {
+	WarrantyID *int32
+	ClaimID    *int32
+}
+
+// ThreadComment is a comment with the files attached to it.
+type ThreadComment struct {
+	*comments.ListCommentsByThreadRow
+	Attachments []*comments.CommentAttachment `json:"attachments"`
+}
+
+// PostedComment is a newly created comment with the files attached to it.
+type PostedComment struct {
+	*comments.Comment
+	Attachments []*comments.CommentAttachment `json:"attachments"`
+}
+
+type CommentsService interface {
+	ListComments(ctx context.Context, userID int32, thread CommentThread) ([]*ThreadComment, error)
+	CreateComment(ctx context.Context, userID int32, thread CommentThread, arg *comments.CreateCommentParams, attachments []*comments.CreateCommentAttachmentParams) (*PostedComment, error)
+	MarkCommentsRead(ctx context.Context, userID int32, thread CommentThread) error
+	ListUnreadCommentCounts(ctx context.Context, userID int32) ([]*comments.ListUnreadCommentCountsRow, error)
+}
+
+type commentsService struct {
+	db *pgxpool.Pool
+	q  *comments.Queries
+}
+
+func NewCommentsService(db *pgxpool.Pool) CommentsService {
+	return &commentsService{
+		db: db,
+		q:  comments.New(db),
+	}
+}
+
+// commentSubject is the warranty or claim a thread is about.
+type commentSubject struct {
+	WarrantyID int32
+	ClaimID    *int32
+	ShopID     int32
+	WarrantyNo string
+	ClaimNo    string
+	CarPlateNo string
+	ShopName   string
+	PicEmail   string
+}
+
+// loadCommentSubject retrieves the warranty or claim of the thread from the database.
+func loadCommentSubject(ctx context.Context, q *comments.Queries, thread CommentThread) (*commentSubject, error) {
+	if (thread.WarrantyID == nil) == (thread.ClaimID == nil) {
+		return nil, fmt.Errorf("a comment thread belongs to either a warranty or a claim")
+	}
+	if thread.ClaimID != nil {
+		claim, err := q.GetClaimCommentSubject(ctx, *thread.ClaimID)
+		if err != nil {
+			return nil, err
+		}
+		return &commentSubject{
+			WarrantyID: claim.WarrantyID,
+			ClaimID:    &claim.ClaimID,
+			ShopID:     claim.ShopID,
+			WarrantyNo: claim.WarrantyNo,
+			ClaimNo:    claim.ClaimNo,
+			CarPlateNo: claim.CarPlateNo,
+			ShopName:   claim.ShopName,
+			PicEmail:   claim.PicEmail,
+		}, nil
+	}
+	warranty, err := q.GetWarrantyCommentSubject(ctx, *thread.WarrantyID)
+	if err != nil {
+		return nil, err
+	}
+	return &commentSubject{
+		WarrantyID: warranty.WarrantyID,
+		ShopID:     warranty.ShopID,
+		WarrantyNo: warranty.WarrantyNo,
+		CarPlateNo: warranty.CarPlateNo,
+		ShopName:   warranty.ShopName,
+		PicEmail:   warranty.PicEmail,
+	}, nil
+}
+
+// authorizeCommentThread loads the user and the subject of the thread and checks that the user
+// works for HQ or for the subject's shop. Only HQ users see internal comments.
+func authorizeCommentThread(ctx context.Context, q *comments.Queries, userID int32, thread CommentThread) (*comments.GetCommentAuthorByUserIDRow, *commentSubject, error) {
+	author, err := q.GetCommentAuthorByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	subject, err := loadCommentSubject(ctx, q, thread)
+	if err != nil {
+		return nil, nil, err
+	}
+	if author.ShopID != nil && *author.ShopID != subject.ShopID {
+		return nil, nil, fmt.Errorf("%w: the thread belongs to another shop", ErrCommentForbidden)
+	}
+	return author, subject, nil
+}
+
+// ListComments retrieves the comments of a thread and their attachments from the database,
+// oldest first. Internal comments are left out for shop users.
+func (s *commentsService) ListComments(ctx context.Context, userID int32, thread CommentThread) ([]*ThreadComment, error) {
+	author, _, err := authorizeCommentThread(ctx, s.q, userID, thread)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListCommentsByThread(ctx, &comments.ListCommentsByThreadParams{
+		UserID:          userID,
+		WarrantyID:      thread.WarrantyID,
+		ClaimID:         thread.ClaimID,
+		IncludeInternal: author.ShopID == nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int32, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	attachments, err := s.q.ListCommentAttachmentsByCommentIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byComment := map[int32][]*comments.CommentAttachment{}
+	for _, attachment := range attachments {
+		byComment[attachment.CommentID] = append(byComment[attachment.CommentID], attachment)
+	}
+
+	result := make([]*ThreadComment, 0, len(rows))
+	for _, row := range rows {
+		files := byComment[row.ID]
+		if files == nil {
+			files = []*comments.CommentAttachment{}
+		}
+		result = append(result, &ThreadComment{ListCommentsByThreadRow: row, Attachments: files})
+	}
+	return result, nil
+}
+
+// CreateComment posts a comment or a reply with its attachments to a thread in the database
+// and notifies the other side: HQ replies are emailed to the shop's person in charge, shop
+// comments to the HQ staff in COMMENT_HQ_EMAILS. Internal notes may only be posted by HQ, are
+// never emailed, and replies to them are internal as well.
+func (s *commentsService) CreateComment(ctx context.Context, userID int32, thread CommentThread, arg *comments.CreateCommentParams, attachments []*comments.CreateCommentAttachmentParams) (*PostedComment, error) {
+	arg.Body = strings.TrimSpace(arg.Body)
+	if arg.Body == "" {
+		return nil, fmt.Errorf("comment body is required")
+	}
+	if len(attachments) > commentMaxAttachments {
+		return nil, fmt.Errorf("a comment can have at most %d attachments", commentMaxAttachments)
+	}
+	for _, attachment := range attachments {
+		if strings.TrimSpace(attachment.FileUrl) == "" || strings.TrimSpace(attachment.FileName) == "" {
+			return nil, fmt.Errorf("attachment URL and file name are required")
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := comments.New(tx)
+
+	author, subject, err := authorizeCommentThread(ctx, qtx, userID, thread)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if arg.IsInternal && author.ShopID != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: only HQ can post internal notes", ErrCommentForbidden)
+	}
+	if arg.ParentCommentID != nil {
+		parent, err := qtx.GetCommentByID(ctx, *arg.ParentCommentID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		if err != nil || !sameCommentThread(parent, thread) || (parent.IsInternal && author.ShopID != nil) {
+			tx.Rollback(ctx)
+			return nil, ErrCommentParentNotFound
+		}
+		if parent.IsInternal {
+			arg.IsInternal = true
+		}
+	}
+
+	arg.WarrantyID = thread.WarrantyID
+	arg.ClaimID = thread.ClaimID
+	arg.AuthorUserID = author.ID
+	comment, err := qtx.CreateComment(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	posted := &PostedComment{Comment: comment, Attachments: []*comments.CommentAttachment{}}
+	for _, attachment := range attachments {
+		attachment.CommentID = comment.ID
+		file, err := qtx.CreateCommentAttachment(ctx, attachment)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		posted.Attachments = append(posted.Attachments, file)
+	}
+
+	if !comment.IsInternal {
+		recipients := staffEmailsFromEnv("COMMENT_HQ_EMAILS")
+		if author.ShopID == nil {
+			recipients = []string{}
+			if subject.PicEmail != "" {
+				recipients = append(recipients, subject.PicEmail)
+			}
+		}
+		data := &notifier.TemplateData{
+			WarrantyNo:  subject.WarrantyNo,
+			CarPlateNo:  subject.CarPlateNo,
+			ClaimNo:     subject.ClaimNo,
+			ShopName:    subject.ShopName,
+			Author:      author.Username,
+			CommentBody: comment.Body,
+		}
+		if err := enqueueStaffEmails(ctx, tx, notifier.EventCommentPosted, recipients, data, &subject.WarrantyID, subject.ClaimID); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return posted, nil
+}
+
+// sameCommentThread reports whether the comment belongs to the thread.
+func sameCommentThread(comment *comments.Comment, thread CommentThread) bool {
+	if thread.ClaimID != nil {
+		return comment.ClaimID != nil && *comment.ClaimID == *thread.ClaimID
+	}
+	return comment.WarrantyID != nil && *comment.WarrantyID == *thread.WarrantyID
+}
+
+// MarkCommentsRead marks every comment of the thread the user can see as read in the database.
+func (s *commentsService) MarkCommentsRead(ctx context.Context, userID int32, thread CommentThread) error {
+	author, _, err := authorizeCommentThread(ctx, s.q, userID, thread)
+	if err != nil {
+		return err
+	}
+	return s.q.MarkCommentsRead(ctx, &comments.MarkCommentsReadParams{
+		UserID:          userID,
+		WarrantyID:      thread.WarrantyID,
+		ClaimID:         thread.ClaimID,
+		IncludeInternal: author.ShopID == nil,
+	})
+}
+
+// ListUnreadCommentCounts retrieves the number of unread comments per thread the user can see
+// from the database.
+func (s *commentsService) ListUnreadCommentCounts(ctx context.Context, userID int32) ([]*comments.ListUnreadCommentCountsRow, error) {
+	author, err := s.q.GetCommentAuthorByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.q.ListUnreadCommentCounts(ctx, &comments.ListUnreadCommentCountsParams{
+		UserID:          userID,
+		IncludeInternal: author.ShopID == nil,
+		ShopID:          author.ShopID,
+	})
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/customers"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// Weights used to rank duplicate customer candidates.
+const (
+	customerPhoneMatchScore = 50
+	customerEmailMatchScore = 40
+	customerNameMatchScore  = 10
+)
+
+// CustomerDuplicate is a pair of customers that are likely the same person.
+type CustomerDuplicate struct {
+	Customer   *customers.Customer `json:"customer"`
+	Candidate  *customers.Customer `json:"candidate"`
+	PhoneMatch bool                `json:"phoneMatch"`
+	EmailMatch bool                `json:"emailMatch"`
+	NameMatch  bool                `json:"nameMatch"`
+	Score      int                 `json:"score"`
+}
+
+type CustomersService interface {
+	ListCustomers(ctx context.Context) ([]*customers.Customer, error)
+	ListCustomersByShopID(ctx context.Context, shopID int32) ([]*customers.Customer, error)
+	GetCustomerByID(ctx context.Context, id int32) (*customers.Customer, error)
+
+	ListDuplicateCustomers(ctx context.Context) ([]*CustomerDuplicate, error)
+	ListCustomerCandidatesByCustomerID(ctx context.Context, id int32) ([]*CustomerDuplicate, error)
+	MergeCustomers(ctx context.Context, targetID int32, sourceIDs []int32, mergedBy *int32) (*customers.Customer, error)
+	ListCustomerMergesByTargetID(ctx context.Context, targetID int32) ([]*customers.CustomerMerge, error)
+
+	ListVehiclesByCustomerID(ctx context.Context, customerID int32) ([]*customers.Vehicle, error)
+	ListWarrantiesByCustomerID(ctx context.Context, customerID int32) ([]*customers.ListWarrantiesByCustomerIDRow, error)
+	ListClaimsByCustomerID(ctx context.Context, customerID int32) ([]*customers.ClaimView, error)
+}
+
+type customersService struct {
+	db *pgxpool.Pool
+	q  *customers.Queries
+}
+
+func NewCustomersService(db *pgxpool.Pool) CustomersService {
+	return &customersService{
+		db: db,
+		q:  customers.New(db),
+	}
+}
+
+// ListCustomers retrieves all customers that have not been merged from the database.
+func (s *customersService) ListCustomers(ctx context.Context) ([]*customers.Customer, error) {
+	return s.q.ListCustomers(ctx)
+}
+
+// ListCustomersByShopID retrieves the customers, not merged, with a warranty installed by a shop
+// from the database.
+func (s *customersService) ListCustomersByShopID(ctx context.Context, shopID int32) ([]*customers.Customer, error) {
+	return s.q.ListCustomersByShopID(ctx, shopID)
+}
+
+// GetCustomerByID retrieves a customer by its ID from the database.
+// A merged customer resolves to the customer it was merged into.
+func (s *customersService) GetCustomerByID(ctx context.Context, id int32) (*customers.Customer, error) {
+	customer, err := s.q.GetCustomerByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if customer.MergedIntoID != nil {
+		return s.q.GetCustomerByID(ctx, *customer.MergedIntoID)
+	}
+	return customer, nil
+}
+
+// ListDuplicateCustomers retrieves pairs of customers sharing a phone number or e-mail address,
+// ranked by how closely they match.
+func (s *customersService) ListDuplicateCustomers(ctx context.Context) ([]*CustomerDuplicate, error) {
+	pairs, err := s.q.ListCustomerDuplicatePairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int32, 0, len(pairs)*2)
+	for _, p := range pairs {
+		ids = append(ids, p.CustomerID, p.CandidateID)
+	}
+	list, err := s.q.ListCustomersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int32]*customers.Customer, len(list))
+	for _, c := range list {
+		byID[c.ID] = c
+	}
+
+	duplicates := make([]*CustomerDuplicate, 0, len(pairs))
+	for _, p := range pairs {
+		duplicates = append(duplicates, newCustomerDuplicate(byID[p.CustomerID], byID[p.CandidateID]))
+	}
+	sortCustomerDuplicates(duplicates)
+	return duplicates, nil
+}
+
+// ListCustomerCandidatesByCustomerID retrieves the customers that are likely duplicates of a customer.
+func (s *customersService) ListCustomerCandidatesByCustomerID(ctx context.Context, id int32) ([]*CustomerDuplicate, error) {
+	customer, err := s.q.GetCustomerByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.q.ListCustomerCandidatesByCustomerID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]*CustomerDuplicate, 0, len(candidates))
+	for _, c := range candidates {
+		duplicates = append(duplicates, newCustomerDuplicate(customer, c))
+	}
+	sortCustomerDuplicates(duplicates)
+	return duplicates, nil
+}
+
+// MergeCustomers merges the source customers into the target customer in a transaction.
+// The warranties of the source customers are moved to the target and each merge is recorded.
+func (s *customersService) MergeCustomers(ctx context.Context, targetID int32, sourceIDs []int32, mergedBy *int32) (*customers.Customer, error) {
+	if len(sourceIDs) == 0 {
+		return nil, fmt.Errorf("at least one customer to merge is required")
+	}
+	for _, id := range sourceIDs {
+		if id == targetID {
+			return nil, fmt.Errorf("cannot merge customer %d into itself", targetID)
+		}
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := customers.New(tx)
+
+	target, err := qtx.GetCustomerByID(ctx, targetID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("customer %d not found", targetID)
+	}
+	if target.MergedIntoID != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("customer %d has already been merged", targetID)
+	}
+
+	sources, err := qtx.ListCustomersByIDs(ctx, sourceIDs)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if len(sources) != len(sourceIDs) {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("one or more customers to merge were not found")
+	}
+	for _, source := range sources {
+		if source.MergedIntoID != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("customer %d has already been merged", source.ID)
+		}
+	}
+
+	if _, err := qtx.MoveWarrantiesToCustomer(ctx, &customers.MoveWarrantiesToCustomerParams{
+		TargetCustomerID:  &target.ID,
+		SourceCustomerIds: sourceIDs,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := qtx.MarkCustomersMerged(ctx, &customers.MarkCustomersMergedParams{
+		TargetCustomerID:  &target.ID,
+		SourceCustomerIds: sourceIDs,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	for _, source := range sources {
+		if _, err := qtx.CreateCustomerMerge(ctx, &customers.CreateCustomerMergeParams{
+			TargetCustomerID: target.ID,
+			SourceCustomerID: source.ID,
+			MergedBy:         mergedBy,
+		}); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// ListCustomerMergesByTargetID retrieves the merges into a customer from the database.
+func (s *customersService) ListCustomerMergesByTargetID(ctx context.Context, targetID int32) ([]*customers.CustomerMerge, error) {
+	return s.q.ListCustomerMergesByTargetID(ctx, targetID)
+}
+
+// ListVehiclesByCustomerID retrieves the vehicles of a customer from the database.
+func (s *customersService) ListVehiclesByCustomerID(ctx context.Context, customerID int32) ([]*customers.Vehicle, error) {
+	return s.q.ListVehiclesByCustomerID(ctx, &customerID)
+}
+
+// ListWarrantiesByCustomerID retrieves the warranties of a customer from the database.
+func (s *customersService) ListWarrantiesByCustomerID(ctx context.Context, customerID int32) ([]*customers.ListWarrantiesByCustomerIDRow, error) {
+	return s.q.ListWarrantiesByCustomerID(ctx, &customerID)
+}
+
+// ListClaimsByCustomerID retrieves the claims of a customer from the database.
+func (s *customersService) ListClaimsByCustomerID(ctx context.Context, customerID int32) ([]*customers.ClaimView, error) {
+	return s.q.ListClaimsByCustomerID(ctx, &customerID)
+}
+
+func newCustomerDuplicate(customer, candidate *customers.Customer) *CustomerDuplicate {
+	d := &CustomerDuplicate{
+		Customer:   customer,
+		Candidate:  candidate,
+		PhoneMatch: customer.PhoneNormalized == candidate.PhoneNormalized,
+		EmailMatch: customer.EmailNormalized != "" && customer.EmailNormalized == candidate.EmailNormalized,
+		NameMatch:  customerNameKey(customer.Name) == customerNameKey(candidate.Name),
+	}
+	if d.PhoneMatch {
+		d.Score += customerPhoneMatchScore
+	}
+	if d.EmailMatch {
+		d.Score += customerEmailMatchScore
+	}
+	if d.NameMatch {
+		d.Score += customerNameMatchScore
+	}
+	return d
+}
+
+func sortCustomerDuplicates(duplicates []*CustomerDuplicate) {
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+}
+
+// customerNameKey reduces a name to lower-case letters so that spacing and punctuation do not matter.
+func customerNameKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// linkWarrantyCustomer links a warranty to the customer with its phone number and e-mail
+// address, creating the customer if needed. Warranties of a merged customer are linked to
+// the customer it was merged into.
+func linkWarrantyCustomer(ctx context.Context, db customers.DBTX, warranty *warranties.Warranty) error {
+	qtx := customers.New(db)
+
+	phone, err := utils.NormalizeMalaysianPhone(warranty.ClientContact)
+	if err != nil {
+		return err
+	}
+	email := strings.ToLower(strings.TrimSpace(warranty.ClientEmail))
+
+	customer, err := qtx.GetCustomerByContact(ctx, &customers.GetCustomerByContactParams{
+		PhoneNormalized: phone,
+		EmailNormalized: email,
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		customer, err = qtx.CreateCustomer(ctx, &customers.CreateCustomerParams{
+			Name:            warranty.ClientName,
+			Phone:           warranty.ClientContact,
+			PhoneNormalized: phone,
+			Email:           warranty.ClientEmail,
+		})
+	case err != nil:
+	case customer.MergedIntoID != nil:
+		customer, err = qtx.GetCustomerByID(ctx, *customer.MergedIntoID)
+	default:
+		// keep the latest name and contact formatting of the customer
+		customer, err = qtx.UpdateCustomerDetails(ctx, &customers.UpdateCustomerDetailsParams{
+			ID:    customer.ID,
+			Name:  warranty.ClientName,
+			Phone: warranty.ClientContact,
+			Email: warranty.ClientEmail,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := qtx.UpdateWarrantyCustomerID(ctx, &customers.UpdateWarrantyCustomerIDParams{
+		ID:         warranty.ID,
+		CustomerID: &customer.ID,
+	}); err != nil {
+		return err
+	}
+	warranty.CustomerID = &customer.ID
+	return nil
+}
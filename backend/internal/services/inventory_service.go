@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+var (
+	// ErrInsufficientStock is returned when a movement would take a location's on-hand film below zero.
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrInvalidInventoryQuantity is returned when a movement quantity has the wrong sign or is zero.
+	ErrInvalidInventoryQuantity = errors.New("invalid inventory quantity")
+)
+
+type InventoryService interface {
+	ListInventoryBalances(ctx context.Context) ([]*inventory.ListInventoryBalancesRow, error)
+	ListHQInventoryBalances(ctx context.Context) ([]*inventory.ListHQInventoryBalancesRow, error)
+	ListShopInventoryBalances(ctx context.Context, shopID int32) ([]*inventory.ListShopInventoryBalancesRow, error)
+	ListInventoryMovements(ctx context.Context, arg *inventory.ListInventoryMovementsParams) ([]*inventory.ListInventoryMovementsRow, error)
+
+	AdjustInventory(ctx context.Context, userID int32, arg *inventory.CreateInventoryMovementParams) (*inventory.InventoryMovement, error)
+	WriteOffInventory(ctx context.Context, userID int32, arg *inventory.CreateInventoryMovementParams) (*inventory.InventoryMovement, error)
+}
+
+type inventoryService struct {
+	db *pgxpool.Pool
+	q  *inventory.Queries
+}
+
+func NewInventoryService(db *pgxpool.Pool) InventoryService {
+	return &inventoryService{
+		db: db,
+		q:  inventory.New(db),
+	}
+}
+
+// ListInventoryBalances retrieves the on-hand film of every product at HQ and each shop from the database.
+func (s *inventoryService) ListInventoryBalances(ctx context.Context) ([]*inventory.ListInventoryBalancesRow, error) {
+	return s.q.ListInventoryBalances(ctx)
+}
+
+// ListHQInventoryBalances retrieves the on-hand film of every product at HQ from the database.
+func (s *inventoryService) ListHQInventoryBalances(ctx context.Context) ([]*inventory.ListHQInventoryBalancesRow, error) {
+	return s.q.ListHQInventoryBalances(ctx)
+}
+
+// ListShopInventoryBalances retrieves the on-hand film of every product at a shop from the database.
+func (s *inventoryService) ListShopInventoryBalances(ctx context.Context, shopID int32) ([]*inventory.ListShopInventoryBalancesRow, error) {
+	return s.q.ListShopInventoryBalances(ctx, shopID)
+}
+
+// ListInventoryMovements retrieves stock movements, newest first, from the database.
+func (s *inventoryService) ListInventoryMovements(ctx context.Context, arg *inventory.ListInventoryMovementsParams) ([]*inventory.ListInventoryMovementsRow, error) {
+	return s.q.ListInventoryMovements(ctx, arg)
+}
+
+// AdjustInventory records a signed correction of a product's on-hand film at HQ or a shop in
+// the database. A negative adjustment may not take the location below zero.
+func (s *inventoryService) AdjustInventory(ctx context.Context, userID int32, arg *inventory.CreateInventoryMovementParams) (*inventory.InventoryMovement, error) {
+	if arg.Quantity = roundQuantity(arg.Quantity); arg.Quantity == 0 {
+		return nil, fmt.Errorf("%w: adjustment quantity cannot be zero", ErrInvalidInventoryQuantity)
+	}
+	arg.MovementType = models.InventoryMovementAdjustment
+	return s.recordMovement(ctx, userID, arg)
+}
+
+// WriteOffInventory records damaged or lost film at HQ or a shop in the database. The quantity
+// is given as a positive number and is taken off the location's on-hand film.
+func (s *inventoryService) WriteOffInventory(ctx context.Context, userID int32, arg *inventory.CreateInventoryMovementParams) (*inventory.InventoryMovement, error) {
+	if arg.Quantity = roundQuantity(arg.Quantity); arg.Quantity <= 0 {
+		return nil, fmt.Errorf("%w: write-off quantity must be greater than zero", ErrInvalidInventoryQuantity)
+	}
+	arg.MovementType = models.InventoryMovementWriteOff
+	arg.Quantity = -arg.Quantity
+	return s.recordMovement(ctx, userID, arg)
+}
+
+func (s *inventoryService) recordMovement(ctx context.Context, userID int32, arg *inventory.CreateInventoryMovementParams) (*inventory.InventoryMovement, error) {
+	arg.CreatedByUserID = &userID
+	arg.ProductAllocationID = nil
+	arg.ClaimResolutionID = nil
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := postInventoryMovements(ctx, inventory.New(tx), arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return movements[0], nil
+}
+
+// postInventoryMovements records stock movements within the caller's transaction. The products
+// involved are locked first, and every location that gives up film must still have a
+// non-negative on-hand balance afterwards. Movements with a zero quantity are skipped.
+func postInventoryMovements(ctx context.Context, q *inventory.Queries, movements ...*inventory.CreateInventoryMovementParams) ([]*inventory.InventoryMovement, error) {
+	productIDs := make([]int32, 0, len(movements))
+	for _, m := range movements {
+		productIDs = append(productIDs, m.ProductID)
+	}
+	// Lock in a fixed order so that concurrent postings cannot deadlock.
+	slices.Sort(productIDs)
+	for _, id := range slices.Compact(productIDs) {
+		if _, err := q.LockProductForInventory(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	created := make([]*inventory.InventoryMovement, 0, len(movements))
+	var drawn []*inventory.GetInventoryOnHandParams
+	for _, m := range movements {
+		m.Quantity = roundQuantity(m.Quantity)
+		if m.Quantity == 0 {
+			continue
+		}
+		movement, err := q.CreateInventoryMovement(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, movement)
+		if m.Quantity < 0 {
+			drawn = append(drawn, &inventory.GetInventoryOnHandParams{ProductID: m.ProductID, ShopID: m.ShopID})
+		}
+	}
+
+	for _, location := range drawn {
+		onHand, err := q.GetInventoryOnHand(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		if roundQuantity(onHand) < 0 {
+			return nil, fmt.Errorf("%w: product %d at %s is %g short", ErrInsufficientStock, location.ProductID, inventoryLocation(location.ShopID), -roundQuantity(onHand))
+		}
+	}
+	return created, nil
+}
+
+// inventoryLocation names a ledger location for error messages.
+func inventoryLocation(shopID *int32) string {
+	if shopID == nil {
+		return "HQ"
+	}
+	return fmt.Sprintf("shop %d", *shopID)
+}
+
+// allocationMovements returns the pair of movements that transfers film of an allocation from
+// HQ to its shop. A negative quantity transfers it back.
+func allocationMovements(allocationID, productID, shopID int32, quantity float64, remarks string) []*inventory.CreateInventoryMovementParams {
+	return []*inventory.CreateInventoryMovementParams{
+		{
+			ProductID:           productID,
+			MovementType:        models.InventoryMovementAllocation,
+			Quantity:            -quantity,
+			ProductAllocationID: &allocationID,
+			Remarks:             &remarks,
+		},
+		{
+			ProductID:           productID,
+			ShopID:              &shopID,
+			MovementType:        models.InventoryMovementAllocation,
+			Quantity:            quantity,
+			ProductAllocationID: &allocationID,
+			Remarks:             &remarks,
+		},
+	}
+}
+
+// transferMovements returns the pair of movements that moves film of a product between two
+// locations, each end booked against its own allocation. A nil shop ID is HQ.
+func transferMovements(movementType string, productID int32, fromShopID, toShopID *int32, fromAllocationID, toAllocationID int32, quantity float64, remarks string, userID int32) []*inventory.CreateInventoryMovementParams {
+	return []*inventory.CreateInventoryMovementParams{
+		{
+			ProductID:           productID,
+			ShopID:              fromShopID,
+			MovementType:        movementType,
+			Quantity:            -quantity,
+			ProductAllocationID: &fromAllocationID,
+			Remarks:             &remarks,
+			CreatedByUserID:     &userID,
+		},
+		{
+			ProductID:           productID,
+			ShopID:              toShopID,
+			MovementType:        movementType,
+			Quantity:            quantity,
+			ProductAllocationID: &toAllocationID,
+			Remarks:             &remarks,
+			CreatedByUserID:     &userID,
+		},
+	}
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/notifications"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+// ErrNotificationNotRetryable is returned when a notification that has not failed or been skipped
+// is retried.
+var ErrNotificationNotRetryable = errors.New("only failed or skipped notifications can be retried")
+
+// notificationRetryAttempts is the number of further attempts a retried notification gets.
+const notificationRetryAttempts = 5
+
+// notificationBatchSize is the maximum number of notifications delivered per dispatch run.
+const notificationBatchSize = 50
+
+// notificationSendTimeout bounds a single provider call.
+const notificationSendTimeout = 30 * time.Second
+
+// notificationLease is how long a dispatcher holds a notification it is sending before another
+// dispatcher may pick it up; it comfortably exceeds the send timeout.
+const notificationLease = 5 * time.Minute
+
+// notificationRetryBackoff is the delay before each retry; the last value is reused for later attempts.
+var notificationRetryBackoff = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+}
+
+type NotificationsService interface {
+	ListNotifications(ctx context.Context, status string) ([]*notifications.Notification, error)
+	GetNotificationByID(ctx context.Context, id int32) (*notifications.Notification, error)
+	GetNotificationAttemptsByNotificationID(ctx context.Context, notificationID int32) ([]*notifications.NotificationAttempt, error)
+	GetNotificationsByWarrantyID(ctx context.Context, warrantyID int32) ([]*notifications.Notification, error)
+	GetNotificationsByClaimID(ctx context.Context, claimID int32) ([]*notifications.Notification, error)
+	RetryNotification(ctx context.Context, id int32) (*notifications.Notification, error)
+
+	DispatchPendingNotifications(ctx context.Context) (int, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type notificationsService struct {
+	db     *pgxpool.Pool
+	q      *notifications.Queries
+	email  notifier.Notifier
+	sms    notifier.Notifier
+	locale string
+}
+
+func NewNotificationsService(db *pgxpool.Pool) (NotificationsService, error) {
+	email, err := newNotifierFromEnv("NOTIFY_EMAIL_PROVIDER", "log", notifier.ChannelEmail)
+	if err != nil {
+		return nil, err
+	}
+	sms, err := newNotifierFromEnv("NOTIFY_SMS_PROVIDER", "none", notifier.ChannelSMS)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := os.Getenv("NOTIFY_LOCALE")
+	if locale == "" {
+		locale = notifier.DefaultLocale
+	}
+
+	return &notificationsService{
+		db:     db,
+		q:      notifications.New(db),
+		email:  email,
+		sms:    sms,
+		locale: locale,
+	}, nil
+}
+
+// newNotifierFromEnv builds the provider configured in the given environment variable.
+// A nil notifier is returned when the provider is "none".
+func newNotifierFromEnv(key, fallback string, channel notifier.Channel) (notifier.Notifier, error) {
+	provider := strings.ToLower(os.Getenv(key))
+	if provider == "" {
+		provider = fallback
+	}
+
+	switch provider {
+	case "none":
+		return nil, nil
+	case "log":
+		return notifier.NewLogNotifier(), nil
+	case "file":
+		dir := os.Getenv("NOTIFY_FILE_DIR")
+		if dir == "" {
+			dir = "notifications"
+		}
+		return notifier.NewFileNotifier(dir)
+	case "smtp":
+		if channel != notifier.ChannelEmail {
+			break
+		}
+		return notifier.NewSMTPNotifier(
+			os.Getenv("SMTP_HOST"),
+			os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("SMTP_FROM"),
+		), nil
+	case "http":
+		if channel != notifier.ChannelSMS {
+			break
+		}
+		return notifier.NewHTTPSMSNotifier(
+			os.Getenv("SMS_API_URL"),
+			os.Getenv("SMS_API_KEY"),
+			os.Getenv("SMS_SENDER_ID"),
+		), nil
+	}
+	return nil, fmt.Errorf("unsupported %s provider %q for %s", key, provider, channel)
+}
+
+// ListNotifications retrieves notifications, optionally filtered by status, from the database.
+func (s *notificationsService) ListNotifications(ctx context.Context, status string) ([]*notifications.Notification, error) {
+	if status == "" {
+		return s.q.ListNotifications(ctx)
+	}
+	return s.q.ListNotificationsByStatus(ctx, strings.ToUpper(status))
+}
+
+// GetNotificationByID retrieves a notification by its ID from the database.
+func (s *notificationsService) GetNotificationByID(ctx context.Context, id int32) (*notifications.Notification, error) {
+	return s.q.GetNotificationByID(ctx, id)
+}
+
+// GetNotificationAttemptsByNotificationID retrieves the delivery attempts of a notification from the database.
+func (s *notificationsService) GetNotificationAttemptsByNotificationID(ctx context.Context, notificationID int32) ([]*notifications.NotificationAttempt, error) {
+	return s.q.GetNotificationAttemptsByNotificationID(ctx, notificationID)
+}
+
+// GetNotificationsByWarrantyID retrieves notifications sent for a warranty from the database.
+func (s *notificationsService) GetNotificationsByWarrantyID(ctx context.Context, warrantyID int32) ([]*notifications.Notification, error) {
+	return s.q.GetNotificationsByWarrantyID(ctx, &warrantyID)
+}
+
+// GetNotificationsByClaimID retrieves notifications sent for a claim from the database.
+func (s *notificationsService) GetNotificationsByClaimID(ctx context.Context, claimID int32) ([]*notifications.Notification, error) {
+	return s.q.GetNotificationsByClaimID(ctx, &claimID)
+}
+
+// RetryNotification puts a failed or skipped notification back in the queue for immediate delivery
+// with notificationRetryAttempts more attempts. Its earlier attempts are kept and keep counting.
+func (s *notificationsService) RetryNotification(ctx context.Context, id int32) (*notifications.Notification, error) {
+	notification, err := s.q.RequeueNotification(ctx, &notifications.RequeueNotificationParams{
+		RetryAttempts: notificationRetryAttempts,
+		ID:            id,
+	})
+	if err == nil || !errors.Is(err, pgx.ErrNoRows) {
+		return notification, err
+	}
+	// tell a missing notification from one that is pending or already sent
+	current, err := s.q.GetNotificationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("%w: notification %d is %s", ErrNotificationNotRetryable, id, current.Status)
+}
+
+// DispatchPendingNotifications delivers due notifications and records each attempt.
+// Failed deliveries are rescheduled with backoff until max attempts is reached.
+// Each notification is leased in its own short statement, sent outside any transaction and
+// its outcome recorded in its own transaction, so that a database error never undoes the
+// record of messages that already went out and no row stays locked while a provider is called.
+// It returns the number of notifications processed.
+func (s *notificationsService) DispatchPendingNotifications(ctx context.Context) (int, error) {
+	processed := 0
+	for processed < notificationBatchSize {
+		n, err := s.q.ClaimDueNotification(ctx, int32(notificationLease/time.Second))
+		if errors.Is(err, pgx.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return processed, err
+		}
+		if err := s.deliver(ctx, n); err != nil {
+			return processed, err
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// deliver renders and sends a single notification, then records the outcome.
+// Only database errors are returned; delivery errors are recorded on the notification.
+func (s *notificationsService) deliver(ctx context.Context, n *notifications.Notification) error {
+	channel := notifier.Channel(n.Channel)
+
+	provider := s.email
+	if channel == notifier.ChannelSMS {
+		provider = s.sms
+	}
+	if provider == nil {
+		_, err := s.q.MarkNotificationSkipped(ctx, &notifications.MarkNotificationSkippedParams{
+			ID:        n.ID,
+			LastError: utils.ToStringPtr(fmt.Sprintf("no %s provider configured", channel)),
+		})
+		return err
+	}
+	providerName := provider.Name()
+
+	var data notifier.TemplateData
+	if err := json.Unmarshal(n.Payload, &data); err != nil {
+		return s.markNotificationFailed(ctx, s.q, n, providerName, fmt.Errorf("invalid payload: %w", err))
+	}
+
+	locale := s.locale
+	if n.Locale != nil && *n.Locale != "" {
+		locale = *n.Locale
+	}
+	msg, locale, err := notifier.Render(notifier.Event(n.EventType), channel, locale, &data)
+	if err != nil {
+		return s.markNotificationFailed(ctx, s.q, n, providerName, err)
+	}
+	msg.Recipient = n.Recipient
+
+	sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+	sendErr := provider.Send(sendCtx, msg)
+	cancel()
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	q := notifications.New(tx)
+
+	attempt := &notifications.CreateNotificationAttemptParams{
+		NotificationID: n.ID,
+		AttemptNo:      n.Attempts + 1,
+		Provider:       providerName,
+		IsSuccess:      sendErr == nil,
+	}
+	if sendErr != nil {
+		attempt.ErrorMessage = utils.ToStringPtr(sendErr.Error())
+	}
+	if _, err := q.CreateNotificationAttempt(ctx, attempt); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	switch {
+	case sendErr != nil && n.Attempts+1 >= n.MaxAttempts:
+		_, err = q.MarkNotificationFailed(ctx, &notifications.MarkNotificationFailedParams{
+			ID:        n.ID,
+			Provider:  &providerName,
+			LastError: utils.ToStringPtr(sendErr.Error()),
+		})
+	case sendErr != nil:
+		backoff := notificationRetryBackoff[len(notificationRetryBackoff)-1]
+		if int(n.Attempts) < len(notificationRetryBackoff) {
+			backoff = notificationRetryBackoff[n.Attempts]
+		}
+		_, err = q.MarkNotificationRetry(ctx, &notifications.MarkNotificationRetryParams{
+			ID:            n.ID,
+			Provider:      &providerName,
+			LastError:     utils.ToStringPtr(sendErr.Error()),
+			NextAttemptAt: time.Now().Add(backoff),
+		})
+	default:
+		_, err = q.MarkNotificationSent(ctx, &notifications.MarkNotificationSentParams{
+			ID:       n.ID,
+			Provider: &providerName,
+			Locale:   &locale,
+			Subject:  &msg.Subject,
+			Body:     &msg.Body,
+		})
+	}
+	if err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// markNotificationFailed marks a notification that can never be delivered (e.g. bad template data) as failed.
+func (s *notificationsService) markNotificationFailed(ctx context.Context, q *notifications.Queries, n *notifications.Notification, provider string, cause error) error {
+	log.Printf("Failed to render notification %d: %v", n.ID, cause)
+	_, err := q.MarkNotificationFailed(ctx, &notifications.MarkNotificationFailedParams{
+		ID:        n.ID,
+		Provider:  &provider,
+		LastError: utils.ToStringPtr(cause.Error()),
+	})
+	return err
+}
+
+// Run dispatches pending notifications every interval until the context is cancelled.
+func (s *notificationsService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.DispatchPendingNotifications(ctx); err != nil {
+			log.Printf("Failed to dispatch notifications: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NotificationDispatchInterval returns the dispatcher interval configured in NOTIFY_DISPATCH_INTERVAL_SECONDS.
+func NotificationDispatchInterval() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("NOTIFY_DISPATCH_INTERVAL_SECONDS"))
+	if err != nil || seconds <= 0 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// customerContact holds the customer details on file used to address notifications.
+type customerContact struct {
+	Email   string
+	Contact string
+}
+
+// enqueueCustomerNotifications queues an email and an SMS for the customer on the caller's
+// transaction so that notifications are only recorded when the triggering change commits.
+// Channels without a recipient on file are skipped, as is SMS when the contact on file is not a
+// valid Malaysian phone number; SMS recipients are stored in the normalized form providers expect.
+func enqueueCustomerNotifications(ctx context.Context, db notifications.DBTX, event notifier.Event, to customerContact, data *notifier.TemplateData, warrantyID, claimID *int32) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	q := notifications.New(db)
+	recipients := map[notifier.Channel]string{
+		notifier.ChannelEmail: strings.TrimSpace(to.Email),
+	}
+	if phone, err := utils.NormalizeMalaysianPhone(to.Contact); err == nil {
+		recipients[notifier.ChannelSMS] = phone
+	}
+	for _, channel := range []notifier.Channel{notifier.ChannelEmail, notifier.ChannelSMS} {
+		recipient := recipients[channel]
+		if recipient == "" {
+			continue
+		}
+		_, err := q.CreateNotification(ctx, &notifications.CreateNotificationParams{
+			EventType:  string(event),
+			Channel:    string(channel),
+			Recipient:  recipient,
+			WarrantyID: warrantyID,
+			ClaimID:    claimID,
+			Payload:    payload,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue %s notification: %w", channel, err)
+		}
+	}
+	return nil
+}
+
+// enqueueStaffEmails queues an email to each staff recipient on the caller's transaction.
+func enqueueStaffEmails(ctx context.Context, db notifications.DBTX, event notifier.Event, recipients []string, data *notifier.TemplateData, warrantyID, claimID *int32) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	q := notifications.New(db)
+	for _, recipient := range recipients {
+		_, err := q.CreateNotification(ctx, &notifications.CreateNotificationParams{
+			EventType:  string(event),
+			Channel:    string(notifier.ChannelEmail),
+			Recipient:  recipient,
+			WarrantyID: warrantyID,
+			ClaimID:    claimID,
+			Payload:    payload,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue email notification: %w", err)
+		}
+	}
+	return nil
+}
+
+// staffEmailsFromEnv reads the comma separated staff emails in the environment variable.
+func staffEmailsFromEnv(key string) []string {
+	emails := []string{}
+	for _, email := range strings.Split(os.Getenv(key), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
@@ -0,0 +1,460 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"html/template"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/claims"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/portal"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Customer portal login code settings.
+const (
+	portalLoginCodeDigits         = 6
+	portalLoginCodeTTL            = 10 * time.Minute
+	portalLoginCodeMaxAttempts    = 5
+	portalLoginCodeResendInterval = time.Minute
+	portalLoginCodesPerHour       = 5
+)
+
+// ErrInvalidLoginCode is returned when a portal login code is wrong, expired or used up.
+var ErrInvalidLoginCode = errors.New("invalid or expired login code")
+
+// PortalLogin is the result of a verified portal login code.
+type PortalLogin struct {
+	Customer *portal.Customer `json:"customer"`
+	Channel  string           `json:"channel"`
+	Contact  string           `json:"contact"`
+}
+
+type PortalService interface {
+	RequestLoginCode(ctx context.Context, contact string) error
+	VerifyLoginCode(ctx context.Context, contact, code string) (*PortalLogin, error)
+
+	ListWarranties(ctx context.Context, channel, contact string) ([]*portal.ListPortalWarrantiesRow, error)
+	GetWarrantyByID(ctx context.Context, channel, contact string, id int32) (*portal.GetPortalWarrantyByIDRow, error)
+	ListWarrantyPartsByWarrantyID(ctx context.Context, channel, contact string, warrantyID int32) ([]*warranties.GetWarrantyPartsByWarrantyIDRow, error)
+	RenderWarrantyCertificate(ctx context.Context, channel, contact string, warrantyID int32) ([]byte, error)
+
+	ListClaims(ctx context.Context, channel, contact string) ([]*portal.ClaimView, error)
+	SubmitClaim(ctx context.Context, channel, contact string, warrantyID int32, partsArgs []*claims.CreateClaimWarrantyPartParams) (*portal.Claim, error)
+}
+
+type portalService struct {
+	db *pgxpool.Pool
+	q  *portal.Queries
+}
+
+func NewPortalService(db *pgxpool.Pool) PortalService {
+	return &portalService{
+		db: db,
+		q:  portal.New(db),
+	}
+}
+
+// parsePortalContact returns the notification channel and normalized value of a phone number
+// or e-mail address entered on the portal login page.
+func parsePortalContact(contact string) (string, string, error) {
+	contact = strings.TrimSpace(contact)
+	if strings.Contains(contact, "@") {
+		return string(notifier.ChannelEmail), strings.ToLower(contact), nil
+	}
+	phone, err := utils.NormalizeMalaysianPhone(contact)
+	if err != nil {
+		return "", "", err
+	}
+	return string(notifier.ChannelSMS), phone, nil
+}
+
+// generateLoginCode returns a random numeric login code.
+func generateLoginCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < portalLoginCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", portalLoginCodeDigits, n), nil
+}
+
+// RequestLoginCode sends a one-time login code to the phone number or e-mail address if it is
+// on file for a customer. Unknown contacts are ignored without an error so that the portal does
+// not reveal which contacts exist in the database; for the same reason a throttled request for a
+// known contact is dropped silently rather than reported.
+func (s *portalService) RequestLoginCode(ctx context.Context, contact string) error {
+	channel, recipient, err := parsePortalContact(contact)
+	if err != nil {
+		return err
+	}
+
+	customers, err := s.q.ListPortalCustomersByContact(ctx, &portal.ListPortalCustomersByContactParams{
+		Channel: channel,
+		Contact: recipient,
+	})
+	if err != nil {
+		return err
+	}
+	if len(customers) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	recent, err := s.q.CountCustomerOtpsSince(ctx, &portal.CountCustomerOtpsSinceParams{
+		Channel:   channel,
+		Recipient: recipient,
+		Since:     now.Add(-portalLoginCodeResendInterval),
+	})
+	if err != nil {
+		return err
+	}
+	if recent > 0 {
+		return nil
+	}
+	hourly, err := s.q.CountCustomerOtpsSince(ctx, &portal.CountCustomerOtpsSinceParams{
+		Channel:   channel,
+		Recipient: recipient,
+		Since:     now.Add(-time.Hour),
+	})
+	if err != nil {
+		return err
+	}
+	if hourly >= portalLoginCodesPerHour {
+		return nil
+	}
+
+	code, err := generateLoginCode()
+	if err != nil {
+		return err
+	}
+	codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	qtx := portal.New(tx)
+	// only the latest code can be used
+	if err := qtx.ConsumeCustomerOtps(ctx, &portal.ConsumeCustomerOtpsParams{
+		Channel:   channel,
+		Recipient: recipient,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	if _, err := qtx.CreateCustomerOtp(ctx, &portal.CreateCustomerOtpParams{
+		Channel:   channel,
+		Recipient: recipient,
+		CodeHash:  string(codeHash),
+		ExpiresAt: now.Add(portalLoginCodeTTL),
+	}); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+
+	customer := customers[0]
+	to := customerContact{Contact: customer.Phone}
+	if channel == string(notifier.ChannelEmail) {
+		to = customerContact{Email: customer.Email}
+	}
+	data := &notifier.TemplateData{
+		CustomerName: customer.Name,
+		LoginCode:    code,
+		CodeMinutes:  int(portalLoginCodeTTL / time.Minute),
+	}
+	if err := enqueueCustomerNotifications(ctx, tx, notifier.EventCustomerLoginCode, to, data, nil, nil); err != nil {
+		tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// VerifyLoginCode checks the latest login code sent to the contact and consumes it on success.
+// A code is rejected after it expires or after too many wrong attempts.
+func (s *portalService) VerifyLoginCode(ctx context.Context, contact, code string) (*PortalLogin, error) {
+	channel, recipient, err := parsePortalContact(contact)
+	if err != nil {
+		return nil, ErrInvalidLoginCode
+	}
+
+	otp, err := s.q.GetLatestCustomerOtp(ctx, &portal.GetLatestCustomerOtpParams{
+		Channel:   channel,
+		Recipient: recipient,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInvalidLoginCode
+		}
+		return nil, err
+	}
+	if time.Now().After(otp.ExpiresAt) {
+		return nil, ErrInvalidLoginCode
+	}
+	// count the attempt before comparing so that concurrent guesses cannot exceed the limit
+	otp, err = s.q.IncrementCustomerOtpAttempts(ctx, otp.ID)
+	if err != nil {
+		return nil, err
+	}
+	if otp.Attempts > portalLoginCodeMaxAttempts {
+		return nil, ErrInvalidLoginCode
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(otp.CodeHash), []byte(strings.TrimSpace(code))); err != nil {
+		return nil, ErrInvalidLoginCode
+	}
+	if err := s.q.ConsumeCustomerOtps(ctx, &portal.ConsumeCustomerOtpsParams{
+		Channel:   channel,
+		Recipient: recipient,
+	}); err != nil {
+		return nil, err
+	}
+
+	customers, err := s.q.ListPortalCustomersByContact(ctx, &portal.ListPortalCustomersByContactParams{
+		Channel: channel,
+		Contact: recipient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(customers) == 0 {
+		return nil, ErrInvalidLoginCode
+	}
+
+	return &PortalLogin{
+		Customer: customers[0],
+		Channel:  channel,
+		Contact:  recipient,
+	}, nil
+}
+
+// ListWarranties retrieves the warranties of the customers with the verified contact from the database.
+func (s *portalService) ListWarranties(ctx context.Context, channel, contact string) ([]*portal.ListPortalWarrantiesRow, error) {
+	return s.q.ListPortalWarranties(ctx, &portal.ListPortalWarrantiesParams{
+		Channel: channel,
+		Contact: contact,
+	})
+}
+
+// GetWarrantyByID retrieves a warranty of the customers with the verified contact from the database.
+func (s *portalService) GetWarrantyByID(ctx context.Context, channel, contact string, id int32) (*portal.GetPortalWarrantyByIDRow, error) {
+	return s.q.GetPortalWarrantyByID(ctx, &portal.GetPortalWarrantyByIDParams{
+		ID:      id,
+		Channel: channel,
+		Contact: contact,
+	})
+}
+
+// ListWarrantyPartsByWarrantyID retrieves the parts of a warranty of the customers with the
+// verified contact from the database.
+func (s *portalService) ListWarrantyPartsByWarrantyID(ctx context.Context, channel, contact string, warrantyID int32) ([]*warranties.GetWarrantyPartsByWarrantyIDRow, error) {
+	if _, err := s.GetWarrantyByID(ctx, channel, contact, warrantyID); err != nil {
+		return nil, err
+	}
+	return warranties.New(s.db).GetWarrantyPartsByWarrantyID(ctx, warrantyID)
+}
+
+// portalCertificatePart is a warranty part as printed on the certificate.
+type portalCertificatePart struct {
+	CarPartName      string
+	ProductName      string
+	FilmSerialNumber string
+	WarrantyInMonths int32
+	ExpiryDate       string
+}
+
+var portalCertificateTemplate = template.Must(template.New("certificate").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Warranty Certificate {{.WarrantyNo}}</title>
+<style>
+body { font-family: Arial, sans-serif; margin: 40px; color: #222; }
+h1 { text-align: center; letter-spacing: 2px; }
+table { width: 100%; border-collapse: collapse; margin-top: 16px; }
+th, td { border: 1px solid #999; padding: 6px 8px; text-align: left; }
+.details td:first-child { width: 30%; font-weight: bold; }
+.footer { margin-top: 32px; font-size: 12px; color: #666; }
+</style>
+</head>
+<body>
+<h1>PROFILM WARRANTY CERTIFICATE</h1>
+<table class="details">
+<tr><td>Warranty No.</td><td>{{.WarrantyNo}}</td></tr>
+<tr><td>Customer</td><td>{{.CustomerName}}</td></tr>
+<tr><td>Vehicle</td><td>{{.CarBrand}} {{.CarModel}} ({{.CarColour}})</td></tr>
+<tr><td>Plate No.</td><td>{{.CarPlateNo}}</td></tr>
+<tr><td>Chassis No.</td><td>{{.CarChassisNo}}</td></tr>
+<tr><td>Installation Date</td><td>{{.InstallationDate}}</td></tr>
+<tr><td>Installer</td><td>{{.ShopName}} ({{.BranchCode}})</td></tr>
+</table>
+<table>
+<tr><th>Car Part</th><th>Product</th><th>Film Serial No.</th><th>Warranty</th><th>Valid Until</th></tr>
+{{range .Parts}}<tr><td>{{.CarPartName}}</td><td>{{.ProductName}}</td><td>{{.FilmSerialNumber}}</td><td>{{.WarrantyInMonths}} months</td><td>{{.ExpiryDate}}</td></tr>
+{{end}}</table>
+<p class="footer">Issued on {{.IssuedOn}}. This certificate is valid only for the vehicle and parts listed above.</p>
+</body>
+</html>
+`))
+
+// RenderWarrantyCertificate renders the printable HTML certificate of an approved warranty of
+// the customers with the verified contact.
+func (s *portalService) RenderWarrantyCertificate(ctx context.Context, channel, contact string, warrantyID int32) ([]byte, error) {
+	warranty, err := s.GetWarrantyByID(ctx, channel, contact, warrantyID)
+	if err != nil {
+		return nil, err
+	}
+	if !warranty.ApprovalStatus.IsGranted() {
+		return nil, fmt.Errorf("certificate is only available for approved warranties")
+	}
+	parts, err := warranties.New(s.db).GetWarrantyPartsByWarrantyID(ctx, warrantyID)
+	if err != nil {
+		return nil, err
+	}
+
+	certificateParts := make([]portalCertificatePart, 0, len(parts))
+	for _, part := range parts {
+		certificateParts = append(certificateParts, portalCertificatePart{
+			CarPartName:      part.CarPartName,
+			ProductName:      strings.Join([]string{part.ProductBrand, part.ProductSeries, part.ProductName}, " "),
+			FilmSerialNumber: part.FilmSerialNumber,
+			WarrantyInMonths: part.WarrantyInMonths,
+			ExpiryDate:       warranty.InstallationDate.AddDate(0, int(part.WarrantyInMonths), 0).Format("02 Jan 2006"),
+		})
+	}
+
+	var buf bytes.Buffer
+	err = portalCertificateTemplate.Execute(&buf, map[string]any{
+		"WarrantyNo":       warranty.WarrantyNo,
+		"CustomerName":     warranty.ClientName,
+		"CarBrand":         warranty.CarBrand,
+		"CarModel":         warranty.CarModel,
+		"CarColour":        warranty.CarColour,
+		"CarPlateNo":       warranty.CarPlateNo,
+		"CarChassisNo":     warranty.CarChassisNo,
+		"InstallationDate": warranty.InstallationDate.Format("02 Jan 2006"),
+		"ShopName":         warranty.ShopName,
+		"BranchCode":       warranty.BranchCode,
+		"Parts":            certificateParts,
+		"IssuedOn":         time.Now().Format("02 Jan 2006"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render certificate: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ListClaims retrieves the claims on warranties of the customers with the verified contact from the database.
+func (s *portalService) ListClaims(ctx context.Context, channel, contact string) ([]*portal.ClaimView, error) {
+	return s.q.ListPortalClaims(ctx, &portal.ListPortalClaimsParams{
+		Channel: channel,
+		Contact: contact,
+	})
+}
+
+// SubmitClaim creates a claim dated today on an approved warranty of the customers with the
+// verified contact. Every part must pass the claim eligibility rules and include a photo of the
+// damage.
+func (s *portalService) SubmitClaim(ctx context.Context, channel, contact string, warrantyID int32, partsArgs []*claims.CreateClaimWarrantyPartParams) (*portal.Claim, error) {
+	if len(partsArgs) == 0 {
+		return nil, fmt.Errorf("at least one damaged part is required")
+	}
+
+	warranty, err := s.GetWarrantyByID(ctx, channel, contact, warrantyID)
+	if err != nil {
+		return nil, fmt.Errorf("warranty %d not found", warrantyID)
+	}
+	if !warranty.ApprovalStatus.IsGranted() || !warranty.IsActive {
+		return nil, fmt.Errorf("claims can only be submitted for active approved warranties")
+	}
+	warrantyPartIDs := make([]int32, 0, len(partsArgs))
+	for _, partArg := range partsArgs {
+		if strings.TrimSpace(partArg.DamagedImageUrl) == "" {
+			return nil, fmt.Errorf("a photo of the damage is required for warranty part %d", partArg.WarrantyPartID)
+		}
+		warrantyPartIDs = append(warrantyPartIDs, partArg.WarrantyPartID)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := portal.New(tx)
+	cqtx := claims.New(tx)
+
+	now := time.Now()
+	claimDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	eligibility, err := checkClaimEligibility(ctx, cqtx, warrantyID, claimDate, warrantyPartIDs, nil)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if !eligibility.Eligible {
+		tx.Rollback(ctx)
+		return nil, &ClaimIneligibleError{Eligibility: eligibility}
+	}
+	claim, err := qtx.CreatePortalClaim(ctx, &portal.CreatePortalClaimParams{
+		WarrantyID:            warrantyID,
+		ClaimNo:               nextClaimNo(ctx, cqtx, warranty.WarrantyNo, claimDate.Format("20060102")),
+		ClaimDate:             claimDate,
+		SubmittedByCustomerID: warranty.CustomerID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	for _, partArg := range partsArgs {
+		partArg.ClaimID = claim.ID
+		// resolution details are recorded by the shop
+		partArg.ResolutionDate = nil
+		partArg.ResolutionImageUrl = nil
+		if _, err := cqtx.CreateClaimWarrantyPart(ctx, partArg); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if _, err := cqtx.CreateClaimStatusTransition(ctx, &claims.CreateClaimStatusTransitionParams{
+		ClaimID:             claim.ID,
+		ToStatus:            claim.Status,
+		ChangedByCustomerID: warranty.CustomerID,
+		ActorRole:           models.ClaimActorCustomer,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if _, err := startClaimSLA(ctx, tx, claim.ID, claim.Status, claimSLAToday()); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	claimView, err := cqtx.GetClaimByID(ctx, claim.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := enqueueClaimNotifications(ctx, tx, notifier.EventClaimCreated, claimView); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
@@ -2,11 +2,42 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/productallocations"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
 )
 
+var (
+	// ErrAllocationInUse is returned when an allocation that warranties, claims, returns,
+	// transfers or stock-take adjustments already draw on would be moved to another product or
+	// shop, or when an allocation opened by a transfer would be edited.
+	ErrAllocationInUse = errors.New("product allocation is in use")
+	// ErrInvalidAllocationTransfer is returned when a transfer has no receiving shop or sends the
+	// film to the shop that already holds it.
+	ErrInvalidAllocationTransfer = errors.New("invalid allocation transfer")
+	// ErrFilmSerialNotFound is returned when no product has the film serial number of a scanned label.
+	ErrFilmSerialNotFound = errors.New("film serial number not found")
+)
+
+// FilmLabelLookup is the product matched by a scanned film roll label together with its
+// allocations at a shop.
+type FilmLabelLookup struct {
+	Label             *utils.FilmLabel                                                       `json:"label"`
+	Product           *productallocations.GetProductByFilmSerialNumberRow                    `json:"product"`
+	ShopID            int32                                                                  `json:"shopId"`
+	AllocatedToShop   bool                                                                   `json:"allocatedToShop"`
+	RemainingQuantity float64                                                                `json:"remainingQuantity"`
+	Allocations       []*productallocations.ListProductAllocationBalancesByProductAndShopRow `json:"allocations"`
+	Message           string                                                                 `json:"message,omitempty"`
+}
+
 type ProductAllocationsService interface {
 	ListProductAllocationsView(ctx context.Context) ([]*productallocations.ListProductAllocationsViewRow, error)
 	CreateProductAllocation(ctx context.Context, arg *productallocations.CreateProductAllocationParams) (*productallocations.ProductAllocation, error)
@@ -14,6 +45,13 @@ type ProductAllocationsService interface {
 	UpdateProductAllocation(ctx context.Context, arg *productallocations.UpdateProductAllocationParams) (*productallocations.ProductAllocation, error)
 
 	GetProductsFromProductAllocationsByShopID(ctx context.Context, shopID int32) ([]*productallocations.GetProductsFromProductAllocationsByShopIDRow, error)
+	ListProductAllocationBalancesByShopID(ctx context.Context, shopID int32) ([]*productallocations.ListProductAllocationBalancesByShopIDRow, error)
+
+	ReturnProductAllocation(ctx context.Context, userID int32, arg *productallocations.CreateProductAllocationTransferParams) (*productallocations.ProductAllocationTransfer, error)
+	TransferProductAllocation(ctx context.Context, userID int32, arg *productallocations.CreateProductAllocationTransferParams) (*productallocations.ProductAllocationTransfer, error)
+	ListProductAllocationTransfers(ctx context.Context, arg *productallocations.ListProductAllocationTransfersParams) ([]*productallocations.ListProductAllocationTransfersRow, error)
+
+	LookupFilmLabel(ctx context.Context, payload string, shopID int32) (*FilmLabelLookup, error)
 }
 
 type productAllocationsService struct {
@@ -33,9 +71,41 @@ func (s *productAllocationsService) ListProductAllocationsView(ctx context.Conte
 	return s.q.ListProductAllocationsView(ctx)
 }
 
-// CreateProductAllocation creates a new product allocation in the database.
+// CreateProductAllocation creates a new product allocation in the database and moves its film
+// from HQ to the shop in the inventory ledger. The quantity is given in the allocation's unit of
+// measure and converted to rolls. HQ must have enough of the product on hand, and film frozen by
+// an open recall cannot be allocated.
 func (s *productAllocationsService) CreateProductAllocation(ctx context.Context, arg *productallocations.CreateProductAllocationParams) (*productallocations.ProductAllocation, error) {
-	return s.q.CreateProductAllocation(ctx, arg)
+	var err error
+	arg.UnitOfMeasure, arg.FilmQuantity, err = allocationRolls(ctx, s.q, arg.ProductID, arg.UnitOfMeasure, arg.UnitQuantity)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := productallocations.New(tx)
+
+	if err := recallFreeze(qtx.GetInstallFreezeRecallNoByProductID(ctx, arg.ProductID)); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	allocation, err := qtx.CreateProductAllocation(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	movements := allocationMovements(allocation.ID, allocation.ProductID, allocation.ShopID, allocation.FilmQuantity, "Allocated to shop")
+	if _, err := postInventoryMovements(ctx, inventory.New(tx), movements...); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return allocation, nil
 }
 
 // GetProductAllocationByID retrieves a product allocation by its ID from the database.
@@ -43,12 +113,278 @@ func (s *productAllocationsService) GetProductAllocationByID(ctx context.Context
 	return s.q.GetProductAllocationByID(ctx, id)
 }
 
-// UpdateProductAllocation updates an existing product allocation in the database.
+// UpdateProductAllocation corrects an existing product allocation in the database and posts the
+// difference to the inventory ledger. Moving the allocation to another product or shop returns
+// its film to HQ and allocates it again, which is only allowed while nothing draws on it yet;
+// use a return or transfer otherwise. HQ must have enough film for any increase, the shop must
+// still hold any film taken back, and the allocation cannot drop below what claim resolutions,
+// warranty installs, returns and transfers have already taken, net of stock-take adjustments.
+// Allocations opened by a transfer, or being counted by a stock take, cannot be edited, and film
+// frozen by an open recall cannot be added to an allocation or moved onto another one.
 func (s *productAllocationsService) UpdateProductAllocation(ctx context.Context, arg *productallocations.UpdateProductAllocationParams) (*productallocations.ProductAllocation, error) {
-	return s.q.UpdateProductAllocation(ctx, arg)
+	var err error
+	arg.UnitOfMeasure, arg.FilmQuantity, err = allocationRolls(ctx, s.q, arg.ProductID, arg.UnitOfMeasure, arg.UnitQuantity)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := productallocations.New(tx)
+
+	current, err := qtx.GetProductAllocationByIDForUpdate(ctx, arg.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := stockTakeLock(qtx.GetInProgressStockTakeNoByAllocationID(ctx, arg.ID)); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	usage, err := qtx.GetProductAllocationUsage(ctx, arg.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if usage.IsTransferIn {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: allocation %d was opened by a transfer; return or transfer its film instead", ErrAllocationInUse, arg.ID)
+	}
+	moved := current.ProductID != arg.ProductID || current.ShopID != arg.ShopID
+	if moved && (usage.WarrantyPartCount > 0 || usage.ConsumedQuantity > 0 || usage.TransferredQuantity > 0 || usage.AdjustedQuantity != 0) {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: allocation %d has %d warranty parts, %g used by claims, %g returned or transferred and %g adjusted by stock takes; return or transfer its film instead",
+			ErrAllocationInUse, arg.ID, usage.WarrantyPartCount, usage.ConsumedQuantity, usage.TransferredQuantity, usage.AdjustedQuantity)
+	}
+	if moved || arg.FilmQuantity > current.FilmQuantity {
+		// more film of the product reaches a shop, as with a new allocation
+		if err := recallFreeze(qtx.GetInstallFreezeRecallNoByProductID(ctx, arg.ProductID)); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+	if drawn := roundQuantity(current.FilmQuantity - usage.RemainingQuantity); arg.FilmQuantity < drawn {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: claim resolutions, warranty installs, returns, transfers and stock takes have taken %g of allocation %d", ErrInsufficientStock, drawn, arg.ID)
+	}
+
+	allocation, err := qtx.UpdateProductAllocation(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	var movements []*inventory.CreateInventoryMovementParams
+	if moved {
+		movements = append(movements, allocationMovements(current.ID, current.ProductID, current.ShopID, -current.FilmQuantity, "Allocation moved")...)
+		movements = append(movements, allocationMovements(allocation.ID, allocation.ProductID, allocation.ShopID, allocation.FilmQuantity, "Allocation moved")...)
+	} else {
+		movements = allocationMovements(allocation.ID, allocation.ProductID, allocation.ShopID, allocation.FilmQuantity-current.FilmQuantity, "Allocation corrected")
+	}
+	if _, err := postInventoryMovements(ctx, inventory.New(tx), movements...); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return allocation, nil
 }
 
 // GetProductsFromProductAllocationsByShopID retrieves products associated with a specific shop ID from the database.
 func (s *productAllocationsService) GetProductsFromProductAllocationsByShopID(ctx context.Context, shopID int32) ([]*productallocations.GetProductsFromProductAllocationsByShopIDRow, error) {
 	return s.q.GetProductsFromProductAllocationsByShopID(ctx, shopID)
 }
+
+// ListProductAllocationBalancesByShopID retrieves the remaining film of each allocation of a shop from the database.
+func (s *productAllocationsService) ListProductAllocationBalancesByShopID(ctx context.Context, shopID int32) ([]*productallocations.ListProductAllocationBalancesByShopIDRow, error) {
+	return s.q.ListProductAllocationBalancesByShopID(ctx, shopID)
+}
+
+// LookupFilmLabel parses a scanned film roll label and retrieves the matching product and its
+// allocations at a shop, with the film each has left, from the database. A GS1 label is matched
+// on its serial (21) and then its batch (10). The message explains when the roll cannot be used
+// by the shop.
+func (s *productAllocationsService) LookupFilmLabel(ctx context.Context, payload string, shopID int32) (*FilmLabelLookup, error) {
+	label, err := utils.ParseFilmLabel(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var product *productallocations.GetProductByFilmSerialNumberRow
+	for _, serial := range []string{label.Serial, label.Lot} {
+		if serial == "" {
+			continue
+		}
+		product, err = s.q.GetProductByFilmSerialNumber(ctx, serial)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrFilmSerialNotFound, label.FilmSerialNumber())
+	}
+
+	allocations, err := s.q.ListProductAllocationBalancesByProductAndShop(ctx, &productallocations.ListProductAllocationBalancesByProductAndShopParams{
+		ProductID: product.ID,
+		ShopID:    shopID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	lookup := &FilmLabelLookup{
+		Label:           label,
+		Product:         product,
+		ShopID:          shopID,
+		AllocatedToShop: len(allocations) > 0,
+		Allocations:     allocations,
+	}
+	for _, a := range allocations {
+		if a.RemainingQuantity > 0 {
+			lookup.RemainingQuantity += a.RemainingQuantity
+		}
+	}
+	lookup.RemainingQuantity = roundQuantity(lookup.RemainingQuantity)
+	switch {
+	case !lookup.AllocatedToShop:
+		lookup.Message = fmt.Sprintf("Film %s is not allocated to this shop", product.FilmSerialNumber)
+	case lookup.RemainingQuantity <= 0:
+		lookup.Message = fmt.Sprintf("Film %s has no stock left at this shop", product.FilmSerialNumber)
+	case !product.IsActive:
+		lookup.Message = fmt.Sprintf("Film %s is inactive", product.FilmSerialNumber)
+	}
+	return lookup, nil
+}
+
+// ListProductAllocationTransfers retrieves returns and transfers of allocations, newest first, from the database.
+func (s *productAllocationsService) ListProductAllocationTransfers(ctx context.Context, arg *productallocations.ListProductAllocationTransfersParams) ([]*productallocations.ListProductAllocationTransfersRow, error) {
+	return s.q.ListProductAllocationTransfers(ctx, arg)
+}
+
+// ReturnProductAllocation records film sent back from a shop allocation to HQ in the database
+// and moves it in the inventory ledger. The allocation itself is left unchanged.
+func (s *productAllocationsService) ReturnProductAllocation(ctx context.Context, userID int32, arg *productallocations.CreateProductAllocationTransferParams) (*productallocations.ProductAllocationTransfer, error) {
+	arg.TransferType = models.AllocationTransferReturn
+	arg.ToShopID = nil
+	return s.releaseProductAllocation(ctx, userID, arg)
+}
+
+// TransferProductAllocation records film sent from a shop allocation to another shop in the
+// database. A new allocation is opened at the receiving shop and the film is moved between the
+// shops in the inventory ledger. The original allocation is left unchanged. Film frozen by an
+// open recall cannot be transferred; it can still be returned to HQ.
+func (s *productAllocationsService) TransferProductAllocation(ctx context.Context, userID int32, arg *productallocations.CreateProductAllocationTransferParams) (*productallocations.ProductAllocationTransfer, error) {
+	if arg.ToShopID == nil {
+		return nil, fmt.Errorf("%w: receiving shop is required", ErrInvalidAllocationTransfer)
+	}
+	arg.TransferType = models.AllocationTransferTransfer
+	return s.releaseProductAllocation(ctx, userID, arg)
+}
+
+// releaseProductAllocation takes film off an allocation by returning it to HQ or transferring it
+// to another shop. Only film not yet used by claim resolutions or warranty installs, or released
+// before, can leave, and none while a stock take is counting the allocation.
+func (s *productAllocationsService) releaseProductAllocation(ctx context.Context, userID int32, arg *productallocations.CreateProductAllocationTransferParams) (*productallocations.ProductAllocationTransfer, error) {
+	if arg.FilmQuantity = roundQuantity(arg.FilmQuantity); arg.FilmQuantity <= 0 {
+		return nil, fmt.Errorf("%w: film quantity must be greater than zero", ErrInvalidInventoryQuantity)
+	}
+	arg.CreatedByUserID = &userID
+	arg.ToProductAllocationID = nil
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := productallocations.New(tx)
+
+	allocation, err := qtx.GetProductAllocationByIDForUpdate(ctx, arg.ProductAllocationID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := stockTakeLock(qtx.GetInProgressStockTakeNoByAllocationID(ctx, allocation.ID)); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	usage, err := qtx.GetProductAllocationUsage(ctx, allocation.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if remaining := roundQuantity(usage.RemainingQuantity); arg.FilmQuantity > remaining {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %g rolls left on allocation %d, %g requested", ErrInsufficientAllocationBalance, remaining, allocation.ID, arg.FilmQuantity)
+	}
+
+	var movements []*inventory.CreateInventoryMovementParams
+	if arg.TransferType == models.AllocationTransferReturn {
+		remarks := fmt.Sprintf("Returned to HQ from allocation %d", allocation.ID)
+		movements = transferMovements(models.InventoryMovementReturn, allocation.ProductID, &allocation.ShopID, nil, allocation.ID, allocation.ID, arg.FilmQuantity, remarks, userID)
+	} else {
+		if *arg.ToShopID == allocation.ShopID {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: allocation %d is already at shop %d", ErrInvalidAllocationTransfer, allocation.ID, allocation.ShopID)
+		}
+		if err := recallFreeze(qtx.GetInstallFreezeRecallNoByProductID(ctx, allocation.ProductID)); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		target, err := qtx.CreateProductAllocation(ctx, &productallocations.CreateProductAllocationParams{
+			ProductID:      allocation.ProductID,
+			ShopID:         *arg.ToShopID,
+			FilmQuantity:   arg.FilmQuantity,
+			UnitOfMeasure:  models.UnitOfMeasureRoll,
+			UnitQuantity:   arg.FilmQuantity,
+			AllocationDate: arg.TransferDate,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		arg.ToProductAllocationID = &target.ID
+		remarks := fmt.Sprintf("Transferred from allocation %d to allocation %d", allocation.ID, target.ID)
+		movements = transferMovements(models.InventoryMovementTransfer, allocation.ProductID, &allocation.ShopID, &target.ShopID, allocation.ID, target.ID, arg.FilmQuantity, remarks, userID)
+	}
+
+	transfer, err := qtx.CreateProductAllocationTransfer(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if _, err := postInventoryMovements(ctx, inventory.New(tx), movements...); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// allocationRolls converts the quantity of an allocation, given in the unit of measure it was
+// entered in, to rolls using the conversion factors of its product.
+func allocationRolls(ctx context.Context, q *productallocations.Queries, productID int32, unit string, quantity float64) (string, float64, error) {
+	unit, err := normalizeUnitOfMeasure(unit)
+	if err != nil {
+		return "", 0, err
+	}
+	if quantity <= 0 {
+		return "", 0, fmt.Errorf("%w: film quantity must be greater than zero", ErrInvalidInventoryQuantity)
+	}
+	product, err := q.GetProductUnitsByID(ctx, productID)
+	if err != nil {
+		return "", 0, err
+	}
+	rolls, err := toRolls(quantity, unit, product.RollLengthMetres, product.SheetsPerRoll)
+	if err != nil {
+		return "", 0, err
+	}
+	if rolls <= 0 {
+		return "", 0, fmt.Errorf("%w: %g %s is less than the smallest part roll", ErrInvalidInventoryQuantity, quantity, strings.ToLower(unit))
+	}
+	return unit, rolls, nil
+}
@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/products"
+)
+
+var (
+	// ErrCatalogNameRequired is returned when a catalog level is saved without a name.
+	ErrCatalogNameRequired = errors.New("catalog name is required")
+	// ErrCatalogNameTaken is returned when another level under the same parent already uses the name.
+	ErrCatalogNameTaken = errors.New("catalog name already exists")
+	// ErrCatalogParentInvalid is returned when the parent level does not exist or is inactive.
+	ErrCatalogParentInvalid = errors.New("catalog parent is invalid")
+	// ErrCatalogLevelInUse is returned when a level referenced by products or active children
+	// would be moved or deactivated.
+	ErrCatalogLevelInUse = errors.New("catalog level is in use")
+)
+
+// normalizeCatalogName trims the name and rejects an empty one.
+func normalizeCatalogName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrCatalogNameRequired
+	}
+	return name, nil
+}
+
+// checkCatalogDeactivation refuses to deactivate a level that active products or active
+// child levels still reference.
+func checkCatalogDeactivation(activeProducts, activeChildren int32) error {
+	if activeProducts > 0 {
+		return fmt.Errorf("%w: %d active products reference it", ErrCatalogLevelInUse, activeProducts)
+	}
+	if activeChildren > 0 {
+		return fmt.Errorf("%w: %d active child levels reference it", ErrCatalogLevelInUse, activeChildren)
+	}
+	return nil
+}
+
+// CreateProductBrand creates a new product brand in the database.
+func (s *productsService) CreateProductBrand(ctx context.Context, arg *products.CreateProductBrandParams) (*products.ProductBrand, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	if err := s.checkProductBrandName(ctx, 0, name); err != nil {
+		return nil, err
+	}
+	return s.q.CreateProductBrand(ctx, arg)
+}
+
+// UpdateProductBrand updates the name and description of a product brand in the database.
+func (s *productsService) UpdateProductBrand(ctx context.Context, arg *products.UpdateProductBrandParams) (*products.ProductBrand, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	if _, err := s.q.GetProductBrandByID(ctx, arg.ID); err != nil {
+		return nil, err
+	}
+	if err := s.checkProductBrandName(ctx, arg.ID, name); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateProductBrand(ctx, arg)
+}
+
+// SetProductBrandActive activates or deactivates a product brand in the database. A brand
+// cannot be deactivated while active products or active types reference it.
+func (s *productsService) SetProductBrandActive(ctx context.Context, arg *products.SetProductBrandActiveParams) (*products.ProductBrand, error) {
+	if _, err := s.q.GetProductBrandByID(ctx, arg.ID); err != nil {
+		return nil, err
+	}
+	if !arg.IsActive {
+		counts, err := s.q.CountProductsByBrandID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		children, err := s.q.CountActiveProductTypesByBrandID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCatalogDeactivation(counts.Active, children); err != nil {
+			return nil, err
+		}
+	}
+	return s.q.SetProductBrandActive(ctx, arg)
+}
+
+func (s *productsService) checkProductBrandName(ctx context.Context, id int32, name string) error {
+	total, err := s.q.CountProductBrandsByName(ctx, &products.CountProductBrandsByNameParams{
+		Name:      name,
+		ExcludeID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("%w: brand %q", ErrCatalogNameTaken, name)
+	}
+	return nil
+}
+
+// CreateProductType creates a new product type under an active brand in the database.
+func (s *productsService) CreateProductType(ctx context.Context, arg *products.CreateProductTypeParams) (*products.ProductType, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	if err := s.checkProductTypeParent(ctx, arg.BrandID); err != nil {
+		return nil, err
+	}
+	if err := s.checkProductTypeName(ctx, 0, arg.BrandID, name); err != nil {
+		return nil, err
+	}
+	return s.q.CreateProductType(ctx, arg)
+}
+
+// UpdateProductType updates a product type in the database. The type can only be moved to
+// another brand while no products reference it.
+func (s *productsService) UpdateProductType(ctx context.Context, arg *products.UpdateProductTypeParams) (*products.ProductType, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	current, err := s.q.GetProductTypeByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.BrandID != arg.BrandID {
+		if err := s.checkProductTypeParent(ctx, arg.BrandID); err != nil {
+			return nil, err
+		}
+		counts, err := s.q.CountProductsByTypeID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if counts.Total > 0 {
+			return nil, fmt.Errorf("%w: %d products reference the type", ErrCatalogLevelInUse, counts.Total)
+		}
+	}
+	if err := s.checkProductTypeName(ctx, arg.ID, arg.BrandID, name); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateProductType(ctx, arg)
+}
+
+// SetProductTypeActive activates or deactivates a product type in the database. A type cannot
+// be deactivated while active products or active series reference it, nor activated under an
+// inactive brand.
+func (s *productsService) SetProductTypeActive(ctx context.Context, arg *products.SetProductTypeActiveParams) (*products.ProductType, error) {
+	current, err := s.q.GetProductTypeByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if arg.IsActive {
+		if err := s.checkProductTypeParent(ctx, current.BrandID); err != nil {
+			return nil, err
+		}
+	} else {
+		counts, err := s.q.CountProductsByTypeID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		children, err := s.q.CountActiveProductSeriesByTypeID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCatalogDeactivation(counts.Active, children); err != nil {
+			return nil, err
+		}
+	}
+	return s.q.SetProductTypeActive(ctx, arg)
+}
+
+func (s *productsService) checkProductTypeParent(ctx context.Context, brandID int32) error {
+	brand, err := s.q.GetProductBrandByID(ctx, brandID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: brand %d not found", ErrCatalogParentInvalid, brandID)
+		}
+		return err
+	}
+	if !brand.IsActive {
+		return fmt.Errorf("%w: brand %q is inactive", ErrCatalogParentInvalid, brand.Name)
+	}
+	return nil
+}
+
+func (s *productsService) checkProductTypeName(ctx context.Context, id, brandID int32, name string) error {
+	total, err := s.q.CountProductTypesByName(ctx, &products.CountProductTypesByNameParams{
+		BrandID:   brandID,
+		Name:      name,
+		ExcludeID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("%w: type %q under this brand", ErrCatalogNameTaken, name)
+	}
+	return nil
+}
+
+// CreateProductSeries creates a new product series under an active type in the database.
+func (s *productsService) CreateProductSeries(ctx context.Context, arg *products.CreateProductSeriesParams) (*products.ProductSeries, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	if err := s.checkProductSeriesParent(ctx, arg.TypeID); err != nil {
+		return nil, err
+	}
+	if err := s.checkProductSeriesName(ctx, 0, arg.TypeID, name); err != nil {
+		return nil, err
+	}
+	return s.q.CreateProductSeries(ctx, arg)
+}
+
+// UpdateProductSeries updates a product series in the database. The series can only be moved
+// to another type while no products reference it.
+func (s *productsService) UpdateProductSeries(ctx context.Context, arg *products.UpdateProductSeriesParams) (*products.ProductSeries, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	current, err := s.q.GetProductSeriesByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.TypeID != arg.TypeID {
+		if err := s.checkProductSeriesParent(ctx, arg.TypeID); err != nil {
+			return nil, err
+		}
+		counts, err := s.q.CountProductsBySeriesID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if counts.Total > 0 {
+			return nil, fmt.Errorf("%w: %d products reference the series", ErrCatalogLevelInUse, counts.Total)
+		}
+	}
+	if err := s.checkProductSeriesName(ctx, arg.ID, arg.TypeID, name); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateProductSeries(ctx, arg)
+}
+
+// SetProductSeriesActive activates or deactivates a product series in the database. A series
+// cannot be deactivated while active products or active names reference it, nor activated
+// under an inactive type.
+func (s *productsService) SetProductSeriesActive(ctx context.Context, arg *products.SetProductSeriesActiveParams) (*products.ProductSeries, error) {
+	current, err := s.q.GetProductSeriesByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if arg.IsActive {
+		if err := s.checkProductSeriesParent(ctx, current.TypeID); err != nil {
+			return nil, err
+		}
+	} else {
+		counts, err := s.q.CountProductsBySeriesID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		children, err := s.q.CountActiveProductNamesBySeriesID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCatalogDeactivation(counts.Active, children); err != nil {
+			return nil, err
+		}
+	}
+	return s.q.SetProductSeriesActive(ctx, arg)
+}
+
+func (s *productsService) checkProductSeriesParent(ctx context.Context, typeID int32) error {
+	productType, err := s.q.GetProductTypeByID(ctx, typeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: type %d not found", ErrCatalogParentInvalid, typeID)
+		}
+		return err
+	}
+	if !productType.IsActive {
+		return fmt.Errorf("%w: type %q is inactive", ErrCatalogParentInvalid, productType.Name)
+	}
+	return nil
+}
+
+func (s *productsService) checkProductSeriesName(ctx context.Context, id, typeID int32, name string) error {
+	total, err := s.q.CountProductSeriesByName(ctx, &products.CountProductSeriesByNameParams{
+		TypeID:    typeID,
+		Name:      name,
+		ExcludeID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("%w: series %q under this type", ErrCatalogNameTaken, name)
+	}
+	return nil
+}
+
+// CreateProductName creates a new product name under an active series in the database.
+func (s *productsService) CreateProductName(ctx context.Context, arg *products.CreateProductNameParams) (*products.ProductName, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	if err := s.checkProductNameParent(ctx, arg.SeriesID); err != nil {
+		return nil, err
+	}
+	if err := s.checkProductNameName(ctx, 0, arg.SeriesID, name); err != nil {
+		return nil, err
+	}
+	return s.q.CreateProductName(ctx, arg)
+}
+
+// UpdateProductName updates a product name in the database. The name can only be moved to
+// another series while no products reference it.
+func (s *productsService) UpdateProductName(ctx context.Context, arg *products.UpdateProductNameParams) (*products.ProductName, error) {
+	name, err := normalizeCatalogName(arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = name
+
+	current, err := s.q.GetProductNameByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.SeriesID != arg.SeriesID {
+		if err := s.checkProductNameParent(ctx, arg.SeriesID); err != nil {
+			return nil, err
+		}
+		counts, err := s.q.CountProductsByNameID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if counts.Total > 0 {
+			return nil, fmt.Errorf("%w: %d products reference the name", ErrCatalogLevelInUse, counts.Total)
+		}
+	}
+	if err := s.checkProductNameName(ctx, arg.ID, arg.SeriesID, name); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateProductName(ctx, arg)
+}
+
+// SetProductNameActive activates or deactivates a product name in the database. A name cannot
+// be deactivated while active products reference it, nor activated under an inactive series.
+func (s *productsService) SetProductNameActive(ctx context.Context, arg *products.SetProductNameActiveParams) (*products.ProductName, error) {
+	current, err := s.q.GetProductNameByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if arg.IsActive {
+		if err := s.checkProductNameParent(ctx, current.SeriesID); err != nil {
+			return nil, err
+		}
+	} else {
+		counts, err := s.q.CountProductsByNameID(ctx, arg.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkCatalogDeactivation(counts.Active, 0); err != nil {
+			return nil, err
+		}
+	}
+	return s.q.SetProductNameActive(ctx, arg)
+}
+
+func (s *productsService) checkProductNameParent(ctx context.Context, seriesID int32) error {
+	series, err := s.q.GetProductSeriesByID(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: series %d not found", ErrCatalogParentInvalid, seriesID)
+		}
+		return err
+	}
+	if !series.IsActive {
+		return fmt.Errorf("%w: series %q is inactive", ErrCatalogParentInvalid, series.Name)
+	}
+	return nil
+}
+
+func (s *productsService) checkProductNameName(ctx context.Context, id, seriesID int32, name string) error {
+	total, err := s.q.CountProductNamesByName(ctx, &products.CountProductNamesByNameParams{
+		SeriesID:  seriesID,
+		Name:      name,
+		ExcludeID: id,
+	})
+	if err != nil {
+		return err
+	}
+	if total > 0 {
+		return fmt.Errorf("%w: name %q under this series", ErrCatalogNameTaken, name)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/products"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// ErrProductHierarchyMismatch is returned when the brand, type or series given for a product
+// do not belong to its product name.
+var ErrProductHierarchyMismatch = errors.New("product hierarchy does not match product name")
+
+// resolveProductHierarchy derives the series, type and brand of a product from its name. IDs
+// already set on the product must match the derived ones; zero IDs are filled in. New products
+// may only use an active name.
+func resolveProductHierarchy(ctx context.Context, q *products.Queries, nameID int32, brandID, typeID, seriesID *int32, requireActive bool) error {
+	chain, err := q.GetProductHierarchyByNameID(ctx, nameID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: product name %d not found", ErrCatalogParentInvalid, nameID)
+		}
+		return err
+	}
+	if requireActive && !chain.NameIsActive {
+		return fmt.Errorf("%w: product name %d is inactive", ErrCatalogParentInvalid, nameID)
+	}
+
+	levels := []struct {
+		label    string
+		given    *int32
+		expected int32
+	}{
+		{"brand", brandID, chain.BrandID},
+		{"type", typeID, chain.TypeID},
+		{"series", seriesID, chain.SeriesID},
+	}
+	for _, l := range levels {
+		if *l.given != 0 && *l.given != l.expected {
+			return fmt.Errorf("%w: %s %d given, product name %d belongs to %s %d",
+				ErrProductHierarchyMismatch, l.label, *l.given, nameID, l.label, l.expected)
+		}
+		*l.given = l.expected
+	}
+	return nil
+}
+
+// createReceivedProduct creates a product within the caller's transaction and books its film
+// as received at HQ in the inventory ledger. The hierarchy is derived from the active product
+// name. The film quantity is in rolls; the unit of measure defaults to rolls.
+func createReceivedProduct(ctx context.Context, tx pgx.Tx, arg *products.CreateProductParams, remarks string, userID *int32) (*products.Product, error) {
+	qtx := products.New(tx)
+	if err := resolveProductHierarchy(ctx, qtx, arg.NameID, &arg.BrandID, &arg.TypeID, &arg.SeriesID, true); err != nil {
+		return nil, err
+	}
+	unit, err := validateProductUnits(arg.UnitOfMeasure, arg.RollLengthMetres, arg.SheetsPerRoll)
+	if err != nil {
+		return nil, err
+	}
+	arg.UnitOfMeasure = unit
+	arg.FilmQuantity = roundQuantity(arg.FilmQuantity)
+	product, err := qtx.CreateProduct(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	_, err = postInventoryMovements(ctx, inventory.New(tx), &inventory.CreateInventoryMovementParams{
+		ProductID:       product.ID,
+		MovementType:    models.InventoryMovementReceipt,
+		Quantity:        product.FilmQuantity,
+		Remarks:         &remarks,
+		CreatedByUserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// RepairProductHierarchies reports the products whose stored brand, type or series disagree
+// with their product name and rewrites them from the name. With dryRun set the changes are
+// rolled back.
+func (s *productsService) RepairProductHierarchies(ctx context.Context, dryRun bool) ([]*products.ListInconsistentProductHierarchiesRow, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := products.New(tx)
+
+	rows, err := qtx.ListInconsistentProductHierarchies(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	for _, row := range rows {
+		err := qtx.UpdateProductHierarchy(ctx, &products.UpdateProductHierarchyParams{
+			ID:       row.ID,
+			BrandID:  row.ExpectedBrandID,
+			TypeID:   row.ExpectedTypeID,
+			SeriesID: row.ExpectedSeriesID,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		tx.Rollback(ctx)
+		return rows, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/products"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
 )
 
 type ProductsService interface {
@@ -12,10 +14,23 @@ type ProductsService interface {
 	GetProductByID(ctx context.Context, id int32) (*products.Product, error)
 	CreateProduct(ctx context.Context, arg *products.CreateProductParams) (*products.Product, error)
 	UpdateProduct(ctx context.Context, arg *products.UpdateProductParams) (*products.Product, error)
-	ListProductBrands(ctx context.Context) ([]*products.ProductBrand, error)
-	ListProductTypes(ctx context.Context) ([]*products.ProductType, error)
-	ListProductSeries(ctx context.Context) ([]*products.ProductSeries, error)
-	ListProductNames(ctx context.Context) ([]*products.ProductName, error)
+	RepairProductHierarchies(ctx context.Context, dryRun bool) ([]*products.ListInconsistentProductHierarchiesRow, error)
+	ListProductBrands(ctx context.Context, includeInactive bool) ([]*products.ProductBrand, error)
+	CreateProductBrand(ctx context.Context, arg *products.CreateProductBrandParams) (*products.ProductBrand, error)
+	UpdateProductBrand(ctx context.Context, arg *products.UpdateProductBrandParams) (*products.ProductBrand, error)
+	SetProductBrandActive(ctx context.Context, arg *products.SetProductBrandActiveParams) (*products.ProductBrand, error)
+	ListProductTypes(ctx context.Context, includeInactive bool) ([]*products.ProductType, error)
+	CreateProductType(ctx context.Context, arg *products.CreateProductTypeParams) (*products.ProductType, error)
+	UpdateProductType(ctx context.Context, arg *products.UpdateProductTypeParams) (*products.ProductType, error)
+	SetProductTypeActive(ctx context.Context, arg *products.SetProductTypeActiveParams) (*products.ProductType, error)
+	ListProductSeries(ctx context.Context, includeInactive bool) ([]*products.ProductSeries, error)
+	CreateProductSeries(ctx context.Context, arg *products.CreateProductSeriesParams) (*products.ProductSeries, error)
+	UpdateProductSeries(ctx context.Context, arg *products.UpdateProductSeriesParams) (*products.ProductSeries, error)
+	SetProductSeriesActive(ctx context.Context, arg *products.SetProductSeriesActiveParams) (*products.ProductSeries, error)
+	ListProductNames(ctx context.Context, includeInactive bool) ([]*products.ProductName, error)
+	CreateProductName(ctx context.Context, arg *products.CreateProductNameParams) (*products.ProductName, error)
+	UpdateProductName(ctx context.Context, arg *products.UpdateProductNameParams) (*products.ProductName, error)
+	SetProductNameActive(ctx context.Context, arg *products.SetProductNameActiveParams) (*products.ProductName, error)
 }
 
 type productsService struct {
@@ -40,32 +55,95 @@ func (s *productsService) GetProductByID(ctx context.Context, id int32) (*produc
 	return s.q.GetProductByID(ctx, id)
 }
 
-// CreateProduct creates a new product in the database.
+// CreateProduct creates a new product in the database and records its film as received at HQ
+// in the inventory ledger. The brand, type and series are derived from the active product
+// name and, when given, must match it.
 func (s *productsService) CreateProduct(ctx context.Context, arg *products.CreateProductParams) (*products.Product, error) {
-	return s.q.CreateProduct(ctx, arg)
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	product, err := createReceivedProduct(ctx, tx, arg, "Received at HQ", nil)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 
-// UpdateProduct updates an existing product in the database.
+// UpdateProduct updates an existing product in the database. The brand, type and series are
+// derived from the product name and, when given, must match it. Switching to another name
+// requires that name to be active. A changed film quantity is posted to the inventory ledger
+// as an HQ adjustment.
 func (s *productsService) UpdateProduct(ctx context.Context, arg *products.UpdateProductParams) (*products.Product, error) {
-	return s.q.UpdateProduct(ctx, arg)
+	unit, err := validateProductUnits(arg.UnitOfMeasure, arg.RollLengthMetres, arg.SheetsPerRoll)
+	if err != nil {
+		return nil, err
+	}
+	arg.UnitOfMeasure = unit
+	arg.FilmQuantity = roundQuantity(arg.FilmQuantity)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := products.New(tx)
+	itx := inventory.New(tx)
+
+	if _, err := itx.LockProductForInventory(ctx, arg.ID); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	current, err := qtx.GetProductByID(ctx, arg.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := resolveProductHierarchy(ctx, qtx, arg.NameID, &arg.BrandID, &arg.TypeID, &arg.SeriesID, current.NameID != arg.NameID); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	product, err := qtx.UpdateProduct(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	remarks := "Received film quantity corrected"
+	_, err = postInventoryMovements(ctx, itx, &inventory.CreateInventoryMovementParams{
+		ProductID:    product.ID,
+		MovementType: models.InventoryMovementAdjustment,
+		Quantity:     product.FilmQuantity - current.FilmQuantity,
+		Remarks:      &remarks,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return product, nil
 }
 
-// ListProductBrands retrieves a list of product brands from the database.
-func (s *productsService) ListProductBrands(ctx context.Context) ([]*products.ProductBrand, error) {
-	return s.q.ListProductBrands(ctx)
+// ListProductBrands retrieves the active product brands, or all of them when includeInactive is set, from the database.
+func (s *productsService) ListProductBrands(ctx context.Context, includeInactive bool) ([]*products.ProductBrand, error) {
+	return s.q.ListProductBrands(ctx, includeInactive)
 }
 
-// ListProductTypes retrieves a list of product types from the database.
-func (s *productsService) ListProductTypes(ctx context.Context) ([]*products.ProductType, error) {
-	return s.q.ListProductTypes(ctx)
+// ListProductTypes retrieves the active product types, or all of them when includeInactive is set, from the database.
+func (s *productsService) ListProductTypes(ctx context.Context, includeInactive bool) ([]*products.ProductType, error) {
+	return s.q.ListProductTypes(ctx, includeInactive)
 }
 
-// ListProductSeries retrieves a list of product series from the database.
-func (s *productsService) ListProductSeries(ctx context.Context) ([]*products.ProductSeries, error) {
-	return s.q.ListProductSeries(ctx)
+// ListProductSeries retrieves the active product series, or all of them when includeInactive is set, from the database.
+func (s *productsService) ListProductSeries(ctx context.Context, includeInactive bool) ([]*products.ProductSeries, error) {
+	return s.q.ListProductSeries(ctx, includeInactive)
 }
 
-// ListProductNames retrieves a list of product names from the database.
-func (s *productsService) ListProductNames(ctx context.Context) ([]*products.ProductName, error) {
-	return s.q.ListProductNames(ctx)
+// ListProductNames retrieves the active product names, or all of them when includeInactive is set, from the database.
+func (s *productsService) ListProductNames(ctx context.Context, includeInactive bool) ([]*products.ProductName, error) {
+	return s.q.ListProductNames(ctx, includeInactive)
 }
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/publiclookups"
+)
+
+type PublicLookupsService interface {
+	RecordPublicLookup(ctx context.Context, arg *publiclookups.CreatePublicLookupLogParams) error
+	ListPublicLookupLogs(ctx context.Context, since time.Time, ipAddress string) ([]*publiclookups.PublicLookupLog, error)
+	ListPublicLookupSummaries(ctx context.Context, since time.Time) ([]*publiclookups.ListPublicLookupSummariesRow, error)
+	PurgePublicLookupLogs(ctx context.Context) (int64, error)
+}
+
+type publicLookupsService struct {
+	db *pgxpool.Pool
+	q  *publiclookups.Queries
+}
+
+func NewPublicLookupsService(db *pgxpool.Pool) PublicLookupsService {
+	return &publicLookupsService{
+		db: db,
+		q:  publiclookups.New(db),
+	}
+}
+
+// RecordPublicLookup stores a public lookup in the database for abuse analysis.
+func (s *publicLookupsService) RecordPublicLookup(ctx context.Context, arg *publiclookups.CreatePublicLookupLogParams) error {
+	_, err := s.q.CreatePublicLookupLog(ctx, arg)
+	return err
+}
+
+// ListPublicLookupLogs retrieves the latest public lookups since the given time, optionally for
+// a single IP address, from the database.
+func (s *publicLookupsService) ListPublicLookupLogs(ctx context.Context, since time.Time, ipAddress string) ([]*publiclookups.PublicLookupLog, error) {
+	return s.q.ListPublicLookupLogs(ctx, &publiclookups.ListPublicLookupLogsParams{
+		Since:     since,
+		IpAddress: ipAddress,
+	})
+}
+
+// ListPublicLookupSummaries retrieves public lookup counts per IP address since the given time from the database.
+func (s *publicLookupsService) ListPublicLookupSummaries(ctx context.Context, since time.Time) ([]*publiclookups.ListPublicLookupSummariesRow, error) {
+	return s.q.ListPublicLookupSummaries(ctx, since)
+}
+
+// PurgePublicLookupLogs deletes public lookups older than PUBLIC_LOOKUP_LOG_RETENTION_DAYS
+// (default 90) from the database and returns the number of rows deleted.
+func (s *publicLookupsService) PurgePublicLookupLogs(ctx context.Context) (int64, error) {
+	days := 90
+	if v, err := strconv.Atoi(os.Getenv("PUBLIC_LOOKUP_LOG_RETENTION_DAYS")); err == nil && v > 0 {
+		days = v
+	}
+	return s.q.DeletePublicLookupLogsBefore(ctx, time.Now().AddDate(0, 0, -days))
+}
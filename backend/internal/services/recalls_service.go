@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/recalls"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+var (
+	// ErrInvalidRecall is returned when a recall names neither or both of a film serial number and a
+	// shipment, or has no reason.
+	ErrInvalidRecall = errors.New("invalid recall")
+	// ErrRecallTargetNotFound is returned when the recalled film serial number or shipment does not
+	// exist, or the shipment has no received film.
+	ErrRecallTargetNotFound = errors.New("recalled film not found")
+	// ErrRecallClosed is returned when a closed recall is changed.
+	ErrRecallClosed = errors.New("recall is closed")
+	// ErrRecallCustomersNotified is returned when the customers of a recall have already been notified.
+	ErrRecallCustomersNotified = errors.New("recall customers have already been notified")
+	// ErrProductRecalled is returned when film frozen by an open recall would be installed,
+	// used for a claim, or allocated, transferred or reordered to a shop.
+	ErrProductRecalled = errors.New("film is under recall")
+)
+
+// RecallImpact traces a recall to the allocations, warranty parts, shops and customers that
+// received the recalled film.
+type RecallImpact struct {
+	Recall        *recalls.Recall                       `json:"recall"`
+	Products      []*recalls.ListRecallProductsRow      `json:"products"`
+	Allocations   []*recalls.ListRecallAllocationsRow   `json:"allocations"`
+	WarrantyParts []*recalls.ListRecallWarrantyPartsRow `json:"warrantyParts"`
+	ShopCount     int                                   `json:"shopCount"`
+	WarrantyCount int                                   `json:"warrantyCount"`
+	CustomerCount int                                   `json:"customerCount"`
+	UnusedFilm    float64                               `json:"unusedFilm"`
+}
+
+type RecallsService interface {
+	ListRecalls(ctx context.Context) ([]*recalls.ListRecallsRow, error)
+	GetRecallByID(ctx context.Context, id int32) (*recalls.Recall, error)
+	GetRecallImpact(ctx context.Context, id int32) (*RecallImpact, error)
+
+	CreateRecall(ctx context.Context, userID int32, filmSerialNumber string, shipmentID *int32, reason string, freezeInstalls bool) (*recalls.Recall, error)
+	CloseRecall(ctx context.Context, userID, id int32) (*recalls.Recall, error)
+	NotifyRecallCustomers(ctx context.Context, id int32) (int, error)
+}
+
+type recallsService struct {
+	db *pgxpool.Pool
+	q  *recalls.Queries
+}
+
+func NewRecallsService(db *pgxpool.Pool) RecallsService {
+	return &recallsService{
+		db: db,
+		q:  recalls.New(db),
+	}
+}
+
+// ListRecalls retrieves all recalls with the number of rolls and warranties they cover, newest
+// first, from the database.
+func (s *recallsService) ListRecalls(ctx context.Context) ([]*recalls.ListRecallsRow, error) {
+	return s.q.ListRecalls(ctx)
+}
+
+// GetRecallByID retrieves a recall by its ID from the database.
+func (s *recallsService) GetRecallByID(ctx context.Context, id int32) (*recalls.Recall, error) {
+	return s.q.GetRecallByID(ctx, id)
+}
+
+// GetRecallImpact traces the recalled film through its allocations to the warranty parts, shops
+// and customers it went to in the database.
+func (s *recallsService) GetRecallImpact(ctx context.Context, id int32) (*RecallImpact, error) {
+	recall, err := s.q.GetRecallByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.q.ListRecallProducts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.q.ListRecallAllocations(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := s.q.ListRecallWarrantyParts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &RecallImpact{
+		Recall:        recall,
+		Products:      products,
+		Allocations:   allocations,
+		WarrantyParts: parts,
+	}
+	shops := make(map[int32]bool)
+	for _, allocation := range allocations {
+		shops[allocation.ShopID] = true
+		if allocation.RemainingQuantity > 0 {
+			impact.UnusedFilm += allocation.RemainingQuantity
+		}
+	}
+	warranties := make(map[int32]bool)
+	customers := make(map[string]bool)
+	for _, part := range parts {
+		shops[part.ShopID] = true
+		warranties[part.WarrantyID] = true
+		customers[recallCustomerKey(part)] = true
+	}
+	impact.UnusedFilm = roundQuantity(impact.UnusedFilm)
+	impact.ShopCount = len(shops)
+	impact.WarrantyCount = len(warranties)
+	impact.CustomerCount = len(customers)
+	return impact, nil
+}
+
+// recallCustomerKey identifies the customer of a warranty part, falling back to the contact on
+// the warranty for warranties not yet linked to a customer record.
+func recallCustomerKey(part *recalls.ListRecallWarrantyPartsRow) string {
+	if part.CustomerID != nil {
+		return fmt.Sprintf("id:%d", *part.CustomerID)
+	}
+	return "contact:" + strings.TrimSpace(part.ClientContact) + "|" + strings.ToLower(strings.TrimSpace(part.ClientEmail))
+}
+
+// CreateRecall opens a recall in the database for the roll with the given film serial number, or
+// for every roll received in the given shipment. The serial number may be a scanned label.
+func (s *recallsService) CreateRecall(ctx context.Context, userID int32, filmSerialNumber string, shipmentID *int32, reason string, freezeInstalls bool) (*recalls.Recall, error) {
+	filmSerialNumber = strings.TrimSpace(filmSerialNumber)
+	reason = strings.TrimSpace(reason)
+	if (filmSerialNumber == "") == (shipmentID == nil) {
+		return nil, fmt.Errorf("%w: give either a film serial number or a shipment", ErrInvalidRecall)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidRecall)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := recalls.New(tx)
+
+	arg := &recalls.CreateRecallParams{
+		Reason:          reason,
+		FreezeInstalls:  freezeInstalls,
+		CreatedByUserID: &userID,
+	}
+	var productIDs []int32
+	if shipmentID != nil {
+		shipmentNumber, err := qtx.GetShipmentNumberByID(ctx, *shipmentID)
+		if err != nil {
+			tx.Rollback(ctx)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%w: shipment %d", ErrRecallTargetNotFound, *shipmentID)
+			}
+			return nil, err
+		}
+		productIDs, err = qtx.ListProductIDsByShipmentID(ctx, shipmentID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		if len(productIDs) == 0 {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: shipment %s has no received film", ErrRecallTargetNotFound, shipmentNumber)
+		}
+		arg.Scope = models.RecallScopeShipment
+		arg.ShipmentID = shipmentID
+	} else {
+		label, err := utils.ParseFilmLabel(filmSerialNumber)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecall, err)
+		}
+		productID, err := qtx.GetProductIDByFilmSerialNumber(ctx, label.FilmSerialNumber())
+		if err != nil {
+			tx.Rollback(ctx)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%w: film serial number %s", ErrRecallTargetNotFound, label.FilmSerialNumber())
+			}
+			return nil, err
+		}
+		productIDs = []int32{productID}
+		arg.Scope = models.RecallScopeSerial
+		arg.ProductID = &productID
+	}
+
+	arg.RecallNo = nextRecallNo(ctx, qtx, time.Now())
+	recall, err := qtx.CreateRecall(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	for _, productID := range productIDs {
+		err := qtx.AddRecallProduct(ctx, &recalls.AddRecallProductParams{
+			RecallID:  recall.ID,
+			ProductID: productID,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
+
+// nextRecallNo returns the next recall number for the given date.
+// R + Recall Date - Sequence
+// E.g. R261019-01
+func nextRecallNo(ctx context.Context, q *recalls.Queries, date time.Time) string {
+	prefix := "R" + date.Format("060102") + "-"
+	latestRecallNo, err := q.GetLatestRecallNoByPrefix(ctx, prefix+"%")
+	if err != nil {
+		return prefix + "01"
+	}
+	var nextSequence int
+	fmt.Sscanf(latestRecallNo[len(prefix):], "%02d", &nextSequence)
+	nextSequence++
+	return fmt.Sprintf("%s%02d", prefix, nextSequence)
+}
+
+// CloseRecall closes an open recall in the database, which lifts its install freeze.
+func (s *recallsService) CloseRecall(ctx context.Context, userID, id int32) (*recalls.Recall, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := recalls.New(tx)
+
+	recall, err := qtx.GetRecallByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if recall.Status == models.RecallStatusClosed {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %s", ErrRecallClosed, recall.RecallNo)
+	}
+	recall, err = qtx.CloseRecall(ctx, &recalls.CloseRecallParams{
+		ID:             id,
+		ClosedByUserID: &userID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
+
+// NotifyRecallCustomers queues a recall notice for the customer of every active warranty fitted
+// with the recalled film and records the recall as notified in the database. It returns the
+// number of warranties notified. Customers are notified once per recall.
+func (s *recallsService) NotifyRecallCustomers(ctx context.Context, id int32) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	qtx := recalls.New(tx)
+
+	recall, err := qtx.GetRecallByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	if recall.Status == models.RecallStatusClosed {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("%w: %s", ErrRecallClosed, recall.RecallNo)
+	}
+	if recall.CustomersNotifiedAt != nil {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("%w: %s", ErrRecallCustomersNotified, recall.RecallNo)
+	}
+	parts, err := qtx.ListRecallWarrantyParts(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	notified := make(map[int32]bool)
+	for _, part := range parts {
+		if !part.IsActive || notified[part.WarrantyID] {
+			continue
+		}
+		data := &notifier.TemplateData{
+			CustomerName: part.ClientName,
+			WarrantyNo:   part.WarrantyNo,
+			CarBrand:     part.CarBrand,
+			CarModel:     part.CarModel,
+			CarPlateNo:   part.CarPlateNo,
+			ShopName:     part.ShopName,
+			RecallNo:     recall.RecallNo,
+		}
+		to := customerContact{Email: part.ClientEmail, Contact: part.ClientContact}
+		if err := enqueueCustomerNotifications(ctx, tx, notifier.EventProductRecall, to, data, &part.WarrantyID, nil); err != nil {
+			tx.Rollback(ctx)
+			return 0, err
+		}
+		notified[part.WarrantyID] = true
+	}
+	if _, err := qtx.SetRecallCustomersNotified(ctx, id); err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return len(notified), nil
+}
+
+// recallFreeze turns the lookup of the open recall freezing installs from some film into
+// ErrProductRecalled, or nil when no recall freezes it.
+func recallFreeze(recallNo string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: the film is under recall %s", ErrProductRecalled, recallNo)
+}
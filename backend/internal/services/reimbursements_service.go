@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reimbursements"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+var (
+	// ErrReimbursementStatementEmpty is returned when the shop has no unbilled resolutions in the period.
+	ErrReimbursementStatementEmpty = errors.New("no unbilled claim resolutions in the period")
+	// ErrReimbursementStatementReviewed is returned when the statement has already been approved or rejected.
+	ErrReimbursementStatementReviewed = errors.New("reimbursement statement has already been reviewed")
+)
+
+type ReimbursementsService interface {
+	ListReimbursementStatements(ctx context.Context) ([]*reimbursements.ListReimbursementStatementsRow, error)
+	ListReimbursementStatementsByShopID(ctx context.Context, shopID int32) ([]*reimbursements.ListReimbursementStatementsByShopIDRow, error)
+	GetReimbursementStatementByID(ctx context.Context, id int32) (*reimbursements.GetReimbursementStatementByIDRow, error)
+	ListReimbursementStatementLines(ctx context.Context, id int32) ([]*reimbursements.ListReimbursementStatementLinesRow, error)
+
+	CreateReimbursementStatement(ctx context.Context, userID int32, arg *reimbursements.CreateReimbursementStatementParams) (*reimbursements.ShopReimbursementStatement, error)
+	ReviewReimbursementStatement(ctx context.Context, arg *reimbursements.ReviewReimbursementStatementParams) (*reimbursements.ShopReimbursementStatement, error)
+}
+
+type reimbursementsService struct {
+	db *pgxpool.Pool
+	q  *reimbursements.Queries
+}
+
+func NewReimbursementsService(db *pgxpool.Pool) ReimbursementsService {
+	return &reimbursementsService{
+		db: db,
+		q:  reimbursements.New(db),
+	}
+}
+
+// ListReimbursementStatements retrieves the reimbursement statements of all shops from the database.
+func (s *reimbursementsService) ListReimbursementStatements(ctx context.Context) ([]*reimbursements.ListReimbursementStatementsRow, error) {
+	return s.q.ListReimbursementStatements(ctx)
+}
+
+// ListReimbursementStatementsByShopID retrieves the reimbursement statements of a shop from the database.
+func (s *reimbursementsService) ListReimbursementStatementsByShopID(ctx context.Context, shopID int32) ([]*reimbursements.ListReimbursementStatementsByShopIDRow, error) {
+	return s.q.ListReimbursementStatementsByShopID(ctx, shopID)
+}
+
+// GetReimbursementStatementByID retrieves a reimbursement statement by its ID from the database.
+func (s *reimbursementsService) GetReimbursementStatementByID(ctx context.Context, id int32) (*reimbursements.GetReimbursementStatementByIDRow, error) {
+	return s.q.GetReimbursementStatementByID(ctx, id)
+}
+
+// ListReimbursementStatementLines retrieves the claim resolutions billed on a statement from the database.
+func (s *reimbursementsService) ListReimbursementStatementLines(ctx context.Context, id int32) ([]*reimbursements.ListReimbursementStatementLinesRow, error) {
+	return s.q.ListReimbursementStatementLines(ctx, &id)
+}
+
+// CreateReimbursementStatement bills the shop's unbilled resolutions of claims resolved or closed
+// within the period on a new statement in the database. ErrReimbursementStatementEmpty is
+// returned when there is nothing to bill.
+func (s *reimbursementsService) CreateReimbursementStatement(ctx context.Context, userID int32, arg *reimbursements.CreateReimbursementStatementParams) (*reimbursements.ShopReimbursementStatement, error) {
+	if arg.PeriodEnd.Before(arg.PeriodStart) {
+		return nil, fmt.Errorf("period end must not be before period start")
+	}
+	arg.CreatedByUserID = &userID
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := reimbursements.New(tx)
+
+	statement, err := qtx.CreateReimbursementStatement(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := qtx.AssignClaimResolutionsToStatement(ctx, &reimbursements.AssignClaimResolutionsToStatementParams{
+		StatementID: statement.ID,
+		ShopID:      arg.ShopID,
+		PeriodStart: arg.PeriodStart,
+		PeriodEnd:   arg.PeriodEnd,
+	}); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	statement, err = qtx.UpdateReimbursementStatementTotals(ctx, statement.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if statement.ResolutionCount == 0 {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %s to %s", ErrReimbursementStatementEmpty, arg.PeriodStart.Format(time.DateOnly), arg.PeriodEnd.Format(time.DateOnly))
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// ReviewReimbursementStatement approves or rejects a pending statement in the database. The
+// resolutions of a rejected statement are released so that they can be billed again.
+func (s *reimbursementsService) ReviewReimbursementStatement(ctx context.Context, arg *reimbursements.ReviewReimbursementStatementParams) (*reimbursements.ShopReimbursementStatement, error) {
+	if arg.Status != models.ReimbursementStatusApproved && arg.Status != models.ReimbursementStatusRejected {
+		return nil, fmt.Errorf("invalid review status: %s", arg.Status)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := reimbursements.New(tx)
+
+	statement, err := qtx.ReviewReimbursementStatement(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, err := s.q.GetReimbursementStatementByID(ctx, arg.ID); err != nil {
+				return nil, err
+			}
+			return nil, ErrReimbursementStatementReviewed
+		}
+		return nil, err
+	}
+	if statement.Status == models.ReimbursementStatusRejected {
+		if err := qtx.ReleaseClaimResolutionsFromStatement(ctx, &statement.ID); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reminders"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+)
+
+// ReminderRunResult summarises a reminder rule run.
+type ReminderRunResult struct {
+	RuleID   int32  `json:"ruleId"`
+	RuleName string `json:"ruleName"`
+	Queued   int    `json:"queued"`
+	Error    string `json:"error,omitempty"`
+}
+
+type RemindersService interface {
+	ListReminderRules(ctx context.Context) ([]*reminders.ListReminderRulesRow, error)
+	GetReminderRuleByID(ctx context.Context, id int32) (*reminders.ReminderRule, error)
+	CreateReminderRule(ctx context.Context, arg *reminders.CreateReminderRuleParams) (*reminders.ReminderRule, error)
+	UpdateReminderRule(ctx context.Context, arg *reminders.UpdateReminderRuleParams) (*reminders.ReminderRule, error)
+	ListReminderDeliveriesByRuleID(ctx context.Context, ruleID int32) ([]*reminders.ReminderDelivery, error)
+
+	ListRenewalLeads(ctx context.Context) ([]*reminders.ListRenewalLeadsRow, error)
+	ListRenewalLeadsByShopID(ctx context.Context, shopID int32) ([]*reminders.ListRenewalLeadsByShopIDRow, error)
+
+	RunReminderRules(ctx context.Context) ([]*ReminderRunResult, error)
+}
+
+type remindersService struct {
+	db *pgxpool.Pool
+	q  *reminders.Queries
+}
+
+func NewRemindersService(db *pgxpool.Pool) RemindersService {
+	return &remindersService{
+		db: db,
+		q:  reminders.New(db),
+	}
+}
+
+// ListReminderRules retrieves all reminder rules along with their product type from the database.
+func (s *remindersService) ListReminderRules(ctx context.Context) ([]*reminders.ListReminderRulesRow, error) {
+	return s.q.ListReminderRules(ctx)
+}
+
+// GetReminderRuleByID retrieves a reminder rule by its ID from the database.
+func (s *remindersService) GetReminderRuleByID(ctx context.Context, id int32) (*reminders.ReminderRule, error) {
+	return s.q.GetReminderRuleByID(ctx, id)
+}
+
+// CreateReminderRule validates and creates a new reminder rule in the database.
+func (s *remindersService) CreateReminderRule(ctx context.Context, arg *reminders.CreateReminderRuleParams) (*reminders.ReminderRule, error) {
+	if err := validateReminderRule(arg.ReminderType, arg.OffsetDays, arg.OffsetMonths, arg.WindowDays); err != nil {
+		return nil, err
+	}
+	return s.q.CreateReminderRule(ctx, arg)
+}
+
+// UpdateReminderRule validates and updates an existing reminder rule in the database.
+func (s *remindersService) UpdateReminderRule(ctx context.Context, arg *reminders.UpdateReminderRuleParams) (*reminders.ReminderRule, error) {
+	if err := validateReminderRule(arg.ReminderType, arg.OffsetDays, arg.OffsetMonths, arg.WindowDays); err != nil {
+		return nil, err
+	}
+	return s.q.UpdateReminderRule(ctx, arg)
+}
+
+// ListReminderDeliveriesByRuleID retrieves the reminders already queued for a rule from the database.
+func (s *remindersService) ListReminderDeliveriesByRuleID(ctx context.Context, ruleID int32) ([]*reminders.ReminderDelivery, error) {
+	return s.q.ListReminderDeliveriesByRuleID(ctx, ruleID)
+}
+
+// ListRenewalLeads retrieves warranties that received an expiry reminder from the database.
+func (s *remindersService) ListRenewalLeads(ctx context.Context) ([]*reminders.ListRenewalLeadsRow, error) {
+	return s.q.ListRenewalLeads(ctx)
+}
+
+// ListRenewalLeadsByShopID retrieves a shop's warranties that received an expiry reminder from the database.
+func (s *remindersService) ListRenewalLeadsByShopID(ctx context.Context, shopID int32) ([]*reminders.ListRenewalLeadsByShopIDRow, error) {
+	return s.q.ListRenewalLeadsByShopID(ctx, shopID)
+}
+
+// RunReminderRules runs every active reminder rule and queues customer notifications
+// for warranties whose reminder is due. Each rule runs in its own transaction so that
+// a failing rule does not block the others.
+func (s *remindersService) RunReminderRules(ctx context.Context) ([]*ReminderRunResult, error) {
+	rules, err := s.q.ListActiveReminderRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ReminderRunResult, 0, len(rules))
+	for _, rule := range rules {
+		result := &ReminderRunResult{RuleID: rule.ID, RuleName: rule.Name}
+		queued, err := s.runReminderRule(ctx, rule)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		result.Queued = queued
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// runReminderRule queues the due reminders of a single rule and returns how many were queued.
+func (s *remindersService) runReminderRule(ctx context.Context, rule *reminders.ReminderRule) (int, error) {
+	event := notifier.EventWarrantyExpiryReminder
+	if rule.ReminderType == models.ReminderTypeInspection {
+		event = notifier.EventInspectionReminder
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	qtx := reminders.New(tx)
+
+	candidates, err := qtx.ListReminderCandidatesByRuleID(ctx, rule.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+
+	queued := 0
+	for _, c := range candidates {
+		_, err := qtx.CreateReminderDelivery(ctx, &reminders.CreateReminderDeliveryParams{
+			ReminderRuleID: rule.ID,
+			WarrantyID:     c.ID,
+			DueDate:        c.DueDate,
+			ExpiryDate:     c.ExpiryDate,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			// already queued by a concurrent run
+			continue
+		}
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, err
+		}
+
+		data := &notifier.TemplateData{
+			CustomerName: c.ClientName,
+			WarrantyNo:   c.WarrantyNo,
+			CarBrand:     c.CarBrand,
+			CarModel:     c.CarModel,
+			CarPlateNo:   c.CarPlateNo,
+			ExpiryDate:   c.ExpiryDate.Format("2006-01-02"),
+		}
+		to := customerContact{Email: c.ClientEmail, Contact: c.ClientContact}
+		if err := enqueueCustomerNotifications(ctx, tx, event, to, data, &c.ID, nil); err != nil {
+			tx.Rollback(ctx)
+			return 0, err
+		}
+		queued++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return queued, nil
+}
+
+// validateReminderRule checks the reminder type and offsets of a reminder rule.
+func validateReminderRule(reminderType string, offsetDays, offsetMonths, windowDays int32) error {
+	switch reminderType {
+	case models.ReminderTypeWarrantyExpiry, models.ReminderTypeInspection:
+	default:
+		return fmt.Errorf("invalid reminder type: %s", reminderType)
+	}
+	if offsetDays < 0 || offsetMonths < 0 || windowDays < 0 {
+		return fmt.Errorf("offsets and window must not be negative")
+	}
+	if reminderType == models.ReminderTypeInspection && offsetMonths == 0 && offsetDays == 0 {
+		return fmt.Errorf("inspection reminders require an offset after installation")
+	}
+	return nil
+}
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/productallocations"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/reorders"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+)
+
+var (
+	// ErrInvalidStockThreshold is returned when a threshold has a negative quantity or an unknown
+	// product name.
+	ErrInvalidStockThreshold = errors.New("invalid stock threshold")
+	// ErrInvalidReorderRequest is returned when a reorder request has a non-positive quantity or an
+	// unknown or inactive product name.
+	ErrInvalidReorderRequest = errors.New("invalid reorder request")
+	// ErrInvalidReorderApproval is returned when the roll chosen to fill a reorder request does not
+	// exist, is inactive or is of another product name.
+	ErrInvalidReorderApproval = errors.New("invalid reorder approval")
+	// ErrReorderRequestReviewed is returned when a reorder request is no longer pending.
+	ErrReorderRequestReviewed = errors.New("reorder request is no longer pending")
+)
+
+// LowStockCheckResult summarises a low-stock check.
+type LowStockCheckResult struct {
+	// Alerted is the number of thresholds that newly fell to or below their minimum.
+	Alerted int `json:"alerted"`
+	// Resolved is the number of open alerts closed because the balance recovered or the
+	// threshold was switched off.
+	Resolved int `json:"resolved"`
+	// Errors describes each threshold whose alert could not be opened or resolved; the check
+	// carries on with the other thresholds.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ReorderApproval is how HQ fills a reorder request: the roll allocated to the shop and how much
+// of it.
+type ReorderApproval struct {
+	ProductID      int32
+	FilmQuantity   float64
+	AllocationDate time.Time
+	Remarks        *string
+}
+
+type ReordersService interface {
+	ListStockThresholds(ctx context.Context, shopID *int32) ([]*reorders.ListStockThresholdsRow, error)
+	GetStockThresholdByID(ctx context.Context, id int32) (*reorders.ShopStockThreshold, error)
+	UpsertStockThreshold(ctx context.Context, arg *reorders.UpsertStockThresholdParams) (*reorders.ShopStockThreshold, error)
+	DeleteStockThreshold(ctx context.Context, id int32) error
+
+	ListLowStockAlerts(ctx context.Context, arg *reorders.ListLowStockAlertsParams) ([]*reorders.ListLowStockAlertsRow, error)
+	CheckLowStock(ctx context.Context) (*LowStockCheckResult, error)
+
+	ListReorderRequests(ctx context.Context, arg *reorders.ListReorderRequestsParams) ([]*reorders.ListReorderRequestsRow, error)
+	GetReorderRequestByID(ctx context.Context, id int32) (*reorders.ReorderRequest, error)
+	CreateReorderRequest(ctx context.Context, userID int32, arg *reorders.CreateReorderRequestParams) (*reorders.ReorderRequest, error)
+	CancelReorderRequest(ctx context.Context, userID, id int32) (*reorders.ReorderRequest, error)
+	RejectReorderRequest(ctx context.Context, userID, id int32, remarks *string) (*reorders.ReorderRequest, error)
+	ApproveReorderRequest(ctx context.Context, userID, id int32, approval *ReorderApproval) (*reorders.ReorderRequest, error)
+}
+
+type reordersService struct {
+	db *pgxpool.Pool
+	q  *reorders.Queries
+}
+
+func NewReordersService(db *pgxpool.Pool) ReordersService {
+	return &reordersService{
+		db: db,
+		q:  reorders.New(db),
+	}
+}
+
+// ListStockThresholds retrieves the stock thresholds with each shop's current balance, optionally
+// of a single shop, from the database.
+func (s *reordersService) ListStockThresholds(ctx context.Context, shopID *int32) ([]*reorders.ListStockThresholdsRow, error) {
+	return s.q.ListStockThresholds(ctx, shopID)
+}
+
+// GetStockThresholdByID retrieves a stock threshold by its ID from the database.
+func (s *reordersService) GetStockThresholdByID(ctx context.Context, id int32) (*reorders.ShopStockThreshold, error) {
+	return s.q.GetStockThresholdByID(ctx, id)
+}
+
+// UpsertStockThreshold validates and saves the threshold of a shop and product name in the
+// database, replacing any threshold the shop already has for the product name.
+func (s *reordersService) UpsertStockThreshold(ctx context.Context, arg *reorders.UpsertStockThresholdParams) (*reorders.ShopStockThreshold, error) {
+	if arg.MinQuantity < 0 || arg.ReorderQuantity < 0 {
+		return nil, fmt.Errorf("%w: quantities cannot be negative", ErrInvalidStockThreshold)
+	}
+	if _, err := s.q.GetProductNameByID(ctx, arg.NameID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: product name %d not found", ErrInvalidStockThreshold, arg.NameID)
+		}
+		return nil, err
+	}
+	return s.q.UpsertStockThreshold(ctx, arg)
+}
+
+// DeleteStockThreshold deletes a stock threshold and its alerts from the database.
+func (s *reordersService) DeleteStockThreshold(ctx context.Context, id int32) error {
+	return s.q.DeleteStockThreshold(ctx, id)
+}
+
+// ListLowStockAlerts retrieves low-stock alerts, newest first, from the database.
+func (s *reordersService) ListLowStockAlerts(ctx context.Context, arg *reorders.ListLowStockAlertsParams) ([]*reorders.ListLowStockAlertsRow, error) {
+	return s.q.ListLowStockAlerts(ctx, arg)
+}
+
+// CheckLowStock compares each shop's balance with its active thresholds. A threshold whose
+// balance is at or below its minimum opens an alert and emails the shop and the HQ staff in
+// LOW_STOCK_ALERT_EMAILS; the alert stays open, without further emails, until the balance
+// recovers or the threshold is switched off. Each alert is handled in its own transaction so
+// that a failing threshold does not block the others; its error is reported in the result.
+func (s *reordersService) CheckLowStock(ctx context.Context) (*LowStockCheckResult, error) {
+	result := &LowStockCheckResult{}
+	checks, err := s.q.ListStockThresholdChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hqEmails := staffEmailsFromEnv("LOW_STOCK_ALERT_EMAILS")
+
+	for _, c := range checks {
+		low := c.IsActive && c.Balance <= c.MinQuantity
+		switch {
+		case low && c.OpenAlertID == nil:
+			alerted, err := s.openLowStockAlert(ctx, c, hqEmails)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to alert low stock of %s at %s: %v", c.ProductName, c.ShopName, err))
+				continue
+			}
+			if alerted {
+				result.Alerted++
+			}
+		case !low && c.OpenAlertID != nil:
+			if err := s.q.ResolveLowStockAlert(ctx, *c.OpenAlertID); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to resolve low-stock alert %d: %v", *c.OpenAlertID, err))
+				continue
+			}
+			result.Resolved++
+		}
+	}
+	return result, nil
+}
+
+// openLowStockAlert records the alert and queues an email to the shop and to HQ. It reports
+// false when a concurrent check already opened the alert.
+func (s *reordersService) openLowStockAlert(ctx context.Context, c *reorders.ListStockThresholdChecksRow, hqEmails []string) (bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = reorders.New(tx).CreateLowStockAlert(ctx, &reorders.CreateLowStockAlertParams{
+		ThresholdID: c.ID,
+		Balance:     c.Balance,
+		MinQuantity: c.MinQuantity,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	recipients := shopStaffEmails(c.PicEmail, c.CompanyEmail)
+	for _, email := range hqEmails {
+		if !containsFold(recipients, email) {
+			recipients = append(recipients, email)
+		}
+	}
+	data := &notifier.TemplateData{
+		ShopName:     c.ShopName,
+		ProductName:  c.ProductName,
+		StockBalance: c.Balance,
+		StockMinimum: c.MinQuantity,
+	}
+	if err := enqueueStaffEmails(ctx, tx, notifier.EventLowStock, recipients, data, nil, nil); err != nil {
+		tx.Rollback(ctx)
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// shopStaffEmails returns the distinct, non-empty emails a shop is reached at.
+func shopStaffEmails(emails ...string) []string {
+	recipients := []string{}
+	for _, email := range emails {
+		if email = strings.TrimSpace(email); email != "" && !containsFold(recipients, email) {
+			recipients = append(recipients, email)
+		}
+	}
+	return recipients
+}
+
+// containsFold reports whether the list holds the email, ignoring case.
+func containsFold(emails []string, email string) bool {
+	for _, e := range emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListReorderRequests retrieves reorder requests, newest first, from the database.
+func (s *reordersService) ListReorderRequests(ctx context.Context, arg *reorders.ListReorderRequestsParams) ([]*reorders.ListReorderRequestsRow, error) {
+	return s.q.ListReorderRequests(ctx, arg)
+}
+
+// GetReorderRequestByID retrieves a reorder request by its ID from the database.
+func (s *reordersService) GetReorderRequestByID(ctx context.Context, id int32) (*reorders.ReorderRequest, error) {
+	return s.q.GetReorderRequestByID(ctx, id)
+}
+
+// CreateReorderRequest records a shop's request for more film of an active product name in the
+// database.
+func (s *reordersService) CreateReorderRequest(ctx context.Context, userID int32, arg *reorders.CreateReorderRequestParams) (*reorders.ReorderRequest, error) {
+	if arg.RequestedQuantity <= 0 {
+		return nil, fmt.Errorf("%w: requested quantity must be greater than zero", ErrInvalidReorderRequest)
+	}
+	name, err := s.q.GetProductNameByID(ctx, arg.NameID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: product name %d not found", ErrInvalidReorderRequest, arg.NameID)
+		}
+		return nil, err
+	}
+	if !name.IsActive {
+		return nil, fmt.Errorf("%w: product name %s is inactive", ErrInvalidReorderRequest, name.Name)
+	}
+	arg.RequestedByUserID = &userID
+	return s.q.CreateReorderRequest(ctx, arg)
+}
+
+// CancelReorderRequest withdraws a pending reorder request in the database.
+func (s *reordersService) CancelReorderRequest(ctx context.Context, userID, id int32) (*reorders.ReorderRequest, error) {
+	return s.closeReorderRequest(ctx, userID, id, models.ReorderRequestStatusCancelled, nil)
+}
+
+// RejectReorderRequest declines a pending reorder request in the database.
+func (s *reordersService) RejectReorderRequest(ctx context.Context, userID, id int32, remarks *string) (*reorders.ReorderRequest, error) {
+	return s.closeReorderRequest(ctx, userID, id, models.ReorderRequestStatusRejected, remarks)
+}
+
+// closeReorderRequest moves a pending reorder request to a final status without allocating film.
+func (s *reordersService) closeReorderRequest(ctx context.Context, userID, id int32, status string, remarks *string) (*reorders.ReorderRequest, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := reorders.New(tx)
+
+	request, err := qtx.GetReorderRequestByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if request.Status != models.ReorderRequestStatusPending {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: request %d is %s", ErrReorderRequestReviewed, id, request.Status)
+	}
+	request, err = qtx.ReviewReorderRequest(ctx, &reorders.ReviewReorderRequestParams{
+		ID:               id,
+		Status:           status,
+		ReviewedByUserID: &userID,
+		ReviewRemarks:    remarks,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ApproveReorderRequest fills a pending reorder request by allocating film of a roll of the
+// requested product name to the shop, and records the allocation on the request in the database.
+// The quantity defaults to the quantity requested; HQ must hold enough of the roll, and a roll
+// frozen by an open recall cannot be allocated.
+func (s *reordersService) ApproveReorderRequest(ctx context.Context, userID, id int32, approval *ReorderApproval) (*reorders.ReorderRequest, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := reorders.New(tx)
+
+	request, err := qtx.GetReorderRequestByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if request.Status != models.ReorderRequestStatusPending {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: request %d is %s", ErrReorderRequestReviewed, id, request.Status)
+	}
+	product, err := qtx.GetProductForReorder(ctx, approval.ProductID)
+	if err != nil {
+		tx.Rollback(ctx)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: product %d not found", ErrInvalidReorderApproval, approval.ProductID)
+		}
+		return nil, err
+	}
+	if !product.IsActive {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: product %d is inactive", ErrInvalidReorderApproval, product.ID)
+	}
+	if product.NameID != request.NameID {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: product %d is not of the requested product name", ErrInvalidReorderApproval, product.ID)
+	}
+	quantity := roundQuantity(approval.FilmQuantity)
+	if quantity == 0 {
+		quantity = request.RequestedQuantity
+	}
+	if quantity < 0 {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: film quantity must be greater than zero", ErrInvalidInventoryQuantity)
+	}
+
+	pqtx := productallocations.New(tx)
+	if err := recallFreeze(pqtx.GetInstallFreezeRecallNoByProductID(ctx, product.ID)); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	allocation, err := pqtx.CreateProductAllocation(ctx, &productallocations.CreateProductAllocationParams{
+		ProductID:      product.ID,
+		ShopID:         request.ShopID,
+		FilmQuantity:   quantity,
+		UnitOfMeasure:  models.UnitOfMeasureRoll,
+		UnitQuantity:   quantity,
+		AllocationDate: approval.AllocationDate,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	movements := allocationMovements(allocation.ID, allocation.ProductID, allocation.ShopID, allocation.FilmQuantity, fmt.Sprintf("Allocated for reorder request %d", request.ID))
+	for _, m := range movements {
+		m.CreatedByUserID = &userID
+	}
+	if _, err := postInventoryMovements(ctx, inventory.New(tx), movements...); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	request, err = qtx.ReviewReorderRequest(ctx, &reorders.ReviewReorderRequestParams{
+		ID:                  id,
+		Status:              models.ReorderRequestStatusApproved,
+		ProductAllocationID: &allocation.ID,
+		ReviewedByUserID:    &userID,
+		ReviewRemarks:       approval.Remarks,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
@@ -14,6 +14,23 @@ type ServiceInitializeParams struct {
 	ClaimsService             ClaimsService
 	UsersService              UsersService
 	UploadsService            UploadsService
+	NotificationsService      NotificationsService
+	RemindersService          RemindersService
+	CarsService               CarsService
+	VehiclesService           VehiclesService
+	CustomersService          CustomersService
+	PortalService             PortalService
+	PublicLookupsService      PublicLookupsService
+	ApprovalRulesService      ApprovalRulesService
+	ClaimSLAService           ClaimSLAService
+	ReimbursementsService     ReimbursementsService
+	CommentsService           CommentsService
+	AnalyticsService          AnalyticsService
+	InventoryService          InventoryService
+	ShipmentsService          ShipmentsService
+	RecallsService            RecallsService
+	ReordersService           ReordersService
+	StockTakesService         StockTakesService
 }
 
 func NewServiceInitializeParams(ctx context.Context, db *pgxpool.Pool) (*ServiceInitializeParams, error) {
@@ -22,6 +39,11 @@ func NewServiceInitializeParams(ctx context.Context, db *pgxpool.Pool) (*Service
 		return nil, err
 	}
 
+	notificationsService, err := NewNotificationsService(db)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ServiceInitializeParams{
 		ShopsService:              NewShopsService(db),
 		ProductsService:           NewProductsService(db),
@@ -30,5 +52,22 @@ func NewServiceInitializeParams(ctx context.Context, db *pgxpool.Pool) (*Service
 		ClaimsService:             NewClaimsService(db),
 		UsersService:              NewUsersService(db),
 		UploadsService:            uploadsService,
+		NotificationsService:      notificationsService,
+		RemindersService:          NewRemindersService(db),
+		CarsService:               NewCarsService(db),
+		VehiclesService:           NewVehiclesService(db),
+		CustomersService:          NewCustomersService(db),
+		PortalService:             NewPortalService(db),
+		PublicLookupsService:      NewPublicLookupsService(db),
+		ApprovalRulesService:      NewApprovalRulesService(db),
+		ClaimSLAService:           NewClaimSLAService(db),
+		ReimbursementsService:     NewReimbursementsService(db),
+		CommentsService:           NewCommentsService(db),
+		AnalyticsService:          NewAnalyticsService(db),
+		InventoryService:          NewInventoryService(db),
+		ShipmentsService:          NewShipmentsService(db),
+		RecallsService:            NewRecallsService(db),
+		ReordersService:           NewReordersService(db),
+		StockTakesService:         NewStockTakesService(db),
 	}, nil
 }
@@ -0,0 +1,531 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/products"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/shipments"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+var (
+	// ErrInvalidShipment is returned when a shipment has no number or no lines.
+	ErrInvalidShipment = errors.New("invalid shipment")
+	// ErrInvalidShipmentLine is returned when a shipment line is missing its serial number or has a
+	// non-positive quantity or warranty.
+	ErrInvalidShipmentLine = errors.New("invalid shipment line")
+	// ErrShipmentNumberTaken is returned when a shipment number is already in use.
+	ErrShipmentNumberTaken = errors.New("shipment number already exists")
+	// ErrShipmentSerialTaken is returned when a film serial number already belongs to a product or
+	// to a line of another shipment.
+	ErrShipmentSerialTaken = errors.New("film serial number already exists")
+	// ErrShipmentReceived is returned when a shipment has already been received.
+	ErrShipmentReceived = errors.New("shipment has already been received")
+	// ErrInvalidShipmentImport is returned when a packing list cannot be read as CSV or lacks a
+	// required column.
+	ErrInvalidShipmentImport = errors.New("invalid shipment import file")
+)
+
+// shipmentImportColumns are the columns a supplier packing list must have. The supplier,
+// series, shipped_date and description columns are optional.
+var shipmentImportColumns = []string{"shipment_number", "film_serial_number", "product_name", "film_quantity", "warranty_in_months"}
+
+// ShipmentImportRow describes the outcome of one row of a packing list import.
+type ShipmentImportRow struct {
+	Row              int    `json:"row"`
+	ShipmentNumber   string `json:"shipmentNumber"`
+	FilmSerialNumber string `json:"filmSerialNumber"`
+	Status           string `json:"status"`
+	Message          string `json:"message,omitempty"`
+}
+
+// ShipmentImportReport summarises a packing list import. The import is applied only when it is
+// not a dry run and no row has an error.
+type ShipmentImportReport struct {
+	DryRun    bool                 `json:"dryRun"`
+	Applied   bool                 `json:"applied"`
+	New       int                  `json:"new"`
+	Updated   int                  `json:"updated"`
+	Unchanged int                  `json:"unchanged"`
+	Errors    int                  `json:"errors"`
+	Rows      []*ShipmentImportRow `json:"rows"`
+}
+
+type ShipmentsService interface {
+	ListShipments(ctx context.Context) ([]*shipments.ListShipmentsRow, error)
+	GetShipmentByID(ctx context.Context, id int32) (*shipments.Shipment, error)
+	ListShipmentLines(ctx context.Context, shipmentID int32) ([]*shipments.ListShipmentLinesRow, error)
+
+	CreateShipment(ctx context.Context, userID int32, arg *shipments.CreateShipmentParams, lines []*shipments.CreateShipmentLineParams) (*shipments.Shipment, error)
+	ReceiveShipment(ctx context.Context, userID, id int32, receivedDate time.Time) (*shipments.Shipment, error)
+	ImportShipments(ctx context.Context, userID int32, r io.Reader, dryRun bool) (*ShipmentImportReport, error)
+}
+
+type shipmentsService struct {
+	db *pgxpool.Pool
+	q  *shipments.Queries
+}
+
+func NewShipmentsService(db *pgxpool.Pool) ShipmentsService {
+	return &shipmentsService{
+		db: db,
+		q:  shipments.New(db),
+	}
+}
+
+// ListShipments retrieves all shipments with their line counts, newest first, from the database.
+func (s *shipmentsService) ListShipments(ctx context.Context) ([]*shipments.ListShipmentsRow, error) {
+	return s.q.ListShipments(ctx)
+}
+
+// GetShipmentByID retrieves a shipment by its ID from the database.
+func (s *shipmentsService) GetShipmentByID(ctx context.Context, id int32) (*shipments.Shipment, error) {
+	return s.q.GetShipmentByID(ctx, id)
+}
+
+// ListShipmentLines retrieves the lines of a shipment from the database.
+func (s *shipmentsService) ListShipmentLines(ctx context.Context, shipmentID int32) ([]*shipments.ListShipmentLinesRow, error) {
+	return s.q.ListShipmentLines(ctx, shipmentID)
+}
+
+// CreateShipment creates a pending shipment with its lines in the database. Each line must use
+// an active product name and a film serial number that is not yet in use.
+func (s *shipmentsService) CreateShipment(ctx context.Context, userID int32, arg *shipments.CreateShipmentParams, lines []*shipments.CreateShipmentLineParams) (*shipments.Shipment, error) {
+	arg.ShipmentNumber = strings.TrimSpace(arg.ShipmentNumber)
+	arg.SupplierName = strings.TrimSpace(arg.SupplierName)
+	if arg.ShipmentNumber == "" {
+		return nil, fmt.Errorf("%w: shipment number is required", ErrInvalidShipment)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: shipment must have at least one line", ErrInvalidShipment)
+	}
+	arg.CreatedByUserID = &userID
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := shipments.New(tx)
+	pqtx := products.New(tx)
+
+	_, err = qtx.GetShipmentByNumberForUpdate(ctx, arg.ShipmentNumber)
+	if err == nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %s", ErrShipmentNumberTaken, arg.ShipmentNumber)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	shipment, err := qtx.CreateShipment(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(lines))
+	for i, line := range lines {
+		line.ShipmentID = shipment.ID
+		line.FilmSerialNumber = strings.TrimSpace(line.FilmSerialNumber)
+		if seen[line.FilmSerialNumber] {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: line %d repeats film serial number %s", ErrInvalidShipmentLine, i+1, line.FilmSerialNumber)
+		}
+		seen[line.FilmSerialNumber] = true
+		if err := checkShipmentLine(ctx, qtx, line); err != nil {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		chain, err := pqtx.GetProductHierarchyByNameID(ctx, line.NameID)
+		if err != nil {
+			tx.Rollback(ctx)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("%w: line %d: product name %d not found", ErrCatalogParentInvalid, i+1, line.NameID)
+			}
+			return nil, err
+		}
+		if !chain.NameIsActive {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: line %d: product name %d is inactive", ErrCatalogParentInvalid, i+1, line.NameID)
+		}
+		if _, err := qtx.CreateShipmentLine(ctx, line); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// ReceiveShipment marks a pending shipment as received in the database. Every line becomes a
+// product, and its film is booked into HQ stock.
+func (s *shipmentsService) ReceiveShipment(ctx context.Context, userID, id int32, receivedDate time.Time) (*shipments.Shipment, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := shipments.New(tx)
+
+	shipment, err := qtx.GetShipmentByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if shipment.Status != models.ShipmentStatusPending {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %s", ErrShipmentReceived, shipment.ShipmentNumber)
+	}
+	lines, err := qtx.ListUnreceivedShipmentLines(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if len(lines) == 0 {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: shipment %s has no lines to receive", ErrInvalidShipment, shipment.ShipmentNumber)
+	}
+
+	remarks := fmt.Sprintf("Received on shipment %s", shipment.ShipmentNumber)
+	for _, line := range lines {
+		count, err := qtx.CountProductsBySerialNumber(ctx, line.FilmSerialNumber)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		if count > 0 {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: %s", ErrShipmentSerialTaken, line.FilmSerialNumber)
+		}
+		product, err := createReceivedProduct(ctx, tx, &products.CreateProductParams{
+			NameID:           line.NameID,
+			WarrantyInMonths: line.WarrantyInMonths,
+			FilmSerialNumber: line.FilmSerialNumber,
+			FilmQuantity:     line.FilmQuantity,
+			ShipmentNumber:   shipment.ShipmentNumber,
+			Description:      line.Description,
+		}, remarks, &userID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		err = qtx.SetProductShipment(ctx, &shipments.SetProductShipmentParams{ID: product.ID, ShipmentID: &shipment.ID})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		err = qtx.SetShipmentLineProduct(ctx, &shipments.SetShipmentLineProductParams{ID: line.ID, ProductID: &product.ID})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	shipment, err = qtx.MarkShipmentReceived(ctx, &shipments.MarkShipmentReceivedParams{
+		ID:               id,
+		ReceivedDate:     &receivedDate,
+		ReceivedByUserID: &userID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// ImportShipments loads a supplier packing list in CSV form into the database. Rows are matched
+// to existing shipments by shipment number and to existing lines by film serial number, so the
+// same file can be imported again without creating duplicates. Lines of received shipments can
+// no longer change. Nothing is written when dryRun is set or when any row has an error; the
+// report lists the outcome of each row either way.
+func (s *shipmentsService) ImportShipments(ctx context.Context, userID int32, r io.Reader, dryRun bool) (*ShipmentImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: file is empty", ErrInvalidShipmentImport)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidShipmentImport, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.TrimPrefix(h, "\ufeff")
+		columns[strings.ReplaceAll(strings.ToLower(strings.TrimSpace(h)), " ", "_")] = i
+	}
+	for _, c := range shipmentImportColumns {
+		if _, ok := columns[c]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidShipmentImport, c)
+		}
+	}
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidShipmentImport, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file has no rows", ErrInvalidShipmentImport)
+	}
+
+	activeNames, err := s.q.ListActiveProductNamesWithSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string][]*shipments.ListActiveProductNamesWithSeriesRow, len(activeNames))
+	for _, n := range activeNames {
+		key := strings.ToLower(strings.TrimSpace(n.Name))
+		names[key] = append(names[key], n)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := shipments.New(tx)
+
+	report := &ShipmentImportReport{DryRun: dryRun, Rows: make([]*ShipmentImportRow, 0, len(records))}
+	headers := make(map[string]*shipments.Shipment)
+	seen := make(map[string]int, len(records))
+	for i, record := range records {
+		field := func(name string) string {
+			idx, ok := columns[name]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		row := &ShipmentImportRow{
+			Row:              i + 2,
+			ShipmentNumber:   field("shipment_number"),
+			FilmSerialNumber: field("film_serial_number"),
+		}
+		report.Rows = append(report.Rows, row)
+
+		if first, ok := seen[row.FilmSerialNumber]; ok && row.FilmSerialNumber != "" {
+			row.Status, row.Message = models.ShipmentImportStatusError, fmt.Sprintf("film serial number repeats row %d", first)
+		} else {
+			seen[row.FilmSerialNumber] = row.Row
+			row.Status, row.Message, err = importShipmentRow(ctx, qtx, userID, row, field, names, headers)
+			if err != nil {
+				tx.Rollback(ctx)
+				return nil, err
+			}
+		}
+
+		switch row.Status {
+		case models.ShipmentImportStatusNew:
+			report.New++
+		case models.ShipmentImportStatusUpdated:
+			report.Updated++
+		case models.ShipmentImportStatusUnchanged:
+			report.Unchanged++
+		default:
+			report.Errors++
+		}
+	}
+
+	if dryRun || report.Errors > 0 {
+		tx.Rollback(ctx)
+		return report, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	report.Applied = true
+	return report, nil
+}
+
+// importShipmentRow upserts the shipment and line of one packing list row within the import
+// transaction. It returns the row status, and a message when the row is rejected; the error is
+// reserved for database failures.
+func importShipmentRow(ctx context.Context, qtx *shipments.Queries, userID int32, row *ShipmentImportRow, field func(string) string, names map[string][]*shipments.ListActiveProductNamesWithSeriesRow, headers map[string]*shipments.Shipment) (string, string, error) {
+	rejected := func(format string, args ...any) (string, string, error) {
+		return models.ShipmentImportStatusError, fmt.Sprintf(format, args...), nil
+	}
+	if row.ShipmentNumber == "" {
+		return rejected("shipment_number is required")
+	}
+	if row.FilmSerialNumber == "" {
+		return rejected("film_serial_number is required")
+	}
+	quantity, err := strconv.ParseFloat(field("film_quantity"), 64)
+	if quantity = roundQuantity(quantity); err != nil || math.IsNaN(quantity) || math.IsInf(quantity, 0) || quantity <= 0 {
+		return rejected("film_quantity must be a number of rolls greater than zero")
+	}
+	if quantity >= maxQuantity {
+		return rejected("film_quantity must be less than %.0f rolls", float64(maxQuantity))
+	}
+	warranty, err := strconv.ParseInt(field("warranty_in_months"), 10, 32)
+	if err != nil || warranty <= 0 {
+		return rejected("warranty_in_months must be a whole number greater than zero")
+	}
+	var shippedDate *time.Time
+	if v := field("shipped_date"); v != "" {
+		t, err := utils.ConvertDateStringToStandardFormat(v)
+		if err != nil {
+			return rejected("shipped_date must be in YYYY-MM-DD format")
+		}
+		shippedDate = &t
+	}
+	nameID, msg := matchShipmentProductName(names, field("product_name"), field("series"))
+	if msg != "" {
+		return rejected("%s", msg)
+	}
+
+	shipment, ok := headers[row.ShipmentNumber]
+	if !ok {
+		shipment, err = qtx.GetShipmentByNumberForUpdate(ctx, row.ShipmentNumber)
+		if errors.Is(err, pgx.ErrNoRows) {
+			shipment, err = qtx.CreateShipment(ctx, &shipments.CreateShipmentParams{
+				ShipmentNumber:  row.ShipmentNumber,
+				SupplierName:    field("supplier"),
+				ShippedDate:     shippedDate,
+				CreatedByUserID: &userID,
+			})
+		}
+		if err != nil {
+			return "", "", err
+		}
+		headers[row.ShipmentNumber] = shipment
+	}
+	if shipment.Status == models.ShipmentStatusPending {
+		supplier := field("supplier")
+		supplierChanged := supplier != "" && supplier != shipment.SupplierName
+		dateChanged := shippedDate != nil && (shipment.ShippedDate == nil || !shipment.ShippedDate.Equal(*shippedDate))
+		if supplierChanged || dateChanged {
+			arg := &shipments.UpdateShipmentHeaderParams{ID: shipment.ID, SupplierName: shipment.SupplierName, ShippedDate: shipment.ShippedDate}
+			if supplierChanged {
+				arg.SupplierName = supplier
+			}
+			if dateChanged {
+				arg.ShippedDate = shippedDate
+			}
+			if shipment, err = qtx.UpdateShipmentHeader(ctx, arg); err != nil {
+				return "", "", err
+			}
+			headers[row.ShipmentNumber] = shipment
+		}
+	}
+
+	line := &shipments.CreateShipmentLineParams{
+		ShipmentID:       shipment.ID,
+		NameID:           nameID,
+		FilmSerialNumber: row.FilmSerialNumber,
+		FilmQuantity:     quantity,
+		WarrantyInMonths: int32(warranty),
+		Description:      field("description"),
+	}
+	existing, err := qtx.GetShipmentLineBySerial(ctx, line.FilmSerialNumber)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		if shipment.Status != models.ShipmentStatusPending {
+			return rejected("shipment %s has already been received", shipment.ShipmentNumber)
+		}
+		if err := checkShipmentLine(ctx, qtx, line); err != nil {
+			if errors.Is(err, ErrShipmentSerialTaken) || errors.Is(err, ErrInvalidShipmentLine) {
+				return rejected("%s", err.Error())
+			}
+			return "", "", err
+		}
+		if _, err := qtx.CreateShipmentLine(ctx, line); err != nil {
+			return "", "", err
+		}
+		return models.ShipmentImportStatusNew, "", nil
+	case err != nil:
+		return "", "", err
+	case existing.ShipmentID != shipment.ID:
+		return rejected("film serial number belongs to another shipment")
+	case existing.NameID == line.NameID && existing.FilmQuantity == line.FilmQuantity &&
+		existing.WarrantyInMonths == line.WarrantyInMonths && existing.Description == line.Description:
+		return models.ShipmentImportStatusUnchanged, "", nil
+	case shipment.Status != models.ShipmentStatusPending:
+		return rejected("shipment %s has already been received; its lines cannot change", shipment.ShipmentNumber)
+	}
+	_, err = qtx.UpdateShipmentLine(ctx, &shipments.UpdateShipmentLineParams{
+		ID:               existing.ID,
+		NameID:           line.NameID,
+		FilmQuantity:     line.FilmQuantity,
+		WarrantyInMonths: line.WarrantyInMonths,
+		Description:      line.Description,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return models.ShipmentImportStatusUpdated, "", nil
+}
+
+// checkShipmentLine validates a new shipment line and checks that its film serial number is not
+// used by a product or by a line of another shipment.
+func checkShipmentLine(ctx context.Context, q *shipments.Queries, line *shipments.CreateShipmentLineParams) error {
+	switch {
+	case line.FilmSerialNumber == "":
+		return fmt.Errorf("%w: film serial number is required", ErrInvalidShipmentLine)
+	case line.FilmQuantity <= 0:
+		return fmt.Errorf("%w: film quantity must be greater than zero", ErrInvalidShipmentLine)
+	case line.WarrantyInMonths <= 0:
+		return fmt.Errorf("%w: warranty in months must be greater than zero", ErrInvalidShipmentLine)
+	}
+
+	existing, err := q.GetShipmentLineBySerial(ctx, line.FilmSerialNumber)
+	if err == nil && existing.ShipmentID != line.ShipmentID {
+		return fmt.Errorf("%w: %s is on another shipment", ErrShipmentSerialTaken, line.FilmSerialNumber)
+	}
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	count, err := q.CountProductsBySerialNumber(ctx, line.FilmSerialNumber)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: %s is already a product", ErrShipmentSerialTaken, line.FilmSerialNumber)
+	}
+	return nil
+}
+
+// matchShipmentProductName finds the active product name of a packing list row, ignoring case.
+// The series is only needed when the same name is used in more than one series. It returns a
+// message instead of an ID when no single name matches.
+func matchShipmentProductName(names map[string][]*shipments.ListActiveProductNamesWithSeriesRow, name, series string) (int32, string) {
+	if name == "" {
+		return 0, "product_name is required"
+	}
+	candidates := names[strings.ToLower(name)]
+	if series != "" {
+		var inSeries []*shipments.ListActiveProductNamesWithSeriesRow
+		for _, c := range candidates {
+			if strings.EqualFold(strings.TrimSpace(c.SeriesName), series) {
+				inSeries = append(inSeries, c)
+			}
+		}
+		candidates = inSeries
+	}
+	switch len(candidates) {
+	case 0:
+		if series != "" {
+			return 0, fmt.Sprintf("no active product name %q in series %q", name, series)
+		}
+		return 0, fmt.Sprintf("no active product name %q", name)
+	case 1:
+		return candidates[0].ID, ""
+	default:
+		return 0, fmt.Sprintf("product name %q is used in %d series; fill in the series column", name, len(candidates))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/shops"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/users"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
 	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
 )
 
@@ -80,7 +81,7 @@ func (s *shopsService) CreateShop(ctx context.Context, arg *shops.CreateShopPara
 	userParams := &users.CreateUserParams{
 		ShopID:       &shop.ID,
 		Username:     username,
-		Role:         "shop_admin",
+		Role:         models.UserRoleShopAdmin,
 		PasswordHash: hashPassword,
 	}
 
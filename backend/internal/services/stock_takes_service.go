@@ -0,0 +1,539 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/stocktakes"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
+)
+
+var (
+	// ErrInvalidStockTake is returned when a stock take has a tolerance outside 0 to 100 percent.
+	ErrInvalidStockTake = errors.New("invalid stock take")
+	// ErrStockTakeExists is returned when the shop already has a stock take for the period or
+	// one still in progress.
+	ErrStockTakeExists = errors.New("stock take already exists")
+	// ErrStockTakeLocked is returned when a stock take is not in the status the action needs.
+	ErrStockTakeLocked = errors.New("stock take cannot be changed")
+	// ErrStockTakeIncomplete is returned when a stock take is submitted with lines still to count
+	// or posted with variances still to review.
+	ErrStockTakeIncomplete = errors.New("stock take is incomplete")
+	// ErrInvalidStockTakeCount is returned when a count is negative or does not match exactly one
+	// line of the stock take.
+	ErrInvalidStockTakeCount = errors.New("invalid stock take count")
+	// ErrStockTakeInProgress is returned when an allocation being counted by an open or submitted
+	// stock take would be changed.
+	ErrStockTakeInProgress = errors.New("stock take in progress")
+)
+
+// StockTakeCount is a physical count of the film left on a line of a stock take. The line is
+// chosen by its allocation or, when the shop holds a single allocation of the roll, by the film
+// serial number or scanned label. The quantity is given in the unit of measure and converted to
+// rolls.
+type StockTakeCount struct {
+	ProductAllocationID *int32
+	FilmSerialNumber    string
+	UnitOfMeasure       string
+	Quantity            float64
+	Remarks             *string
+}
+
+// StockTakeReport is a stock take with its lines and their variances.
+type StockTakeReport struct {
+	StockTake *stocktakes.StockTake               `json:"stockTake"`
+	Lines     []*stocktakes.ListStockTakeLinesRow `json:"lines"`
+}
+
+type StockTakesService interface {
+	ListStockTakes(ctx context.Context, arg *stocktakes.ListStockTakesParams) ([]*stocktakes.ListStockTakesRow, error)
+	GetStockTakeByID(ctx context.Context, id int32) (*stocktakes.StockTake, error)
+	GetStockTakeReport(ctx context.Context, id int32) (*StockTakeReport, error)
+
+	OpenStockTake(ctx context.Context, userID int32, arg *stocktakes.CreateStockTakeParams) (*stocktakes.StockTake, error)
+	RecordStockTakeCounts(ctx context.Context, userID, id int32, counts []*StockTakeCount) ([]*stocktakes.StockTakeLine, error)
+	SubmitStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error)
+	ReviewStockTakeLine(ctx context.Context, userID, id, lineID int32, approve bool, remarks *string) (*stocktakes.StockTakeLine, error)
+	PostStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error)
+	CancelStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error)
+	DiscardStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error)
+}
+
+type stockTakesService struct {
+	db *pgxpool.Pool
+	q  *stocktakes.Queries
+}
+
+func NewStockTakesService(db *pgxpool.Pool) StockTakesService {
+	return &stockTakesService{
+		db: db,
+		q:  stocktakes.New(db),
+	}
+}
+
+// ListStockTakes retrieves stock takes, newest period first, from the database.
+func (s *stockTakesService) ListStockTakes(ctx context.Context, arg *stocktakes.ListStockTakesParams) ([]*stocktakes.ListStockTakesRow, error) {
+	return s.q.ListStockTakes(ctx, arg)
+}
+
+// GetStockTakeByID retrieves a stock take by its ID from the database.
+func (s *stockTakesService) GetStockTakeByID(ctx context.Context, id int32) (*stocktakes.StockTake, error) {
+	return s.q.GetStockTakeByID(ctx, id)
+}
+
+// GetStockTakeReport retrieves a stock take with its lines, counts and variances from the
+// database.
+func (s *stockTakesService) GetStockTakeReport(ctx context.Context, id int32) (*StockTakeReport, error) {
+	stockTake, err := s.q.GetStockTakeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := s.q.ListStockTakeLines(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &StockTakeReport{StockTake: stockTake, Lines: lines}, nil
+}
+
+// OpenStockTake starts the shop's stock take of a month in the database. Every allocation of
+// the shop with film left gets a line holding its remaining film as the system quantity, and
+// stays locked until the stock take is posted or cancelled. A shop has one stock take per month
+// and only one in progress at a time.
+func (s *stockTakesService) OpenStockTake(ctx context.Context, userID int32, arg *stocktakes.CreateStockTakeParams) (*stocktakes.StockTake, error) {
+	if arg.TolerancePercent < 0 || arg.TolerancePercent > 100 {
+		return nil, fmt.Errorf("%w: tolerance must be between 0 and 100 percent", ErrInvalidStockTake)
+	}
+	arg.Period = time.Date(arg.Period.Year(), arg.Period.Month(), 1, 0, 0, 0, 0, time.UTC)
+	arg.OpenedByUserID = &userID
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := stocktakes.New(tx)
+
+	// Locking the allocations first also serialises concurrent openings for the shop.
+	balances, err := qtx.ListShopAllocationBalancesForUpdate(ctx, arg.ShopID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	conflict, err := qtx.GetConflictingStockTake(ctx, &stocktakes.GetConflictingStockTakeParams{
+		ShopID: arg.ShopID,
+		Period: arg.Period,
+	})
+	if err == nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: stock take %s of %s is %s", ErrStockTakeExists, conflict.StockTakeNo, conflict.Period.Format("2006-01"), conflict.Status)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	arg.StockTakeNo = nextStockTakeNo(ctx, qtx, arg.Period)
+	stockTake, err := qtx.CreateStockTake(ctx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	for _, b := range balances {
+		if roundQuantity(b.RemainingQuantity) == 0 {
+			continue
+		}
+		err := qtx.CreateStockTakeLine(ctx, &stocktakes.CreateStockTakeLineParams{
+			StockTakeID:         stockTake.ID,
+			ProductAllocationID: b.ProductAllocationID,
+			SystemQuantity:      roundQuantity(b.RemainingQuantity),
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return stockTake, nil
+}
+
+// nextStockTakeNo returns the next stock take number of a period, e.g. ST2610-001.
+func nextStockTakeNo(ctx context.Context, q *stocktakes.Queries, period time.Time) string {
+	prefix := "ST" + period.Format("0601") + "-"
+	latestStockTakeNo, err := q.GetLatestStockTakeNoByPrefix(ctx, prefix+"%")
+	if err != nil {
+		return prefix + "001"
+	}
+	var nextSequence int
+	fmt.Sscanf(latestStockTakeNo[len(prefix):], "%03d", &nextSequence)
+	nextSequence++
+	return fmt.Sprintf("%s%03d", prefix, nextSequence)
+}
+
+// RecordStockTakeCounts records the physical counts of an open stock take in the database and
+// compares each with the line's system quantity. A variance beyond the stock take's tolerance
+// percentage of the system quantity is flagged for HQ review. Counting a line again replaces its
+// count.
+func (s *stockTakesService) RecordStockTakeCounts(ctx context.Context, userID, id int32, counts []*StockTakeCount) ([]*stocktakes.StockTakeLine, error) {
+	if len(counts) == 0 {
+		return nil, fmt.Errorf("%w: no counts given", ErrInvalidStockTakeCount)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := stocktakes.New(tx)
+
+	stockTake, err := qtx.GetStockTakeByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if stockTake.Status != models.StockTakeStatusOpen {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: stock take %s is %s", ErrStockTakeLocked, stockTake.StockTakeNo, stockTake.Status)
+	}
+
+	lines := make([]*stocktakes.StockTakeLine, 0, len(counts))
+	for _, count := range counts {
+		if count.Quantity < 0 {
+			tx.Rollback(ctx)
+			return nil, fmt.Errorf("%w: counted quantity cannot be negative", ErrInvalidStockTakeCount)
+		}
+		unit, err := normalizeUnitOfMeasure(count.UnitOfMeasure)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		lineID, err := stockTakeLineID(ctx, qtx, stockTake.ID, count)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		line, err := qtx.GetStockTakeLineForCount(ctx, lineID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		counted, err := toRolls(count.Quantity, unit, line.RollLengthMetres, line.SheetsPerRoll)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		variance := roundQuantity(counted - line.SystemQuantity)
+		tolerance := roundQuantity(math.Abs(line.SystemQuantity) * stockTake.TolerancePercent / 100)
+		unitQuantity := roundQuantity(count.Quantity)
+
+		record, err := qtx.RecordStockTakeCount(ctx, &stocktakes.RecordStockTakeCountParams{
+			ID:               line.ID,
+			CountedQuantity:  &counted,
+			UnitOfMeasure:    &unit,
+			UnitQuantity:     &unitQuantity,
+			VarianceQuantity: &variance,
+			IsFlagged:        math.Abs(variance) > tolerance,
+			Remarks:          count.Remarks,
+			CountedByUserID:  &userID,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		lines = append(lines, record)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// stockTakeLineID finds the line a count is for, by allocation or by film serial number. A
+// scanned label is matched on its serial and then its batch, as in LookupFilmLabel.
+func stockTakeLineID(ctx context.Context, q *stocktakes.Queries, stockTakeID int32, count *StockTakeCount) (int32, error) {
+	if count.ProductAllocationID != nil {
+		lineID, err := q.GetStockTakeLineIDByAllocationID(ctx, &stocktakes.GetStockTakeLineIDByAllocationIDParams{
+			StockTakeID:         stockTakeID,
+			ProductAllocationID: *count.ProductAllocationID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("%w: allocation %d is not on the stock take", ErrInvalidStockTakeCount, *count.ProductAllocationID)
+		}
+		return lineID, err
+	}
+	if strings.TrimSpace(count.FilmSerialNumber) == "" {
+		return 0, fmt.Errorf("%w: an allocation or film serial number is required", ErrInvalidStockTakeCount)
+	}
+	label, err := utils.ParseFilmLabel(count.FilmSerialNumber)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidStockTakeCount, err)
+	}
+	for _, serial := range []string{label.Serial, label.Lot} {
+		if serial == "" {
+			continue
+		}
+		lineIDs, err := q.ListStockTakeLineIDsByFilmSerialNumber(ctx, &stocktakes.ListStockTakeLineIDsByFilmSerialNumberParams{
+			StockTakeID:      stockTakeID,
+			FilmSerialNumber: serial,
+		})
+		if err != nil {
+			return 0, err
+		}
+		switch len(lineIDs) {
+		case 0:
+			continue
+		case 1:
+			return lineIDs[0], nil
+		default:
+			return 0, fmt.Errorf("%w: film %s has %d allocations on the stock take; count each by its allocation", ErrInvalidStockTakeCount, serial, len(lineIDs))
+		}
+	}
+	return 0, fmt.Errorf("%w: film %s is not on the stock take", ErrInvalidStockTakeCount, label.FilmSerialNumber())
+}
+
+// SubmitStockTake hands a fully counted stock take to HQ in the database. Lines within the
+// tolerance are approved straight away; flagged lines wait for HQ review.
+func (s *stockTakesService) SubmitStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := stocktakes.New(tx)
+
+	stockTake, err := qtx.GetStockTakeByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if stockTake.Status != models.StockTakeStatusOpen {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: stock take %s is %s", ErrStockTakeLocked, stockTake.StockTakeNo, stockTake.Status)
+	}
+	counts, err := qtx.GetStockTakeLineCounts(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if counts.CountedCount < counts.LineCount {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %d of %d lines have not been counted", ErrStockTakeIncomplete, counts.LineCount-counts.CountedCount, counts.LineCount)
+	}
+	if err := qtx.ApproveUnflaggedStockTakeLines(ctx, id); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	stockTake, err = qtx.SubmitStockTake(ctx, &stocktakes.SubmitStockTakeParams{
+		ID:                id,
+		SubmittedByUserID: &userID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return stockTake, nil
+}
+
+// ReviewStockTakeLine approves or rejects the flagged variance of a submitted stock take in the
+// database. A rejected variance is not posted. The decision can be changed until the stock take
+// is posted.
+func (s *stockTakesService) ReviewStockTakeLine(ctx context.Context, userID, id, lineID int32, approve bool, remarks *string) (*stocktakes.StockTakeLine, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := stocktakes.New(tx)
+
+	stockTake, err := qtx.GetStockTakeByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if stockTake.Status != models.StockTakeStatusSubmitted {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: stock take %s is %s", ErrStockTakeLocked, stockTake.StockTakeNo, stockTake.Status)
+	}
+	line, err := qtx.GetStockTakeLineByID(ctx, &stocktakes.GetStockTakeLineByIDParams{
+		ID:          lineID,
+		StockTakeID: id,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if !line.IsFlagged {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: line %d is within the tolerance and needs no review", ErrStockTakeLocked, line.ID)
+	}
+	status := models.StockTakeLineReviewRejected
+	if approve {
+		status = models.StockTakeLineReviewApproved
+	}
+	line, err = qtx.ReviewStockTakeLine(ctx, &stocktakes.ReviewStockTakeLineParams{
+		ID:               line.ID,
+		ReviewStatus:     status,
+		ReviewedByUserID: &userID,
+		ReviewRemarks:    remarks,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// PostStockTake posts the approved variances of a reviewed stock take in the database. Each
+// variance is booked as an adjustment of the allocation's film at the shop in the inventory
+// ledger and counts towards the allocation's remaining film from then on. Posting releases the
+// allocations.
+func (s *stockTakesService) PostStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := stocktakes.New(tx)
+
+	stockTake, err := qtx.GetStockTakeByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if stockTake.Status != models.StockTakeStatusSubmitted {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: stock take %s is %s", ErrStockTakeLocked, stockTake.StockTakeNo, stockTake.Status)
+	}
+	counts, err := qtx.GetStockTakeLineCounts(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if counts.PendingCount > 0 {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: %d flagged variances have not been reviewed", ErrStockTakeIncomplete, counts.PendingCount)
+	}
+
+	variances, err := qtx.ListApprovedStockTakeVariances(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	movements := make([]*inventory.CreateInventoryMovementParams, 0, len(variances))
+	for _, v := range variances {
+		remarks := fmt.Sprintf("Stock take %s of allocation %d", stockTake.StockTakeNo, v.ProductAllocationID)
+		movements = append(movements, &inventory.CreateInventoryMovementParams{
+			ProductID:           v.ProductID,
+			ShopID:              &v.ShopID,
+			MovementType:        models.InventoryMovementAdjustment,
+			Quantity:            *v.VarianceQuantity,
+			ProductAllocationID: &v.ProductAllocationID,
+			Remarks:             &remarks,
+			CreatedByUserID:     &userID,
+		})
+	}
+	posted, err := postInventoryMovements(ctx, inventory.New(tx), movements...)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	// Variances are stored rounded and non-zero, so each one posts exactly one movement.
+	if len(posted) != len(variances) {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("posted %d adjustments for %d variances of stock take %s", len(posted), len(variances), stockTake.StockTakeNo)
+	}
+	for i, v := range variances {
+		err := qtx.PostStockTakeLine(ctx, &stocktakes.PostStockTakeLineParams{
+			ID:                  v.ID,
+			AdjustedQuantity:    v.VarianceQuantity,
+			InventoryMovementID: &posted[i].ID,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
+	stockTake, err = qtx.PostStockTake(ctx, &stocktakes.PostStockTakeParams{
+		ID:             id,
+		PostedByUserID: &userID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return stockTake, nil
+}
+
+// CancelStockTake abandons an open stock take in the database, releasing its allocations without
+// adjusting them. Once submitted, only HQ can abandon it with DiscardStockTake.
+func (s *stockTakesService) CancelStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error) {
+	return s.closeStockTake(ctx, userID, id, models.StockTakeStatusOpen)
+}
+
+// DiscardStockTake abandons a submitted stock take in the database on HQ's behalf, releasing its
+// allocations without adjusting them.
+func (s *stockTakesService) DiscardStockTake(ctx context.Context, userID, id int32) (*stocktakes.StockTake, error) {
+	return s.closeStockTake(ctx, userID, id, models.StockTakeStatusSubmitted)
+}
+
+// closeStockTake cancels a stock take that is still in the given status.
+func (s *stockTakesService) closeStockTake(ctx context.Context, userID, id int32, status string) (*stocktakes.StockTake, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := stocktakes.New(tx)
+
+	stockTake, err := qtx.GetStockTakeByIDForUpdate(ctx, id)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if stockTake.Status != status {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("%w: stock take %s is %s", ErrStockTakeLocked, stockTake.StockTakeNo, stockTake.Status)
+	}
+	stockTake, err = qtx.CancelStockTake(ctx, &stocktakes.CancelStockTakeParams{
+		ID:                id,
+		CancelledByUserID: &userID,
+	})
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return stockTake, nil
+}
+
+// stockTakeLock turns the lookup of the stock take counting an allocation into
+// ErrStockTakeInProgress, or nil when no stock take is counting it.
+func stockTakeLock(stockTakeNo string, err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: the allocation is being counted by stock take %s", ErrStockTakeInProgress, stockTakeNo)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+)
+
+// ErrInvalidUnitOfMeasure is returned when a quantity is given in an unknown unit, or in metres
+// or sheets for a product without a roll length or sheet count to convert it with.
+var ErrInvalidUnitOfMeasure = errors.New("invalid unit of measure")
+
+// quantityScale is the number of decimal places film quantities are stored with.
+const quantityScale = 10000
+
+// maxQuantity is the bound film quantities, stored as NUMERIC(12, 4), must stay below.
+const maxQuantity = 1e8
+
+// roundQuantity rounds a film quantity to the precision it is stored with, so that sums of
+// part rolls compare equal to what the database holds.
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*quantityScale) / quantityScale
+}
+
+// normalizeUnitOfMeasure upper-cases a unit of measure, defaulting to rolls when none is given.
+func normalizeUnitOfMeasure(unit string) (string, error) {
+	unit = strings.ToUpper(strings.TrimSpace(unit))
+	switch unit {
+	case "":
+		return models.UnitOfMeasureRoll, nil
+	case models.UnitOfMeasureRoll, models.UnitOfMeasureMetre, models.UnitOfMeasureSheet:
+		return unit, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrInvalidUnitOfMeasure, unit)
+}
+
+// toRolls converts a film quantity in the given unit to rolls, the base unit inventory,
+// allocations and consumption are kept in, using the product's roll length and sheets per roll.
+func toRolls(quantity float64, unit string, rollLengthMetres *float64, sheetsPerRoll *int32) (float64, error) {
+	switch unit {
+	case models.UnitOfMeasureRoll:
+		return roundQuantity(quantity), nil
+	case models.UnitOfMeasureMetre:
+		if rollLengthMetres == nil || *rollLengthMetres <= 0 {
+			return 0, fmt.Errorf("%w: the product has no roll length to convert metres", ErrInvalidUnitOfMeasure)
+		}
+		return roundQuantity(quantity / *rollLengthMetres), nil
+	case models.UnitOfMeasureSheet:
+		if sheetsPerRoll == nil || *sheetsPerRoll <= 0 {
+			return 0, fmt.Errorf("%w: the product has no sheet count to convert sheets", ErrInvalidUnitOfMeasure)
+		}
+		return roundQuantity(quantity / float64(*sheetsPerRoll)), nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrInvalidUnitOfMeasure, unit)
+}
+
+// validateProductUnits checks that a product can be handled in its unit of measure and that its
+// conversion factors are positive.
+func validateProductUnits(unit string, rollLengthMetres *float64, sheetsPerRoll *int32) (string, error) {
+	unit, err := normalizeUnitOfMeasure(unit)
+	if err != nil {
+		return "", err
+	}
+	if rollLengthMetres != nil && *rollLengthMetres <= 0 {
+		return "", fmt.Errorf("%w: roll length must be greater than zero", ErrInvalidUnitOfMeasure)
+	}
+	if sheetsPerRoll != nil && *sheetsPerRoll <= 0 {
+		return "", fmt.Errorf("%w: sheets per roll must be greater than zero", ErrInvalidUnitOfMeasure)
+	}
+	if _, err := toRolls(1, unit, rollLengthMetres, sheetsPerRoll); err != nil {
+		return "", err
+	}
+	return unit, nil
+}
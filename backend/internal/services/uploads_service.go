@@ -23,6 +23,7 @@ const (
 	FolderDamagedImages      UploadFolder = "damaged_images"
 	FolderResolutionImages   UploadFolder = "resolution_images"
 	FolderInvoices           UploadFolder = "invoices"
+	FolderCommentAttachments UploadFolder = "comment_attachments"
 	FolderOther              UploadFolder = "other"
 )
 
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/vehicles"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
+)
+
+type VehiclesService interface {
+	GetVehicleByID(ctx context.Context, id int32) (*vehicles.Vehicle, error)
+	ListVehiclePlatesByVehicleID(ctx context.Context, vehicleID int32) ([]*vehicles.VehiclePlate, error)
+	ListWarrantiesByVehicleID(ctx context.Context, vehicleID int32) ([]*vehicles.ListWarrantiesByVehicleIDRow, error)
+	ListClaimsByVehicleID(ctx context.Context, vehicleID int32) ([]*vehicles.ClaimView, error)
+}
+
+type vehiclesService struct {
+	db *pgxpool.Pool
+	q  *vehicles.Queries
+}
+
+func NewVehiclesService(db *pgxpool.Pool) VehiclesService {
+	return &vehiclesService{
+		db: db,
+		q:  vehicles.New(db),
+	}
+}
+
+// GetVehicleByID retrieves a vehicle by its ID from the database.
+func (s *vehiclesService) GetVehicleByID(ctx context.Context, id int32) (*vehicles.Vehicle, error) {
+	return s.q.GetVehicleByID(ctx, id)
+}
+
+// ListVehiclePlatesByVehicleID retrieves the plate history of a vehicle from the database.
+func (s *vehiclesService) ListVehiclePlatesByVehicleID(ctx context.Context, vehicleID int32) ([]*vehicles.VehiclePlate, error) {
+	return s.q.ListVehiclePlatesByVehicleID(ctx, vehicleID)
+}
+
+// ListWarrantiesByVehicleID retrieves all warranties of a vehicle from the database.
+func (s *vehiclesService) ListWarrantiesByVehicleID(ctx context.Context, vehicleID int32) ([]*vehicles.ListWarrantiesByVehicleIDRow, error) {
+	return s.q.ListWarrantiesByVehicleID(ctx, &vehicleID)
+}
+
+// ListClaimsByVehicleID retrieves all claims made under the warranties of a vehicle from the database.
+func (s *vehiclesService) ListClaimsByVehicleID(ctx context.Context, vehicleID int32) ([]*vehicles.ClaimView, error) {
+	return s.q.ListClaimsByVehicleID(ctx, &vehicleID)
+}
+
+// linkWarrantyVehicle links a warranty to the vehicle with its chassis number, creating the
+// vehicle if needed, and records the warranty's plate number in the vehicle's plate history.
+func linkWarrantyVehicle(ctx context.Context, db vehicles.DBTX, warranty *warranties.Warranty) error {
+	qtx := vehicles.New(db)
+
+	vehicle, err := qtx.UpsertVehicle(ctx, &vehicles.UpsertVehicleParams{
+		ChassisNo: warranty.CarChassisNo,
+		PlateNo:   warranty.CarPlateNo,
+		CarBrand:  warranty.CarBrand,
+		CarModel:  warranty.CarModel,
+		CarColour: warranty.CarColour,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = qtx.UpsertVehiclePlate(ctx, &vehicles.UpsertVehiclePlateParams{
+		VehicleID: vehicle.ID,
+		PlateNo:   warranty.CarPlateNo,
+		SeenOn:    warranty.InstallationDate,
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := qtx.RefreshVehiclePlateNo(ctx, vehicle.ID); err != nil {
+		return err
+	}
+
+	if err := qtx.UpdateWarrantyVehicleID(ctx, &vehicles.UpdateWarrantyVehicleIDParams{
+		ID:        warranty.ID,
+		VehicleID: &vehicle.ID,
+	}); err != nil {
+		return err
+	}
+	warranty.VehicleID = &vehicle.ID
+	return nil
+}
@@ -2,15 +2,32 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/inventory"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/db/sqlc/warranties"
 	"github.com/kokweikhong/profilm_ewarranty/backend/internal/models"
+	"github.com/kokweikhong/profilm_ewarranty/backend/internal/notifier"
+	"github.com/kokweikhong/profilm_ewarranty/backend/pkg/utils"
 )
 
+// VehicleNormalizationResult describes how the plate and chassis numbers of a warranty were normalized.
+type VehicleNormalizationResult struct {
+	WarrantyID      int32  `json:"warrantyId"`
+	WarrantyNo      string `json:"warrantyNo"`
+	CarPlateNo      string `json:"carPlateNo"`
+	CarChassisNo    string `json:"carChassisNo"`
+	NewCarPlateNo   string `json:"newCarPlateNo"`
+	NewCarChassisNo string `json:"newCarChassisNo"`
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+}
+
 type WarrantiesService interface {
 	ListWarranties(ctx context.Context) ([]*warranties.ListWarrantiesRow, error)
 	GetWarrantyByID(ctx context.Context, id int32) (*warranties.Warranty, error)
@@ -31,6 +48,8 @@ type WarrantiesService interface {
 	GetWarrantyPartsByWarrantyID(ctx context.Context, warrantyID int32) ([]*warranties.GetWarrantyPartsByWarrantyIDRow, error)
 
 	GenerateNextWarrantyNo(ctx context.Context, branchCode string, installationDate string) (string, error)
+
+	NormalizeWarrantyVehicles(ctx context.Context, dryRun bool) ([]*VehicleNormalizationResult, error)
 }
 
 type warrantiesService struct {
@@ -61,6 +80,8 @@ func (s *warrantiesService) GetWarrantiesByShopID(ctx context.Context, shopID in
 }
 
 // CreateWarrantyWithParts creates a new warranty along with its associated parts in a transaction.
+// The film each part installs is converted to rolls, drawn from its allocation and booked as
+// consumption at the shop in the inventory ledger.
 func (s *warrantiesService) CreateWarrantyWithParts(ctx context.Context, warrantyArg *warranties.CreateWarrantyParams, partsArgs []*warranties.CreateWarrantyPartParams) (*warranties.Warranty, error) {
 	// use a transaction to ensure both warranty and parts are created successfully
 	tx, err := s.db.Begin(ctx)
@@ -70,20 +91,43 @@ func (s *warrantiesService) CreateWarrantyWithParts(ctx context.Context, warrant
 
 	qtx := warranties.New(tx)
 
+	// Store the car make and model using their catalog names
+	warrantyArg.CarBrand, warrantyArg.CarModel, err = resolveCarMakeAndModel(ctx, tx, warrantyArg.CarBrand, warrantyArg.CarModel)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Store the plate and chassis numbers in their canonical form
+	warrantyArg.CarPlateNo, warrantyArg.CarChassisNo, err = normalizeWarrantyVehicle(warrantyArg.CarPlateNo, warrantyArg.CarChassisNo)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
 	warranty, err := qtx.CreateWarranty(ctx, warrantyArg)
 	if err != nil {
 		tx.Rollback(ctx)
 		return nil, err
 	}
 
+	if err := linkWarrantyVehicle(ctx, tx, warranty); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := linkWarrantyCustomer(ctx, tx, warranty); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
 	// Create parts associated with the warranty
 	for _, partArg := range partsArgs {
 		partArg.WarrantyID = warranty.ID
 	}
 
 	for _, partArg := range partsArgs {
-		_, err = qtx.CreateWarrantyPart(ctx, partArg)
-		if err != nil {
+		if _, err := createWarrantyPart(ctx, tx, partArg); err != nil {
 			tx.Rollback(ctx)
 			return nil, err
 		}
@@ -96,6 +140,8 @@ func (s *warrantiesService) CreateWarrantyWithParts(ctx context.Context, warrant
 }
 
 // UpdateWarrantyWithParts updates an existing warranty along with its associated parts in a transaction.
+// When a part's allocation or film quantity changes, the install is drawn from the allocation again
+// and its consumption in the inventory ledger is corrected.
 func (s *warrantiesService) UpdateWarrantyWithParts(ctx context.Context, warrantyArg *warranties.UpdateWarrantyParams, partsArgs []*warranties.UpdateWarrantyPartParams) (*warranties.Warranty, error) {
 	// use a transaction to ensure both warranty and parts are updated successfully
 	tx, err := s.db.Begin(ctx)
@@ -103,12 +149,37 @@ func (s *warrantiesService) UpdateWarrantyWithParts(ctx context.Context, warrant
 		return nil, err
 	}
 	qtx := warranties.New(tx)
+
+	// Store the car make and model using their catalog names
+	warrantyArg.CarBrand, warrantyArg.CarModel, err = resolveCarMakeAndModel(ctx, tx, warrantyArg.CarBrand, warrantyArg.CarModel)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	// Store the plate and chassis numbers in their canonical form
+	warrantyArg.CarPlateNo, warrantyArg.CarChassisNo, err = normalizeWarrantyVehicle(warrantyArg.CarPlateNo, warrantyArg.CarChassisNo)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
 	warranty, err := qtx.UpdateWarranty(ctx, warrantyArg)
 	if err != nil {
 		tx.Rollback(ctx)
 		return nil, err
 	}
 
+	if err := linkWarrantyVehicle(ctx, tx, warranty); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	if err := linkWarrantyCustomer(ctx, tx, warranty); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
 	// Get the existing parts associated with the warranty
 	existingParts, err := qtx.GetWarrantyPartsByWarrantyID(ctx, warranty.ID)
 	if err != nil {
@@ -120,6 +191,11 @@ func (s *warrantiesService) UpdateWarrantyWithParts(ctx context.Context, warrant
 	// need to check if the part is latest version by comparing all data fields
 	for _, partArg := range partsArgs {
 		partArg.WarrantyID = warranty.ID
+		partArg.UnitOfMeasure, err = normalizeUnitOfMeasure(partArg.UnitOfMeasure)
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
 		found := false
 		for _, existingPart := range existingParts {
 			if partArg.ID == existingPart.ID {
@@ -128,10 +204,11 @@ func (s *warrantiesService) UpdateWarrantyWithParts(ctx context.Context, warrant
 				if partArg.CarPartID != existingPart.CarPartID ||
 					partArg.ProductAllocationID != existingPart.ProductAllocationID ||
 					partArg.InstallationImageUrl != existingPart.InstallationImageUrl ||
-					partArg.Remarks != existingPart.Remarks {
+					partArg.Remarks != existingPart.Remarks ||
+					partArg.UnitOfMeasure != existingPart.UnitOfMeasure ||
+					partArg.UnitQuantity != existingPart.UnitQuantity {
 					// part has changes, update part
-					_, err = qtx.UpdateWarrantyPart(ctx, partArg)
-					if err != nil {
+					if _, err := updateWarrantyPart(ctx, tx, partArg); err != nil {
 						tx.Rollback(ctx)
 						return nil, err
 					}
@@ -150,12 +227,14 @@ func (s *warrantiesService) UpdateWarrantyWithParts(ctx context.Context, warrant
 		}
 		if !found {
 			// part not found, create new part
-			_, err = qtx.CreateWarrantyPart(ctx, &warranties.CreateWarrantyPartParams{
+			_, err = createWarrantyPart(ctx, tx, &warranties.CreateWarrantyPartParams{
 				WarrantyID:           warranty.ID,
 				CarPartID:            partArg.CarPartID,
 				ProductAllocationID:  partArg.ProductAllocationID,
 				InstallationImageUrl: partArg.InstallationImageUrl,
 				Remarks:              partArg.Remarks,
+				UnitOfMeasure:        partArg.UnitOfMeasure,
+				UnitQuantity:         partArg.UnitQuantity,
 			})
 			if err != nil {
 				tx.Rollback(ctx)
@@ -182,11 +261,19 @@ func (s *warrantiesService) UpdateWarrantyApproval(ctx context.Context, arg *war
 	// Update the warranty approval status
 	// if true then all warranty parts should also be approved
 	// use a transaction to ensure both warranty and parts are updated successfully
+	if err := checkParentApprovalStatus(arg.ApprovalStatus); err != nil {
+		return nil, err
+	}
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	qtx := warranties.New(tx)
+	previous, err := qtx.GetWarrantyByID(ctx, arg.ID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	warranty, err := qtx.UpdateWarrantyApproval(ctx, arg)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -210,6 +297,13 @@ func (s *warrantiesService) UpdateWarrantyApproval(ctx context.Context, arg *war
 			return nil, err
 		}
 	}
+	// notify the customer when the warranty becomes approved
+	if warranty.ApprovalStatus.IsGranted() && !previous.ApprovalStatus.IsGranted() {
+		if err := enqueueWarrantyApprovedNotifications(ctx, tx, warranty); err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
@@ -217,8 +311,13 @@ func (s *warrantiesService) UpdateWarrantyApproval(ctx context.Context, arg *war
 }
 
 // GetWarrantiesByExactSearch retrieves warranties with their parts matching an exact search term from the database.
+// The plate number is compared by its search key so that spacing and case do not matter.
 func (s *warrantiesService) GetWarrantiesByExactSearch(ctx context.Context, searchTerm string) ([]*warranties.GetWarrantiesByExactSearchRow, error) {
-	return s.q.GetWarrantiesByExactSearch(ctx, searchTerm)
+	searchTerm = strings.TrimSpace(searchTerm)
+	return s.q.GetWarrantiesByExactSearch(ctx, &warranties.GetWarrantiesByExactSearchParams{
+		SearchTerm: searchTerm,
+		SearchKey:  utils.VehicleSearchKey(searchTerm),
+	})
 }
 
 // GetCarParts retrieves a list of car parts from the database.
@@ -226,26 +325,203 @@ func (s *warrantiesService) GetCarParts(ctx context.Context) ([]*warranties.CarP
 	return s.q.GetCarParts(ctx)
 }
 
-// CreateWarrantyPart creates a new warranty part in the database.
+// CreateWarrantyPart creates a new warranty part in the database and books the film it installs
+// as consumption at the shop in the inventory ledger.
 func (s *warrantiesService) CreateWarrantyPart(ctx context.Context, arg *warranties.CreateWarrantyPartParams) (*warranties.WarrantyPart, error) {
-	return s.q.CreateWarrantyPart(ctx, arg)
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	part, err := createWarrantyPart(ctx, tx, arg)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return part, nil
+}
+
+// createWarrantyPart draws the film a new warranty part installs from its allocation, creates the
+// part and books the install as consumption, all on the caller's transaction.
+func createWarrantyPart(ctx context.Context, tx pgx.Tx, arg *warranties.CreateWarrantyPartParams) (*warranties.WarrantyPart, error) {
+	qtx := warranties.New(tx)
+	allocation, err := checkAllocationAvailable(ctx, qtx, arg.ProductAllocationID)
+	if err != nil {
+		return nil, err
+	}
+	arg.UnitOfMeasure, arg.FilmQuantity, err = warrantyPartRolls(ctx, qtx, allocation, arg.UnitOfMeasure, arg.UnitQuantity, 0)
+	if err != nil {
+		return nil, err
+	}
+	part, err := qtx.CreateWarrantyPart(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	if err := postWarrantyPartConsumption(ctx, inventory.New(tx), part, allocation.ProductID, allocation.ShopID); err != nil {
+		return nil, err
+	}
+	return part, nil
 }
 
-// UpdateWarrantyPart updates a warranty part in the database.
+// updateWarrantyPart updates a warranty part on the caller's transaction. When its allocation or
+// film quantity changes, the install is drawn from the allocation again, with the film the part
+// already held on the same allocation available to it, and its consumption is corrected; otherwise
+// the part keeps the film it has. Neither allocation may be counted by a stock take meanwhile.
+func updateWarrantyPart(ctx context.Context, tx pgx.Tx, arg *warranties.UpdateWarrantyPartParams) (*warranties.WarrantyPart, error) {
+	qtx := warranties.New(tx)
+	current, err := qtx.GetWarrantyPartByID(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	arg.UnitOfMeasure, err = normalizeUnitOfMeasure(arg.UnitOfMeasure)
+	if err != nil {
+		return nil, err
+	}
+	if arg.ProductAllocationID == current.ProductAllocationID &&
+		arg.UnitOfMeasure == current.UnitOfMeasure &&
+		arg.UnitQuantity == current.UnitQuantity {
+		arg.FilmQuantity = current.FilmQuantity
+		return qtx.UpdateWarrantyPart(ctx, arg)
+	}
+
+	allocation, err := checkAllocationAvailable(ctx, qtx, arg.ProductAllocationID)
+	if err != nil {
+		return nil, err
+	}
+	var held float64
+	if arg.ProductAllocationID == current.ProductAllocationID {
+		held = current.FilmQuantity
+	} else {
+		// the film goes back to the allocation the part is moved off
+		if err := stockTakeLock(qtx.GetInProgressStockTakeNoByAllocationID(ctx, current.ProductAllocationID)); err != nil {
+			return nil, err
+		}
+	}
+	arg.UnitOfMeasure, arg.FilmQuantity, err = warrantyPartRolls(ctx, qtx, allocation, arg.UnitOfMeasure, arg.UnitQuantity, held)
+	if err != nil {
+		return nil, err
+	}
+	part, err := qtx.UpdateWarrantyPart(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	if err := postWarrantyPartConsumption(ctx, inventory.New(tx), part, allocation.ProductID, allocation.ShopID); err != nil {
+		return nil, err
+	}
+	return part, nil
+}
+
+// checkAllocationAvailable locks the product allocation a warranty part installs film from, for
+// the rest of the caller's transaction, and refuses it while a stock take is counting it or when
+// its film is frozen by an open recall.
+func checkAllocationAvailable(ctx context.Context, q *warranties.Queries, allocationID int32) (*warranties.GetProductAllocationForInstallRow, error) {
+	allocation, err := q.GetProductAllocationForInstall(ctx, allocationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("product allocation %d not found", allocationID)
+		}
+		return nil, err
+	}
+	if err := stockTakeLock(q.GetInProgressStockTakeNoByAllocationID(ctx, allocationID)); err != nil {
+		return nil, err
+	}
+	if err := recallFreeze(q.GetInstallFreezeRecallNo(ctx, allocationID)); err != nil {
+		return nil, err
+	}
+	return allocation, nil
+}
+
+// warrantyPartRolls normalizes the unit of the film a warranty part installs and converts the
+// quantity to rolls, refusing more than the allocation has left. held is film the part already
+// draws from the allocation, which is available to it again when the part is corrected. The
+// allocation must already be locked by checkAllocationAvailable, so that the film left is read
+// after any concurrent install has committed.
+func warrantyPartRolls(ctx context.Context, q *warranties.Queries, allocation *warranties.GetProductAllocationForInstallRow, unit string, quantity, held float64) (string, float64, error) {
+	unit, err := normalizeUnitOfMeasure(unit)
+	if err != nil {
+		return "", 0, err
+	}
+	if quantity <= 0 {
+		return "", 0, fmt.Errorf("%w: film quantity must be greater than zero", ErrInvalidInventoryQuantity)
+	}
+	rolls, err := toRolls(quantity, unit, allocation.RollLengthMetres, allocation.SheetsPerRoll)
+	if err != nil {
+		return "", 0, err
+	}
+	if rolls <= 0 {
+		return "", 0, fmt.Errorf("%w: %g %s is less than the smallest part roll", ErrInvalidInventoryQuantity, quantity, strings.ToLower(unit))
+	}
+	remaining, err := q.GetProductAllocationRemainingQuantity(ctx, allocation.ID)
+	if err != nil {
+		return "", 0, err
+	}
+	if remaining = roundQuantity(remaining + held); rolls > remaining {
+		return "", 0, fmt.Errorf("%w: %g rolls left on allocation %d, %g requested", ErrInsufficientAllocationBalance, remaining, allocation.ID, rolls)
+	}
+	return unit, rolls, nil
+}
+
+// postWarrantyPartConsumption books the film installed by a warranty part as consumption at the
+// shop in the inventory ledger. When the part is corrected, whatever the ledger holds for it is
+// reversed first.
+func postWarrantyPartConsumption(ctx context.Context, q *inventory.Queries, part *warranties.WarrantyPart, productID, shopID int32) error {
+	posted, err := q.ListInventoryNetByWarrantyPartID(ctx, part.ID)
+	if err != nil {
+		return err
+	}
+	if len(posted) == 1 && posted[0].ProductAllocationID != nil &&
+		*posted[0].ProductAllocationID == part.ProductAllocationID &&
+		posted[0].Quantity == -part.FilmQuantity {
+		return nil
+	}
+
+	remarks := "Warranty install"
+	var movements []*inventory.CreateInventoryMovementParams
+	for _, p := range posted {
+		movements = append(movements, &inventory.CreateInventoryMovementParams{
+			ProductID:           p.ProductID,
+			ShopID:              p.ShopID,
+			MovementType:        models.InventoryMovementConsumption,
+			Quantity:            -p.Quantity,
+			ProductAllocationID: p.ProductAllocationID,
+			WarrantyPartID:      &part.ID,
+			Remarks:             &remarks,
+		})
+	}
+	movements = append(movements, &inventory.CreateInventoryMovementParams{
+		ProductID:           productID,
+		ShopID:              &shopID,
+		MovementType:        models.InventoryMovementConsumption,
+		Quantity:            -part.FilmQuantity,
+		ProductAllocationID: &part.ProductAllocationID,
+		WarrantyPartID:      &part.ID,
+		Remarks:             &remarks,
+	})
+	_, err = postInventoryMovements(ctx, q, movements...)
+	return err
+}
+
+// UpdateWarrantyPart updates a warranty part in the database and sets it and its warranty back
+// to pending approval. A change to its allocation or film quantity corrects the install's
+// consumption in the inventory ledger.
 func (s *warrantiesService) UpdateWarrantyPart(ctx context.Context, arg *warranties.UpdateWarrantyPartParams) (*warranties.WarrantyPart, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
+	qtx := warranties.New(tx)
 
-	result, err := s.q.UpdateWarrantyPart(ctx, arg)
+	result, err := updateWarrantyPart(ctx, tx, arg)
 	if err != nil {
 		tx.Rollback(ctx)
 		return nil, err
 	}
 
 	// update the warranty approval status to pending when a part is updated
-	_, err = s.q.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
+	_, err = qtx.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
 		ID:             result.WarrantyID,
 		ApprovalStatus: models.ApprovalStatusPending,
 	})
@@ -254,7 +530,7 @@ func (s *warrantiesService) UpdateWarrantyPart(ctx context.Context, arg *warrant
 		return nil, err
 	}
 
-	_, err = s.q.UpdateWarrantyPartApproval(ctx, &warranties.UpdateWarrantyPartApprovalParams{
+	_, err = qtx.UpdateWarrantyPartApproval(ctx, &warranties.UpdateWarrantyPartApprovalParams{
 		ID:             result.ID,
 		ApprovalStatus: models.ApprovalStatusPending,
 	})
@@ -262,117 +538,64 @@ func (s *warrantiesService) UpdateWarrantyPart(ctx context.Context, arg *warrant
 		tx.Rollback(ctx)
 		return nil, err
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
-// UpdateWarrantryPartApproval updates the approval status of a warranty part in the database.
+// UpdateWarrantyPartApproval updates the approval status of a warranty part in the database and
+// derives the warranty approval status from all of its parts using the warranty approval rule.
 func (s *warrantiesService) UpdateWarrantyPartApproval(ctx context.Context, arg *warranties.UpdateWarrantyPartApprovalParams) (*warranties.WarrantyPart, error) {
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	qtx := warranties.New(tx)
-	// Get all warranty parts associated with the warranty
-	warrantyPart, err := qtx.GetWarrantyPartByID(ctx, arg.ID)
+	result, err := qtx.UpdateWarrantyPartApproval(ctx, arg)
 	if err != nil {
-		// log error
-		log.Printf("Failed to get warranty part by ID: %v", err)
+		log.Printf("Failed to update current warranty part approval: %v", err)
 		tx.Rollback(ctx)
 		return nil, err
 	}
-	warranty, err := qtx.GetWarrantyByID(ctx, warrantyPart.WarrantyID)
+	warranty, err := qtx.GetWarrantyByID(ctx, result.WarrantyID)
 	if err != nil {
-		tx.Rollback(ctx)
-		// log error
 		log.Printf("Failed to get warranty by ID: %v", err)
+		tx.Rollback(ctx)
 		return nil, err
 	}
 	parts, err := qtx.GetWarrantyPartsByWarrantyID(ctx, warranty.ID)
 	if err != nil {
-		// log error
 		log.Printf("Failed to get warranty parts by warranty ID: %v", err)
 		tx.Rollback(ctx)
 		return nil, err
 	}
-
-	result, err := qtx.UpdateWarrantyPartApproval(ctx, arg)
+	rule, err := loadApprovalRule(ctx, tx, models.ApprovalEntityWarranty)
 	if err != nil {
-		// log error
-		log.Printf("Failed to update current warranty part approval: %v", err)
 		tx.Rollback(ctx)
 		return nil, err
 	}
 
-	isAllApproved := true
-	isAllRejected := true
-
-	// Check if updating this part to approved would result in all parts being approved
-	if strings.ToLower(string(arg.ApprovalStatus)) == "approved" {
-		for _, part := range parts {
-
-			if part.ID != arg.ID && strings.ToLower(string(part.ApprovalStatus)) != "approved" {
-				isAllApproved = false
-				break
-			}
-		}
-	}
-
-	// Check if updating this part to rejected would result in all parts being rejected
-	if strings.ToLower(string(arg.ApprovalStatus)) == "rejected" {
-		for _, part := range parts {
-			if part.ID != arg.ID && strings.ToLower(string(part.ApprovalStatus)) != "rejected" {
-				isAllRejected = false
-				break
-			}
-		}
-	}
-
+	statuses := make([]models.ApprovalStatus, 0, len(parts))
 	for _, part := range parts {
-		status := part.ApprovalStatus
-		if part.ID == arg.ID {
-			status = arg.ApprovalStatus // use the new status for the updated part
-		}
-		if strings.ToLower(string(status)) != "approved" {
-			isAllApproved = false
-		}
-		if strings.ToLower(string(status)) != "rejected" {
-			isAllRejected = false
-		}
-	}
-
-	if isAllApproved {
-		// If all parts are approved, update the warranty to approved as well
-		_, err = qtx.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
-			ID:             warranty.ID,
-			ApprovalStatus: "APPROVED",
-		})
-		if err != nil {
-			log.Printf("Failed to update warranty approval: %v", err)
-			tx.Rollback(ctx)
-			return nil, err
-		}
+		statuses = append(statuses, part.ApprovalStatus)
 	}
-	if isAllRejected {
-		// If all parts are rejected, update the warranty to rejected as well
-		_, err = qtx.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
+	approvalStatus := aggregateApproval(rule, statuses)
+	if approvalStatus != warranty.ApprovalStatus {
+		if _, err := qtx.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
 			ID:             warranty.ID,
-			ApprovalStatus: "REJECTED",
-		})
-		if err != nil {
+			ApprovalStatus: approvalStatus,
+			Remarks:        warranty.Remarks,
+		}); err != nil {
 			log.Printf("Failed to update warranty approval: %v", err)
 			tx.Rollback(ctx)
 			return nil, err
 		}
 	}
-
-	if !isAllApproved && !isAllRejected {
-		// Otherwise, ensure the warranty is set to pending and update current part as approved
-		_, err = qtx.UpdateWarrantyApproval(ctx, &warranties.UpdateWarrantyApprovalParams{
-			ID:             warranty.ID,
-			ApprovalStatus: "PENDING",
-		})
-		if err != nil {
-			log.Printf("Failed to update warranty approval: %v", err)
+	// notify the customer when the warranty becomes approved
+	if approvalStatus.IsGranted() && !warranty.ApprovalStatus.IsGranted() {
+		if err := enqueueWarrantyApprovedNotifications(ctx, tx, warranty); err != nil {
+			log.Printf("Failed to queue warranty approved notifications: %v", err)
 			tx.Rollback(ctx)
 			return nil, err
 		}
@@ -418,3 +641,93 @@ func (s *warrantiesService) GenerateNextWarrantyNo(ctx context.Context, branchCo
 	// Format new warranty number with zero-padded sequence
 	return fmt.Sprintf("%s%02d", prefix, sequence), nil
 }
+
+// enqueueWarrantyApprovedNotifications queues the warranty approved notifications for the customer.
+func enqueueWarrantyApprovedNotifications(ctx context.Context, db warranties.DBTX, warranty *warranties.Warranty) error {
+	data := &notifier.TemplateData{
+		CustomerName: warranty.ClientName,
+		WarrantyNo:   warranty.WarrantyNo,
+		CarBrand:     warranty.CarBrand,
+		CarModel:     warranty.CarModel,
+		CarPlateNo:   warranty.CarPlateNo,
+	}
+	to := customerContact{Email: warranty.ClientEmail, Contact: warranty.ClientContact}
+	return enqueueCustomerNotifications(ctx, db, notifier.EventWarrantyApproved, to, data, &warranty.ID, nil)
+}
+
+// NormalizeWarrantyVehicles rewrites the plate and chassis numbers of existing warranties to
+// their canonical form. Warranties with an invalid plate or chassis number are left untouched
+// and reported. With dryRun set the changes are rolled back.
+func (s *warrantiesService) NormalizeWarrantyVehicles(ctx context.Context, dryRun bool) ([]*VehicleNormalizationResult, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	qtx := warranties.New(tx)
+
+	vehicles, err := qtx.ListWarrantyVehicles(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+
+	results := []*VehicleNormalizationResult{}
+	for _, v := range vehicles {
+		plateNo, chassisNo, err := normalizeWarrantyVehicle(v.CarPlateNo, v.CarChassisNo)
+		if err != nil {
+			results = append(results, &VehicleNormalizationResult{
+				WarrantyID:   v.ID,
+				WarrantyNo:   v.WarrantyNo,
+				CarPlateNo:   v.CarPlateNo,
+				CarChassisNo: v.CarChassisNo,
+				Status:       models.VehicleNormalizationStatusInvalid,
+				Error:        err.Error(),
+			})
+			continue
+		}
+		if plateNo == v.CarPlateNo && chassisNo == v.CarChassisNo {
+			continue
+		}
+
+		err = qtx.UpdateWarrantyVehicle(ctx, &warranties.UpdateWarrantyVehicleParams{
+			ID:           v.ID,
+			CarPlateNo:   plateNo,
+			CarChassisNo: chassisNo,
+		})
+		if err != nil {
+			tx.Rollback(ctx)
+			return nil, err
+		}
+		results = append(results, &VehicleNormalizationResult{
+			WarrantyID:      v.ID,
+			WarrantyNo:      v.WarrantyNo,
+			CarPlateNo:      v.CarPlateNo,
+			CarChassisNo:    v.CarChassisNo,
+			NewCarPlateNo:   plateNo,
+			NewCarChassisNo: chassisNo,
+			Status:          models.VehicleNormalizationStatusNormalized,
+		})
+	}
+
+	if dryRun {
+		tx.Rollback(ctx)
+		return results, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// normalizeWarrantyVehicle validates a warranty's plate and chassis numbers and returns their canonical form.
+func normalizeWarrantyVehicle(plateNo, chassisNo string) (string, string, error) {
+	plateNo, err := utils.NormalizeMalaysianPlate(plateNo)
+	if err != nil {
+		return "", "", err
+	}
+	chassisNo, err = utils.NormalizeChassisNo(chassisNo)
+	if err != nil {
+		return "", "", err
+	}
+	return plateNo, chassisNo, nil
+}
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Outbox of customer notifications. Rows are written in the same transaction
+-- as the warranty/claim change that triggers them and delivered by the
+-- notification dispatcher with retries.
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    event_type VARCHAR(50) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'sms')),
+    recipient VARCHAR(255) NOT NULL,
+    warranty_id INT REFERENCES warranties(id),
+    claim_id INT REFERENCES claims(id),
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    locale VARCHAR(10),
+    subject TEXT,
+    body TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'SENT', 'FAILED', 'SKIPPED')),
+    provider VARCHAR(50),
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS notification_attempts (
+    id SERIAL PRIMARY KEY,
+    notification_id INT NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    attempt_no INT NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    is_success BOOLEAN NOT NULL,
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notifications_status_next_attempt_at ON notifications(status, next_attempt_at);
+CREATE INDEX idx_notifications_warranty_id ON notifications(warranty_id);
+CREATE INDEX idx_notifications_claim_id ON notifications(claim_id);
+CREATE INDEX idx_notification_attempts_notification_id ON notification_attempts(notification_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_attempts;
+DROP TABLE IF EXISTS notifications;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reminder rules are configured per product type. For WARRANTY_EXPIRY rules the reminder
+-- falls due offset_days before the warranty expires; for INSPECTION rules it falls due
+-- offset_months after installation. Reminders are only sent while they are at most
+-- window_days overdue so that newly created rules do not message old customers.
+CREATE TABLE IF NOT EXISTS reminder_rules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    product_type_id INT NOT NULL REFERENCES product_types(id),
+    reminder_type VARCHAR(50) NOT NULL CHECK (reminder_type IN ('WARRANTY_EXPIRY', 'INSPECTION')),
+    offset_days INT NOT NULL DEFAULT 0,
+    offset_months INT NOT NULL DEFAULT 0,
+    window_days INT NOT NULL DEFAULT 7,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per rule and warranty so that each reminder is only queued once.
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+    id SERIAL PRIMARY KEY,
+    reminder_rule_id INT NOT NULL REFERENCES reminder_rules(id),
+    warranty_id INT NOT NULL REFERENCES warranties(id),
+    due_date DATE NOT NULL,
+    expiry_date DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (reminder_rule_id, warranty_id)
+);
+
+CREATE INDEX idx_reminder_deliveries_warranty_id ON reminder_deliveries(warranty_id);
+
+INSERT INTO reminder_rules (name, product_type_id, reminder_type, offset_days, offset_months)
+SELECT 'Window tinting warranty expiring in 30 days', pt.id, 'WARRANTY_EXPIRY', 30, 0
+FROM product_types pt WHERE pt.name = 'Window Tinting Film'
+UNION ALL
+SELECT 'Paint protection warranty expiring in 30 days', pt.id, 'WARRANTY_EXPIRY', 30, 0
+FROM product_types pt WHERE pt.name = 'Paint Protection Film'
+UNION ALL
+SELECT '12-month paint protection inspection', pt.id, 'INSPECTION', 0, 12
+FROM product_types pt WHERE pt.name = 'Paint Protection Film';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reminder_deliveries;
+DROP TABLE IF EXISTS reminder_rules;
+-- +goose StatementEnd
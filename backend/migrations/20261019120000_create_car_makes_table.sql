@@ -0,0 +1,340 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reference catalog of car makes and models. Warranties keep car_brand and car_model as
+-- text, but the values are resolved against this catalog (including aliases such as
+-- "Merc" for "Mercedes-Benz") and stored using the canonical name.
+CREATE TABLE IF NOT EXISTS car_makes (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_car_makes_name ON car_makes(LOWER(name));
+
+CREATE TABLE IF NOT EXISTS car_models (
+    id SERIAL PRIMARY KEY,
+    car_make_id INT NOT NULL REFERENCES car_makes(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_car_models_make_name ON car_models(car_make_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS car_make_aliases (
+    id SERIAL PRIMARY KEY,
+    car_make_id INT NOT NULL REFERENCES car_makes(id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_car_make_aliases_alias ON car_make_aliases(LOWER(alias));
+
+CREATE TABLE IF NOT EXISTS car_model_aliases (
+    id SERIAL PRIMARY KEY,
+    car_model_id INT NOT NULL REFERENCES car_models(id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_car_model_aliases_car_model_id ON car_model_aliases(car_model_id);
+
+INSERT INTO car_makes (name) VALUES
+('Toyota'),
+('Honda'),
+('Nissan'),
+('Mazda'),
+('Subaru'),
+('Mitsubishi'),
+('Suzuki'),
+('Lexus'),
+('Infiniti'),
+('Acura'),
+('BMW'),
+('Mercedes-Benz'),
+('Audi'),
+('Volkswagen'),
+('Porsche'),
+('Ford'),
+('Chevrolet'),
+('Tesla'),
+('Jeep'),
+('Dodge'),
+('Chrysler'),
+('Cadillac'),
+('Buick'),
+('GMC'),
+('Lincoln'),
+('Hyundai'),
+('Kia'),
+('Genesis'),
+('Peugeot'),
+('Renault'),
+('Citroen'),
+('Fiat'),
+('Alfa Romeo'),
+('Ferrari'),
+('Lamborghini'),
+('Maserati'),
+('Volvo'),
+('Saab'),
+('Jaguar'),
+('Land Rover'),
+('Mini'),
+('Rolls-Royce'),
+('Bentley'),
+('Bugatti'),
+('McLaren'),
+('Aston Martin'),
+('Geely'),
+('Chery'),
+('BYD'),
+('Great Wall'),
+('Perodua'),
+('Proton');
+
+INSERT INTO car_models (car_make_id, name)
+SELECT cm.id, v.model_name
+FROM (VALUES
+('Toyota', 'Camry'),
+('Toyota', 'Vios'),
+('Toyota', 'Yaris'),
+('Toyota', 'Corolla Cross'),
+('Toyota', 'Hilux'),
+('Toyota', 'Alphard'),
+('Toyota', 'Vellfire'),
+('Toyota', 'Fortuner'),
+('Honda', 'Civic'),
+('Honda', 'City'),
+('Honda', 'HR-V'),
+('Honda', 'CR-V'),
+('Honda', 'Accord'),
+('Honda', 'BR-V'),
+('Honda', 'WR-V'),
+('Nissan', 'Altima'),
+('Nissan', 'Almera'),
+('Nissan', 'X-Trail'),
+('Nissan', 'Serena'),
+('Nissan', 'Navara'),
+('Mazda', 'CX-5'),
+('Mazda', 'CX-3'),
+('Mazda', 'CX-30'),
+('Mazda', 'CX-8'),
+('Mazda', 'Mazda2'),
+('Mazda', 'Mazda3'),
+('Mazda', 'BT-50'),
+('Subaru', 'Forester'),
+('Subaru', 'XV'),
+('Subaru', 'Outback'),
+('Subaru', 'WRX'),
+('Mitsubishi', 'Xpander'),
+('Mitsubishi', 'Triton'),
+('Mitsubishi', 'ASX'),
+('Mitsubishi', 'Outlander'),
+('Suzuki', 'Swift'),
+('Suzuki', 'Vitara'),
+('Suzuki', 'Jimny'),
+('Lexus', 'ES'),
+('Lexus', 'NX'),
+('Lexus', 'RX'),
+('Lexus', 'UX'),
+('Lexus', 'IS'),
+('Lexus', 'LM'),
+('Infiniti', 'Q50'),
+('Acura', 'TLX'),
+('BMW', '3 Series'),
+('BMW', '5 Series'),
+('BMW', '7 Series'),
+('BMW', 'X1'),
+('BMW', 'X3'),
+('BMW', 'X5'),
+('BMW', 'iX'),
+('Mercedes-Benz', 'A-Class'),
+('Mercedes-Benz', 'C-Class'),
+('Mercedes-Benz', 'E-Class'),
+('Mercedes-Benz', 'S-Class'),
+('Mercedes-Benz', 'GLA'),
+('Mercedes-Benz', 'GLC'),
+('Mercedes-Benz', 'GLE'),
+('Mercedes-Benz', 'EQS'),
+('Audi', 'A4'),
+('Audi', 'A6'),
+('Audi', 'Q5'),
+('Audi', 'Q7'),
+('Audi', 'e-tron'),
+('Volkswagen', 'Golf'),
+('Volkswagen', 'Polo'),
+('Volkswagen', 'Vento'),
+('Volkswagen', 'Passat'),
+('Volkswagen', 'Tiguan'),
+('Volkswagen', 'Arteon'),
+('Porsche', 'Cayenne'),
+('Porsche', 'Macan'),
+('Porsche', 'Panamera'),
+('Porsche', '911'),
+('Porsche', 'Taycan'),
+('Ford', 'Ranger'),
+('Ford', 'Everest'),
+('Ford', 'Mustang'),
+('Chevrolet', 'Colorado'),
+('Tesla', 'Model 3'),
+('Tesla', 'Model Y'),
+('Tesla', 'Model S'),
+('Tesla', 'Model X'),
+('Jeep', 'Wrangler'),
+('Dodge', 'Charger'),
+('Chrysler', '300'),
+('Cadillac', 'CT5'),
+('Buick', 'Encore'),
+('GMC', 'Sierra'),
+('Lincoln', 'Navigator'),
+('Hyundai', 'Tucson'),
+('Hyundai', 'Santa Fe'),
+('Hyundai', 'Ioniq 5'),
+('Hyundai', 'Kona'),
+('Hyundai', 'Staria'),
+('Kia', 'Sportage'),
+('Kia', 'Sorento'),
+('Kia', 'Carnival'),
+('Kia', 'Seltos'),
+('Kia', 'EV6'),
+('Genesis', 'G80'),
+('Peugeot', '3008'),
+('Peugeot', '5008'),
+('Peugeot', '2008'),
+('Peugeot', '408'),
+('Renault', 'Captur'),
+('Renault', 'Koleos'),
+('Citroen', 'C5 Aircross'),
+('Fiat', '500'),
+('Alfa Romeo', 'Giulia'),
+('Ferrari', 'Roma'),
+('Ferrari', '296 GTB'),
+('Ferrari', 'SF90'),
+('Lamborghini', 'Huracan'),
+('Lamborghini', 'Urus'),
+('Maserati', 'Ghibli'),
+('Maserati', 'Levante'),
+('Volvo', 'XC60'),
+('Volvo', 'XC40'),
+('Volvo', 'XC90'),
+('Volvo', 'S60'),
+('Volvo', 'EX30'),
+('Saab', '9-3'),
+('Jaguar', 'XE'),
+('Jaguar', 'F-Pace'),
+('Land Rover', 'Defender'),
+('Land Rover', 'Range Rover'),
+('Land Rover', 'Range Rover Sport'),
+('Land Rover', 'Range Rover Evoque'),
+('Land Rover', 'Discovery'),
+('Mini', 'Cooper'),
+('Mini', 'Countryman'),
+('Rolls-Royce', 'Ghost'),
+('Rolls-Royce', 'Cullinan'),
+('Rolls-Royce', 'Phantom'),
+('Bentley', 'Continental'),
+('Bentley', 'Bentayga'),
+('Bugatti', 'Chiron'),
+('McLaren', '720S'),
+('Aston Martin', 'DB11'),
+('Geely', 'Coolray'),
+('Chery', 'Tiggo 7'),
+('Chery', 'Tiggo 8'),
+('Chery', 'Omoda 5'),
+('BYD', 'Atto 3'),
+('BYD', 'Dolphin'),
+('BYD', 'Seal'),
+('BYD', 'Sealion 7'),
+('BYD', 'M6'),
+('Great Wall', 'Haval H6'),
+('Great Wall', 'Haval Jolion'),
+('Great Wall', 'Ora Good Cat'),
+('Perodua', 'Myvi'),
+('Perodua', 'Axia'),
+('Perodua', 'Bezza'),
+('Perodua', 'Alza'),
+('Perodua', 'Aruz'),
+('Perodua', 'Ativa'),
+('Proton', 'Saga'),
+('Proton', 'Persona'),
+('Proton', 'Iriz'),
+('Proton', 'Exora'),
+('Proton', 'X50'),
+('Proton', 'X70'),
+('Proton', 'X90'),
+('Proton', 'S70'),
+('Proton', 'e.MAS 7')
+) AS v(make_name, model_name)
+JOIN car_makes cm ON cm.name = v.make_name;
+
+INSERT INTO car_make_aliases (car_make_id, alias)
+SELECT cm.id, v.alias
+FROM (VALUES
+('Mercedes-Benz', 'Mercedes'),
+('Mercedes-Benz', 'Merc'),
+('Mercedes-Benz', 'Benz'),
+('Mercedes-Benz', 'MB'),
+('Volkswagen', 'VW'),
+('BMW', 'Bayerische Motoren Werke'),
+('Land Rover', 'Range Rover'),
+('Land Rover', 'LR'),
+('Rolls-Royce', 'Rolls Royce'),
+('Rolls-Royce', 'RR'),
+('Great Wall', 'GWM'),
+('Great Wall', 'Haval'),
+('Alfa Romeo', 'Alfa'),
+('Chevrolet', 'Chevy'),
+('Mini', 'MINI Cooper'),
+('Citroen', 'Citroën'),
+('Perodua', 'P2')
+) AS v(make_name, alias)
+JOIN car_makes cm ON cm.name = v.make_name;
+
+INSERT INTO car_model_aliases (car_model_id, alias)
+SELECT cmo.id, v.alias
+FROM (VALUES
+('Mercedes-Benz', 'C-Class', 'C Class'),
+('Mercedes-Benz', 'C-Class', 'C200'),
+('Mercedes-Benz', 'C-Class', 'C300'),
+('Mercedes-Benz', 'E-Class', 'E Class'),
+('Mercedes-Benz', 'E-Class', 'E200'),
+('Mercedes-Benz', 'E-Class', 'E300'),
+('Mercedes-Benz', 'S-Class', 'S Class'),
+('Mercedes-Benz', 'A-Class', 'A Class'),
+('Mercedes-Benz', 'A-Class', 'A200'),
+('Mercedes-Benz', 'A-Class', 'A250'),
+('BMW', '3 Series', '3-Series'),
+('BMW', '3 Series', '320i'),
+('BMW', '3 Series', '330i'),
+('BMW', '3 Series', '330e'),
+('BMW', '5 Series', '5-Series'),
+('BMW', '5 Series', '520i'),
+('BMW', '5 Series', '530i'),
+('BMW', '5 Series', '530e'),
+('BMW', '7 Series', '7-Series'),
+('BMW', '7 Series', '740Le'),
+('Toyota', 'Alphard', 'Alphard Hybrid'),
+('Toyota', 'Corolla Cross', 'Corolla-Cross'),
+('Honda', 'HR-V', 'HRV'),
+('Honda', 'CR-V', 'CRV'),
+('Honda', 'BR-V', 'BRV'),
+('Mazda', 'CX-5', 'CX5'),
+('Mazda', 'Mazda3', '3'),
+('Great Wall', 'Ora Good Cat', 'Good Cat')
+) AS v(make_name, model_name, alias)
+JOIN car_makes cm ON cm.name = v.make_name
+JOIN car_models cmo ON cmo.car_make_id = cm.id AND cmo.name = v.model_name;
+-- +goose StatementEnd
+
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS car_model_aliases CASCADE;
+DROP TABLE IF EXISTS car_make_aliases CASCADE;
+DROP TABLE IF EXISTS car_models CASCADE;
+DROP TABLE IF EXISTS car_makes CASCADE;
+-- +goose StatementEnd
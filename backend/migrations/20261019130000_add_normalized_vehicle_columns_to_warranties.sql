@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Search keys for plate and chassis numbers: upper-case letters and digits only, so that
+-- "wxy1234", "WXY 1234" and "WXY-1234" all match. Generated columns are filled for
+-- existing rows when they are added.
+ALTER TABLE warranties
+    ADD COLUMN IF NOT EXISTS car_plate_no_normalized VARCHAR(20) NOT NULL
+        GENERATED ALWAYS AS (UPPER(regexp_replace(car_plate_no, '[^A-Za-z0-9]', '', 'g'))) STORED,
+    ADD COLUMN IF NOT EXISTS car_chassis_no_normalized VARCHAR(50) NOT NULL
+        GENERATED ALWAYS AS (UPPER(regexp_replace(car_chassis_no, '[^A-Za-z0-9]', '', 'g'))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_warranties_car_plate_no_normalized ON warranties(car_plate_no_normalized);
+CREATE INDEX IF NOT EXISTS idx_warranties_car_chassis_no_normalized ON warranties(car_chassis_no_normalized);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_warranties_car_chassis_no_normalized;
+DROP INDEX IF EXISTS idx_warranties_car_plate_no_normalized;
+ALTER TABLE warranties
+    DROP COLUMN IF EXISTS car_chassis_no_normalized,
+    DROP COLUMN IF EXISTS car_plate_no_normalized;
+-- +goose StatementEnd
@@ -0,0 +1,75 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A vehicle is identified by its chassis number so that every warranty and claim of the
+-- same car can be found even after its plate number changes.
+CREATE TABLE IF NOT EXISTS vehicles (
+    id SERIAL PRIMARY KEY,
+    chassis_no VARCHAR(50) NOT NULL,
+    chassis_no_normalized VARCHAR(50) NOT NULL
+        GENERATED ALWAYS AS (UPPER(regexp_replace(chassis_no, '[^A-Za-z0-9]', '', 'g'))) STORED,
+    plate_no VARCHAR(20) NOT NULL,
+    car_brand VARCHAR(100) NOT NULL,
+    car_model VARCHAR(100) NOT NULL,
+    car_colour VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_vehicles_chassis_no_normalized ON vehicles(chassis_no_normalized);
+
+-- Every plate a vehicle has been registered under, with the installation dates of the
+-- first and last warranty that used it. vehicles.plate_no is the most recently seen plate.
+CREATE TABLE IF NOT EXISTS vehicle_plates (
+    id SERIAL PRIMARY KEY,
+    vehicle_id INT NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    plate_no VARCHAR(20) NOT NULL,
+    plate_no_normalized VARCHAR(20) NOT NULL
+        GENERATED ALWAYS AS (UPPER(regexp_replace(plate_no, '[^A-Za-z0-9]', '', 'g'))) STORED,
+    first_seen_on DATE NOT NULL,
+    last_seen_on DATE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (vehicle_id, plate_no_normalized)
+);
+
+CREATE INDEX idx_vehicle_plates_plate_no_normalized ON vehicle_plates(plate_no_normalized);
+
+ALTER TABLE warranties ADD COLUMN IF NOT EXISTS vehicle_id INT REFERENCES vehicles(id);
+
+CREATE INDEX IF NOT EXISTS idx_warranties_vehicle_id ON warranties(vehicle_id);
+
+-- Backfill vehicles from existing warranties, using the latest warranty for the details
+INSERT INTO vehicles (chassis_no, plate_no, car_brand, car_model, car_colour)
+SELECT DISTINCT ON (w.car_chassis_no_normalized)
+    w.car_chassis_no,
+    w.car_plate_no,
+    w.car_brand,
+    w.car_model,
+    w.car_colour
+FROM warranties w
+WHERE w.car_chassis_no_normalized <> ''
+ORDER BY w.car_chassis_no_normalized, w.installation_date DESC, w.id DESC;
+
+UPDATE warranties w
+SET vehicle_id = v.id
+FROM vehicles v
+WHERE v.chassis_no_normalized = w.car_chassis_no_normalized;
+
+INSERT INTO vehicle_plates (vehicle_id, plate_no, first_seen_on, last_seen_on)
+SELECT
+    w.vehicle_id,
+    (ARRAY_AGG(w.car_plate_no ORDER BY w.installation_date DESC, w.id DESC))[1],
+    MIN(w.installation_date),
+    MAX(w.installation_date)
+FROM warranties w
+WHERE w.vehicle_id IS NOT NULL
+GROUP BY w.vehicle_id, w.car_plate_no_normalized;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_warranties_vehicle_id;
+ALTER TABLE warranties DROP COLUMN IF EXISTS vehicle_id;
+DROP TABLE IF EXISTS vehicle_plates CASCADE;
+DROP TABLE IF EXISTS vehicles CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,72 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Customers are matched by their normalized phone number (E.164, e.g. +60123456789) and
+-- e-mail address. Duplicates found later are merged by HQ: the merged customer keeps its row
+-- with merged_into_id pointing at the customer that replaced it.
+CREATE TABLE IF NOT EXISTS customers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50) NOT NULL,
+    phone_normalized VARCHAR(20) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    email_normalized VARCHAR(255) NOT NULL
+        GENERATED ALWAYS AS (LOWER(TRIM(email))) STORED,
+    merged_into_id INT REFERENCES customers(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_customers_contact ON customers(phone_normalized, email_normalized) WHERE merged_into_id IS NULL;
+CREATE INDEX idx_customers_phone_normalized ON customers(phone_normalized);
+CREATE INDEX idx_customers_email_normalized ON customers(email_normalized);
+
+CREATE TABLE IF NOT EXISTS customer_merges (
+    id SERIAL PRIMARY KEY,
+    target_customer_id INT NOT NULL REFERENCES customers(id),
+    source_customer_id INT NOT NULL REFERENCES customers(id),
+    merged_by INT REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_customer_merges_target_customer_id ON customer_merges(target_customer_id);
+
+ALTER TABLE warranties ADD COLUMN IF NOT EXISTS customer_id INT REFERENCES customers(id);
+
+CREATE INDEX IF NOT EXISTS idx_warranties_customer_id ON warranties(customer_id);
+
+-- Backfill one customer per distinct phone number and e-mail address, using the latest
+-- warranty for the name. The phone expression mirrors utils.NormalizeMalaysianPhone.
+WITH contacts AS (
+    SELECT
+        w.id,
+        w.client_name,
+        w.client_contact,
+        w.client_email,
+        w.installation_date,
+        '+60' || regexp_replace(regexp_replace(w.client_contact, '[^0-9]', '', 'g'), '^(60|0)', '') AS phone_normalized,
+        LOWER(TRIM(w.client_email)) AS email_normalized
+    FROM warranties w
+)
+INSERT INTO customers (name, phone, phone_normalized, email)
+SELECT DISTINCT ON (c.phone_normalized, c.email_normalized)
+    c.client_name,
+    c.client_contact,
+    c.phone_normalized,
+    c.client_email
+FROM contacts c
+ORDER BY c.phone_normalized, c.email_normalized, c.installation_date DESC, c.id DESC;
+
+UPDATE warranties w
+SET customer_id = c.id
+FROM customers c
+WHERE c.phone_normalized = '+60' || regexp_replace(regexp_replace(w.client_contact, '[^0-9]', '', 'g'), '^(60|0)', '')
+    AND c.email_normalized = LOWER(TRIM(w.client_email));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_warranties_customer_id;
+ALTER TABLE warranties DROP COLUMN IF EXISTS customer_id;
+DROP TABLE IF EXISTS customer_merges CASCADE;
+DROP TABLE IF EXISTS customers CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One-time login codes for the customer portal. Codes are sent to the phone number or
+-- e-mail address on file and only their bcrypt hash is stored.
+CREATE TABLE IF NOT EXISTS customer_otps (
+    id SERIAL PRIMARY KEY,
+    channel VARCHAR(10) NOT NULL CHECK (channel IN ('email', 'sms')),
+    recipient VARCHAR(255) NOT NULL, -- normalized phone number or e-mail address
+    code_hash VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    consumed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_customer_otps_recipient ON customer_otps(channel, recipient, created_at DESC);
+
+-- Claims submitted by customers through the portal
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS submitted_by_customer_id INT REFERENCES customers(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE claims DROP COLUMN IF EXISTS submitted_by_customer_id;
+DROP TABLE IF EXISTS customer_otps;
+-- +goose StatementEnd
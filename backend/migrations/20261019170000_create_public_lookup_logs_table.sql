@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Unauthenticated warranty lookups, kept for abuse analysis. Requests blocked by the rate
+-- limiter are recorded once per client and window.
+CREATE TABLE IF NOT EXISTS public_lookup_logs (
+    id SERIAL PRIMARY KEY,
+    ip_address VARCHAR(64) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    search_term VARCHAR(255) NOT NULL DEFAULT '',
+    result_count INT NOT NULL DEFAULT 0,
+    outcome VARCHAR(30) NOT NULL
+        CHECK (outcome IN ('OK', 'RATE_LIMITED', 'CHALLENGE_REQUIRED', 'CHALLENGE_FAILED')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_public_lookup_logs_created_at ON public_lookup_logs(created_at);
+CREATE INDEX idx_public_lookup_logs_ip_address ON public_lookup_logs(ip_address, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public_lookup_logs;
+-- +goose StatementEnd
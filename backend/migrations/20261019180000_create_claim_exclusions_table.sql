@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Claim exclusions mark warranty parts that cannot be claimed even though the warranty is
+-- approved and in force, e.g. a film series that does not cover the windscreen. An exclusion
+-- applies to a warranty part when every criterion that is set matches the part; criteria left
+-- NULL match any part, but at least one criterion must be set.
+CREATE TABLE IF NOT EXISTS claim_exclusions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    product_type_id INT REFERENCES product_types(id),
+    product_series_id INT REFERENCES product_series(id),
+    product_name_id INT REFERENCES product_names(id),
+    car_part_id INT REFERENCES car_parts(id),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (
+        product_type_id IS NOT NULL
+        OR product_series_id IS NOT NULL
+        OR product_name_id IS NOT NULL
+        OR car_part_id IS NOT NULL
+    )
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS claim_exclusions;
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Claims move through an explicit lifecycle. Claim parts follow the status of their claim.
+UPDATE claims
+SET status = CASE
+    WHEN status ILIKE 'closed' THEN 'CLOSED'
+    WHEN approval_status = 'APPROVED' THEN 'APPROVED'
+    ELSE 'SUBMITTED'
+END;
+
+UPDATE claim_warranty_parts
+SET status = CASE
+    WHEN status ILIKE 'closed' THEN 'CLOSED'
+    WHEN approval_status = 'APPROVED' THEN 'APPROVED'
+    ELSE 'SUBMITTED'
+END;
+
+ALTER TABLE claims
+    ALTER COLUMN status SET DEFAULT 'SUBMITTED',
+    ADD CONSTRAINT claims_status_check CHECK (status IN (
+        'SUBMITTED', 'UNDER_REVIEW', 'APPROVED', 'AWAITING_PARTS', 'IN_REPAIR', 'RESOLVED', 'CLOSED', 'REOPENED'
+    ));
+
+ALTER TABLE claim_warranty_parts
+    ALTER COLUMN status SET DEFAULT 'SUBMITTED',
+    ADD CONSTRAINT claim_warranty_parts_status_check CHECK (status IN (
+        'SUBMITTED', 'UNDER_REVIEW', 'APPROVED', 'AWAITING_PARTS', 'IN_REPAIR', 'RESOLVED', 'CLOSED', 'REOPENED'
+    ));
+
+-- Every status change of a claim, including its creation (from_status NULL). Changes are made
+-- either by a user or by a customer on the portal.
+CREATE TABLE IF NOT EXISTS claim_status_transitions (
+    id SERIAL PRIMARY KEY,
+    claim_id INT NOT NULL REFERENCES claims(id),
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    changed_by_user_id INT REFERENCES users(id),
+    changed_by_customer_id INT REFERENCES customers(id),
+    actor_role VARCHAR(20) NOT NULL,
+    remarks TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_claim_status_transitions_claim_id ON claim_status_transitions(claim_id);
+
+INSERT INTO claim_status_transitions (claim_id, from_status, to_status, actor_role, remarks, created_at)
+SELECT id, NULL, status, 'system', 'Migrated to the claim lifecycle', COALESCE(updated_at, CURRENT_TIMESTAMP)
+FROM claims;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS claim_status_transitions;
+
+ALTER TABLE claim_warranty_parts
+    DROP CONSTRAINT IF EXISTS claim_warranty_parts_status_check,
+    ALTER COLUMN status SET DEFAULT 'Pending';
+
+ALTER TABLE claims
+    DROP CONSTRAINT IF EXISTS claims_status_check,
+    ALTER COLUMN status SET DEFAULT 'Open';
+
+UPDATE claim_warranty_parts SET status = CASE WHEN status = 'CLOSED' THEN 'Closed' ELSE 'Open' END;
+UPDATE claims SET status = CASE WHEN status = 'CLOSED' THEN 'Closed' ELSE 'Open' END;
+-- +goose StatementEnd
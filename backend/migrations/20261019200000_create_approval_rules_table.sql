@@ -0,0 +1,28 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- New enum values cannot be added inside a transaction block.
+ALTER TYPE warranty_approval_status ADD VALUE IF NOT EXISTS 'PARTIALLY_APPROVED';
+ALTER TYPE claim_approval_status ADD VALUE IF NOT EXISTS 'PARTIALLY_APPROVED';
+
+-- +goose StatementBegin
+-- Approval rules decide how the approval status of a warranty or claim is derived from the
+-- approval status of its parts. When every part is decided and the parts are a mix of
+-- approved and rejected, the parent takes mixed_status. When some parts are approved and
+-- others still pending, the parent is PARTIALLY_APPROVED if partial_while_pending is set and
+-- PENDING otherwise.
+CREATE TABLE IF NOT EXISTS approval_rules (
+    entity_type VARCHAR(20) PRIMARY KEY CHECK (entity_type IN ('WARRANTY', 'CLAIM')),
+    mixed_status VARCHAR(20) NOT NULL DEFAULT 'PARTIALLY_APPROVED'
+        CHECK (mixed_status IN ('PARTIALLY_APPROVED', 'APPROVED', 'REJECTED', 'PENDING')),
+    partial_while_pending BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+INSERT INTO approval_rules (entity_type) VALUES ('WARRANTY'), ('CLAIM') ON CONFLICT DO NOTHING;
+
+-- +goose Down
+-- Enum values cannot be dropped; partially approved rows fall back to pending.
+UPDATE warranties SET approval_status = 'PENDING' WHERE approval_status = 'PARTIALLY_APPROVED';
+UPDATE claims SET approval_status = 'PENDING' WHERE approval_status = 'PARTIALLY_APPROVED';
+DROP TABLE IF EXISTS approval_rules;
@@ -0,0 +1,143 @@
+-- +goose Up
+-- Weekend days per state as day-of-week numbers (0 = Sunday ... 6 = Saturday).
+-- Kedah, Kelantan and Terengganu observe a Friday and Saturday weekend.
+ALTER TABLE msia_states ADD COLUMN IF NOT EXISTS weekend_days INT[] NOT NULL DEFAULT '{0,6}';
+UPDATE msia_states SET weekend_days = '{5,6}' WHERE code IN ('KD', 'KN', 'TR');
+
+-- +goose StatementBegin
+-- Public holidays skipped when counting working days. A holiday without a state is observed
+-- nationwide; state holidays only affect shops in that state.
+CREATE TABLE IF NOT EXISTS public_holidays (
+    id SERIAL PRIMARY KEY,
+    holiday_date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    msia_state_id INT REFERENCES msia_states(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_public_holidays_date_state
+    ON public_holidays (holiday_date, COALESCE(msia_state_id, 0));
+
+-- Fixed-date holidays for 2026. Holidays that follow the lunar or Islamic calendar are
+-- gazetted yearly and maintained through the holidays API.
+INSERT INTO public_holidays (holiday_date, name, msia_state_id) VALUES
+('2026-02-17', 'Chinese New Year', NULL),
+('2026-02-18', 'Chinese New Year (Second Day)', NULL),
+('2026-05-01', 'Labour Day', NULL),
+('2026-06-01', 'Birthday of the Yang di-Pertuan Agong', NULL),
+('2026-08-31', 'National Day', NULL),
+('2026-09-16', 'Malaysia Day', NULL),
+('2026-12-25', 'Christmas Day', NULL)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public_holidays (holiday_date, name, msia_state_id)
+SELECT '2026-01-01', 'New Year''s Day', id FROM msia_states
+WHERE code NOT IN ('JH', 'KD', 'KN', 'PL', 'TR')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public_holidays (holiday_date, name, msia_state_id)
+SELECT '2026-02-01', 'Federal Territory Day', id FROM msia_states
+WHERE code IN ('KL', 'PJ')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public_holidays (holiday_date, name, msia_state_id)
+SELECT '2026-07-07', 'George Town World Heritage City Day', id FROM msia_states
+WHERE code = 'PG'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public_holidays (holiday_date, name, msia_state_id)
+SELECT '2026-07-22', 'Sarawak Day', id FROM msia_states
+WHERE code = 'SW'
+ON CONFLICT DO NOTHING;
+
+-- +goose StatementBegin
+-- SLA policies give the number of working days a claim may stay in a status before it is
+-- overdue. Statuses without an active policy are not tracked.
+CREATE TABLE IF NOT EXISTS claim_sla_policies (
+    status VARCHAR(50) PRIMARY KEY CHECK (status IN (
+        'SUBMITTED', 'UNDER_REVIEW', 'APPROVED', 'AWAITING_PARTS',
+        'IN_REPAIR', 'RESOLVED', 'CLOSED', 'REOPENED'
+    )),
+    working_days INT NOT NULL CHECK (working_days > 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+INSERT INTO claim_sla_policies (status, working_days) VALUES
+('SUBMITTED', 3),
+('UNDER_REVIEW', 3),
+('APPROVED', 5),
+('AWAITING_PARTS', 10),
+('IN_REPAIR', 5),
+('REOPENED', 3)
+ON CONFLICT DO NOTHING;
+
+-- The SLA clock of the claim's current status. sla_due_date is NULL when the status is not
+-- tracked; sla_escalated_at is cleared whenever the claim changes status.
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS sla_start_date DATE;
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS sla_due_date DATE;
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS sla_escalated_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_claims_sla_due_date ON claims (sla_due_date);
+
+-- The view is recreated so that c.* picks up the new claim columns. A claim is breached once
+-- its due date has passed in Malaysian time.
+DROP VIEW IF EXISTS claim_view;
+
+-- +goose StatementBegin
+CREATE VIEW claim_view AS
+SELECT
+    c.*,
+    w.shop_id,
+    w.client_name,
+    w.client_contact,
+    w.client_email,
+    w.car_brand,
+    w.car_model,
+    w.car_colour,
+    w.car_plate_no,
+    w.car_chassis_no,
+    w.installation_date,
+    w.reference_no,
+    w.warranty_no,
+    w.invoice_attachment_url,
+    (c.sla_due_date IS NOT NULL
+        AND c.sla_due_date < (CURRENT_TIMESTAMP AT TIME ZONE 'Asia/Kuala_Lumpur')::date) AS sla_breached
+FROM claims c
+JOIN warranties w ON c.warranty_id = w.id;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP VIEW IF EXISTS claim_view;
+DROP INDEX IF EXISTS idx_claims_sla_due_date;
+ALTER TABLE claims DROP COLUMN IF EXISTS sla_escalated_at;
+ALTER TABLE claims DROP COLUMN IF EXISTS sla_due_date;
+ALTER TABLE claims DROP COLUMN IF EXISTS sla_start_date;
+
+-- +goose StatementBegin
+CREATE VIEW claim_view AS
+SELECT
+    c.*,
+    w.shop_id,
+    w.client_name,
+    w.client_contact,
+    w.client_email,
+    w.car_brand,
+    w.car_model,
+    w.car_colour,
+    w.car_plate_no,
+    w.car_chassis_no,
+    w.installation_date,
+    w.reference_no,
+    w.warranty_no,
+    w.invoice_attachment_url
+FROM claims c
+JOIN warranties w ON c.warranty_id = w.id;
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS claim_sla_policies;
+DROP TABLE IF EXISTS public_holidays;
+ALTER TABLE msia_states DROP COLUMN IF EXISTS weekend_days;
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reimbursement statements bundle the resolution costs of a shop over a period for HQ to
+-- approve. Rejected statements release their resolutions for the next statement.
+CREATE TABLE IF NOT EXISTS shop_reimbursement_statements (
+    id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL REFERENCES shops(id),
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    total_labour_cost NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    total_material_cost NUMERIC(12, 2) NOT NULL DEFAULT 0,
+    resolution_count INT NOT NULL DEFAULT 0,
+    remarks TEXT,
+    created_by_user_id INT REFERENCES users(id),
+    reviewed_by_user_id INT REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (period_end >= period_start)
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_shop_reimbursement_statements_shop_id ON shop_reimbursement_statements(shop_id);
+
+-- +goose StatementBegin
+-- A resolution records the replacement film a shop installed for an approved claim part and
+-- what the repair cost. The quantity is taken from the shop's allocation balance.
+CREATE TABLE IF NOT EXISTS claim_resolutions (
+    id SERIAL PRIMARY KEY,
+    claim_warranty_part_id INT NOT NULL UNIQUE REFERENCES claim_warranty_parts(id) ON DELETE CASCADE,
+    product_allocation_id INT NOT NULL REFERENCES product_allocations(id),
+    quantity_used INT NOT NULL CHECK (quantity_used > 0),
+    labour_cost NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (labour_cost >= 0),
+    material_cost NUMERIC(12, 2) NOT NULL DEFAULT 0 CHECK (material_cost >= 0),
+    remarks TEXT,
+    reimbursement_statement_id INT REFERENCES shop_reimbursement_statements(id) ON DELETE SET NULL,
+    recorded_by_user_id INT REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_claim_resolutions_product_allocation_id ON claim_resolutions(product_allocation_id);
+CREATE INDEX IF NOT EXISTS idx_claim_resolutions_statement_id ON claim_resolutions(reimbursement_statement_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS claim_resolutions;
+DROP TABLE IF EXISTS shop_reimbursement_statements;
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Comments form a discussion thread on a warranty or a claim. Replies point at the comment
+-- they answer. Internal comments are HQ notes that shop users never see.
+CREATE TABLE IF NOT EXISTS comments (
+    id SERIAL PRIMARY KEY,
+    warranty_id INT REFERENCES warranties(id) ON DELETE CASCADE,
+    claim_id INT REFERENCES claims(id) ON DELETE CASCADE,
+    parent_comment_id INT REFERENCES comments(id) ON DELETE CASCADE,
+    author_user_id INT NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL,
+    is_internal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (num_nonnulls(warranty_id, claim_id) = 1)
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_comments_warranty_id ON comments(warranty_id);
+CREATE INDEX IF NOT EXISTS idx_comments_claim_id ON comments(claim_id);
+
+-- +goose StatementBegin
+-- Files attached to a comment, uploaded beforehand through the uploads service.
+CREATE TABLE IF NOT EXISTS comment_attachments (
+    id SERIAL PRIMARY KEY,
+    comment_id INT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    file_url TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_comment_attachments_comment_id ON comment_attachments(comment_id);
+
+-- +goose StatementBegin
+-- A row per comment a user has read; comments without a row count as unread.
+CREATE TABLE IF NOT EXISTS comment_reads (
+    comment_id INT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (comment_id, user_id)
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_comment_reads_user_id ON comment_reads(user_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS comment_reads;
+DROP TABLE IF EXISTS comment_attachments;
+DROP TABLE IF EXISTS comments;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Catalog levels are deactivated instead of deleted so that existing products keep their
+-- hierarchy. Names are unique, ignoring case, within their parent level.
+ALTER TABLE product_brands ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE product_types ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE product_series ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE product_names ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_brands_unique_name ON product_brands (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_types_unique_name ON product_types (brand_id, LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_series_unique_name ON product_series (type_id, LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_names_unique_name ON product_names (series_id, LOWER(name));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_product_names_unique_name;
+DROP INDEX IF EXISTS idx_product_series_unique_name;
+DROP INDEX IF EXISTS idx_product_types_unique_name;
+DROP INDEX IF EXISTS idx_product_brands_unique_name;
+
+ALTER TABLE product_names DROP COLUMN IF EXISTS is_active;
+ALTER TABLE product_series DROP COLUMN IF EXISTS is_active;
+ALTER TABLE product_types DROP COLUMN IF EXISTS is_active;
+ALTER TABLE product_brands DROP COLUMN IF EXISTS is_active;
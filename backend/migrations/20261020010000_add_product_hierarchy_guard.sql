@@ -0,0 +1,94 @@
+-- +goose Up
+-- Products store brand_id, type_id and series_id next to name_id. The name determines the
+-- rest of the chain, so reject any insert or update whose stored levels disagree with it.
+-- Existing inconsistent rows are left for `make repair-product-hierarchy` to report and fix.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION check_product_hierarchy()
+RETURNS TRIGGER AS $$
+DECLARE
+    expected_series_id INTEGER;
+    expected_type_id INTEGER;
+    expected_brand_id INTEGER;
+BEGIN
+    SELECT ps.id, pt.id, pb.id
+    INTO expected_series_id, expected_type_id, expected_brand_id
+    FROM product_names pn
+    JOIN product_series ps ON ps.id = pn.series_id
+    JOIN product_types pt ON pt.id = ps.type_id
+    JOIN product_brands pb ON pb.id = pt.brand_id
+    WHERE pn.id = NEW.name_id;
+
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'product name % does not exist', NEW.name_id
+            USING ERRCODE = 'foreign_key_violation';
+    END IF;
+
+    IF NEW.series_id IS DISTINCT FROM expected_series_id
+        OR NEW.type_id IS DISTINCT FROM expected_type_id
+        OR NEW.brand_id IS DISTINCT FROM expected_brand_id THEN
+        RAISE EXCEPTION 'product hierarchy (brand %, type %, series %) does not match product name % (brand %, type %, series %)',
+            NEW.brand_id, NEW.type_id, NEW.series_id, NEW.name_id,
+            expected_brand_id, expected_type_id, expected_series_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER products_check_hierarchy
+    BEFORE INSERT OR UPDATE OF brand_id, type_id, series_id, name_id ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION check_product_hierarchy();
+
+-- Moving a catalog level to another parent would silently break the chain of the products
+-- below it, so only allow it while no product references the level.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION check_product_catalog_reparent()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_TABLE_NAME = 'product_types' THEN
+        IF NEW.brand_id <> OLD.brand_id AND EXISTS (SELECT 1 FROM products WHERE type_id = OLD.id) THEN
+            RAISE EXCEPTION 'product type % is referenced by products and cannot change brand', OLD.id
+                USING ERRCODE = 'check_violation';
+        END IF;
+    ELSIF TG_TABLE_NAME = 'product_series' THEN
+        IF NEW.type_id <> OLD.type_id AND EXISTS (SELECT 1 FROM products WHERE series_id = OLD.id) THEN
+            RAISE EXCEPTION 'product series % is referenced by products and cannot change type', OLD.id
+                USING ERRCODE = 'check_violation';
+        END IF;
+    ELSIF TG_TABLE_NAME = 'product_names' THEN
+        IF NEW.series_id <> OLD.series_id AND EXISTS (SELECT 1 FROM products WHERE name_id = OLD.id) THEN
+            RAISE EXCEPTION 'product name % is referenced by products and cannot change series', OLD.id
+                USING ERRCODE = 'check_violation';
+        END IF;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER product_types_check_reparent
+    BEFORE UPDATE OF brand_id ON product_types
+    FOR EACH ROW
+    EXECUTE FUNCTION check_product_catalog_reparent();
+
+CREATE TRIGGER product_series_check_reparent
+    BEFORE UPDATE OF type_id ON product_series
+    FOR EACH ROW
+    EXECUTE FUNCTION check_product_catalog_reparent();
+
+CREATE TRIGGER product_names_check_reparent
+    BEFORE UPDATE OF series_id ON product_names
+    FOR EACH ROW
+    EXECUTE FUNCTION check_product_catalog_reparent();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS product_names_check_reparent ON product_names;
+DROP TRIGGER IF EXISTS product_series_check_reparent ON product_series;
+DROP TRIGGER IF EXISTS product_types_check_reparent ON product_types;
+DROP FUNCTION IF EXISTS check_product_catalog_reparent();
+DROP TRIGGER IF EXISTS products_check_hierarchy ON products;
+DROP FUNCTION IF EXISTS check_product_hierarchy();
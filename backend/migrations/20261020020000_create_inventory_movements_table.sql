@@ -0,0 +1,47 @@
+-- +goose Up
+-- Every change to film stock is recorded as a movement at a location: a shop, or HQ when
+-- shop_id is NULL. Quantities are signed, so the on-hand balance of a product at a location
+-- is the sum of its movements. Transfers such as allocations post one row at each end.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id),
+    shop_id INT REFERENCES shops(id),
+    movement_type VARCHAR(20) NOT NULL CHECK (movement_type IN ('RECEIPT', 'ALLOCATION', 'RETURN', 'CONSUMPTION', 'ADJUSTMENT', 'WRITE_OFF')),
+    quantity INT NOT NULL CHECK (quantity <> 0),
+    product_allocation_id INT REFERENCES product_allocations(id),
+    claim_resolution_id INT REFERENCES claim_resolutions(id) ON DELETE SET NULL,
+    remarks TEXT,
+    created_by_user_id INT REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_product_shop ON inventory_movements(product_id, shop_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_shop_id ON inventory_movements(shop_id);
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_product_allocation_id ON inventory_movements(product_allocation_id);
+
+-- Opening ledger from the existing data: each product was received at HQ, each allocation
+-- moved film from HQ to its shop and each claim resolution consumed film at the shop.
+INSERT INTO inventory_movements (product_id, shop_id, movement_type, quantity, remarks, created_at)
+SELECT id, NULL, 'RECEIPT', film_quantity, 'Opening balance', created_at
+FROM products
+WHERE film_quantity <> 0;
+
+INSERT INTO inventory_movements (product_id, shop_id, movement_type, quantity, product_allocation_id, remarks, created_at)
+SELECT product_id, NULL, 'ALLOCATION', -film_quantity, id, 'Opening balance', created_at
+FROM product_allocations
+WHERE film_quantity <> 0;
+
+INSERT INTO inventory_movements (product_id, shop_id, movement_type, quantity, product_allocation_id, remarks, created_at)
+SELECT product_id, shop_id, 'ALLOCATION', film_quantity, id, 'Opening balance', created_at
+FROM product_allocations
+WHERE film_quantity <> 0;
+
+INSERT INTO inventory_movements (product_id, shop_id, movement_type, quantity, product_allocation_id, claim_resolution_id, remarks, created_at)
+SELECT pa.product_id, pa.shop_id, 'CONSUMPTION', -cr.quantity_used, pa.id, cr.id, 'Opening balance', cr.created_at
+FROM claim_resolutions cr
+JOIN product_allocations pa ON pa.id = cr.product_allocation_id;
+
+-- +goose Down
+DROP TABLE IF EXISTS inventory_movements;
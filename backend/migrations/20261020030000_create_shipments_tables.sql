@@ -0,0 +1,61 @@
+-- +goose Up
+-- A shipment is a delivery from a supplier. Its lines are the film rolls on the packing list;
+-- receiving the shipment creates a product for each line and books it into HQ stock.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS shipments (
+    id SERIAL PRIMARY KEY,
+    shipment_number VARCHAR(255) NOT NULL UNIQUE,
+    supplier_name VARCHAR(255) NOT NULL DEFAULT '',
+    shipped_date DATE,
+    received_date DATE,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'RECEIVED')),
+    remarks TEXT,
+    created_by_user_id INT REFERENCES users(id),
+    received_by_user_id INT REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS shipment_lines (
+    id SERIAL PRIMARY KEY,
+    shipment_id INT NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    name_id INT NOT NULL REFERENCES product_names(id),
+    film_serial_number VARCHAR(255) NOT NULL UNIQUE,
+    film_quantity INT NOT NULL CHECK (film_quantity > 0),
+    warranty_in_months INT NOT NULL CHECK (warranty_in_months > 0),
+    description TEXT NOT NULL DEFAULT '',
+    product_id INT UNIQUE REFERENCES products(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_shipment_lines_shipment_id ON shipment_lines(shipment_id);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS shipment_id INT REFERENCES shipments(id);
+CREATE INDEX IF NOT EXISTS idx_products_shipment_id ON products(shipment_id);
+
+-- Existing products become received shipments grouped by their free-text shipment number.
+INSERT INTO shipments (shipment_number, received_date, status, remarks, created_at)
+SELECT shipment_number, MIN(created_at)::date, 'RECEIVED', 'Created from existing products', MIN(created_at)
+FROM products
+WHERE shipment_number <> ''
+GROUP BY shipment_number;
+
+UPDATE products p
+SET shipment_id = s.id
+FROM shipments s
+WHERE s.shipment_number = p.shipment_number;
+
+INSERT INTO shipment_lines (shipment_id, name_id, film_serial_number, film_quantity, warranty_in_months, description, product_id, created_at)
+SELECT shipment_id, name_id, film_serial_number, film_quantity, warranty_in_months, description, id, created_at
+FROM products
+WHERE shipment_id IS NOT NULL
+    AND film_quantity > 0
+    AND warranty_in_months > 0;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_products_shipment_id;
+ALTER TABLE products DROP COLUMN IF EXISTS shipment_id;
+DROP TABLE IF EXISTS shipment_lines;
+DROP TABLE IF EXISTS shipments;
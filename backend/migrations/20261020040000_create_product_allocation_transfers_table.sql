@@ -0,0 +1,33 @@
+-- +goose Up
+-- Film leaves a shop allocation either back to HQ (RETURN) or to another shop (TRANSFER). The
+-- original allocation is left as it was; a transfer opens a new allocation at the receiving
+-- shop so that its warranties and claims can draw on the film.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS product_allocation_transfers (
+    id SERIAL PRIMARY KEY,
+    product_allocation_id INT NOT NULL REFERENCES product_allocations(id),
+    transfer_type VARCHAR(20) NOT NULL CHECK (transfer_type IN ('RETURN', 'TRANSFER')),
+    film_quantity INT NOT NULL CHECK (film_quantity > 0),
+    to_shop_id INT REFERENCES shops(id),
+    to_product_allocation_id INT UNIQUE REFERENCES product_allocations(id),
+    transfer_date DATE NOT NULL,
+    remarks TEXT,
+    created_by_user_id INT REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((transfer_type = 'RETURN') = (to_shop_id IS NULL AND to_product_allocation_id IS NULL))
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_product_allocation_transfers_product_allocation_id ON product_allocation_transfers(product_allocation_id);
+CREATE INDEX IF NOT EXISTS idx_product_allocation_transfers_to_shop_id ON product_allocation_transfers(to_shop_id);
+
+ALTER TABLE inventory_movements DROP CONSTRAINT IF EXISTS inventory_movements_movement_type_check;
+ALTER TABLE inventory_movements ADD CONSTRAINT inventory_movements_movement_type_check
+    CHECK (movement_type IN ('RECEIPT', 'ALLOCATION', 'RETURN', 'TRANSFER', 'CONSUMPTION', 'ADJUSTMENT', 'WRITE_OFF'));
+
+-- +goose Down
+DELETE FROM inventory_movements WHERE movement_type = 'TRANSFER';
+ALTER TABLE inventory_movements DROP CONSTRAINT IF EXISTS inventory_movements_movement_type_check;
+ALTER TABLE inventory_movements ADD CONSTRAINT inventory_movements_movement_type_check
+    CHECK (movement_type IN ('RECEIPT', 'ALLOCATION', 'RETURN', 'CONSUMPTION', 'ADJUSTMENT', 'WRITE_OFF'));
+DROP TABLE IF EXISTS product_allocation_transfers;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- A recall flags defective film, either a single roll by its serial number or every roll of a
+-- shipment. The rolls covered are kept in recall_products when the recall is raised; while the
+-- recall is open and freezes installs, no new warranty part may draw on their allocations.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS recalls (
+    id SERIAL PRIMARY KEY,
+    recall_no VARCHAR(50) NOT NULL UNIQUE,
+    scope VARCHAR(20) NOT NULL CHECK (scope IN ('SERIAL', 'SHIPMENT')),
+    product_id INT REFERENCES products(id),
+    shipment_id INT REFERENCES shipments(id),
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'CLOSED')),
+    freeze_installs BOOLEAN NOT NULL DEFAULT TRUE,
+    customers_notified_at TIMESTAMP WITH TIME ZONE,
+    created_by_user_id INT REFERENCES users(id),
+    closed_by_user_id INT REFERENCES users(id),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((scope = 'SERIAL') = (product_id IS NOT NULL AND shipment_id IS NULL)),
+    CHECK ((scope = 'SHIPMENT') = (shipment_id IS NOT NULL AND product_id IS NULL))
+);
+
+CREATE TABLE IF NOT EXISTS recall_products (
+    recall_id INT NOT NULL REFERENCES recalls(id) ON DELETE CASCADE,
+    product_id INT NOT NULL REFERENCES products(id),
+    PRIMARY KEY (recall_id, product_id)
+);
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS idx_recall_products_product_id ON recall_products(product_id);
+CREATE INDEX IF NOT EXISTS idx_warranty_parts_product_allocation_id ON warranty_parts(product_allocation_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_warranty_parts_product_allocation_id;
+DROP TABLE IF EXISTS recall_products;
+DROP TABLE IF EXISTS recalls;
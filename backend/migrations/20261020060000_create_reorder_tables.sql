@@ -0,0 +1,53 @@
+-- +goose Up
+-- HQ sets, per shop and product name, the film balance below which a shop is running low. The
+-- daily low-stock check opens an alert for each threshold a shop is at or below and resolves
+-- it once the balance recovers, so that a shop is alerted once per shortage. Shops ask HQ for
+-- more film with a reorder request; approving a request allocates a roll to the shop.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS shop_stock_thresholds (
+    id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL REFERENCES shops(id),
+    name_id INT NOT NULL REFERENCES product_names(id),
+    min_quantity INT NOT NULL CHECK (min_quantity >= 0),
+    reorder_quantity INT NOT NULL DEFAULT 0 CHECK (reorder_quantity >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (shop_id, name_id)
+);
+
+CREATE TABLE IF NOT EXISTS low_stock_alerts (
+    id SERIAL PRIMARY KEY,
+    threshold_id INT NOT NULL REFERENCES shop_stock_thresholds(id) ON DELETE CASCADE,
+    balance INT NOT NULL,
+    min_quantity INT NOT NULL,
+    alerted_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS reorder_requests (
+    id SERIAL PRIMARY KEY,
+    shop_id INT NOT NULL REFERENCES shops(id),
+    name_id INT NOT NULL REFERENCES product_names(id),
+    requested_quantity INT NOT NULL CHECK (requested_quantity > 0),
+    remarks TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'CANCELLED')),
+    product_allocation_id INT UNIQUE REFERENCES product_allocations(id),
+    requested_by_user_id INT REFERENCES users(id),
+    reviewed_by_user_id INT REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_remarks TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((status = 'APPROVED') = (product_allocation_id IS NOT NULL))
+);
+-- +goose StatementEnd
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_low_stock_alerts_open ON low_stock_alerts(threshold_id) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reorder_requests_shop_id ON reorder_requests(shop_id);
+CREATE INDEX IF NOT EXISTS idx_reorder_requests_status ON reorder_requests(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS reorder_requests;
+DROP TABLE IF EXISTS low_stock_alerts;
+DROP TABLE IF EXISTS shop_stock_thresholds;
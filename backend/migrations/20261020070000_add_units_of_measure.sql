@@ -0,0 +1,121 @@
+-- +goose Up
+-- Film quantities are kept in rolls, the common base unit, as decimals so that part rolls can be
+-- allocated and consumed. Existing whole-number quantities were already counted in rolls and
+-- carry over unchanged. A product records how long its rolls are and how many sheet kits a roll
+-- cuts into, so that quantities entered in metres or sheets can be converted to rolls; its unit
+-- of measure is the unit it is normally handled in. Allocations and claim resolutions keep the
+-- quantity as it was entered next to its value in rolls.
+DROP VIEW IF EXISTS product_allocations_view;
+
+ALTER TABLE products ALTER COLUMN film_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unit_of_measure VARCHAR(10) NOT NULL DEFAULT 'ROLL'
+    CHECK (unit_of_measure IN ('ROLL', 'METRE', 'SHEET'));
+ALTER TABLE products ADD COLUMN IF NOT EXISTS roll_length_metres NUMERIC(10, 2)
+    CHECK (roll_length_metres > 0);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sheets_per_roll INT
+    CHECK (sheets_per_roll > 0);
+ALTER TABLE products ADD CONSTRAINT products_unit_of_measure_conversion_check
+    CHECK ((unit_of_measure <> 'METRE' OR roll_length_metres IS NOT NULL)
+        AND (unit_of_measure <> 'SHEET' OR sheets_per_roll IS NOT NULL));
+
+ALTER TABLE product_allocations ALTER COLUMN film_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE product_allocations ADD COLUMN IF NOT EXISTS unit_of_measure VARCHAR(10) NOT NULL DEFAULT 'ROLL'
+    CHECK (unit_of_measure IN ('ROLL', 'METRE', 'SHEET'));
+ALTER TABLE product_allocations ADD COLUMN IF NOT EXISTS unit_quantity NUMERIC(12, 4);
+UPDATE product_allocations SET unit_quantity = film_quantity;
+ALTER TABLE product_allocations ALTER COLUMN unit_quantity SET NOT NULL;
+
+ALTER TABLE claim_resolutions ALTER COLUMN quantity_used TYPE NUMERIC(12, 4);
+ALTER TABLE claim_resolutions ADD COLUMN IF NOT EXISTS unit_of_measure VARCHAR(10) NOT NULL DEFAULT 'ROLL'
+    CHECK (unit_of_measure IN ('ROLL', 'METRE', 'SHEET'));
+ALTER TABLE claim_resolutions ADD COLUMN IF NOT EXISTS unit_quantity NUMERIC(12, 4);
+UPDATE claim_resolutions SET unit_quantity = quantity_used;
+ALTER TABLE claim_resolutions ALTER COLUMN unit_quantity SET NOT NULL;
+
+ALTER TABLE inventory_movements ALTER COLUMN quantity TYPE NUMERIC(12, 4);
+ALTER TABLE product_allocation_transfers ALTER COLUMN film_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE shipment_lines ALTER COLUMN film_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE shop_stock_thresholds ALTER COLUMN min_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE shop_stock_thresholds ALTER COLUMN reorder_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE low_stock_alerts ALTER COLUMN balance TYPE NUMERIC(12, 4);
+ALTER TABLE low_stock_alerts ALTER COLUMN min_quantity TYPE NUMERIC(12, 4);
+ALTER TABLE reorder_requests ALTER COLUMN requested_quantity TYPE NUMERIC(12, 4);
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW product_allocations_view AS
+SELECT
+    pa.id AS allocation_id,
+    p.film_serial_number,
+    p.warranty_in_months,
+    pb.name AS product_brand,
+    pt.name AS product_type,
+    ps.name AS product_series,
+    pn.name AS product_name,
+    s.shop_name,
+    s.branch_code,
+    pa.film_quantity,
+    pa.allocation_date,
+    pa.created_at,
+    pa.updated_at,
+    pa.unit_of_measure,
+    pa.unit_quantity
+FROM product_allocations pa
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+JOIN product_series ps ON pn.series_id = ps.id
+JOIN product_types pt ON ps.type_id = pt.id
+JOIN product_brands pb ON pt.brand_id = pb.id
+JOIN shops s ON pa.shop_id = s.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- Part rolls are rounded up so that no allocated or consumed film is lost.
+DROP VIEW IF EXISTS product_allocations_view;
+
+ALTER TABLE reorder_requests ALTER COLUMN requested_quantity TYPE INT USING CEIL(requested_quantity);
+ALTER TABLE low_stock_alerts ALTER COLUMN min_quantity TYPE INT USING CEIL(min_quantity);
+ALTER TABLE low_stock_alerts ALTER COLUMN balance TYPE INT USING FLOOR(balance);
+ALTER TABLE shop_stock_thresholds ALTER COLUMN reorder_quantity TYPE INT USING CEIL(reorder_quantity);
+ALTER TABLE shop_stock_thresholds ALTER COLUMN min_quantity TYPE INT USING CEIL(min_quantity);
+ALTER TABLE shipment_lines ALTER COLUMN film_quantity TYPE INT USING CEIL(film_quantity);
+ALTER TABLE product_allocation_transfers ALTER COLUMN film_quantity TYPE INT USING CEIL(film_quantity);
+ALTER TABLE inventory_movements ALTER COLUMN quantity TYPE INT USING SIGN(quantity) * CEIL(ABS(quantity));
+
+ALTER TABLE claim_resolutions DROP COLUMN IF EXISTS unit_quantity;
+ALTER TABLE claim_resolutions DROP COLUMN IF EXISTS unit_of_measure;
+ALTER TABLE claim_resolutions ALTER COLUMN quantity_used TYPE INT USING CEIL(quantity_used);
+
+ALTER TABLE product_allocations DROP COLUMN IF EXISTS unit_quantity;
+ALTER TABLE product_allocations DROP COLUMN IF EXISTS unit_of_measure;
+ALTER TABLE product_allocations ALTER COLUMN film_quantity TYPE INT USING CEIL(film_quantity);
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_unit_of_measure_conversion_check;
+ALTER TABLE products DROP COLUMN IF EXISTS sheets_per_roll;
+ALTER TABLE products DROP COLUMN IF EXISTS roll_length_metres;
+ALTER TABLE products DROP COLUMN IF EXISTS unit_of_measure;
+ALTER TABLE products ALTER COLUMN film_quantity TYPE INT USING CEIL(film_quantity);
+
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW product_allocations_view AS
+SELECT
+    pa.id AS allocation_id,
+    p.film_serial_number,
+    p.warranty_in_months,
+    pb.name AS product_brand,
+    pt.name AS product_type,
+    ps.name AS product_series,
+    pn.name AS product_name,
+    s.shop_name,
+    s.branch_code,
+    pa.film_quantity,
+    pa.allocation_date,
+    pa.created_at,
+    pa.updated_at
+FROM product_allocations pa
+JOIN products p ON pa.product_id = p.id
+JOIN product_names pn ON p.name_id = pn.id
+JOIN product_series ps ON pn.series_id = ps.id
+JOIN product_types pt ON ps.type_id = pt.id
+JOIN product_brands pb ON pt.brand_id = pb.id
+JOIN shops s ON pa.shop_id = s.id;
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- A stock take is a shop's monthly count of its film. Opening one snapshots the remaining film
+-- of every allocation the shop holds into stock_take_lines; the shop then records what it
+-- physically counts. Variances beyond the tolerance are flagged for HQ review, and the approved
+-- variances are posted as adjustments that count towards the allocation's remaining film. While a
+-- stock take is open or submitted, the allocations on its lines cannot change.
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_takes (
+    id SERIAL PRIMARY KEY,
+    stock_take_no VARCHAR(50) NOT NULL UNIQUE,
+    shop_id INT NOT NULL REFERENCES shops(id),
+    period DATE NOT NULL CHECK (EXTRACT(DAY FROM period) = 1),
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'SUBMITTED', 'POSTED', 'CANCELLED')),
+    tolerance_percent NUMERIC(5, 2) NOT NULL DEFAULT 2 CHECK (tolerance_percent >= 0),
+    remarks TEXT,
+    opened_by_user_id INT REFERENCES users(id),
+    submitted_by_user_id INT REFERENCES users(id),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    posted_by_user_id INT REFERENCES users(id),
+    posted_at TIMESTAMP WITH TIME ZONE,
+    cancelled_by_user_id INT REFERENCES users(id),
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS stock_take_lines (
+    id SERIAL PRIMARY KEY,
+    stock_take_id INT NOT NULL REFERENCES stock_takes(id) ON DELETE CASCADE,
+    product_allocation_id INT NOT NULL REFERENCES product_allocations(id),
+    system_quantity NUMERIC(12, 4) NOT NULL,
+    counted_quantity NUMERIC(12, 4) CHECK (counted_quantity >= 0),
+    unit_of_measure VARCHAR(10) CHECK (unit_of_measure IN ('ROLL', 'METRE', 'SHEET')),
+    unit_quantity NUMERIC(12, 4),
+    variance_quantity NUMERIC(12, 4),
+    is_flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    review_status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (review_status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    adjusted_quantity NUMERIC(12, 4),
+    inventory_movement_id INT REFERENCES inventory_movements(id),
+    remarks TEXT,
+    counted_by_user_id INT REFERENCES users(id),
+    counted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by_user_id INT REFERENCES users(id),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_remarks TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (stock_take_id, product_allocation_id)
+);
+-- +goose StatementEnd
+
+-- One stock take per shop and month, and only one in progress per shop at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_takes_shop_id_period ON stock_takes(shop_id, period) WHERE status <> 'CANCELLED';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_takes_shop_id_in_progress ON stock_takes(shop_id) WHERE status IN ('OPEN', 'SUBMITTED');
+CREATE INDEX IF NOT EXISTS idx_stock_take_lines_product_allocation_id ON stock_take_lines(product_allocation_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS stock_take_lines;
+DROP TABLE IF EXISTS stock_takes;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- The film remaining on an allocation: the allocated quantity less what claim resolutions used
+-- and what was returned to HQ or transferred to another shop, corrected by posted stock-take
+-- adjustments. Every query that needs the balance joins this view so that it is defined once.
+-- Film used by warranty installs is subtracted too from 20261020100000 on, which records it.
+-- +goose StatementBegin
+CREATE OR REPLACE VIEW product_allocation_balances_view AS
+SELECT
+    pa.id AS product_allocation_id,
+    pa.shop_id,
+    pa.product_id,
+    pa.film_quantity AS allocated_quantity,
+    COALESCE(used.quantity, 0)::numeric AS consumed_quantity,
+    COALESCE(moved.quantity, 0)::numeric AS transferred_quantity,
+    COALESCE(adjusted.quantity, 0)::numeric AS adjusted_quantity,
+    (pa.film_quantity
+        - COALESCE(used.quantity, 0)
+        - COALESCE(moved.quantity, 0)
+        + COALESCE(adjusted.quantity, 0)
+    )::numeric AS remaining_quantity
+FROM product_allocations pa
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(quantity_used) AS quantity
+    FROM claim_resolutions
+    GROUP BY product_allocation_id
+) used ON used.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(film_quantity) AS quantity
+    FROM product_allocation_transfers
+    GROUP BY product_allocation_id
+) moved ON moved.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(adjusted_quantity) AS quantity
+    FROM stock_take_lines
+    GROUP BY product_allocation_id
+) adjusted ON adjusted.product_allocation_id = pa.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW IF EXISTS product_allocation_balances_view;
+-- +goose StatementEnd
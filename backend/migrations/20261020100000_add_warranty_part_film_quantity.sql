@@ -0,0 +1,99 @@
+-- +goose Up
+-- A warranty part records the film its install used, in rolls next to the quantity as it was
+-- entered, like claim resolutions. The install is booked as consumption at the shop in the
+-- inventory ledger and counts against the allocation's remaining film. Parts installed before
+-- quantities were recorded used an unknown amount of film and count as zero.
+DROP VIEW IF EXISTS product_allocation_balances_view;
+
+ALTER TABLE warranty_parts ADD COLUMN IF NOT EXISTS film_quantity NUMERIC(12, 4) NOT NULL DEFAULT 0
+    CHECK (film_quantity >= 0);
+ALTER TABLE warranty_parts ADD COLUMN IF NOT EXISTS unit_of_measure VARCHAR(10) NOT NULL DEFAULT 'ROLL'
+    CHECK (unit_of_measure IN ('ROLL', 'METRE', 'SHEET'));
+ALTER TABLE warranty_parts ADD COLUMN IF NOT EXISTS unit_quantity NUMERIC(12, 4) NOT NULL DEFAULT 0
+    CHECK (unit_quantity >= 0);
+
+ALTER TABLE inventory_movements ADD COLUMN IF NOT EXISTS warranty_part_id INT REFERENCES warranty_parts(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_inventory_movements_warranty_part_id ON inventory_movements(warranty_part_id);
+
+-- +goose StatementBegin
+CREATE VIEW product_allocation_balances_view AS
+SELECT
+    pa.id AS product_allocation_id,
+    pa.shop_id,
+    pa.product_id,
+    pa.film_quantity AS allocated_quantity,
+    COALESCE(used.quantity, 0)::numeric AS consumed_quantity,
+    COALESCE(installed.quantity, 0)::numeric AS installed_quantity,
+    COALESCE(moved.quantity, 0)::numeric AS transferred_quantity,
+    COALESCE(adjusted.quantity, 0)::numeric AS adjusted_quantity,
+    (pa.film_quantity
+        - COALESCE(used.quantity, 0)
+        - COALESCE(installed.quantity, 0)
+        - COALESCE(moved.quantity, 0)
+        + COALESCE(adjusted.quantity, 0)
+    )::numeric AS remaining_quantity
+FROM product_allocations pa
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(quantity_used) AS quantity
+    FROM claim_resolutions
+    GROUP BY product_allocation_id
+) used ON used.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(film_quantity) AS quantity
+    FROM warranty_parts
+    GROUP BY product_allocation_id
+) installed ON installed.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(film_quantity) AS quantity
+    FROM product_allocation_transfers
+    GROUP BY product_allocation_id
+) moved ON moved.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(adjusted_quantity) AS quantity
+    FROM stock_take_lines
+    GROUP BY product_allocation_id
+) adjusted ON adjusted.product_allocation_id = pa.id;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP VIEW IF EXISTS product_allocation_balances_view;
+
+DROP INDEX IF EXISTS idx_inventory_movements_warranty_part_id;
+ALTER TABLE inventory_movements DROP COLUMN IF EXISTS warranty_part_id;
+
+ALTER TABLE warranty_parts DROP COLUMN IF EXISTS unit_quantity;
+ALTER TABLE warranty_parts DROP COLUMN IF EXISTS unit_of_measure;
+ALTER TABLE warranty_parts DROP COLUMN IF EXISTS film_quantity;
+
+-- +goose StatementBegin
+CREATE VIEW product_allocation_balances_view AS
+SELECT
+    pa.id AS product_allocation_id,
+    pa.shop_id,
+    pa.product_id,
+    pa.film_quantity AS allocated_quantity,
+    COALESCE(used.quantity, 0)::numeric AS consumed_quantity,
+    COALESCE(moved.quantity, 0)::numeric AS transferred_quantity,
+    COALESCE(adjusted.quantity, 0)::numeric AS adjusted_quantity,
+    (pa.film_quantity
+        - COALESCE(used.quantity, 0)
+        - COALESCE(moved.quantity, 0)
+        + COALESCE(adjusted.quantity, 0)
+    )::numeric AS remaining_quantity
+FROM product_allocations pa
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(quantity_used) AS quantity
+    FROM claim_resolutions
+    GROUP BY product_allocation_id
+) used ON used.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(film_quantity) AS quantity
+    FROM product_allocation_transfers
+    GROUP BY product_allocation_id
+) moved ON moved.product_allocation_id = pa.id
+LEFT JOIN (
+    SELECT product_allocation_id, SUM(adjusted_quantity) AS quantity
+    FROM stock_take_lines
+    GROUP BY product_allocation_id
+) adjusted ON adjusted.product_allocation_id = pa.id;
+-- +goose StatementEnd
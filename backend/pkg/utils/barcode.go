@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Symbologies a scanned film roll label can be read from.
+const (
+	BarcodeSymbologyRaw        = "RAW"
+	BarcodeSymbologyGS1128     = "GS1-128"
+	BarcodeSymbologyDataMatrix = "GS1 DataMatrix"
+	BarcodeSymbologyQR         = "GS1 QR"
+)
+
+// gs1GroupSeparator is the ASCII GS character scanners transmit for FNC1 after a
+// variable-length element.
+const gs1GroupSeparator = '\x1d'
+
+// ErrInvalidBarcode is returned when a scanned payload is empty or is not a valid GS1 element
+// string.
+var ErrInvalidBarcode = errors.New("invalid barcode payload")
+
+// gs1SymbologyIdentifiers maps the AIM symbology identifiers that precede GS1 payloads.
+var gs1SymbologyIdentifiers = map[string]string{
+	"]C1": BarcodeSymbologyGS1128,
+	"]d2": BarcodeSymbologyDataMatrix,
+	"]Q3": BarcodeSymbologyQR,
+}
+
+// gs1BracketedElement matches one "(AI)value" pair of a human-readable GS1 element string.
+var gs1BracketedElement = regexp.MustCompile(`^\((\d{2,4})\)([^(]+)`)
+
+// gs1ApplicationIdentifier describes how long an application identifier and its value are.
+// A zero fixed length means the value runs to the next group separator, up to max characters.
+type gs1ApplicationIdentifier struct {
+	digits int
+	fixed  int
+	max    int
+}
+
+// gs1ApplicationIdentifiers lists the application identifiers found on film roll labels, keyed
+// by their first two digits.
+var gs1ApplicationIdentifiers = map[string]gs1ApplicationIdentifier{
+	"00": {digits: 2, fixed: 18},
+	"01": {digits: 2, fixed: 14},
+	"02": {digits: 2, fixed: 14},
+	"10": {digits: 2, max: 20},
+	"11": {digits: 2, fixed: 6},
+	"12": {digits: 2, fixed: 6},
+	"13": {digits: 2, fixed: 6},
+	"15": {digits: 2, fixed: 6},
+	"16": {digits: 2, fixed: 6},
+	"17": {digits: 2, fixed: 6},
+	"20": {digits: 2, fixed: 2},
+	"21": {digits: 2, max: 20},
+	"22": {digits: 2, max: 20},
+	"24": {digits: 3, max: 30},
+	"25": {digits: 3, max: 30},
+	"30": {digits: 2, max: 8},
+	"31": {digits: 4, fixed: 6},
+	"32": {digits: 4, fixed: 6},
+	"33": {digits: 4, fixed: 6},
+	"34": {digits: 4, fixed: 6},
+	"35": {digits: 4, fixed: 6},
+	"36": {digits: 4, fixed: 6},
+	"37": {digits: 2, max: 8},
+	"40": {digits: 3, max: 30},
+	"41": {digits: 3, fixed: 13},
+	"42": {digits: 3, max: 20},
+	"90": {digits: 2, max: 30},
+	"91": {digits: 2, max: 90},
+	"92": {digits: 2, max: 90},
+	"93": {digits: 2, max: 90},
+	"94": {digits: 2, max: 90},
+	"95": {digits: 2, max: 90},
+	"96": {digits: 2, max: 90},
+	"97": {digits: 2, max: 90},
+	"98": {digits: 2, max: 90},
+	"99": {digits: 2, max: 90},
+}
+
+// FilmLabel is the content of a scanned film roll label. Elements holds every GS1 element by
+// application identifier; it is empty for a raw serial.
+type FilmLabel struct {
+	Raw        string            `json:"raw"`
+	Symbology  string            `json:"symbology"`
+	GTIN       string            `json:"gtin,omitempty"`
+	Serial     string            `json:"serial,omitempty"`
+	Lot        string            `json:"lot,omitempty"`
+	ExpiryDate string            `json:"expiryDate,omitempty"` // Format: YYYY-MM-DD
+	Elements   map[string]string `json:"elements,omitempty"`
+}
+
+// ParseFilmLabel reads the payload of a scanned film roll label. GS1-128, GS1 DataMatrix and
+// GS1 QR payloads are recognised by their symbology identifier, a group separator or the
+// bracketed "(01)...(21)..." form; anything else is taken as a raw film serial number.
+func ParseFilmLabel(payload string) (*FilmLabel, error) {
+	raw := strings.TrimSpace(payload)
+	if raw == "" {
+		return nil, fmt.Errorf("%w: payload is empty", ErrInvalidBarcode)
+	}
+	label := &FilmLabel{Raw: raw, Symbology: BarcodeSymbologyRaw}
+
+	data := raw
+	isGS1 := false
+	if len(data) >= 3 && data[0] == ']' {
+		if symbology, ok := gs1SymbologyIdentifiers[data[:3]]; ok {
+			label.Symbology = symbology
+			isGS1 = true
+		}
+		// Other symbology identifiers carry a plain serial.
+		data = data[3:]
+	}
+	if strings.HasPrefix(data, "(") {
+		isGS1 = true
+	}
+	if strings.ContainsRune(data, gs1GroupSeparator) {
+		isGS1 = true
+	}
+	if !isGS1 {
+		label.Serial = strings.TrimSpace(data)
+		if label.Serial == "" {
+			return nil, fmt.Errorf("%w: payload has no serial number", ErrInvalidBarcode)
+		}
+		return label, nil
+	}
+	if label.Symbology == BarcodeSymbologyRaw {
+		label.Symbology = BarcodeSymbologyGS1128
+	}
+
+	var elements map[string]string
+	var err error
+	if strings.HasPrefix(data, "(") {
+		elements, err = parseGS1Bracketed(data)
+	} else {
+		elements, err = parseGS1ElementString(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	label.Elements = elements
+	label.Serial = elements["21"]
+	label.Lot = elements["10"]
+	if gtin, ok := elements["01"]; ok {
+		if !validGS1CheckDigit(gtin) {
+			return nil, fmt.Errorf("%w: GTIN %s has a wrong check digit", ErrInvalidBarcode, gtin)
+		}
+		label.GTIN = gtin
+	}
+	if expiry, ok := elements["17"]; ok {
+		date, err := parseGS1Date(expiry)
+		if err != nil {
+			return nil, err
+		}
+		label.ExpiryDate = date
+	}
+	if label.Serial == "" && label.Lot == "" {
+		return nil, fmt.Errorf("%w: GS1 payload has no serial (21) or batch (10)", ErrInvalidBarcode)
+	}
+	return label, nil
+}
+
+// FilmSerialNumber returns the value that identifies the roll: the GS1 serial, else the GS1
+// batch or lot, else the raw serial.
+func (l *FilmLabel) FilmSerialNumber() string {
+	if l.Serial != "" {
+		return l.Serial
+	}
+	return l.Lot
+}
+
+// parseGS1ElementString splits an unbracketed GS1 element string into its elements.
+func parseGS1ElementString(data string) (map[string]string, error) {
+	elements := make(map[string]string)
+	for len(data) > 0 {
+		if data[0] == gs1GroupSeparator {
+			data = data[1:]
+			continue
+		}
+		if len(data) < 2 {
+			return nil, fmt.Errorf("%w: truncated application identifier %q", ErrInvalidBarcode, data)
+		}
+		spec, ok := gs1ApplicationIdentifiers[data[:2]]
+		if !ok || len(data) < spec.digits {
+			return nil, fmt.Errorf("%w: unknown application identifier at %q", ErrInvalidBarcode, data)
+		}
+		ai := data[:spec.digits]
+		data = data[spec.digits:]
+
+		var value string
+		if spec.fixed > 0 {
+			if len(data) < spec.fixed {
+				return nil, fmt.Errorf("%w: (%s) needs %d characters", ErrInvalidBarcode, ai, spec.fixed)
+			}
+			value, data = data[:spec.fixed], data[spec.fixed:]
+		} else {
+			end := strings.IndexRune(data, gs1GroupSeparator)
+			if end < 0 {
+				end = len(data)
+			}
+			value, data = data[:end], data[end:]
+		}
+		if err := checkGS1Value(ai, value, spec); err != nil {
+			return nil, err
+		}
+		elements[ai] = value
+	}
+	return elements, nil
+}
+
+// parseGS1Bracketed splits a human-readable "(AI)value" element string into its elements.
+func parseGS1Bracketed(data string) (map[string]string, error) {
+	elements := make(map[string]string)
+	for len(data) > 0 {
+		m := gs1BracketedElement.FindStringSubmatch(data)
+		if m == nil {
+			return nil, fmt.Errorf("%w: expected (AI)value at %q", ErrInvalidBarcode, data)
+		}
+		ai, value := m[1], strings.TrimSpace(m[2])
+		spec, ok := gs1ApplicationIdentifiers[ai[:2]]
+		if !ok || len(ai) != spec.digits {
+			return nil, fmt.Errorf("%w: unknown application identifier (%s)", ErrInvalidBarcode, ai)
+		}
+		if err := checkGS1Value(ai, value, spec); err != nil {
+			return nil, err
+		}
+		elements[ai] = value
+		data = data[len(m[0]):]
+	}
+	return elements, nil
+}
+
+// checkGS1Value validates the length of an element value and that fixed-length values are digits.
+func checkGS1Value(ai, value string, spec gs1ApplicationIdentifier) error {
+	switch {
+	case value == "":
+		return fmt.Errorf("%w: (%s) is empty", ErrInvalidBarcode, ai)
+	case spec.fixed > 0 && len(value) != spec.fixed:
+		return fmt.Errorf("%w: (%s) needs %d characters", ErrInvalidBarcode, ai, spec.fixed)
+	case spec.fixed == 0 && len(value) > spec.max:
+		return fmt.Errorf("%w: (%s) is longer than %d characters", ErrInvalidBarcode, ai, spec.max)
+	}
+	if spec.fixed > 0 {
+		for _, c := range value {
+			if c < '0' || c > '9' {
+				return fmt.Errorf("%w: (%s) must be numeric", ErrInvalidBarcode, ai)
+			}
+		}
+	}
+	return nil
+}
+
+// validGS1CheckDigit reports whether the last digit of a GTIN or SSCC is its GS1 mod-10 check digit.
+func validGS1CheckDigit(digits string) bool {
+	sum := 0
+	for i := len(digits) - 2; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10-sum%10)%10 == int(digits[len(digits)-1]-'0')
+}
+
+// parseGS1Date converts a GS1 YYMMDD date to YYYY-MM-DD. A day of 00 means the last day of the
+// month. Years are placed in the century that keeps them closest to the current year.
+func parseGS1Date(value string) (string, error) {
+	var yy, mm, dd int
+	if _, err := fmt.Sscanf(value, "%2d%2d%2d", &yy, &mm, &dd); err != nil || mm < 1 || mm > 12 {
+		return "", fmt.Errorf("%w: invalid date %s", ErrInvalidBarcode, value)
+	}
+	year := time.Now().Year()/100*100 + yy
+	if year-time.Now().Year() > 50 {
+		year -= 100
+	} else if time.Now().Year()-year >= 50 {
+		year += 100
+	}
+	if dd == 0 {
+		return time.Date(year, time.Month(mm)+1, 0, 0, 0, 0, 0, time.UTC).Format("2006-01-02"), nil
+	}
+	date := time.Date(year, time.Month(mm), dd, 0, 0, 0, 0, time.UTC)
+	if date.Day() != dd {
+		return "", fmt.Errorf("%w: invalid date %s", ErrInvalidBarcode, value)
+	}
+	return date.Format("2006-01-02"), nil
+}
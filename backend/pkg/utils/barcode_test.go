@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestParseFilmLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    *FilmLabel
+		wantErr bool
+	}{
+		{
+			name:    "raw serial",
+			payload: "  PF2024-0001 ",
+			want:    &FilmLabel{Raw: "PF2024-0001", Symbology: BarcodeSymbologyRaw, Serial: "PF2024-0001"},
+		},
+		{
+			name:    "raw serial after a non-GS1 symbology identifier",
+			payload: "]C0PF2024-0001",
+			want:    &FilmLabel{Raw: "]C0PF2024-0001", Symbology: BarcodeSymbologyRaw, Serial: "PF2024-0001"},
+		},
+		{
+			name:    "GS1-128 with a group separator after the batch",
+			payload: "]C10109506000134352173112311012AB\x1d21ROLL42",
+			want: &FilmLabel{
+				Raw:        "]C10109506000134352173112311012AB\x1d21ROLL42",
+				Symbology:  BarcodeSymbologyGS1128,
+				GTIN:       "09506000134352",
+				Serial:     "ROLL42",
+				Lot:        "12AB",
+				ExpiryDate: "2031-12-31",
+				Elements:   map[string]string{"01": "09506000134352", "17": "311231", "10": "12AB", "21": "ROLL42"},
+			},
+		},
+		{
+			name:    "GS1 DataMatrix with a leading FNC1",
+			payload: "]d2\x1d0109506000134352\x1d21ROLL42",
+			want: &FilmLabel{
+				Raw:       "]d2\x1d0109506000134352\x1d21ROLL42",
+				Symbology: BarcodeSymbologyDataMatrix,
+				GTIN:      "09506000134352",
+				Serial:    "ROLL42",
+				Elements:  map[string]string{"01": "09506000134352", "21": "ROLL42"},
+			},
+		},
+		{
+			name:    "group separator without a symbology identifier",
+			payload: "10LOT7\x1d21ROLL42",
+			want: &FilmLabel{
+				Raw:       "10LOT7\x1d21ROLL42",
+				Symbology: BarcodeSymbologyGS1128,
+				Serial:    "ROLL42",
+				Lot:       "LOT7",
+				Elements:  map[string]string{"10": "LOT7", "21": "ROLL42"},
+			},
+		},
+		{
+			name:    "bracketed form",
+			payload: "(01)09506000134352(17)310200(21)ROLL42",
+			want: &FilmLabel{
+				Raw:        "(01)09506000134352(17)310200(21)ROLL42",
+				Symbology:  BarcodeSymbologyGS1128,
+				GTIN:       "09506000134352",
+				Serial:     "ROLL42",
+				ExpiryDate: "2031-02-28",
+				Elements:   map[string]string{"01": "09506000134352", "17": "310200", "21": "ROLL42"},
+			},
+		},
+		{
+			name:    "bracketed form after a QR symbology identifier",
+			payload: "]Q3(10)LOT7",
+			want: &FilmLabel{
+				Raw:       "]Q3(10)LOT7",
+				Symbology: BarcodeSymbologyQR,
+				Lot:       "LOT7",
+				Elements:  map[string]string{"10": "LOT7"},
+			},
+		},
+		{name: "empty payload", payload: "  ", wantErr: true},
+		{name: "symbology identifier only", payload: "]C0", wantErr: true},
+		{name: "wrong GTIN check digit", payload: "(01)09506000134353(21)ROLL42", wantErr: true},
+		{name: "GTIN too short", payload: "(01)0950600013435(21)ROLL42", wantErr: true},
+		{name: "GTIN not numeric", payload: "(01)0950600013435A(21)ROLL42", wantErr: true},
+		{name: "unknown application identifier", payload: "]C18012ABC", wantErr: true},
+		{name: "unknown bracketed application identifier", payload: "(80)12(21)ROLL42", wantErr: true},
+		{name: "serial longer than 20 characters", payload: "(21)ABCDEFGHIJKLMNOPQRSTU", wantErr: true},
+		{name: "empty element", payload: "]C121\x1d10LOT7", wantErr: true},
+		{name: "neither serial nor batch", payload: "(01)09506000134352", wantErr: true},
+		{name: "invalid month", payload: "(17)311301(21)ROLL42", wantErr: true},
+		{name: "invalid day", payload: "(17)310230(21)ROLL42", wantErr: true},
+		{name: "malformed bracketed form", payload: "(21)ROLL42(10", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFilmLabel(tt.payload)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBarcode) {
+					t.Fatalf("ParseFilmLabel(%q) error = %v, want ErrInvalidBarcode", tt.payload, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFilmLabel(%q) unexpected error: %v", tt.payload, err)
+			}
+			if got.Raw != tt.want.Raw || got.Symbology != tt.want.Symbology || got.GTIN != tt.want.GTIN ||
+				got.Serial != tt.want.Serial || got.Lot != tt.want.Lot || got.ExpiryDate != tt.want.ExpiryDate {
+				t.Errorf("ParseFilmLabel(%q) = %+v, want %+v", tt.payload, got, tt.want)
+			}
+			if !maps.Equal(got.Elements, tt.want.Elements) {
+				t.Errorf("ParseFilmLabel(%q) elements = %v, want %v", tt.payload, got.Elements, tt.want.Elements)
+			}
+		})
+	}
+}
+
+func TestFilmLabelFilmSerialNumber(t *testing.T) {
+	tests := []struct {
+		label *FilmLabel
+		want  string
+	}{
+		{&FilmLabel{Serial: "ROLL42", Lot: "LOT7"}, "ROLL42"},
+		{&FilmLabel{Lot: "LOT7"}, "LOT7"},
+		{&FilmLabel{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.label.FilmSerialNumber(); got != tt.want {
+			t.Errorf("%+v.FilmSerialNumber() = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestValidGS1CheckDigit(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{"09506000134352", true},
+		{"09506000134353", false},
+		{"00012345600012", true},
+		{"10614141000415", true},
+		{"10614141000416", false},
+		{"4006381333931", true},
+		{"106141410000000002", true},
+		{"106141410000000008", false},
+	}
+	for _, tt := range tests {
+		if got := validGS1CheckDigit(tt.digits); got != tt.want {
+			t.Errorf("validGS1CheckDigit(%q) = %v, want %v", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestParseGS1Date(t *testing.T) {
+	year := time.Now().Year()
+	yy := func(y int) string { return fmt.Sprintf("%02d", y%100) }
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "this year", value: yy(year) + "0615", want: fmt.Sprintf("%d-06-15", year)},
+		{name: "49 years ahead stays in the future", value: yy(year+49) + "0101", want: fmt.Sprintf("%d-01-01", year+49)},
+		{name: "50 years ahead stays in the future", value: yy(year+50) + "0101", want: fmt.Sprintf("%d-01-01", year+50)},
+		{name: "51 years ahead rolls back a century", value: yy(year+51) + "0101", want: fmt.Sprintf("%d-01-01", year-49)},
+		{name: "49 years back stays in the past", value: yy(year-49) + "0101", want: fmt.Sprintf("%d-01-01", year-49)},
+		{name: "day 00 is the last day of the month", value: yy(year) + "0400", want: fmt.Sprintf("%d-04-30", year)},
+		{name: "day 00 of December", value: yy(year) + "1200", want: fmt.Sprintf("%d-12-31", year)},
+		{name: "month 00", value: "310015", wantErr: true},
+		{name: "month 13", value: "311301", wantErr: true},
+		{name: "31 April", value: "310431", wantErr: true},
+		{name: "not numeric", value: "31AB01", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGS1Date(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidBarcode) {
+					t.Fatalf("parseGS1Date(%q) error = %v, want ErrInvalidBarcode", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseGS1Date(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseGS1Date(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaskName keeps the first letter of every word of a person's name, e.g. "Tan Ah Kow"
+// becomes "T** A* K**".
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(words, " ")
+}
+
+// MaskPhone masks every digit of a phone number except the first three and the last four,
+// keeping separators, e.g. "012-345 6789" becomes "012-*** 6789".
+func MaskPhone(phone string) string {
+	total := 0
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			total++
+		}
+	}
+
+	var b strings.Builder
+	seen := 0
+	for _, r := range phone {
+		if !unicode.IsDigit(r) {
+			b.WriteRune(r)
+			continue
+		}
+		seen++
+		if seen <= 3 || seen > total-4 {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('*')
+		}
+	}
+	return b.String()
+}
+
+// MaskEmail keeps the first character of the local part and the domain of an e-mail address,
+// e.g. "ahkow.tan@example.com" becomes "a***@example.com".
+func MaskEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return strings.Repeat("*", len(email))
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// MaskChassisNo keeps only the last four characters of a chassis number.
+func MaskChassisNo(chassisNo string) string {
+	chassisNo = strings.TrimSpace(chassisNo)
+	if len(chassisNo) <= 4 {
+		return strings.Repeat("*", len(chassisNo))
+	}
+	return strings.Repeat("*", len(chassisNo)-4) + chassisNo[len(chassisNo)-4:]
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizeMalaysianPhone converts a Malaysian phone number (e.g. "012-345 6789",
+// "+6012-3456789", "60123456789") to E.164 format ("+60123456789").
+func NormalizeMalaysianPhone(phone string) (string, error) {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	number := digits.String()
+
+	switch {
+	case strings.HasPrefix(number, "60"):
+		number = number[2:]
+	case strings.HasPrefix(number, "0"):
+		number = number[1:]
+	}
+
+	// Malaysian subscriber numbers are 8 to 10 digits without the trunk prefix.
+	if len(number) < 8 || len(number) > 10 {
+		return "", fmt.Errorf("invalid Malaysian phone number: %q", phone)
+	}
+
+	return "+60" + number, nil
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// malaysianSpecialPlateSeries are the special (vanity) series issued by JPJ whose prefix
+// does not follow the one to three letter layout. Longer series are matched first.
+var malaysianSpecialPlateSeries = []string{
+	"XIIINAM",
+	"PUTRAJAYA",
+	"MALAYSIA",
+	"PATRIOT",
+	"BAMBEE",
+	"SUKOM",
+	"GOLD",
+	"LIMO",
+	"NAAM",
+	"XOIC",
+	"1M4U",
+	"G1M",
+	"K1M",
+}
+
+var (
+	// prefix of one to three letters, a number without leading zeros and an optional suffix
+	malaysianPlatePattern = regexp.MustCompile(`^([A-Z]{1,3})([1-9][0-9]{0,3})([A-Z]{0,2})$`)
+	// remainder of a special series plate after the series name
+	malaysianSpecialPlateNumberPattern = regexp.MustCompile(`^([1-9][0-9]{0,3})([A-Z]?)$`)
+	// chassis / frame numbers that are not 17 character VINs, e.g. "ZVW30-1234567"
+	chassisNoPattern = regexp.MustCompile(`^[A-Z0-9]+(-[A-Z0-9]+)*$`)
+)
+
+// VehicleSearchKey reduces a plate or chassis number to upper-case letters and digits,
+// e.g. "wxy-1234" and "WXY 1234" both become "WXY1234". It matches the normalized
+// columns stored on warranties.
+func VehicleSearchKey(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// NormalizeMalaysianPlate validates a Malaysian registration plate and returns it in the
+// canonical "WXY 1234 A" layout. Peninsular, Sabah (S), Sarawak (Q), taxi (H), military (Z)
+// and trade plates follow the prefix, number and suffix layout; special series such as
+// "PUTRAJAYA 1234" or "1M4U 88" are also accepted.
+func NormalizeMalaysianPlate(plate string) (string, error) {
+	key := VehicleSearchKey(plate)
+
+	for _, series := range malaysianSpecialPlateSeries {
+		if !strings.HasPrefix(key, series) {
+			continue
+		}
+		if m := malaysianSpecialPlateNumberPattern.FindStringSubmatch(key[len(series):]); m != nil {
+			return joinPlateParts(series, m[1], m[2]), nil
+		}
+	}
+
+	if m := malaysianPlatePattern.FindStringSubmatch(key); m != nil {
+		return joinPlateParts(m[1], m[2], m[3]), nil
+	}
+
+	return "", fmt.Errorf("invalid Malaysian plate number: %q", plate)
+}
+
+func joinPlateParts(parts ...string) string {
+	nonEmpty := parts[:0]
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
+
+// NormalizeChassisNo validates a chassis number and returns it in upper case without spaces.
+// 17 character numbers are treated as VINs: they may not contain I, O or Q, and the check
+// digit in position 9 is verified for North American (1-5) and Chinese (L) manufacturers,
+// which are required to use it. Shorter numbers, such as Japanese frame numbers
+// ("ZVW30-1234567"), may contain letters, digits and hyphens.
+func NormalizeChassisNo(chassisNo string) (string, error) {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(chassisNo), ""))
+
+	if len(normalized) == 17 && !strings.Contains(normalized, "-") {
+		if strings.ContainsAny(normalized, "IOQ") {
+			return "", fmt.Errorf("invalid VIN %q: I, O and Q are not allowed", chassisNo)
+		}
+		if !chassisNoPattern.MatchString(normalized) {
+			return "", fmt.Errorf("invalid VIN: %q", chassisNo)
+		}
+		if strings.ContainsRune("12345L", rune(normalized[0])) {
+			if check := vinCheckDigit(normalized); normalized[8] != check {
+				return "", fmt.Errorf("invalid VIN %q: check digit should be %c", chassisNo, check)
+			}
+		}
+		return normalized, nil
+	}
+
+	if len(normalized) < 6 || len(normalized) > 50 || !chassisNoPattern.MatchString(normalized) {
+		return "", fmt.Errorf("invalid chassis number: %q", chassisNo)
+	}
+	return normalized, nil
+}
+
+// vinCheckDigit computes the ISO 3779 / FMVSS 115 check digit of an upper-case 17 character VIN.
+func vinCheckDigit(vin string) byte {
+	weights := [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		sum += vinCharValue(vin[i]) * weights[i]
+	}
+	if r := sum % 11; r != 10 {
+		return byte('0' + r)
+	}
+	return 'X'
+}
+
+func vinCharValue(c byte) int {
+	if c >= '0' && c <= '9' {
+		return int(c - '0')
+	}
+	// A-H = 1-8, J-N = 1-5, P = 7, R = 9, S-Z = 2-9
+	return int("12345678_12345_7_923456789"[c-'A'] - '0')
+}
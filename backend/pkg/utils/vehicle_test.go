@@ -0,0 +1,128 @@
+package utils
+
+import "testing"
+
+func TestVehicleSearchKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"wxy-1234", "WXY1234"},
+		{"WXY 1234 a", "WXY1234A"},
+		{" zvw30-1234567 ", "ZVW301234567"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := VehicleSearchKey(tt.in); got != tt.want {
+			t.Errorf("VehicleSearchKey(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeMalaysianPlate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plate   string
+		want    string
+		wantErr bool
+	}{
+		{name: "peninsular", plate: "wxy1234", want: "WXY 1234"},
+		{name: "peninsular with suffix", plate: "WXY-1234-A", want: "WXY 1234 A"},
+		{name: "single letter prefix", plate: "b 1", want: "B 1"},
+		{name: "sabah", plate: "SAB 1234 A", want: "SAB 1234 A"},
+		{name: "sarawak", plate: "QAA 123", want: "QAA 123"},
+		{name: "taxi", plate: "HWA 1234", want: "HWA 1234"},
+		{name: "military", plate: "ZB 1234", want: "ZB 1234"},
+		{name: "two letter suffix", plate: "W 1234 AB", want: "W 1234 AB"},
+		{name: "special series", plate: "putrajaya 1234", want: "PUTRAJAYA 1234"},
+		{name: "special series with suffix", plate: "MALAYSIA 88 A", want: "MALAYSIA 88 A"},
+		{name: "special series with digits in its name", plate: "1M4U88", want: "1M4U 88"},
+		{name: "longer special series matched first", plate: "XIIINAM 8", want: "XIIINAM 8"},
+		{name: "short special series", plate: "G1M 1", want: "G1M 1"},
+		{name: "special series without number", plate: "PATRIOT", wantErr: true},
+		{name: "special series with two letter suffix", plate: "GOLD 1 AB", wantErr: true},
+		{name: "leading zero", plate: "WXY 0123", wantErr: true},
+		{name: "number too long", plate: "WXY 12345", wantErr: true},
+		{name: "prefix too long", plate: "WXYZ 1234", wantErr: true},
+		{name: "no prefix", plate: "1234", wantErr: true},
+		{name: "empty", plate: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMalaysianPlate(tt.plate)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NormalizeMalaysianPlate(%q) = %q, want an error", tt.plate, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeMalaysianPlate(%q) unexpected error: %v", tt.plate, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeMalaysianPlate(%q) = %q, want %q", tt.plate, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeChassisNo(t *testing.T) {
+	tests := []struct {
+		name      string
+		chassisNo string
+		want      string
+		wantErr   bool
+	}{
+		{name: "north american VIN", chassisNo: "1m8gdm9axkp042788", want: "1M8GDM9AXKP042788"},
+		{name: "north american VIN with spaces", chassisNo: "5YJ3E1EA2 KF317000", want: "5YJ3E1EA2KF317000"},
+		{name: "chinese VIN", chassisNo: "LSGPC52U6AF102554", want: "LSGPC52U6AF102554"},
+		{name: "north american VIN with wrong check digit", chassisNo: "5YJ3E1EA7KF317000", wantErr: true},
+		{name: "chinese VIN with wrong check digit", chassisNo: "LSGPC52U0AF102554", wantErr: true},
+		{name: "european VIN is not check digit verified", chassisNo: "WVWZZZ1JZXW000001", want: "WVWZZZ1JZXW000001"},
+		{name: "japanese VIN is not check digit verified", chassisNo: "JT2BG22K1W0123456", want: "JT2BG22K1W0123456"},
+		{name: "VIN with I", chassisNo: "WVWZZZ1JZXI000001", wantErr: true},
+		{name: "VIN with O", chassisNo: "WVWZZZ1JZXO000001", wantErr: true},
+		{name: "VIN with Q", chassisNo: "WVWZZZ1JZXQ000001", wantErr: true},
+		{name: "VIN with punctuation", chassisNo: "WVWZZZ1JZX/000001", wantErr: true},
+		{name: "japanese frame number", chassisNo: "zvw30-1234567", want: "ZVW30-1234567"},
+		{name: "17 characters with a hyphen", chassisNo: "ABCDEFG-123456789", want: "ABCDEFG-123456789"},
+		{name: "short chassis number", chassisNo: "ABC12", wantErr: true},
+		{name: "leading hyphen", chassisNo: "-ZVW301234567", wantErr: true},
+		{name: "double hyphen", chassisNo: "ZVW30--1234567", wantErr: true},
+		{name: "empty", chassisNo: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeChassisNo(tt.chassisNo)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NormalizeChassisNo(%q) = %q, want an error", tt.chassisNo, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeChassisNo(%q) unexpected error: %v", tt.chassisNo, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeChassisNo(%q) = %q, want %q", tt.chassisNo, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVinCheckDigit(t *testing.T) {
+	tests := []struct {
+		vin  string
+		want byte
+	}{
+		{"1M8GDM9AXKP042788", 'X'},
+		{"11111111111111111", '1'},
+		{"5YJ3E1EA2KF317000", '2'},
+		{"LSGPC52U6AF102554", '6'},
+	}
+	for _, tt := range tests {
+		if got := vinCheckDigit(tt.vin); got != tt.want {
+			t.Errorf("vinCheckDigit(%q) = %c, want %c", tt.vin, got, tt.want)
+		}
+	}
+}
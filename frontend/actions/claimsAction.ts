@@ -86,11 +86,13 @@ export async function updateClaimWarrantyPartApprovalAction(
 
 export async function updateClaimStatusAction(
   claimId: number,
-  isOpen: boolean,
+  status: string,
+  remarks?: string,
 ) {
   try {
     const response = await apiClient.put(`/claims/${claimId}/status`, {
-      isOpen,
+      status,
+      remarks,
     });
     return { success: true, data: response.data };
   } catch (error: any) {
@@ -98,33 +100,12 @@ export async function updateClaimStatusAction(
       status: error.response?.status,
       data: error.response?.data,
     });
-    return {
-      success: false,
-      error: error.response?.data?.message || "Failed to update claim status",
-    };
-  }
-}
-
-export async function updateClaimWarrantyPartStatusAction(
-  partId: number,
-  isOpen: boolean,
-) {
-  try {
-    const response = await apiClient.put(
-      `/claims/claim-warranty-parts/${partId}/status`,
-      { isOpen },
-    );
-    return { success: true, data: response.data };
-  } catch (error: any) {
-    console.error("Error updating claim warranty part status:", {
-      status: error.response?.status,
-      data: error.response?.data,
-    });
     return {
       success: false,
       error:
+        error.response?.data?.error ||
         error.response?.data?.message ||
-        "Failed to update claim warranty part status",
+        "Failed to update claim status",
     };
   }
 }
@@ -333,11 +333,6 @@ export default function ClaimForm({
               part.damagedImageUrl,
             status: part.status || "pending",
             remarks: part.remarks || "",
-            resolutionDate: part.resolutionDate || "",
-            resolutionImageUrl:
-              uploadedResolutionUrls.get(part.warrantyPartId) ||
-              part.resolutionImageUrl ||
-              "",
           }),
         );
 
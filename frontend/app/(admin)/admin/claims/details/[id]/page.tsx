@@ -10,7 +10,6 @@ import {
   updateClaimApprovalAction,
   updateClaimWarrantyPartApprovalAction,
   updateClaimStatusAction,
-  updateClaimWarrantyPartStatusAction,
 } from "@/actions/claimsAction";
 
 export default function ClaimDetailsPage() {
@@ -24,7 +23,7 @@ export default function ClaimDetailsPage() {
   const [enlargedImage, setEnlargedImage] = useState<string | null>(null);
   const [confirmModal, setConfirmModal] = useState<{
     show: boolean;
-    type: "claim-approval" | "claim-status" | "part-approval" | "part-status";
+    type: "claim-approval" | "claim-status" | "part-approval";
     id: number;
     currentValue: string;
     title: string;
@@ -76,19 +75,18 @@ export default function ClaimDetailsPage() {
         case "claim-status":
           result = await updateClaimStatusAction(
             confirmModal.id,
-            newValue.toLowerCase() === "open",
+            newValue,
+            newValue === "REOPENED" ? "Reopened from claim details" : undefined,
           );
-          console.log("Update claim status result:", newValue);
           if (result.success) {
             setClaimData({
               ...claimData,
               claim: {
                 ...claimData.claim,
-                status: newValue ? "open" : "closed",
+                status: newValue,
               },
             });
             showToast("Claim status updated successfully", "success");
-            // router.refresh();
           }
           break;
 
@@ -109,25 +107,6 @@ export default function ClaimDetailsPage() {
           }
           break;
 
-        case "part-status":
-          result = await updateClaimWarrantyPartStatusAction(
-            confirmModal.id,
-            newValue.toLowerCase() === "open",
-          );
-          if (result.success) {
-            setClaimData({
-              ...claimData,
-              parts: claimData.parts.map((p) =>
-                p.id === confirmModal.id
-                  ? { ...p, status: newValue ? "open" : "closed" }
-                  : p,
-              ),
-            });
-            showToast("Part status updated successfully", "success");
-            // refresh the page to reflect changes
-            // router.refresh();
-          }
-          break;
       }
 
       if (!result?.success) {
@@ -482,15 +461,14 @@ export default function ClaimDetailsPage() {
                           show: true,
                           type: "claim-status",
                           id: claimData.claim.id,
-                          currentValue: "open",
-                          // claimData.claim.status.toLowerCase() === "open",
+                          currentValue: "REOPENED",
                           title: "Open Claim?",
                           message: "Are you sure you want to open this claim?",
                         })
                       }
-                      disabled={claimData.claim.status.toLowerCase() === "open"}
+                      disabled={claimData.claim.status !== "CLOSED"}
                       className={`inline-flex items-center gap-1.5 px-3 py-2 text-sm font-medium rounded-lg transition-colors ${
-                        claimData.claim.status.toLowerCase() === "open"
+                        claimData.claim.status !== "CLOSED"
                           ? "bg-blue-100 text-blue-800   cursor-not-allowed opacity-60"
                           : "bg-blue-600 text-white hover:bg-blue-700"
                       }`}
@@ -508,7 +486,7 @@ export default function ClaimDetailsPage() {
                           d="M13.5 10.5V6.75a4.5 4.5 0 119 0v3.75M3.75 21.75h10.5a2.25 2.25 0 002.25-2.25v-6.75a2.25 2.25 0 00-2.25-2.25H3.75a2.25 2.25 0 00-2.25 2.25v6.75a2.25 2.25 0 002.25 2.25z"
                         />
                       </svg>
-                      {claimData.claim.status === "open" ? "Open" : "Open"}
+                      {claimData.claim.status !== "CLOSED" ? "Open" : "Reopen"}
                     </button>
                     <button
                       onClick={() =>
@@ -516,16 +494,16 @@ export default function ClaimDetailsPage() {
                           show: true,
                           type: "claim-status",
                           id: claimData.claim.id,
-                          currentValue: "closed",
+                          currentValue: "CLOSED",
                           title: "Close Claim?",
                           message: "Are you sure you want to close this claim?",
                         })
                       }
                       disabled={
-                        claimData.claim.status.toLowerCase() === "closed"
+                        claimData.claim.status === "CLOSED"
                       }
                       className={`inline-flex items-center gap-1.5 px-3 py-2 text-sm font-medium rounded-lg transition-colors ${
-                        claimData.claim.status.toLowerCase() === "closed"
+                        claimData.claim.status === "CLOSED"
                           ? "bg-gray-100 text-gray-800   cursor-not-allowed opacity-60"
                           : "bg-red-600 text-white hover:bg-red-700"
                       }`}
@@ -543,7 +521,7 @@ export default function ClaimDetailsPage() {
                           d="M16.5 10.5V6.75a4.5 4.5 0 10-9 0v3.75m-.75 11.25h10.5a2.25 2.25 0 002.25-2.25v-6.75a2.25 2.25 0 00-2.25-2.25H6.75a2.25 2.25 0 00-2.25 2.25v6.75a2.25 2.25 0 002.25 2.25z"
                         />
                       </svg>
-                      {claimData.claim.status === "closed" ? "Closed" : "Close"}
+                      {claimData.claim.status === "CLOSED" ? "Closed" : "Close"}
                     </button>
                   </div>
                 </div>
@@ -862,82 +840,6 @@ export default function ClaimDetailsPage() {
                           </div>
                         </div>
                       )}
-                      {/* Status Actions - User Only */}
-                      {user?.role === "shop_admin" && (
-                        <div>
-                          <label className="block text-xs text-gray-500  mb-1.5">
-                            Status
-                          </label>
-                          <div className="flex flex-wrap gap-2">
-                            <button
-                              onClick={() =>
-                                setConfirmModal({
-                                  show: true,
-                                  type: "part-status",
-                                  id: part.id,
-                                  currentValue: "open",
-                                  title: "Open Part?",
-                                  message: `Are you sure you want to open"${part.carPartName}"?`,
-                                })
-                              }
-                              disabled={part.status.toLowerCase() === "open"}
-                              className={`inline-flex items-center gap-1.5 px-3 py-1.5 text-xs font-medium rounded-lg transition-colors ${
-                                part.status.toLowerCase() === "open"
-                                  ? "bg-blue-100 text-blue-800   cursor-not-allowed opacity-60"
-                                  : "bg-blue-600 text-white hover:bg-blue-700"
-                              }`}
-                            >
-                              <svg
-                                className="w-3.5 h-3.5"
-                                fill="none"
-                                viewBox="0 0 24 24"
-                                strokeWidth="2"
-                                stroke="currentColor"
-                              >
-                                <path
-                                  strokeLinecap="round"
-                                  strokeLinejoin="round"
-                                  d="M13.5 10.5V6.75a4.5 4.5 0 119 0v3.75M3.75 21.75h10.5a2.25 2.25 0 002.25-2.25v-6.75a2.25 2.25 0 00-2.25-2.25H3.75a2.25 2.25 0 00-2.25 2.25v6.75a2.25 2.25 0 002.25 2.25z"
-                                />
-                              </svg>
-                              {part.status === "open" ? "Open" : "Open"}
-                            </button>
-                            <button
-                              onClick={() =>
-                                setConfirmModal({
-                                  show: true,
-                                  type: "part-status",
-                                  id: part.id,
-                                  currentValue: "closed",
-                                  title: "Close Part?",
-                                  message: `Are you sure you want to close"${part.carPartName}"?`,
-                                })
-                              }
-                              disabled={part.status.toLowerCase() !== "open"}
-                              className={`inline-flex items-center gap-1.5 px-3 py-1.5 text-xs font-medium rounded-lg transition-colors ${
-                                part.status.toLowerCase() !== "open"
-                                  ? "bg-gray-100 text-gray-800   cursor-not-allowed opacity-60"
-                                  : "bg-red-600 text-white hover:bg-red-700"
-                              }`}
-                            >
-                              <svg
-                                className="w-3.5 h-3.5"
-                                fill="none"
-                                viewBox="0 0 24 24"
-                                strokeWidth="2"
-                                stroke="currentColor"
-                              >
-                                <path
-                                  strokeLinecap="round"
-                                  strokeLinejoin="round"
-                                  d="M16.5 10.5V6.75a4.5 4.5 0 10-9 0v3.75m-.75 11.25h10.5a2.25 2.25 0 002.25-2.25v-6.75a2.25 2.25 0 00-2.25-2.25H6.75a2.25 2.25 0 00-2.25 2.25v6.75a2.25 2.25 0 002.25 2.25z"
-                                />
-                              </svg>
-                              {part.status !== "open" ? "Closed" : "Close"}
-                            </button>
-                          </div>
-                        </div>
-                      )}
                     </div>
                   </div>
                 )}
@@ -1058,14 +960,14 @@ export default function ClaimDetailsPage() {
                         show: true,
                         type: "claim-status",
                         id: claimData.claim.id,
-                        currentValue: claimData.claim.status,
+                        currentValue: "REOPENED",
                         title: "Open Claim?",
                         message: "Are you sure you want to open this claim?",
                       })
                     }
-                    disabled={claimData.claim.status.toLowerCase() === "open"}
+                    disabled={claimData.claim.status !== "CLOSED"}
                     className={`inline-flex items-center gap-1.5 px-3 py-2 text-sm font-medium rounded-lg transition-colors ${
-                      claimData.claim.status.toLowerCase() === "open"
+                      claimData.claim.status !== "CLOSED"
                         ? "bg-blue-100 text-blue-800   cursor-not-allowed opacity-60"
                         : "bg-blue-600 text-white hover:bg-blue-700"
                     }`}
@@ -1083,7 +985,7 @@ export default function ClaimDetailsPage() {
                         d="M13.5 10.5V6.75a4.5 4.5 0 119 0v3.75M3.75 21.75h10.5a2.25 2.25 0 002.25-2.25v-6.75a2.25 2.25 0 00-2.25-2.25H3.75a2.25 2.25 0 00-2.25 2.25v6.75a2.25 2.25 0 002.25 2.25z"
                       />
                     </svg>
-                    {claimData.claim.status === "open" ? "Open" : "Open"}
+                    {claimData.claim.status !== "CLOSED" ? "Open" : "Reopen"}
                   </button>
                   <button
                     onClick={() =>
@@ -1091,14 +993,14 @@ export default function ClaimDetailsPage() {
                         show: true,
                         type: "claim-status",
                         id: claimData.claim.id,
-                        currentValue: claimData.claim.status,
+                        currentValue: "CLOSED",
                         title: "Close Claim?",
                         message: "Are you sure you want to close this claim?",
                       })
                     }
-                    disabled={claimData.claim.status.toLowerCase() === "closed"}
+                    disabled={claimData.claim.status === "CLOSED"}
                     className={`inline-flex items-center gap-1.5 px-3 py-2 text-sm font-medium rounded-lg transition-colors ${
-                      claimData.claim.status.toLowerCase() === "closed"
+                      claimData.claim.status === "CLOSED"
                         ? "bg-gray-100 text-gray-800   cursor-not-allowed opacity-60"
                         : "bg-red-600 text-white hover:bg-red-700"
                     }`}
@@ -1116,7 +1018,7 @@ export default function ClaimDetailsPage() {
                         d="M16.5 10.5V6.75a4.5 4.5 0 10-9 0v3.75m-.75 11.25h10.5a2.25 2.25 0 002.25-2.25v-6.75a2.25 2.25 0 00-2.25-2.25H6.75a2.25 2.25 0 00-2.25 2.25v6.75a2.25 2.25 0 002.25 2.25z"
                       />
                     </svg>
-                    {claimData.claim.status === "closed" ? "Closed" : "Close"}
+                    {claimData.claim.status === "CLOSED" ? "Closed" : "Close"}
                   </button>
                 </div>
               </div>
@@ -18,7 +18,7 @@ import {
   DocumentTextIcon,
 } from "@heroicons/react/20/solid";
 import { ClaimColumns } from "@/components/TableColumns";
-import { ClaimView } from "@/types/claimsType";
+import { ClaimSlaState, ClaimView } from "@/types/claimsType";
 import { DebounceInput } from "@/components/DebounceInput";
 import { TablePagination } from "@/components/TablePagination";
 import { getClaimsApi, getClaimsByShopIdApi } from "@/lib/apis/claimsApi";
@@ -29,6 +29,7 @@ export default function Page() {
   const { user } = useAuth();
   const [claims, setClaims] = useState<ClaimView[]>([]);
   const [globalFilter, setGlobalFilter] = useState("");
+  const [slaFilter, setSlaFilter] = useState<ClaimSlaState | "">("");
   const [sorting, setSorting] = useState<any[]>([]);
   const [pagination, setPagination] = useState({
     pageIndex: 0, // Current page index (starts at 0)
@@ -53,18 +54,19 @@ export default function Page() {
   });
 
   useEffect(() => {
+    const filters = slaFilter ? { sla: slaFilter } : undefined;
     if (user?.role === "admin") {
       // If user is admin, fetch all claims
-      getClaimsApi().then((data) => {
+      getClaimsApi(filters).then((data) => {
         setClaims(data);
       });
     } else if (user?.shopId) {
       // If user is shop_admin, fetch claims by shop ID
-      getClaimsByShopIdApi(user.shopId).then((data) => {
+      getClaimsByShopIdApi(user.shopId, filters).then((data) => {
         setClaims(data);
       });
     }
-  }, [user?.role, user?.shopId]);
+  }, [user?.role, user?.shopId, slaFilter]);
 
   return (
     <div className="px-4 sm:px-6 lg:px-8">
@@ -187,8 +189,18 @@ export default function Page() {
       </div>
 
       {/* Search Bar */}
-      <div className="mt-6">
-        <div className="relative">
+      <div className="mt-6 flex flex-col gap-3 sm:flex-row">
+        <select
+          value={slaFilter}
+          onChange={(e) => setSlaFilter(e.target.value as ClaimSlaState | "")}
+          className="block rounded-lg border-gray-300 py-2.5 text-sm shadow-sm focus:border-primary focus:ring-primary sm:w-48"
+        >
+          <option value="">All SLA states</option>
+          <option value="breached">Overdue</option>
+          <option value="due_today">Due today</option>
+          <option value="on_track">On track</option>
+        </select>
+        <div className="relative flex-1">
           <div className="pointer-events-none absolute inset-y-0 left-0 flex items-center pl-3">
             <MagnifyingGlassIcon className="h-5 w-5 text-gray-400" />
           </div>
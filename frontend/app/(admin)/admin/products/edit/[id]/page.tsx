@@ -17,10 +17,10 @@ export default async function Page({
 }) {
   const { id } = await params;
   const product = await getProductByIdApi(Number(id));
-  const brands = await getProductBrandsApi();
-  const types = await getProductTypesApi();
-  const series = await getProductSeriesApi();
-  const names = await getProductNamesApi();
+  const brands = await getProductBrandsApi(true);
+  const types = await getProductTypesApi(true);
+  const series = await getProductSeriesApi(true);
+  const names = await getProductNamesApi(true);
 
   console.log("brands:", brands);
 
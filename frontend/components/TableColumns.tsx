@@ -426,6 +426,17 @@ export const WarrantyColumns = [
           </svg>
           Approved
         </span>
+      ) : info.getValue() === WarrantyApprovalStatus.PARTIALLY_APPROVED ? (
+        <span className="inline-flex items-center gap-x-1.5 rounded-full bg-blue-50 px-2.5 py-1 text-xs font-medium text-blue-700 ring-1 ring-inset ring-blue-700/10">
+          <svg
+            className="h-1.5 w-1.5 fill-blue-500"
+            viewBox="0 0 6 6"
+            aria-hidden="true"
+          >
+            <circle cx={3} cy={3} r={3} />
+          </svg>
+          Partially Approved
+        </span>
       ) : info.getValue() === WarrantyApprovalStatus.PENDING ? (
         <span className="inline-flex items-center gap-x-1.5 rounded-full bg-yellow-50 px-2.5 py-1 text-xs font-medium text-yellow-700 ring-1 ring-inset ring-yellow-600/20">
           <svg
@@ -666,6 +677,17 @@ export const ClaimColumns = [
           </svg>
           Approved
         </span>
+      ) : info.getValue() === "PARTIALLY_APPROVED" ? (
+        <span className="inline-flex items-center gap-x-1.5 rounded-full bg-blue-50 px-2.5 py-1 text-xs font-medium text-blue-700 ring-1 ring-inset ring-blue-700/10">
+          <svg
+            className="h-1.5 w-1.5 fill-blue-500"
+            viewBox="0 0 6 6"
+            aria-hidden="true"
+          >
+            <circle cx={3} cy={3} r={3} />
+          </svg>
+          Partially Approved
+        </span>
       ) : info.getValue() === "PENDING" ? (
         <span className="inline-flex items-center gap-x-1.5 rounded-full bg-amber-50 px-2.5 py-1 text-xs font-medium text-amber-700 ring-1 ring-inset ring-amber-600/20">
           <svg
@@ -696,18 +718,54 @@ export const ClaimColumns = [
     cell: (info) => {
       const status = info.getValue();
       const statusConfig = {
-        open: {
+        SUBMITTED: {
           bg: "bg-blue-50",
           text: "text-blue-700",
           ring: "ring-blue-700/10",
-          label: "Open",
+          label: "Submitted",
+        },
+        UNDER_REVIEW: {
+          bg: "bg-yellow-50",
+          text: "text-yellow-800",
+          ring: "ring-yellow-600/20",
+          label: "Under Review",
+        },
+        APPROVED: {
+          bg: "bg-green-50",
+          text: "text-green-700",
+          ring: "ring-green-600/20",
+          label: "Approved",
+        },
+        AWAITING_PARTS: {
+          bg: "bg-orange-50",
+          text: "text-orange-700",
+          ring: "ring-orange-600/20",
+          label: "Awaiting Parts",
+        },
+        IN_REPAIR: {
+          bg: "bg-indigo-50",
+          text: "text-indigo-700",
+          ring: "ring-indigo-700/10",
+          label: "In Repair",
         },
-        closed: {
+        RESOLVED: {
+          bg: "bg-teal-50",
+          text: "text-teal-700",
+          ring: "ring-teal-600/20",
+          label: "Resolved",
+        },
+        CLOSED: {
           bg: "bg-gray-50",
           text: "text-gray-700",
           ring: "ring-gray-500/10",
           label: "Closed",
         },
+        REOPENED: {
+          bg: "bg-purple-50",
+          text: "text-purple-700",
+          ring: "ring-purple-700/10",
+          label: "Reopened",
+        },
       };
       const config = statusConfig[status as keyof typeof statusConfig] || {
         bg: "bg-gray-50",
@@ -725,6 +783,33 @@ export const ClaimColumns = [
     },
     enableSorting: true,
   }),
+  claimColumnHelper.accessor("slaDueDate", {
+    header: "SLA Due",
+    cell: (info) => {
+      const dueDate = info.getValue();
+      if (!dueDate) {
+        return <span className="text-sm text-gray-400">-</span>;
+      }
+      const breached = info.row.original.slaBreached;
+      return (
+        <span
+          className={`inline-flex items-center rounded-md px-2 py-1 text-xs font-medium ring-1 ring-inset ${
+            breached
+              ? "bg-red-50 text-red-700 ring-red-600/20"
+              : "bg-gray-50 text-gray-700 ring-gray-500/10"
+          }`}
+        >
+          {breached ? "Overdue since " : "Due "}
+          {new Date(dueDate).toLocaleDateString("en-US", {
+            month: "short",
+            day: "numeric",
+            year: "numeric",
+          })}
+        </span>
+      );
+    },
+    enableSorting: true,
+  }),
   claimColumnHelper.accessor("createdAt", {
     header: "Created",
     cell: (info) => (
@@ -0,0 +1,30 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  ClaimRate,
+  TimeToFailure,
+  QualityReportFilters,
+} from "@/types/analyticsType";
+
+export async function getClaimRatesApi(
+  filters?: QualityReportFilters
+): Promise<ClaimRate[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ClaimRate[]>(
+    "/analytics/quality/claim-rates",
+    { params: filters }
+  );
+  return response.data;
+}
+
+export async function getTimeToFailureApi(
+  filters?: QualityReportFilters
+): Promise<TimeToFailure[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<TimeToFailure[]>(
+    "/analytics/quality/time-to-failure",
+    { params: filters }
+  );
+  return response.data;
+}
@@ -4,12 +4,23 @@ import {
   Claim,
   ListClaimsResponse,
   ClaimWithPartsDetailResponse,
+  ClaimEligibility,
+  ClaimStatusTransitionsResponse,
+  ClaimListFilters,
+  ClaimSlaPolicy,
+  PublicHoliday,
+  ClaimResolution,
+  ClaimResolutionRequest,
 } from "@/types/claimsType";
 
-export async function getClaimsApi(): Promise<ClaimView[]> {
+export async function getClaimsApi(
+  filters?: ClaimListFilters
+): Promise<ClaimView[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<ClaimView[]>("/claims");
+  const response = await client.get<ClaimView[]>("/claims", {
+    params: filters,
+  });
   return response.data;
 }
 
@@ -40,11 +51,14 @@ export async function getClaimWithPartsByIdApi(
 }
 
 export async function getClaimsByShopIdApi(
-  shopId: number
+  shopId: number,
+  filters?: ClaimListFilters
 ): Promise<ClaimView[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<ClaimView[]>(`/claims/by-shop/${shopId}`);
+  const response = await client.get<ClaimView[]>(`/claims/by-shop/${shopId}`, {
+    params: filters,
+  });
   return response.data;
 }
 
@@ -60,3 +74,69 @@ export async function generateNextClaimNoApi(
   );
   return response.data;
 }
+
+export async function checkClaimEligibilityApi(
+  warrantyId: number,
+  claimDate: string,
+  warrantyPartIds: number[]
+): Promise<ClaimEligibility> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.post<ClaimEligibility>(`/claims/eligibility`, {
+    warrantyId,
+    claimDate,
+    warrantyPartIds,
+  });
+  return response.data;
+}
+
+export async function getClaimStatusTransitionsApi(
+  id: number
+): Promise<ClaimStatusTransitionsResponse> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ClaimStatusTransitionsResponse>(
+    `/claims/${id}/transitions`
+  );
+  return response.data;
+}
+
+export async function getClaimSlaPoliciesApi(): Promise<ClaimSlaPolicy[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ClaimSlaPolicy[]>("/claim-sla/policies");
+  return response.data;
+}
+
+export async function getPublicHolidaysApi(
+  year?: number
+): Promise<PublicHoliday[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<PublicHoliday[]>("/claim-sla/holidays", {
+    params: { year },
+  });
+  return response.data;
+}
+
+export async function getClaimResolutionsApi(
+  claimId: number
+): Promise<ClaimResolution[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ClaimResolution[]>(
+    `/claims/${claimId}/resolutions`
+  );
+  return response.data;
+}
+
+export async function recordClaimResolutionApi(
+  claimWarrantyPartId: number,
+  data: ClaimResolutionRequest
+): Promise<ClaimResolution> {
+  const response = await apiClient.put<ClaimResolution>(
+    `/claims/claim-warranty-parts/${claimWarrantyPartId}/resolution`,
+    data
+  );
+  return response.data;
+}
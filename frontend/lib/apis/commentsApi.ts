@@ -0,0 +1,43 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  ThreadComment,
+  CreateCommentRequest,
+  UnreadCommentCounts,
+  CommentThreadType,
+} from "@/types/commentsType";
+
+export async function getCommentsApi(
+  type: CommentThreadType,
+  id: number
+): Promise<ThreadComment[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ThreadComment[]>(`/comments/${type}/${id}`);
+  return response.data;
+}
+
+export async function createCommentApi(
+  type: CommentThreadType,
+  id: number,
+  data: CreateCommentRequest
+): Promise<ThreadComment> {
+  const response = await apiClient.post<ThreadComment>(
+    `/comments/${type}/${id}`,
+    data
+  );
+  return response.data;
+}
+
+export async function markCommentsReadApi(
+  type: CommentThreadType,
+  id: number
+): Promise<void> {
+  await apiClient.post(`/comments/${type}/${id}/read`);
+}
+
+export async function getUnreadCommentCountsApi(): Promise<UnreadCommentCounts> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<UnreadCommentCounts>("/comments/unread");
+  return response.data;
+}
@@ -0,0 +1,83 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  InventoryBalance,
+  InventoryMovement,
+  InventoryMovementDetail,
+  InventoryMovementRequest,
+  InventoryMovementsFilter,
+  LocationInventoryBalance,
+} from "@/types/inventoryType";
+
+export async function getInventoryBalancesApi(): Promise<
+  LocationInventoryBalance[]
+> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<LocationInventoryBalance[]>(
+    "/inventory/balances"
+  );
+  return response.data;
+}
+
+export async function getHQInventoryBalancesApi(): Promise<InventoryBalance[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<InventoryBalance[]>(
+    "/inventory/balances/hq"
+  );
+  return response.data;
+}
+
+export async function getShopInventoryBalancesApi(
+  shopId: number
+): Promise<InventoryBalance[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<InventoryBalance[]>(
+    `/inventory/balances/by-shop/${shopId}`
+  );
+  return response.data;
+}
+
+export async function getInventoryMovementsApi(
+  filter: InventoryMovementsFilter = {}
+): Promise<InventoryMovementDetail[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<InventoryMovementDetail[]>(
+    "/inventory/movements",
+    { params: filter }
+  );
+  return response.data;
+}
+
+export async function getShopInventoryMovementsApi(
+  shopId: number
+): Promise<InventoryMovementDetail[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<InventoryMovementDetail[]>(
+    `/inventory/movements/by-shop/${shopId}`
+  );
+  return response.data;
+}
+
+export async function adjustInventoryApi(
+  data: InventoryMovementRequest
+): Promise<InventoryMovement> {
+  const response = await apiClient.post<InventoryMovement>(
+    "/inventory/adjustments",
+    data
+  );
+  return response.data;
+}
+
+export async function writeOffInventoryApi(
+  data: InventoryMovementRequest
+): Promise<InventoryMovement> {
+  const response = await apiClient.post<InventoryMovement>(
+    "/inventory/write-offs",
+    data
+  );
+  return response.data;
+}
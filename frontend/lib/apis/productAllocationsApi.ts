@@ -3,6 +3,12 @@ import {
   ProductAllocationsListResponse,
   ProductAllocation,
   ProductsFromAllocationByShopIdResponse,
+  ProductAllocationBalance,
+  ProductAllocationTransfer,
+  ProductAllocationTransferDetail,
+  ProductAllocationTransferRequest,
+  ProductAllocationTransfersFilter,
+  FilmLabelLookup,
 } from "@/types/productAllocationsType";
 
 export async function getProductAllocationsApi(): Promise<
@@ -37,3 +43,64 @@ export async function getProductsFromAllocationByShopIdApi(
   );
   return response.data;
 }
+
+export async function getProductAllocationBalancesByShopIdApi(
+  shopId: number
+): Promise<ProductAllocationBalance[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ProductAllocationBalance[]>(
+    `/product-allocations/balances/by-shop/${shopId}`
+  );
+  return response.data;
+}
+
+export async function getProductAllocationTransfersApi(
+  filter: ProductAllocationTransfersFilter = {}
+): Promise<ProductAllocationTransferDetail[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ProductAllocationTransferDetail[]>(
+    "/product-allocations/transfers",
+    { params: filter }
+  );
+  return response.data;
+}
+
+export async function returnProductAllocationApi(
+  id: number,
+  data: ProductAllocationTransferRequest
+): Promise<ProductAllocationTransfer> {
+  const response = await apiClient.post<ProductAllocationTransfer>(
+    `/product-allocations/${id}/returns`,
+    data
+  );
+  return response.data;
+}
+
+export async function transferProductAllocationApi(
+  id: number,
+  data: ProductAllocationTransferRequest
+): Promise<ProductAllocationTransfer> {
+  const response = await apiClient.post<ProductAllocationTransfer>(
+    `/product-allocations/${id}/transfers`,
+    data
+  );
+  return response.data;
+}
+
+// lookupFilmLabelApi matches a scanned roll label (raw serial, GS1-128 or
+// DataMatrix payload) to a product and the shop's allocations of it. HQ users
+// must pass the shop.
+export async function lookupFilmLabelApi(
+  code: string,
+  shopId?: number
+): Promise<FilmLabelLookup> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<FilmLabelLookup>(
+    "/product-allocations/lookup",
+    { params: { code, shopId } }
+  );
+  return response.data;
+}
@@ -6,6 +6,10 @@ import {
   ProductName,
   Product,
   ProductDetailResponse,
+  ProductBrandRequest,
+  ProductTypeRequest,
+  ProductSeriesRequest,
+  ProductNameRequest,
 } from "@/types/productsType";
 
 export async function getProductsApi(): Promise<ProductDetailResponse[]> {
@@ -22,30 +26,138 @@ export async function getProductByIdApi(productId: number): Promise<Product> {
   return response.data;
 }
 
-export async function getProductBrandsApi(): Promise<ProductBrand[]> {
+export async function getProductBrandsApi(
+  includeInactive = false
+): Promise<ProductBrand[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<ProductBrand[]>("/products/brands");
+  const response = await client.get<ProductBrand[]>("/products/brands", {
+    params: includeInactive ? { includeInactive } : undefined,
+  });
   return response.data;
 }
 
-export async function getProductTypesApi(): Promise<ProductType[]> {
+export async function getProductTypesApi(
+  includeInactive = false
+): Promise<ProductType[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<ProductType[]>("/products/types");
+  const response = await client.get<ProductType[]>("/products/types", {
+    params: includeInactive ? { includeInactive } : undefined,
+  });
   return response.data;
 }
 
-export async function getProductSeriesApi(): Promise<ProductSeries[]> {
+export async function getProductSeriesApi(
+  includeInactive = false
+): Promise<ProductSeries[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<ProductSeries[]>("/products/series");
+  const response = await client.get<ProductSeries[]>("/products/series", {
+    params: includeInactive ? { includeInactive } : undefined,
+  });
   return response.data;
 }
 
-export async function getProductNamesApi(): Promise<ProductName[]> {
+export async function getProductNamesApi(
+  includeInactive = false
+): Promise<ProductName[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<ProductName[]>("/products/names");
+  const response = await client.get<ProductName[]>("/products/names", {
+    params: includeInactive ? { includeInactive } : undefined,
+  });
+  return response.data;
+}
+
+export async function createProductBrandApi(data: ProductBrandRequest): Promise<ProductBrand> {
+  const response = await apiClient.post<ProductBrand>("/products/brands", data);
+  return response.data;
+}
+
+export async function updateProductBrandApi(
+  id: number,
+  data: ProductBrandRequest
+): Promise<ProductBrand> {
+  const response = await apiClient.put<ProductBrand>(`/products/brands/${id}`, data);
+  return response.data;
+}
+
+export async function setProductBrandActiveApi(
+  id: number,
+  isActive: boolean
+): Promise<ProductBrand> {
+  const response = await apiClient.put<ProductBrand>(`/products/brands/${id}/active`, {
+    isActive,
+  });
+  return response.data;
+}
+
+export async function createProductTypeApi(data: ProductTypeRequest): Promise<ProductType> {
+  const response = await apiClient.post<ProductType>("/products/types", data);
+  return response.data;
+}
+
+export async function updateProductTypeApi(
+  id: number,
+  data: ProductTypeRequest
+): Promise<ProductType> {
+  const response = await apiClient.put<ProductType>(`/products/types/${id}`, data);
+  return response.data;
+}
+
+export async function setProductTypeActiveApi(
+  id: number,
+  isActive: boolean
+): Promise<ProductType> {
+  const response = await apiClient.put<ProductType>(`/products/types/${id}/active`, {
+    isActive,
+  });
+  return response.data;
+}
+
+export async function createProductSeriesApi(data: ProductSeriesRequest): Promise<ProductSeries> {
+  const response = await apiClient.post<ProductSeries>("/products/series", data);
+  return response.data;
+}
+
+export async function updateProductSeriesApi(
+  id: number,
+  data: ProductSeriesRequest
+): Promise<ProductSeries> {
+  const response = await apiClient.put<ProductSeries>(`/products/series/${id}`, data);
+  return response.data;
+}
+
+export async function setProductSeriesActiveApi(
+  id: number,
+  isActive: boolean
+): Promise<ProductSeries> {
+  const response = await apiClient.put<ProductSeries>(`/products/series/${id}/active`, {
+    isActive,
+  });
+  return response.data;
+}
+
+export async function createProductNameApi(data: ProductNameRequest): Promise<ProductName> {
+  const response = await apiClient.post<ProductName>("/products/names", data);
+  return response.data;
+}
+
+export async function updateProductNameApi(
+  id: number,
+  data: ProductNameRequest
+): Promise<ProductName> {
+  const response = await apiClient.put<ProductName>(`/products/names/${id}`, data);
+  return response.data;
+}
+
+export async function setProductNameActiveApi(
+  id: number,
+  isActive: boolean
+): Promise<ProductName> {
+  const response = await apiClient.put<ProductName>(`/products/names/${id}/active`, {
+    isActive,
+  });
   return response.data;
 }
@@ -0,0 +1,50 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  CreateRecallRequest,
+  NotifyRecallCustomersResponse,
+  Recall,
+  RecallImpact,
+  RecallSummary,
+} from "@/types/recallsType";
+
+export async function getRecallsApi(): Promise<RecallSummary[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<RecallSummary[]>("/recalls");
+  return response.data;
+}
+
+export async function getRecallImpactApi(id: number): Promise<RecallImpact> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<RecallImpact>(`/recalls/${id}`);
+  return response.data;
+}
+
+export async function exportRecallImpactApi(id: number): Promise<Blob> {
+  const response = await apiClient.get<Blob>(`/recalls/${id}/export`, {
+    responseType: "blob",
+  });
+  return response.data;
+}
+
+export async function createRecallApi(
+  data: CreateRecallRequest
+): Promise<Recall> {
+  const response = await apiClient.post<Recall>("/recalls", data);
+  return response.data;
+}
+
+export async function closeRecallApi(id: number): Promise<Recall> {
+  const response = await apiClient.post<Recall>(`/recalls/${id}/close`);
+  return response.data;
+}
+
+export async function notifyRecallCustomersApi(
+  id: number
+): Promise<NotifyRecallCustomersResponse> {
+  const response = await apiClient.post<NotifyRecallCustomersResponse>(
+    `/recalls/${id}/notify`
+  );
+  return response.data;
+}
@@ -0,0 +1,71 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  ReimbursementStatement,
+  ReimbursementStatementDetail,
+  CreateReimbursementStatementRequest,
+  ReviewReimbursementStatementRequest,
+} from "@/types/reimbursementsType";
+
+export async function getReimbursementStatementsApi(): Promise<
+  ReimbursementStatement[]
+> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ReimbursementStatement[]>(
+    "/reimbursement-statements"
+  );
+  return response.data;
+}
+
+export async function getReimbursementStatementsByShopIdApi(
+  shopId: number
+): Promise<ReimbursementStatement[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ReimbursementStatement[]>(
+    `/reimbursement-statements/by-shop/${shopId}`
+  );
+  return response.data;
+}
+
+export async function getReimbursementStatementByIdApi(
+  id: number
+): Promise<ReimbursementStatementDetail> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ReimbursementStatementDetail>(
+    `/reimbursement-statements/${id}`
+  );
+  return response.data;
+}
+
+export async function createReimbursementStatementApi(
+  data: CreateReimbursementStatementRequest
+): Promise<ReimbursementStatement> {
+  const response = await apiClient.post<ReimbursementStatement>(
+    "/reimbursement-statements",
+    data
+  );
+  return response.data;
+}
+
+export async function reviewReimbursementStatementApi(
+  id: number,
+  data: ReviewReimbursementStatementRequest
+): Promise<ReimbursementStatement> {
+  const response = await apiClient.put<ReimbursementStatement>(
+    `/reimbursement-statements/${id}/review`,
+    data
+  );
+  return response.data;
+}
+
+export async function exportReimbursementStatementApi(
+  id: number
+): Promise<Blob> {
+  const response = await apiClient.get<Blob>(
+    `/reimbursement-statements/${id}/export`,
+    { responseType: "blob" }
+  );
+  return response.data;
+}
@@ -0,0 +1,157 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  ApproveReorderRequestRequest,
+  CreateReorderRequestRequest,
+  LowStockAlert,
+  LowStockCheckResult,
+  RejectReorderRequestRequest,
+  ReorderRequest,
+  ReorderRequestStatus,
+  ReorderRequestWithNames,
+  StockThreshold,
+  StockThresholdWithBalance,
+  UpsertStockThresholdRequest,
+} from "@/types/reordersType";
+
+export async function getStockThresholdsApi(
+  shopId?: number
+): Promise<StockThresholdWithBalance[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<StockThresholdWithBalance[]>(
+    "/reorders/thresholds",
+    { params: { shopId } }
+  );
+  return response.data;
+}
+
+export async function getShopStockThresholdsApi(
+  shopId: number
+): Promise<StockThresholdWithBalance[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<StockThresholdWithBalance[]>(
+    `/reorders/thresholds/by-shop/${shopId}`
+  );
+  return response.data;
+}
+
+export async function upsertStockThresholdApi(
+  data: UpsertStockThresholdRequest
+): Promise<StockThreshold> {
+  const response = await apiClient.put<StockThreshold>(
+    "/reorders/thresholds",
+    data
+  );
+  return response.data;
+}
+
+export async function deleteStockThresholdApi(id: number): Promise<void> {
+  await apiClient.delete(`/reorders/thresholds/${id}`);
+}
+
+export async function getLowStockAlertsApi(
+  params: { shopId?: number; open?: boolean } = {}
+): Promise<LowStockAlert[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<LowStockAlert[]>("/reorders/alerts", {
+    params,
+  });
+  return response.data;
+}
+
+export async function getShopLowStockAlertsApi(
+  shopId: number,
+  open?: boolean
+): Promise<LowStockAlert[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<LowStockAlert[]>(
+    `/reorders/alerts/by-shop/${shopId}`,
+    { params: { open } }
+  );
+  return response.data;
+}
+
+export async function checkLowStockApi(): Promise<LowStockCheckResult> {
+  const response = await apiClient.post<LowStockCheckResult>(
+    "/reorders/alerts/check"
+  );
+  return response.data;
+}
+
+export async function getReorderRequestsApi(
+  params: { shopId?: number; status?: ReorderRequestStatus } = {}
+): Promise<ReorderRequestWithNames[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ReorderRequestWithNames[]>(
+    "/reorders/requests",
+    { params }
+  );
+  return response.data;
+}
+
+export async function getShopReorderRequestsApi(
+  shopId: number,
+  status?: ReorderRequestStatus
+): Promise<ReorderRequestWithNames[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ReorderRequestWithNames[]>(
+    `/reorders/requests/by-shop/${shopId}`,
+    { params: { status } }
+  );
+  return response.data;
+}
+
+export async function getReorderRequestByIdApi(
+  id: number
+): Promise<ReorderRequest> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ReorderRequest>(`/reorders/requests/${id}`);
+  return response.data;
+}
+
+export async function createReorderRequestApi(
+  data: CreateReorderRequestRequest
+): Promise<ReorderRequest> {
+  const response = await apiClient.post<ReorderRequest>(
+    "/reorders/requests",
+    data
+  );
+  return response.data;
+}
+
+export async function cancelReorderRequestApi(
+  id: number
+): Promise<ReorderRequest> {
+  const response = await apiClient.post<ReorderRequest>(
+    `/reorders/requests/${id}/cancel`
+  );
+  return response.data;
+}
+
+export async function rejectReorderRequestApi(
+  id: number,
+  data: RejectReorderRequestRequest = {}
+): Promise<ReorderRequest> {
+  const response = await apiClient.post<ReorderRequest>(
+    `/reorders/requests/${id}/reject`,
+    data
+  );
+  return response.data;
+}
+
+export async function approveReorderRequestApi(
+  id: number,
+  data: ApproveReorderRequestRequest
+): Promise<ReorderRequest> {
+  const response = await apiClient.post<ReorderRequest>(
+    `/reorders/requests/${id}/approve`,
+    data
+  );
+  return response.data;
+}
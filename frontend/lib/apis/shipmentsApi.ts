@@ -0,0 +1,63 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  CreateShipmentRequest,
+  ReceiveShipmentRequest,
+  Shipment,
+  ShipmentImportReport,
+  ShipmentSummary,
+  ShipmentWithLines,
+} from "@/types/shipmentsType";
+
+export async function getShipmentsApi(): Promise<ShipmentSummary[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ShipmentSummary[]>("/shipments");
+  return response.data;
+}
+
+export async function getShipmentByIdApi(
+  id: number
+): Promise<ShipmentWithLines> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<ShipmentWithLines>(`/shipments/${id}`);
+  return response.data;
+}
+
+export async function createShipmentApi(
+  data: CreateShipmentRequest
+): Promise<Shipment> {
+  const response = await apiClient.post<Shipment>("/shipments", data);
+  return response.data;
+}
+
+export async function receiveShipmentApi(
+  id: number,
+  data: ReceiveShipmentRequest = {}
+): Promise<Shipment> {
+  const response = await apiClient.post<Shipment>(
+    `/shipments/${id}/receive`,
+    data
+  );
+  return response.data;
+}
+
+// importShipmentsApi uploads a supplier packing list. A report with errors is
+// returned with status 422, so it is read from the response either way.
+export async function importShipmentsApi(
+  file: File,
+  dryRun: boolean
+): Promise<ShipmentImportReport> {
+  const formData = new FormData();
+  formData.append("file", file);
+  const response = await apiClient.post<ShipmentImportReport>(
+    "/shipments/import",
+    formData,
+    {
+      headers: { "Content-Type": "multipart/form-data" },
+      params: { dryRun },
+      validateStatus: (status) => status < 300 || status === 422,
+    }
+  );
+  return response.data;
+}
@@ -0,0 +1,107 @@
+import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  OpenStockTakeRequest,
+  RecordStockTakeCountsRequest,
+  ReviewStockTakeLineRequest,
+  StockTake,
+  StockTakeLine,
+  StockTakeReport,
+  StockTakeStatus,
+  StockTakeSummary,
+} from "@/types/stockTakesType";
+
+export async function getStockTakesApi(
+  params: { shopId?: number; status?: StockTakeStatus } = {}
+): Promise<StockTakeSummary[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<StockTakeSummary[]>("/stock-takes", {
+    params,
+  });
+  return response.data;
+}
+
+export async function getShopStockTakesApi(
+  shopId: number,
+  status?: StockTakeStatus
+): Promise<StockTakeSummary[]> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<StockTakeSummary[]>(
+    `/stock-takes/by-shop/${shopId}`,
+    { params: { status } }
+  );
+  return response.data;
+}
+
+export async function getStockTakeByIdApi(
+  id: number
+): Promise<StockTakeReport> {
+  const client =
+    typeof window === "undefined" ? await getServerApiClient() : apiClient;
+  const response = await client.get<StockTakeReport>(`/stock-takes/${id}`);
+  return response.data;
+}
+
+export async function exportStockTakeVariancesApi(id: number): Promise<Blob> {
+  const response = await apiClient.get<Blob>(`/stock-takes/${id}/export`, {
+    responseType: "blob",
+  });
+  return response.data;
+}
+
+export async function openStockTakeApi(
+  data: OpenStockTakeRequest
+): Promise<StockTake> {
+  const response = await apiClient.post<StockTake>("/stock-takes", data);
+  return response.data;
+}
+
+export async function recordStockTakeCountsApi(
+  id: number,
+  data: RecordStockTakeCountsRequest
+): Promise<StockTakeLine[]> {
+  const response = await apiClient.post<StockTakeLine[]>(
+    `/stock-takes/${id}/counts`,
+    data
+  );
+  return response.data;
+}
+
+export async function submitStockTakeApi(id: number): Promise<StockTake> {
+  const response = await apiClient.post<StockTake>(
+    `/stock-takes/${id}/submit`
+  );
+  return response.data;
+}
+
+export async function cancelStockTakeApi(id: number): Promise<StockTake> {
+  const response = await apiClient.post<StockTake>(
+    `/stock-takes/${id}/cancel`
+  );
+  return response.data;
+}
+
+export async function discardStockTakeApi(id: number): Promise<StockTake> {
+  const response = await apiClient.post<StockTake>(
+    `/stock-takes/${id}/discard`
+  );
+  return response.data;
+}
+
+export async function reviewStockTakeLineApi(
+  id: number,
+  lineId: number,
+  data: ReviewStockTakeLineRequest
+): Promise<StockTakeLine> {
+  const response = await apiClient.post<StockTakeLine>(
+    `/stock-takes/${id}/lines/${lineId}/review`,
+    data
+  );
+  return response.data;
+}
+
+export async function postStockTakeApi(id: number): Promise<StockTake> {
+  const response = await apiClient.post<StockTake>(`/stock-takes/${id}/post`);
+  return response.data;
+}
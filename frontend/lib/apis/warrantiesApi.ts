@@ -1,4 +1,11 @@
+import { isAxiosError } from "axios";
 import apiClient, { getServerApiClient } from "@/lib/axios";
+import {
+  LookupChallenge,
+  LOOKUP_CHALLENGE_HEADER,
+  LOOKUP_SOLUTION_HEADER,
+  solveLookupChallenge,
+} from "@/lib/utils/lookupChallenge";
 import {
   Warranty,
   CarPart,
@@ -67,10 +74,25 @@ export async function getWarrantiesByExactSearchApi(
 ): Promise<WarrantySearchResult[]> {
   const client =
     typeof window === "undefined" ? await getServerApiClient() : apiClient;
-  const response = await client.get<WarrantySearchResult[]>(
-    `/warranties/search/${encodeURIComponent(searchTerm)}`
-  );
-  return response.data;
+  const url = `/warranties/search/${encodeURIComponent(searchTerm)}`;
+  try {
+    const response = await client.get<WarrantySearchResult[]>(url);
+    return response.data;
+  } catch (error) {
+    // Bursts of lookups must solve a proof-of-work challenge before retrying
+    if (!isAxiosError(error) || error.response?.status !== 428) {
+      throw error;
+    }
+    const challenge = error.response.data as LookupChallenge;
+    const solution = await solveLookupChallenge(challenge);
+    const response = await client.get<WarrantySearchResult[]>(url, {
+      headers: {
+        [LOOKUP_CHALLENGE_HEADER]: challenge.challenge,
+        [LOOKUP_SOLUTION_HEADER]: solution,
+      },
+    });
+    return response.data;
+  }
 }
 
 export async function getWarrantiesWithPartsByShopIdApi(
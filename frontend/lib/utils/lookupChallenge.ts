@@ -0,0 +1,48 @@
+/**
+ * Proof-of-work solver for the public warranty lookup.
+ * When a client makes a burst of lookups the API answers 428 with a challenge;
+ * the lookup is retried with a solution proving some work was done.
+ */
+
+export interface LookupChallenge {
+  challenge: string;
+  difficulty: number;
+}
+
+export const LOOKUP_CHALLENGE_HEADER = "X-Lookup-Challenge";
+export const LOOKUP_SOLUTION_HEADER = "X-Lookup-Solution";
+
+function leadingZeroBits(hash: Uint8Array): number {
+  let bits = 0;
+  for (const byte of hash) {
+    if (byte === 0) {
+      bits += 8;
+      continue;
+    }
+    return bits + Math.clz32(byte) - 24;
+  }
+  return bits;
+}
+
+/**
+ * Find a solution such that SHA-256(challenge + solution) starts with
+ * `difficulty` zero bits.
+ * @param challenge - Challenge returned by the API
+ * @returns The solution to send in the X-Lookup-Solution header
+ */
+export async function solveLookupChallenge({
+  challenge,
+  difficulty,
+}: LookupChallenge): Promise<string> {
+  const encoder = new TextEncoder();
+  for (let nonce = 0; ; nonce++) {
+    const solution = nonce.toString(36);
+    const digest = await crypto.subtle.digest(
+      "SHA-256",
+      encoder.encode(challenge + solution)
+    );
+    if (leadingZeroBits(new Uint8Array(digest)) >= difficulty) {
+      return solution;
+    }
+  }
+}
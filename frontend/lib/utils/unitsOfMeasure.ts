@@ -0,0 +1,45 @@
+import { UnitOfMeasure } from "@/types/productsType";
+
+interface UnitConversion {
+  rollLengthMetres: number | null;
+  sheetsPerRoll: number | null;
+}
+
+/**
+ * Convert a quantity in rolls, the unit stock is kept in, to the given unit.
+ * Returns null when the product has no roll length or sheet count for the unit.
+ */
+export function fromRolls(
+  rolls: number,
+  unit: UnitOfMeasure,
+  product: UnitConversion
+): number | null {
+  switch (unit) {
+    case "METRE":
+      return product.rollLengthMetres ? rolls * product.rollLengthMetres : null;
+    case "SHEET":
+      return product.sheetsPerRoll ? rolls * product.sheetsPerRoll : null;
+    default:
+      return rolls;
+  }
+}
+
+/**
+ * Format a quantity in rolls in the given unit, e.g. "12.5 m" or "3 sheets".
+ * Falls back to rolls when the product cannot be converted.
+ */
+export function formatQuantity(
+  rolls: number,
+  unit: UnitOfMeasure,
+  product: UnitConversion
+): string {
+  const quantity = fromRolls(rolls, unit, product);
+  if (quantity === null || unit === "ROLL") {
+    return `${Number(rolls.toFixed(4))} ${rolls === 1 ? "roll" : "rolls"}`;
+  }
+  const value = Number(quantity.toFixed(2));
+  if (unit === "METRE") {
+    return `${value} m`;
+  }
+  return `${value} ${value === 1 ? "sheet" : "sheets"}`;
+}
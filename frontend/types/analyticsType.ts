@@ -0,0 +1,39 @@
+export type QualityDimension =
+  | "product_name"
+  | "series"
+  | "shipment"
+  | "car_part"
+  | "shop"
+  | "all";
+
+export interface QualityReportFilters {
+  dimension?: QualityDimension;
+  installedFrom?: string; // YYYY-MM-DD
+  installedTo?: string;
+  claimedFrom?: string;
+  claimedTo?: string;
+}
+
+export interface ClaimRate {
+  groupKey: string;
+  installations: number;
+  claimedParts: number;
+  approvedClaimedParts: number;
+  claimsPer100: number;
+  approvedClaimsPer100: number;
+}
+
+export interface TimeToFailure {
+  groupKey: string;
+  failures: number;
+  minDays: number;
+  maxDays: number;
+  avgDays: number;
+  medianDays: number;
+  p90Days: number;
+  within3Months: number;
+  within3To6Months: number;
+  within6To12Months: number;
+  within1To2Years: number;
+  after2Years: number;
+}
@@ -1,3 +1,5 @@
+import { UnitOfMeasure } from "@/types/productsType";
+
 export interface ClaimView {
   id: number;
   warrantyId: number;
@@ -8,6 +10,10 @@ export interface ClaimView {
   remarks?: string;
   createdAt: string;
   updatedAt: string;
+  submittedByCustomerId?: number;
+  slaStartDate?: string;
+  slaDueDate?: string;
+  slaEscalatedAt?: string;
   shopId: number;
   clientName: string;
   clientContact: string;
@@ -21,6 +27,31 @@ export interface ClaimView {
   referenceNo?: string;
   warrantyNo: string;
   invoiceAttachmentUrl: string;
+  slaBreached?: boolean;
+}
+
+export type ClaimSlaState = "breached" | "due_today" | "on_track";
+
+export interface ClaimListFilters {
+  status?: string;
+  sla?: ClaimSlaState;
+}
+
+export interface ClaimSlaPolicy {
+  status: string;
+  workingDays: number;
+  isActive: boolean;
+  updatedAt: string;
+}
+
+export interface PublicHoliday {
+  id: number;
+  holidayDate: string;
+  name: string;
+  msiaStateId?: number;
+  msiaStateName?: string;
+  createdAt: string;
+  updatedAt: string;
 }
 
 export interface ClaimWarrantyPartsView {
@@ -105,8 +136,6 @@ export interface UpdateClaimWarrantyPartRequest {
   damagedImageUrl: string;
   status: string;
   remarks?: string;
-  resolutionDate?: string;
-  resolutionImageUrl?: string;
 }
 
 export interface UpdateClaimWithPartsRequest {
@@ -157,3 +186,74 @@ export interface ClaimWithPartsDetailResponse {
   claim: ClaimView;
   parts: ClaimWarrantyPartsView[];
 }
+
+export interface ClaimEligibilityReason {
+  code: string;
+  message: string;
+}
+
+export interface ClaimPartEligibility {
+  warrantyPartId: number;
+  carPartName: string;
+  coverageExpiryDate: string | null;
+  eligible: boolean;
+  reasons: ClaimEligibilityReason[];
+}
+
+export interface ClaimEligibility {
+  warrantyId: number;
+  claimDate: string;
+  eligible: boolean;
+  parts: ClaimPartEligibility[];
+}
+
+export interface ClaimStatusTransition {
+  id: number;
+  claimId: number;
+  fromStatus: string | null;
+  toStatus: string;
+  changedByUserId: number | null;
+  changedByCustomerId: number | null;
+  actorRole: string;
+  remarks: string | null;
+  createdAt: string;
+  changedByUsername: string | null;
+  changedByCustomerName: string | null;
+}
+
+export interface ClaimStatusTransitionsResponse {
+  transitions: ClaimStatusTransition[];
+  allowedStatuses: string[];
+}
+
+export interface ClaimResolution {
+  id: number;
+  claimWarrantyPartId: number;
+  productAllocationId: number;
+  quantityUsed: number;
+  labourCost: number;
+  materialCost: number;
+  remarks: string | null;
+  reimbursementStatementId: number | null;
+  recordedByUserId: number | null;
+  createdAt: string;
+  updatedAt: string;
+  unitOfMeasure: UnitOfMeasure;
+  unitQuantity: number;
+  claimId: number;
+  resolutionDate: string | null;
+  carPartName: string;
+  filmSerialNumber: string;
+  productName: string;
+}
+
+export interface ClaimResolutionRequest {
+  productAllocationId: number;
+  quantityUsed: number;
+  unitOfMeasure?: UnitOfMeasure; // Unit of quantityUsed, defaults to ROLL
+  labourCost: number;
+  materialCost: number;
+  remarks?: string | null;
+  resolutionDate: string; // YYYY-MM-DD
+  resolutionImageUrl?: string | null;
+}
@@ -0,0 +1,45 @@
+export interface CommentAttachment {
+  id: number;
+  commentId: number;
+  fileUrl: string;
+  fileName: string;
+  createdAt: string;
+}
+
+export interface ThreadComment {
+  id: number;
+  warrantyId: number | null;
+  claimId: number | null;
+  parentCommentId: number | null;
+  authorUserId: number;
+  body: string;
+  isInternal: boolean;
+  createdAt: string;
+  updatedAt: string;
+  authorUsername: string;
+  authorShopId: number | null;
+  isRead: boolean;
+  attachments: CommentAttachment[];
+}
+
+export interface CreateCommentRequest {
+  body: string;
+  parentCommentId?: number | null;
+  isInternal?: boolean; // HQ only
+  attachments?: { fileUrl: string; fileName: string }[];
+}
+
+export interface UnreadCommentThread {
+  warrantyId: number | null;
+  claimId: number | null;
+  warrantyNo: string;
+  claimNo: string | null;
+  unreadCount: number;
+}
+
+export interface UnreadCommentCounts {
+  total: number;
+  threads: UnreadCommentThread[];
+}
+
+export type CommentThreadType = "warranties" | "claims";
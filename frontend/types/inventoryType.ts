@@ -0,0 +1,55 @@
+export type InventoryMovementType =
+  | "RECEIPT"
+  | "ALLOCATION"
+  | "RETURN"
+  | "TRANSFER"
+  | "CONSUMPTION"
+  | "ADJUSTMENT"
+  | "WRITE_OFF";
+
+export interface InventoryMovement {
+  id: number;
+  productId: number;
+  shopId: number | null;
+  movementType: InventoryMovementType;
+  quantity: number;
+  productAllocationId: number | null;
+  claimResolutionId: number | null;
+  warrantyPartId: number | null;
+  remarks: string | null;
+  createdByUserId: number | null;
+  createdAt: string;
+}
+
+export interface InventoryMovementDetail extends InventoryMovement {
+  filmSerialNumber: string;
+  productName: string;
+  shopName: string | null;
+}
+
+export interface InventoryBalance {
+  productId: number;
+  filmSerialNumber: string;
+  productName: string;
+  onHand: number;
+}
+
+export interface LocationInventoryBalance extends InventoryBalance {
+  shopId: number | null;
+  shopName: string | null;
+  branchCode: string | null;
+}
+
+export interface InventoryMovementsFilter {
+  productId?: number;
+  shopId?: number;
+  location?: "hq";
+}
+
+// Leave shopId empty for HQ stock. Adjustments are signed, write-offs positive.
+export interface InventoryMovementRequest {
+  productId: number;
+  shopId: number | null;
+  quantity: number;
+  remarks?: string;
+}
@@ -1,29 +1,36 @@
+import { UnitOfMeasure } from "@/types/productsType";
+
 export interface ProductAllocationsListResponse {
   allocationId: number;
   filmSerialNumber: string;
   productName: string;
   shopName: string;
   branchCode: string;
-  filmQuantity: number;
+  filmQuantity: number; // In rolls
   allocationDate: string; // ISO date string
   createdAt: string; // ISO date string
   updatedAt: string; // ISO date string
+  unitOfMeasure: UnitOfMeasure;
+  unitQuantity: number;
 }
 
 export interface ProductAllocation {
   id: number;
   productId: number;
   shopId: number;
-  filmQuantity: number;
+  filmQuantity: number; // In rolls
   allocationDate: string; // ISO date string
   createdAt: string;
   updatedAt: string;
+  unitOfMeasure: UnitOfMeasure; // Unit the allocation was entered in
+  unitQuantity: number;
 }
 
 export interface CreateProductAllocationRequest {
   productId: number;
   shopId: number;
   filmQuantity: number;
+  unitOfMeasure?: UnitOfMeasure; // Unit of filmQuantity, defaults to ROLL
   allocationDate: string; // ISO date string
 }
 
@@ -32,6 +39,7 @@ export interface UpdateProductAllocationRequest {
   productId: number;
   shopId: number;
   filmQuantity: number;
+  unitOfMeasure?: UnitOfMeasure; // Unit of filmQuantity, defaults to ROLL
   allocationDate: string; // ISO date string
 }
 
@@ -45,6 +53,105 @@ export interface ProductsFromAllocationByShopIdResponse {
   warrantyInMonths: number;
   filmSerialNumber: string;
   filmQuantity: number;
+  unitOfMeasure: UnitOfMeasure;
+  rollLengthMetres: number | null;
+  sheetsPerRoll: number | null;
   shipmentNumber: string;
   description: string;
+  remainingQuantity: number; // In rolls
+}
+
+export interface ProductAllocationBalance {
+  productAllocationId: number;
+  filmSerialNumber: string;
+  productName: string;
+  allocationDate: string;
+  allocatedQuantity: number;
+  consumedQuantity: number;
+  installedQuantity: number; // Film used by warranty installs
+  transferredQuantity: number;
+  adjustedQuantity: number; // Posted stock-take adjustments
+  remainingQuantity: number;
+}
+
+export type ProductAllocationTransferType = "RETURN" | "TRANSFER";
+
+export interface ProductAllocationTransfer {
+  id: number;
+  productAllocationId: number;
+  transferType: ProductAllocationTransferType;
+  filmQuantity: number;
+  toShopId: number | null;
+  toProductAllocationId: number | null;
+  transferDate: string;
+  remarks: string | null;
+  createdByUserId: number | null;
+  createdAt: string;
+}
+
+export interface ProductAllocationTransferDetail extends ProductAllocationTransfer {
+  fromShopId: number;
+  fromShopName: string;
+  toShopName: string | null;
+  filmSerialNumber: string;
+  productName: string;
+}
+
+export interface ProductAllocationTransferRequest {
+  filmQuantity: number; // In rolls
+  toShopId?: number; // Required for transfers
+  transferDate: string; // Format: YYYY-MM-DD
+  remarks?: string;
+}
+
+export interface ProductAllocationTransfersFilter {
+  shopId?: number;
+  allocationId?: number;
+}
+
+export interface FilmLabel {
+  raw: string;
+  symbology: "RAW" | "GS1-128" | "GS1 DataMatrix" | "GS1 QR";
+  gtin?: string;
+  serial?: string;
+  lot?: string;
+  expiryDate?: string;
+  elements?: Record<string, string>;
+}
+
+export interface FilmLabelProduct {
+  id: number;
+  filmSerialNumber: string;
+  filmQuantity: number;
+  unitOfMeasure: UnitOfMeasure;
+  rollLengthMetres: number | null;
+  sheetsPerRoll: number | null;
+  warrantyInMonths: number;
+  shipmentNumber: string;
+  isActive: boolean;
+  brandName: string;
+  typeName: string;
+  seriesName: string;
+  productName: string;
+}
+
+export interface FilmLabelAllocation {
+  productAllocationId: number;
+  allocationDate: string;
+  allocatedQuantity: number;
+  consumedQuantity: number;
+  installedQuantity: number; // Film used by warranty installs
+  transferredQuantity: number;
+  adjustedQuantity: number; // Posted stock-take adjustments
+  remainingQuantity: number;
+}
+
+export interface FilmLabelLookup {
+  label: FilmLabel;
+  product: FilmLabelProduct;
+  shopId: number;
+  allocatedToShop: boolean;
+  remainingQuantity: number;
+  allocations: FilmLabelAllocation[];
+  message?: string;
 }
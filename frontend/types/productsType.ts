@@ -1,3 +1,7 @@
+// Film quantities are kept in rolls; metres and sheets are converted with the product's roll
+// length and sheets per roll.
+export type UnitOfMeasure = "ROLL" | "METRE" | "SHEET";
+
 export interface ProductDetailResponse {
   id: number;
   warrantyInMonths: number;
@@ -12,6 +16,9 @@ export interface ProductDetailResponse {
   typeName: string;
   seriesName: string;
   productName: string;
+  unitOfMeasure: UnitOfMeasure;
+  rollLengthMetres: number | null;
+  sheetsPerRoll: number | null;
 }
 
 export interface Product {
@@ -28,6 +35,10 @@ export interface Product {
   isActive: boolean;
   createdAt: string;
   updatedAt: string;
+  shipmentId: number | null;
+  unitOfMeasure: UnitOfMeasure;
+  rollLengthMetres: number | null;
+  sheetsPerRoll: number | null;
 }
 
 export interface CreateProductRequest {
@@ -37,9 +48,12 @@ export interface CreateProductRequest {
   nameId: number;
   warrantyInMonths: number;
   filmSerialNumber: string;
-  filmQuantity: number;
+  filmQuantity: number; // In rolls
   shipmentNumber: string;
   description: string;
+  unitOfMeasure?: UnitOfMeasure; // Defaults to ROLL
+  rollLengthMetres?: number | null;
+  sheetsPerRoll?: number | null;
 }
 
 export interface UpdateProductRequest {
@@ -50,10 +64,13 @@ export interface UpdateProductRequest {
   nameId: number;
   warrantyInMonths: number;
   filmSerialNumber: string;
-  filmQuantity: number;
+  filmQuantity: number; // In rolls
   shipmentNumber: string;
   description: string;
   isActive: boolean;
+  unitOfMeasure?: UnitOfMeasure; // Defaults to ROLL
+  rollLengthMetres?: number | null;
+  sheetsPerRoll?: number | null;
 }
 
 export interface ProductBrand {
@@ -62,6 +79,7 @@ export interface ProductBrand {
   description: string;
   createdAt: string;
   updatedAt: string;
+  isActive: boolean;
 }
 
 export interface ProductType {
@@ -71,6 +89,7 @@ export interface ProductType {
   description: string;
   createdAt: string;
   updatedAt: string;
+  isActive: boolean;
 }
 
 export interface ProductSeries {
@@ -80,6 +99,7 @@ export interface ProductSeries {
   description: string;
   createdAt: string;
   updatedAt: string;
+  isActive: boolean;
 }
 
 export interface ProductName {
@@ -89,4 +109,28 @@ export interface ProductName {
   description: string;
   createdAt: string;
   updatedAt: string;
+  isActive: boolean;
+}
+
+export interface ProductBrandRequest {
+  name: string;
+  description: string;
+}
+
+export interface ProductTypeRequest {
+  brandId: number;
+  name: string;
+  description: string;
+}
+
+export interface ProductSeriesRequest {
+  typeId: number;
+  name: string;
+  description: string;
+}
+
+export interface ProductNameRequest {
+  seriesId: number;
+  name: string;
+  description: string;
 }
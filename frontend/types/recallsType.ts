@@ -0,0 +1,105 @@
+import { WarrantyApprovalStatus } from "@/types/warrantiesType";
+
+export type RecallScope = "SERIAL" | "SHIPMENT";
+
+export type RecallStatus = "OPEN" | "CLOSED";
+
+export interface Recall {
+  id: number;
+  recallNo: string;
+  scope: RecallScope;
+  productId: number | null;
+  shipmentId: number | null;
+  reason: string;
+  status: RecallStatus;
+  freezeInstalls: boolean;
+  customersNotifiedAt: string | null;
+  createdByUserId: number | null;
+  closedByUserId: number | null;
+  closedAt: string | null;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface RecallSummary {
+  id: number;
+  recallNo: string;
+  scope: RecallScope;
+  productId: number | null;
+  filmSerialNumber: string | null;
+  shipmentId: number | null;
+  shipmentNumber: string | null;
+  reason: string;
+  status: RecallStatus;
+  freezeInstalls: boolean;
+  customersNotifiedAt: string | null;
+  createdAt: string;
+  closedAt: string | null;
+  productCount: number;
+  warrantyCount: number;
+}
+
+export interface RecallProduct {
+  id: number;
+  filmSerialNumber: string;
+  filmQuantity: number;
+  shipmentNumber: string;
+  productName: string;
+}
+
+export interface RecallAllocation {
+  id: number;
+  productId: number;
+  filmSerialNumber: string;
+  shopId: number;
+  shopName: string;
+  branchCode: string;
+  filmQuantity: number;
+  allocationDate: string;
+  remainingQuantity: number;
+  warrantyPartCount: number;
+}
+
+export interface RecallWarrantyPart {
+  id: number;
+  warrantyId: number;
+  warrantyNo: string;
+  installationDate: string;
+  approvalStatus: WarrantyApprovalStatus;
+  isActive: boolean;
+  customerId: number | null;
+  clientName: string;
+  clientContact: string;
+  clientEmail: string;
+  carBrand: string;
+  carModel: string;
+  carPlateNo: string;
+  shopId: number;
+  shopName: string;
+  productAllocationId: number;
+  filmSerialNumber: string;
+  carPartName: string;
+}
+
+export interface RecallImpact {
+  recall: Recall;
+  products: RecallProduct[];
+  allocations: RecallAllocation[];
+  warrantyParts: RecallWarrantyPart[];
+  shopCount: number;
+  warrantyCount: number;
+  customerCount: number;
+  unusedFilm: number;
+}
+
+// Exactly one of filmSerialNumber and shipmentId is given.
+export interface CreateRecallRequest {
+  filmSerialNumber?: string;
+  shipmentId?: number;
+  reason: string;
+  freezeInstalls?: boolean; // defaults to true
+}
+
+export interface NotifyRecallCustomersResponse {
+  warrantiesNotified: number;
+}
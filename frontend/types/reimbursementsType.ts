@@ -0,0 +1,50 @@
+export type ReimbursementStatus = "PENDING" | "APPROVED" | "REJECTED";
+
+export interface ReimbursementStatement {
+  id: number;
+  shopId: number;
+  periodStart: string;
+  periodEnd: string;
+  status: ReimbursementStatus;
+  totalLabourCost: number;
+  totalMaterialCost: number;
+  resolutionCount: number;
+  remarks: string | null;
+  createdByUserId: number | null;
+  reviewedByUserId: number | null;
+  reviewedAt: string | null;
+  createdAt: string;
+  updatedAt: string;
+  shopName: string;
+  branchCode: string;
+}
+
+export interface ReimbursementStatementLine {
+  claimResolutionId: number;
+  claimNo: string;
+  warrantyNo: string;
+  carPlateNo: string;
+  carPartName: string;
+  resolutionDate: string | null;
+  filmSerialNumber: string;
+  productName: string;
+  quantityUsed: number;
+  labourCost: number;
+  materialCost: number;
+}
+
+export interface ReimbursementStatementDetail {
+  statement: ReimbursementStatement;
+  lines: ReimbursementStatementLine[];
+}
+
+export interface CreateReimbursementStatementRequest {
+  shopId: number;
+  periodStart: string; // YYYY-MM-DD
+  periodEnd: string; // YYYY-MM-DD
+}
+
+export interface ReviewReimbursementStatementRequest {
+  status: Exclude<ReimbursementStatus, "PENDING">;
+  remarks?: string | null;
+}
@@ -0,0 +1,92 @@
+export interface StockThreshold {
+  id: number;
+  shopId: number;
+  nameId: number;
+  minQuantity: number;
+  reorderQuantity: number;
+  isActive: boolean;
+  createdAt: string;
+  updatedAt: string;
+}
+
+// StockThresholdWithBalance carries the shop's current balance of the product
+// name: film allocated less what was consumed, returned or transferred.
+export interface StockThresholdWithBalance extends StockThreshold {
+  shopName: string;
+  branchCode: string;
+  productName: string;
+  balance: number;
+}
+
+export interface UpsertStockThresholdRequest {
+  shopId: number;
+  nameId: number;
+  minQuantity: number;
+  reorderQuantity: number;
+  isActive?: boolean; // defaults to true
+}
+
+export interface LowStockAlert {
+  id: number;
+  thresholdId: number;
+  shopId: number;
+  shopName: string;
+  nameId: number;
+  productName: string;
+  balance: number;
+  minQuantity: number;
+  alertedAt: string;
+  resolvedAt: string | null;
+}
+
+export interface LowStockCheckResult {
+  alerted: number;
+  resolved: number;
+  errors?: string[]; // Thresholds whose alert could not be opened or resolved
+}
+
+export type ReorderRequestStatus =
+  | "PENDING"
+  | "APPROVED"
+  | "REJECTED"
+  | "CANCELLED";
+
+export interface ReorderRequest {
+  id: number;
+  shopId: number;
+  nameId: number;
+  requestedQuantity: number;
+  remarks: string | null;
+  status: ReorderRequestStatus;
+  productAllocationId: number | null;
+  requestedByUserId: number | null;
+  reviewedByUserId: number | null;
+  reviewedAt: string | null;
+  reviewRemarks: string | null;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface ReorderRequestWithNames extends ReorderRequest {
+  shopName: string;
+  productName: string;
+}
+
+// Shop users always request for their own shop; HQ users must give shopId.
+export interface CreateReorderRequestRequest {
+  shopId?: number;
+  nameId: number;
+  requestedQuantity: number;
+  remarks?: string;
+}
+
+export interface RejectReorderRequestRequest {
+  remarks?: string;
+}
+
+export interface ApproveReorderRequestRequest {
+  productId: number;
+  filmQuantity?: number; // defaults to the quantity requested
+  allocationDate?: string; // YYYY-MM-DD, defaults to today
+  remarks?: string;
+}
@@ -0,0 +1,87 @@
+export type ShipmentStatus = "PENDING" | "RECEIVED";
+
+export interface Shipment {
+  id: number;
+  shipmentNumber: string;
+  supplierName: string;
+  shippedDate: string | null;
+  receivedDate: string | null;
+  status: ShipmentStatus;
+  remarks: string | null;
+  createdByUserId: number | null;
+  receivedByUserId: number | null;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface ShipmentSummary {
+  id: number;
+  shipmentNumber: string;
+  supplierName: string;
+  shippedDate: string | null;
+  receivedDate: string | null;
+  status: ShipmentStatus;
+  createdAt: string;
+  lineCount: number;
+  totalQuantity: number;
+}
+
+export interface ShipmentLine {
+  id: number;
+  shipmentId: number;
+  nameId: number;
+  productName: string;
+  seriesName: string;
+  filmSerialNumber: string;
+  filmQuantity: number;
+  warrantyInMonths: number;
+  description: string;
+  productId: number | null;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface ShipmentWithLines {
+  shipment: Shipment;
+  lines: ShipmentLine[];
+}
+
+export interface ShipmentLineRequest {
+  nameId: number;
+  filmSerialNumber: string;
+  filmQuantity: number;
+  warrantyInMonths: number;
+  description: string;
+}
+
+export interface CreateShipmentRequest {
+  shipmentNumber: string;
+  supplierName: string;
+  shippedDate?: string; // Format: YYYY-MM-DD
+  remarks?: string;
+  lines: ShipmentLineRequest[];
+}
+
+export interface ReceiveShipmentRequest {
+  receivedDate?: string; // Format: YYYY-MM-DD, defaults to today
+}
+
+export type ShipmentImportStatus = "NEW" | "UPDATED" | "UNCHANGED" | "ERROR";
+
+export interface ShipmentImportRow {
+  row: number;
+  shipmentNumber: string;
+  filmSerialNumber: string;
+  status: ShipmentImportStatus;
+  message?: string;
+}
+
+export interface ShipmentImportReport {
+  dryRun: boolean;
+  applied: boolean;
+  new: number;
+  updated: number;
+  unchanged: number;
+  errors: number;
+  rows: ShipmentImportRow[];
+}
@@ -0,0 +1,107 @@
+import { UnitOfMeasure } from "@/types/productsType";
+
+export type StockTakeStatus = "OPEN" | "SUBMITTED" | "POSTED" | "CANCELLED";
+
+export type StockTakeLineReviewStatus = "PENDING" | "APPROVED" | "REJECTED";
+
+export interface StockTake {
+  id: number;
+  stockTakeNo: string;
+  shopId: number;
+  period: string; // First day of the month counted
+  status: StockTakeStatus;
+  tolerancePercent: number;
+  remarks: string | null;
+  openedByUserId: number | null;
+  submittedByUserId: number | null;
+  submittedAt: string | null;
+  postedByUserId: number | null;
+  postedAt: string | null;
+  cancelledByUserId: number | null;
+  cancelledAt: string | null;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface StockTakeSummary {
+  id: number;
+  stockTakeNo: string;
+  shopId: number;
+  shopName: string;
+  period: string;
+  status: StockTakeStatus;
+  tolerancePercent: number;
+  lineCount: number;
+  countedCount: number;
+  flaggedCount: number;
+  openedByUserId: number | null;
+  submittedAt: string | null;
+  postedAt: string | null;
+  cancelledAt: string | null;
+  createdAt: string;
+}
+
+// Quantities are in rolls; unitQuantity and unitOfMeasure keep the count as
+// it was entered.
+export interface StockTakeLine {
+  id: number;
+  stockTakeId: number;
+  productAllocationId: number;
+  systemQuantity: number;
+  countedQuantity: number | null;
+  unitOfMeasure: UnitOfMeasure | null;
+  unitQuantity: number | null;
+  varianceQuantity: number | null;
+  isFlagged: boolean;
+  reviewStatus: StockTakeLineReviewStatus;
+  adjustedQuantity: number | null;
+  inventoryMovementId: number | null;
+  remarks: string | null;
+  countedByUserId: number | null;
+  countedAt: string | null;
+  reviewedByUserId: number | null;
+  reviewedAt: string | null;
+  reviewRemarks: string | null;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface StockTakeReportLine
+  extends Omit<StockTakeLine, "createdAt" | "updatedAt"> {
+  productId: number;
+  filmSerialNumber: string;
+  productName: string;
+  allocationDate: string;
+}
+
+export interface StockTakeReport {
+  stockTake: StockTake;
+  lines: StockTakeReportLine[];
+}
+
+// Shop users always open their own shop's stock take; HQ users must give
+// shopId.
+export interface OpenStockTakeRequest {
+  shopId?: number;
+  period?: string; // YYYY-MM, defaults to the current month
+  tolerancePercent?: number; // defaults to 2
+  remarks?: string;
+}
+
+// Either productAllocationId or filmSerialNumber picks the line.
+export interface StockTakeCountRequest {
+  productAllocationId?: number;
+  filmSerialNumber?: string; // Raw serial or scanned label
+  unitOfMeasure?: UnitOfMeasure; // defaults to ROLL
+  quantity: number;
+  remarks?: string;
+}
+
+export interface RecordStockTakeCountsRequest {
+  counts: StockTakeCountRequest[];
+}
+
+export interface ReviewStockTakeLineRequest {
+  approve: boolean;
+  remarks?: string;
+}
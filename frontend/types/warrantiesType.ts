@@ -1,6 +1,9 @@
+import { UnitOfMeasure } from "@/types/productsType";
+
 export enum WarrantyApprovalStatus {
   PENDING = "PENDING",
   APPROVED = "APPROVED",
+  PARTIALLY_APPROVED = "PARTIALLY_APPROVED",
   REJECTED = "REJECTED",
 }
 
@@ -72,6 +75,8 @@ export interface CreateWarrantyPartRequest {
   productAllocationId: number;
   carPartId: number;
   installationImageUrl: string;
+  unitOfMeasure?: UnitOfMeasure; // Unit of unitQuantity, defaults to ROLL
+  unitQuantity: number; // Film used by the install
   remarks?: string; // Optional
 }
 
@@ -81,6 +86,8 @@ export interface UpdateWarrantyPartRequest {
   productAllocationId: number;
   carPartId: number;
   installationImageUrl: string;
+  unitOfMeasure?: UnitOfMeasure; // Unit of unitQuantity, defaults to ROLL
+  unitQuantity: number; // Film used by the install
   remarks?: string; // Optional
 }
 
@@ -100,6 +107,9 @@ export interface WarrantyPartDetails {
   productAllocationId: number;
   carPartId: number;
   installationImageUrl: string;
+  filmQuantity: number; // Film used by the install, in rolls
+  unitOfMeasure: UnitOfMeasure; // Unit the film used was entered in
+  unitQuantity: number;
   approvalStatus: WarrantyApprovalStatus;
   remarks?: string; // Optional
   createdAt: string; // ISO date string